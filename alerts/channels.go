@@ -0,0 +1,78 @@
+package alerts
+
+import (
+	"os"
+	"strings"
+)
+
+// Channels is a set of Telegram destinations. Empty fields in a per-chain set fall
+// back to the global channels, so a chain can override only its chat IDs.
+type Channels struct {
+	BusinessBotToken  string
+	BusinessChatID    string
+	DeveloperBotToken string
+	DeveloperChatID   string
+}
+
+func (c Channels) empty() bool {
+	return c == Channels{}
+}
+
+// ChainChannelsFromEnv reads the per-chain overrides TELEGRAM_BUSINESS_BOT_TOKEN_<CHAIN>,
+// TELEGRAM_BUSINESS_CHAT_ID_<CHAIN>, TELEGRAM_DEVELOPER_BOT_TOKEN_<CHAIN> and
+// TELEGRAM_DEVELOPER_CHAT_ID_<CHAIN>, e.g. TELEGRAM_BUSINESS_CHAT_ID_BASE
+func ChainChannelsFromEnv(chain string) Channels {
+	suffix := "_" + strings.ToUpper(chain)
+	return Channels{
+		BusinessBotToken:  os.Getenv("TELEGRAM_BUSINESS_BOT_TOKEN" + suffix),
+		BusinessChatID:    os.Getenv("TELEGRAM_BUSINESS_CHAT_ID" + suffix),
+		DeveloperBotToken: os.Getenv("TELEGRAM_DEVELOPER_BOT_TOKEN" + suffix),
+		DeveloperChatID:   os.Getenv("TELEGRAM_DEVELOPER_CHAT_ID" + suffix),
+	}
+}
+
+// SetChainChannels routes alerts from chain's jobs to channels. Must be called
+// before alerts are sent. An empty set removes the override.
+func (s *Service) SetChainChannels(chain string, channels Channels) {
+	if channels.empty() {
+		delete(s.chainChannels, chain)
+		return
+	}
+	if s.chainChannels == nil {
+		s.chainChannels = make(map[string]Channels)
+	}
+	s.chainChannels[chain] = channels
+}
+
+// channelsFor resolves the channels for a job. Chain-scoped job names end in the
+// chain ID ("oracle_base", "market_totals_base"); other jobs use the global channels.
+func (s *Service) channelsFor(job string) Channels {
+	channels := Channels{
+		BusinessBotToken:  s.BusinessBotToken,
+		BusinessChatID:    s.BusinessChatID,
+		DeveloperBotToken: s.DeveloperBotToken,
+		DeveloperChatID:   s.DeveloperChatID,
+	}
+	// Longest matching chain ID wins, so "base_mainnet" takes precedence over "mainnet"
+	match := ""
+	for chain := range s.chainChannels {
+		if (job == chain || strings.HasSuffix(job, "_"+chain)) && len(chain) > len(match) {
+			match = chain
+		}
+	}
+	if override, ok := s.chainChannels[match]; ok {
+		if override.BusinessBotToken != "" {
+			channels.BusinessBotToken = override.BusinessBotToken
+		}
+		if override.BusinessChatID != "" {
+			channels.BusinessChatID = override.BusinessChatID
+		}
+		if override.DeveloperBotToken != "" {
+			channels.DeveloperBotToken = override.DeveloperBotToken
+		}
+		if override.DeveloperChatID != "" {
+			channels.DeveloperChatID = override.DeveloperChatID
+		}
+	}
+	return channels
+}
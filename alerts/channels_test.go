@@ -0,0 +1,25 @@
+package alerts
+
+import "testing"
+
+func TestChannelsForJob(t *testing.T) {
+	s := New("global-bot", "global-business", "global-dev-bot", "global-dev", "")
+	s.SetChainChannels("base", Channels{BusinessChatID: "base-business"})
+	s.SetChainChannels("optimism", Channels{DeveloperBotToken: "op-dev-bot", DeveloperChatID: "op-dev"})
+
+	tests := []struct {
+		job  string
+		want Channels
+	}{
+		{"oracle_base", Channels{"global-bot", "base-business", "global-dev-bot", "global-dev"}},
+		{"market_totals_base", Channels{"global-bot", "base-business", "global-dev-bot", "global-dev"}},
+		{"oracle_optimism", Channels{"global-bot", "global-business", "op-dev-bot", "op-dev"}},
+		{"health_factor", Channels{"global-bot", "global-business", "global-dev-bot", "global-dev"}},
+		{"oracle_database", Channels{"global-bot", "global-business", "global-dev-bot", "global-dev"}},
+	}
+	for _, tt := range tests {
+		if got := s.channelsFor(tt.job); got != tt.want {
+			t.Errorf("channelsFor(%q) = %+v, want %+v", tt.job, got, tt.want)
+		}
+	}
+}
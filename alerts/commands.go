@@ -0,0 +1,160 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0x0Glitch/internal/httpclient"
+)
+
+// Bot commands accepted from the business and developer chats, see PollCommands:
+//
+//	/mute business [duration]  mutes the business channel, e.g. /mute business 2h
+//	                           (no duration: until unmuted)
+//	/unmute business           lifts the mute and reports what was suppressed
+const (
+	commandPollTimeout = 30 * time.Second // how long one getUpdates request waits
+	commandRetryDelay  = 5 * time.Second  // pause after a failed getUpdates
+)
+
+type telegramUpdate struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Date int64  `json:"date"` // Unix time
+		Text string `json:"text"`
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+}
+
+// PollCommands reads bot commands sent to the business bot and applies them until ctx
+// is cancelled, replying in the chat the command came from. Only the business and
+// developer chats are obeyed, and messages sent before it started are ignored.
+func (m *Manager) PollCommands(ctx context.Context) {
+	s := m.service
+	if s.BusinessBotToken == "" {
+		log.Printf("[alerts] bot commands need the business bot; not polling")
+		return
+	}
+	started := m.clock().Unix()
+	client := httpclient.New(commandPollTimeout + 10*time.Second)
+
+	var offset int64
+	for ctx.Err() == nil {
+		updates, err := s.getUpdates(ctx, client, offset)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("[alerts] failed to read bot commands: %s", redact(err.Error(), s.BusinessBotToken))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(commandRetryDelay):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			msg := update.Message
+			if msg == nil || msg.Date < started {
+				continue
+			}
+			chatID := strconv.FormatInt(msg.Chat.ID, 10)
+			channel := businessChannel(chatID)
+			switch chatID {
+			case s.BusinessChatID:
+			case s.DeveloperChatID:
+				channel = developerChannel(chatID)
+			default:
+				continue
+			}
+			reply, ok := m.runCommand(ctx, msg.Text)
+			if !ok {
+				continue
+			}
+			if err := s.sendTelegram(ctx, channel, s.BusinessBotToken, chatID, reply, ""); err != nil {
+				log.Printf("[alerts] failed to reply to bot command: %v", err)
+			}
+		}
+	}
+}
+
+// runCommand applies a bot command and returns the reply, or false when text is not
+// a command
+func (m *Manager) runCommand(ctx context.Context, text string) (string, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", false
+	}
+	// In groups Telegram may address the command to the bot: /mute@oracle_bot
+	command, _, _ := strings.Cut(fields[0], "@")
+
+	switch command {
+	case "/mute":
+		if len(fields) < 2 || len(fields) > 3 || fields[1] != "business" {
+			return "Usage: /mute business [duration], e.g. /mute business 2h. The developer channel always receives alerts.", true
+		}
+		var d time.Duration
+		if len(fields) == 3 {
+			parsed, err := time.ParseDuration(fields[2])
+			if err != nil || parsed <= 0 {
+				return "Duration must be positive, such as 2h", true
+			}
+			d = parsed
+		}
+		m.MuteBusiness(d)
+		return "🔕 Business alerts muted " + muteUntil(m.BusinessMute().Until), true
+	case "/unmute":
+		if len(fields) != 2 || fields[1] != "business" {
+			return "Usage: /unmute business", true
+		}
+		if err := m.UnmuteBusiness(ctx); err != nil {
+			log.Printf("[alerts] failed to send mute summary: %v", err)
+		}
+		return "🔔 Business alerts unmuted", true
+	}
+	return "", false
+}
+
+// getUpdates waits up to commandPollTimeout for the business bot's messages from offset
+func (s *Service) getUpdates(ctx context.Context, client *http.Client, offset int64) ([]telegramUpdate, error) {
+	query := url.Values{
+		"offset":          {strconv.FormatInt(offset, 10)},
+		"timeout":         {strconv.Itoa(int(commandPollTimeout.Seconds()))},
+		"allowed_updates": {`["message"]`},
+	}
+	endpoint := fmt.Sprintf("%s/bot%s/getUpdates?%s", s.TelegramAPIURL, s.BusinessBotToken, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return nil, fmt.Errorf("telegram API returned status %d: %s", resp.StatusCode, string(body))
+	}
+	var result struct {
+		Result []telegramUpdate `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode telegram updates: %w", err)
+	}
+	return result.Result, nil
+}
@@ -0,0 +1,107 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPollCommandsMutesBusiness(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	message := func(id int, chat int64, age time.Duration, text string) string {
+		return fmt.Sprintf(`{"update_id": %d, "message": {"date": %d, "text": %q, "chat": {"id": %d}}}`,
+			id, now.Add(-age).Unix(), text, chat)
+	}
+	batches := []string{
+		message(1, -100, time.Hour, "/mute business 1h") + "," + // sent before startup
+			message(2, -300, 0, "/mute business") + "," + // another chat
+			message(3, -200, 0, "hello") + "," +
+			message(4, -100, 0, "/mute@oracle_bot business 2h"),
+		message(5, -200, 0, "/unmute business"),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	var offsets []string
+	replies := map[string][]string{} // chat ID -> texts
+	var m *Manager
+	var mutedDuringPoll MuteStatus
+	s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if strings.HasSuffix(r.URL.Path, "/getUpdates") {
+			offsets = append(offsets, r.URL.Query().Get("offset"))
+			if len(offsets) == 2 {
+				mutedDuringPoll = m.BusinessMute()
+			}
+			if len(offsets) > len(batches) {
+				cancel()
+				w.Write([]byte(`{"ok": true, "result": []}`))
+				return
+			}
+			fmt.Fprintf(w, `{"ok": true, "result": [%s]}`, batches[len(offsets)-1])
+			return
+		}
+		var payload struct {
+			ChatID string `json:"chat_id"`
+			Text   string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		replies[payload.ChatID] = append(replies[payload.ChatID], payload.Text)
+		w.Write([]byte(`{"ok": true}`))
+	})
+	s.BusinessChatID, s.DeveloperChatID = "-100", "-200"
+	m = NewManager(s)
+	m.clock = func() time.Time { return now }
+
+	done := make(chan struct{})
+	go func() {
+		m.PollCommands(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("PollCommands did not return after cancel")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"0", "5", "6"}; strings.Join(offsets, ",") != strings.Join(want, ",") {
+		t.Errorf("getUpdates offsets = %q, want %q", offsets, want)
+	}
+	if !mutedDuringPoll.Muted || !mutedDuringPoll.Until.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("after /mute business 2h: %+v, want muted until %s", mutedDuringPoll, now.Add(2*time.Hour))
+	}
+	if m.BusinessMute().Muted {
+		t.Error("still muted after /unmute business")
+	}
+	if business := replies["-100"]; len(business) != 1 || !strings.Contains(business[0], "muted until") {
+		t.Errorf("business chat replies = %q, want the mute confirmation", business)
+	}
+	if developer := replies["-200"]; len(developer) != 1 || !strings.Contains(developer[0], "unmuted") {
+		t.Errorf("developer chat replies = %q, want the unmute confirmation", developer)
+	}
+	if len(replies["-300"]) != 0 {
+		t.Errorf("replied to another chat: %q", replies["-300"])
+	}
+}
+
+func TestRunCommandRejectsDeveloperMute(t *testing.T) {
+	m := NewManager(New("", "", "", "", ""))
+	for _, text := range []string{"/mute developer 2h", "/mute business soon", "/mute business -1h"} {
+		reply, ok := m.runCommand(context.Background(), text)
+		if !ok || reply == "" || m.BusinessMute().Muted {
+			t.Errorf("%q: reply %q, %v, muted %v; want a usage reply and no mute", text, reply, ok, m.BusinessMute().Muted)
+		}
+	}
+	if _, ok := m.runCommand(context.Background(), "good morning"); ok {
+		t.Error("plain text treated as a command")
+	}
+}
@@ -0,0 +1,122 @@
+package alerts
+
+import "time"
+
+// DefaultDecisionLogSize is how many Observe decisions a Manager keeps by default
+const DefaultDecisionLogSize = 1000
+
+// Decision outcomes recorded for each Observe call
+const (
+	OutcomeNew                   = "new"
+	OutcomeEscalation            = "escalation"
+	OutcomeDeescalation          = "deescalation"
+	OutcomeReminder              = "reminder"
+	OutcomeUpdate                = "update"
+	OutcomeCooldownSuppressed    = "cooldown_suppressed"
+	OutcomeMinChangeSuppressed   = "min_change_suppressed"
+	OutcomeOKCounted             = "ok_counted"
+	OutcomeOKNoIncident          = "ok_no_incident"
+	OutcomeCleared               = "cleared"
+	OutcomeMaintenanceSuppressed = "maintenance_suppressed"
+	OutcomeDuplicateSuppressed   = "duplicate_suppressed"
+	OutcomeGrouped               = "grouped"  // held for a rolled-up message, see FlushGroups
+	OutcomePromoted              = "promoted" // paged business after MinIncidentDuration
+	OutcomeDigestSuppressed      = "digest_suppressed"
+)
+
+// Decision records how the Manager handled one observation and the policy
+// numbers behind it, for answering "why didn't this alert fire"
+type Decision struct {
+	Time       time.Time `json:"time"`
+	Job        string    `json:"job"`
+	Entity     string    `json:"entity"`
+	Metric     string    `json:"metric"`
+	Severity   Severity  `json:"severity"`
+	Value      float64   `json:"value"`
+	Outcome    string    `json:"outcome"`
+	IncidentID string    `json:"incident_id,omitempty"`
+	Business   bool      `json:"business"`
+	// Policy inputs, set where they influenced the outcome
+	CooldownSeconds       float64 `json:"cooldown_seconds,omitempty"`
+	SinceLastSentSeconds  float64 `json:"since_last_sent_seconds,omitempty"`
+	ValueChangePercent    float64 `json:"value_change_percent,omitempty"`
+	MinValueChange        float64 `json:"min_value_change,omitempty"`
+	ConsecutiveOK         int     `json:"consecutive_ok,omitempty"`
+	ConsecutiveOKRequired int     `json:"consecutive_ok_required,omitempty"`
+	// Maintenance is the reason of the maintenance window that withheld the message
+	Maintenance string `json:"maintenance,omitempty"`
+	// NonFiniteValue marks an observation whose NaN or infinite value was replaced by Value
+	NonFiniteValue bool `json:"non_finite_value,omitempty"`
+}
+
+// decisionLog is a fixed-size ring buffer of decisions, guarded by Manager.mu
+type decisionLog struct {
+	entries []Decision
+	next    int
+	full    bool
+}
+
+func newDecisionLog(size int) *decisionLog {
+	return &decisionLog{entries: make([]Decision, size)}
+}
+
+func (l *decisionLog) add(d Decision) {
+	if len(l.entries) == 0 {
+		return
+	}
+	l.entries[l.next] = d
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// snapshot returns the recorded decisions, oldest first
+func (l *decisionLog) snapshot() []Decision {
+	if !l.full {
+		return append([]Decision(nil), l.entries[:l.next]...)
+	}
+	out := make([]Decision, 0, len(l.entries))
+	out = append(out, l.entries[l.next:]...)
+	return append(out, l.entries[:l.next]...)
+}
+
+// SetDecisionLogSize resizes the decision log, keeping the most recent decisions
+// that fit. Zero disables recording.
+func (m *Manager) SetDecisionLogSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if size == len(m.decisions.entries) {
+		return
+	}
+	recent := m.decisions.snapshot()
+	if len(recent) > size {
+		recent = recent[len(recent)-size:]
+	}
+	m.decisions = newDecisionLog(size)
+	for _, d := range recent {
+		m.decisions.add(d)
+	}
+}
+
+// DecisionLog returns recent Observe decisions, oldest first, optionally filtered
+// by job and metric (empty matches all)
+func (m *Manager) DecisionLog(job, metric string) []Decision {
+	m.mu.RLock()
+	decisions := m.decisions.snapshot()
+	m.mu.RUnlock()
+
+	if job == "" && metric == "" {
+		return decisions
+	}
+	filtered := decisions[:0]
+	for _, d := range decisions {
+		if (job == "" || d.Job == job) && (metric == "" || d.Metric == metric) {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,98 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newTestManager() (*Manager, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewManager(New("", "", "", "", ""))
+	m.clock = func() time.Time { return now }
+	return m, &now
+}
+
+func TestDecisionLogOutcomes(t *testing.T) {
+	m, now := newTestManager()
+	m.RegisterPolicy("oracle_base", "price_deviation", AlertPolicy{
+		MinValueChange:        50,
+		CooldownWarning:       10 * time.Minute,
+		CooldownCritical:      10 * time.Minute,
+		ConsecutiveOKRequired: 2,
+	})
+	key := AlertKey{Job: "oracle_base", Entity: "WETH", Metric: "price_deviation"}
+	ctx := context.Background()
+
+	steps := []struct {
+		advance  time.Duration
+		severity Severity
+		value    float64
+		want     string
+	}{
+		{0, SeverityOK, 0, OutcomeOKNoIncident},
+		{0, SeverityWarning, 2, OutcomeNew},
+		{time.Minute, SeverityWarning, 10, OutcomeCooldownSuppressed},
+		{20 * time.Minute, SeverityWarning, 2.5, OutcomeMinChangeSuppressed},
+		{0, SeverityWarning, 4, OutcomeUpdate},
+		{0, SeverityCritical, 8, OutcomeEscalation},
+		{0, SeverityWarning, 4, OutcomeDeescalation},
+		{0, SeverityOK, 0, OutcomeOKCounted},
+		{0, SeverityOK, 0, OutcomeCleared},
+	}
+	for _, step := range steps {
+		*now = now.Add(step.advance)
+		m.Observe(ctx, key, step.severity, step.value, "", fmt.Sprintf("value %v", step.value), false, "")
+	}
+
+	log := m.DecisionLog("", "")
+	if len(log) != len(steps) {
+		t.Fatalf("got %d decisions, want %d", len(log), len(steps))
+	}
+	for i, step := range steps {
+		if log[i].Outcome != step.want {
+			t.Errorf("decision %d: outcome %q, want %q", i, log[i].Outcome, step.want)
+		}
+	}
+	if d := log[3]; d.MinValueChange != 50 || d.ValueChangePercent != 25 {
+		t.Errorf("min-change decision recorded %v%% change against %v", d.ValueChangePercent, d.MinValueChange)
+	}
+	if d := log[2]; d.CooldownSeconds != 600 || d.SinceLastSentSeconds != 60 {
+		t.Errorf("cooldown decision recorded %vs since last send against %vs", d.SinceLastSentSeconds, d.CooldownSeconds)
+	}
+}
+
+func TestDecisionLogBoundedAndFiltered(t *testing.T) {
+	m, _ := newTestManager()
+	m.SetDecisionLogSize(3)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		job := fmt.Sprintf("job%d", i%2)
+		m.Observe(ctx, AlertKey{Job: job, Entity: fmt.Sprint(i), Metric: "m"}, SeverityOK, 0, "", "", false, "")
+	}
+
+	log := m.DecisionLog("", "")
+	if len(log) != 3 {
+		t.Fatalf("got %d decisions, want 3", len(log))
+	}
+	for i, want := range []string{"2", "3", "4"} {
+		if log[i].Entity != want {
+			t.Errorf("decision %d is entity %s, want %s (oldest first)", i, log[i].Entity, want)
+		}
+	}
+	if got := m.DecisionLog("job1", "m"); len(got) != 1 || got[0].Entity != "3" {
+		t.Errorf("filtered log = %+v, want only entity 3", got)
+	}
+
+	m.SetDecisionLogSize(2)
+	if log := m.DecisionLog("", ""); len(log) != 2 || log[0].Entity != "3" {
+		t.Errorf("after shrinking: %+v, want the two newest decisions", log)
+	}
+	m.SetDecisionLogSize(0)
+	m.Observe(ctx, AlertKey{Job: "job0", Metric: "m"}, SeverityOK, 0, "", "", false, "")
+	if log := m.DecisionLog("", ""); len(log) != 0 {
+		t.Errorf("disabled log recorded %d decisions", len(log))
+	}
+}
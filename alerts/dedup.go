@@ -0,0 +1,59 @@
+package alerts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// defaultDedupWindow is how long an identical message to the same destination is suppressed
+const defaultDedupWindow = 2 * time.Minute
+
+// sentLog remembers recently sent message hashes per destination. Telegram and Slack
+// do not deduplicate, so a retry after a send that timed out but was delivered would
+// page recipients twice.
+type sentLog struct {
+	mu     sync.Mutex
+	window time.Duration
+	sent   map[string]time.Time // destination + content hash -> send time
+}
+
+func newSentLog(window time.Duration) *sentLog {
+	return &sentLog{window: window, sent: make(map[string]time.Time)}
+}
+
+func messageKey(destination, message string) string {
+	sum := sha256.Sum256([]byte(message))
+	return destination + ":" + hex.EncodeToString(sum[:])
+}
+
+// recent reports whether message was sent to destination within the window
+func (l *sentLog) recent(destination, message string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sentAt, ok := l.sent[messageKey(destination, message)]
+	return ok && now.Sub(sentAt) < l.window
+}
+
+// record marks message as sent (or possibly sent) to destination and prunes expired entries
+func (l *sentLog) record(destination, message string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, sentAt := range l.sent {
+		if now.Sub(sentAt) >= l.window {
+			delete(l.sent, key)
+		}
+	}
+	l.sent[messageKey(destination, message)] = now
+}
+
+// isTimeout reports whether err is a timeout, after which the request may still have been delivered
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,159 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultDegradedAfter is how many consecutive failed sends mark a channel degraded
+const DefaultDegradedAfter = 3
+
+// ChannelHealth is the delivery state of one destination, see Service.DeliveryHealth
+type ChannelHealth struct {
+	Channel             string `json:"channel"` // e.g. "developer Telegram (chat -100123)" or "Slack"
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	Degraded            bool   `json:"degraded"`
+	LastError           string `json:"last_error,omitempty"`
+}
+
+// deliveryHealth counts consecutive failed sends per destination
+type deliveryHealth struct {
+	mu            sync.Mutex
+	degradedAfter int // 0 never degrades
+	channels      map[string]*ChannelHealth
+	// onChange is called outside the lock when a channel becomes degraded or recovers
+	onChange func(ctx context.Context, health ChannelHealth)
+}
+
+// record counts a send to channel, err being nil on success. Secrets such as bot
+// tokens are removed from the stored error.
+func (h *deliveryHealth) record(ctx context.Context, channel string, err error, secrets ...string) {
+	h.mu.Lock()
+	if h.channels == nil {
+		h.channels = make(map[string]*ChannelHealth)
+	}
+	state, ok := h.channels[channel]
+	if !ok {
+		state = &ChannelHealth{Channel: channel}
+		h.channels[channel] = state
+	}
+	wasDegraded := state.Degraded
+	if err == nil {
+		state.ConsecutiveFailures, state.Degraded, state.LastError = 0, false, ""
+	} else {
+		state.ConsecutiveFailures++
+		state.LastError = redact(err.Error(), secrets...)
+		state.Degraded = h.degradedAfter > 0 && state.ConsecutiveFailures >= h.degradedAfter
+	}
+	snapshot, onChange := *state, h.onChange
+	h.mu.Unlock()
+
+	if snapshot.Degraded == wasDegraded {
+		return
+	}
+	if snapshot.Degraded {
+		log.Printf("[alerts] %s delivery degraded after %d consecutive failures: %s", channel, snapshot.ConsecutiveFailures, snapshot.LastError)
+	} else {
+		log.Printf("[alerts] %s delivery recovered", channel)
+	}
+	if onChange != nil {
+		onChange(ctx, snapshot)
+	}
+}
+
+func redact(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, "<redacted>")
+		}
+	}
+	return s
+}
+
+// SetDegradedAfter marks a channel degraded after n consecutive failed sends (0 never does)
+func (s *Service) SetDegradedAfter(n int) {
+	s.health.mu.Lock()
+	defer s.health.mu.Unlock()
+	s.health.degradedAfter = n
+	for _, state := range s.health.channels {
+		state.Degraded = n > 0 && state.ConsecutiveFailures >= n
+	}
+}
+
+// DeliveryHealth returns the delivery state of every destination sent to so far,
+// sorted by channel
+func (s *Service) DeliveryHealth() []ChannelHealth {
+	s.health.mu.Lock()
+	defer s.health.mu.Unlock()
+	health := make([]ChannelHealth, 0, len(s.health.channels))
+	for _, state := range s.health.channels {
+		health = append(health, *state)
+	}
+	sort.Slice(health, func(i, j int) bool { return health[i].Channel < health[j].Channel })
+	return health
+}
+
+// Healthy reports whether no destination is degraded
+func (s *Service) Healthy() bool {
+	for _, state := range s.DeliveryHealth() {
+		if state.Degraded {
+			return false
+		}
+	}
+	return true
+}
+
+// sendToOtherChannels sends message to the global developer Telegram channel and
+// Slack, skipping except and any that are not configured. The business channel is
+// left out; delivery problems are for developers.
+func (s *Service) sendToOtherChannels(ctx context.Context, except, message string) error {
+	var errs []error
+	if s.DeveloperBotToken != "" && s.DeveloperChatID != "" && developerChannel(s.DeveloperChatID) != except {
+		errs = append(errs, s.sendTelegram(ctx, developerChannel(s.DeveloperChatID), s.DeveloperBotToken, s.DeveloperChatID, message, ""))
+	}
+	if s.SlackWebhookURL != "" && slackChannel != except {
+		errs = append(errs, s.sendSlack(ctx, message))
+	}
+	return errors.Join(errs...)
+}
+
+const slackChannel = "Slack"
+
+func businessChannel(chatID string) string {
+	return fmt.Sprintf("business Telegram (chat %s)", chatID)
+}
+
+func developerChannel(chatID string) string {
+	return fmt.Sprintf("developer Telegram (chat %s)", chatID)
+}
+
+// DeliveryHealth returns the delivery state of each alert destination
+func (m *Manager) DeliveryHealth() []ChannelHealth {
+	return m.service.DeliveryHealth()
+}
+
+// DeliveryHealthy reports whether every alert destination is delivering
+func (m *Manager) DeliveryHealthy() bool {
+	return m.service.Healthy()
+}
+
+// notifyDeliveryChange tells the other channels that health.Channel stopped or resumed
+// delivering, so a revoked bot token does not silence alerting unnoticed
+func (m *Manager) notifyDeliveryChange(ctx context.Context, health ChannelHealth) {
+	var msg string
+	if health.Degraded {
+		msg = fmt.Sprintf("⚠️ ALERT DELIVERY DEGRADED\n\nChannel: %s\nConsecutive failures: %d\nLast error: %s\n\n"+
+			"Alerts for this channel are not arriving. Check its bot token, chat ID or webhook.",
+			health.Channel, health.ConsecutiveFailures, health.LastError)
+	} else {
+		msg = fmt.Sprintf("✅ ALERT DELIVERY RECOVERED\n\nChannel: %s\nMessages are being delivered again.", health.Channel)
+	}
+	if err := m.service.sendToOtherChannels(ctx, health.Channel, withBuildFooter(msg)); err != nil {
+		log.Printf("[alerts] failed to send delivery notice for %s: %v", health.Channel, err)
+	}
+}
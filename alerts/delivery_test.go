@@ -0,0 +1,71 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDeliveryDegradedAndRecovered(t *testing.T) {
+	businessDown := true
+	var developerMessages []string
+	s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/botbusiness-token/sendMessage" {
+			if businessDown {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"ok": false, "description": "Unauthorized"}`))
+				return
+			}
+			w.Write([]byte(`{"ok": true}`))
+			return
+		}
+		var payload map[string]any
+		json.NewDecoder(r.Body).Decode(&payload)
+		developerMessages = append(developerMessages, fmt.Sprint(payload["text"]))
+		w.Write([]byte(`{"ok": true}`))
+	})
+	NewManager(s)
+	ctx := context.Background()
+
+	for i := 1; i <= DefaultDegradedAfter; i++ {
+		if !s.Healthy() {
+			t.Fatalf("unhealthy after %d failures, want degraded after %d", i-1, DefaultDegradedAfter)
+		}
+		if err := s.SendBusinessAlert(ctx, fmt.Sprintf("alert %d", i)); err == nil {
+			t.Fatal("SendBusinessAlert with a revoked token succeeded")
+		}
+	}
+	if s.Healthy() {
+		t.Fatal("healthy after repeated failures")
+	}
+	health := s.DeliveryHealth()
+	if len(health) != 2 || health[0].Channel != businessChannel("business-chat") || health[0].ConsecutiveFailures != DefaultDegradedAfter {
+		t.Fatalf("health = %+v", health)
+	}
+	if len(developerMessages) != 1 || !strings.Contains(developerMessages[0], "ALERT DELIVERY DEGRADED") {
+		t.Fatalf("developer messages = %q, want one degraded notice", developerMessages)
+	}
+	if strings.Contains(developerMessages[0], "business-token") || strings.Contains(health[0].LastError, "business-token") {
+		t.Errorf("bot token leaked: %q", developerMessages[0])
+	}
+
+	// Further failures do not repeat the notice
+	s.SendBusinessAlert(ctx, "alert 4")
+	if len(developerMessages) != 1 {
+		t.Errorf("%d developer messages, want the notice sent once", len(developerMessages))
+	}
+
+	businessDown = false
+	if err := s.SendBusinessAlert(ctx, "alert 5"); err != nil {
+		t.Fatalf("SendBusinessAlert: %v", err)
+	}
+	if !s.Healthy() {
+		t.Error("still unhealthy after a successful send")
+	}
+	if len(developerMessages) != 2 || !strings.Contains(developerMessages[1], "ALERT DELIVERY RECOVERED") {
+		t.Errorf("developer messages = %q, want a recovery notice", developerMessages)
+	}
+}
@@ -0,0 +1,68 @@
+package alerts
+
+import (
+	"context"
+	"html"
+	"log"
+	"slices"
+)
+
+// TelegramMessageLimit is the longest text Telegram accepts in one message
+const TelegramMessageLimit = 4096
+
+// SetDigestMetrics withholds messages for job's metrics, which the job reports in a
+// digest instead (see SendDigest). Incident state still advances and is marked unsent,
+// so incidents still open when the metrics are removed are sent on their next
+// observation. No metrics removes the job.
+func (m *Manager) SetDigestMetrics(job string, metrics []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(metrics) == 0 {
+		delete(m.digestMetrics, job)
+		return
+	}
+	if m.digestMetrics == nil {
+		m.digestMetrics = make(map[string][]string)
+	}
+	m.digestMetrics[job] = slices.Clone(metrics)
+}
+
+// reportedInDigest reports whether key's messages are withheld for a digest (called
+// with m.mu held)
+func (m *Manager) reportedInDigest(key AlertKey) bool {
+	return slices.Contains(m.digestMetrics[key.Job], key.Metric)
+}
+
+// suppressForDigest withholds action's message when key is reported in a digest,
+// keeping its state change and marking the state unsent (called with m.mu held)
+func (m *Manager) suppressForDigest(key AlertKey, action *alertAction, decision *Decision) {
+	if !action.shouldSend || !m.reportedInDigest(key) {
+		return
+	}
+	action.shouldSend = false
+	if action.newState != nil {
+		action.newState.Unsent = true
+	}
+	decision.Outcome, decision.Business = OutcomeDigestSuppressed, false
+}
+
+// SendDeveloperNotice sends a one-off message that belongs to no incident to the
+// developer channel of job's chain
+func (m *Manager) SendDeveloperNotice(ctx context.Context, job, message string) error {
+	return m.service.SendDeveloperAlertFor(ctx, job, withBuildFooter(message))
+}
+
+// SendDigest sends a job's periodic report to the business channel of its chain, or
+// to developers while business alerts are muted. body is shown in a monospace block
+// and together with title must fit in TelegramMessageLimit.
+func (m *Manager) SendDigest(ctx context.Context, job, title, body string) error {
+	message := "<b>" + html.EscapeString(title) + "</b>\n<pre>" + html.EscapeString(body) + "</pre>"
+	if m.suppressBusiness(ctx, job) {
+		return m.service.SendDeveloperHTMLFor(ctx, job, message)
+	}
+	if err := m.service.SendBusinessHTMLFor(ctx, job, message); err != nil {
+		return err
+	}
+	log.Printf("[alerts] sent %s digest", job)
+	return nil
+}
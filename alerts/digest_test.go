@@ -0,0 +1,35 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDigestMetricsTrackWithoutSending(t *testing.T) {
+	m, now := newTestManager()
+	m.RegisterPolicy("oracle_moonriver", "price_deviation_volatile", AlertPolicy{
+		CooldownWarning:       time.Hour,
+		CooldownCritical:      time.Hour,
+		ConsecutiveOKRequired: 1,
+	})
+	m.SetDigestMetrics("oracle_moonriver", []string{"price_deviation_volatile"})
+	key := AlertKey{Job: "oracle_moonriver", Entity: "moonriver:xcKSM", Metric: "price_deviation_volatile"}
+	ctx := context.Background()
+
+	m.Observe(ctx, key, SeverityWarning, 4, "", "details", true, "")
+	state, ok := m.GetActiveIncidents()[key]
+	if !ok || !state.Unsent {
+		t.Fatalf("incident = %+v (active %v), want tracked and unsent", state, ok)
+	}
+	incidentID := state.IncidentID
+
+	// Switching back to incident mode sends the open incident under the same ID
+	m.SetDigestMetrics("oracle_moonriver", nil)
+	*now = now.Add(time.Minute)
+	m.Observe(ctx, key, SeverityWarning, 4, "", "details", true, "")
+	decisions := m.DecisionLog("oracle_moonriver", "")
+	if first, last := decisions[0], decisions[len(decisions)-1]; first.Outcome != OutcomeDigestSuppressed || last.Outcome != OutcomeNew || last.IncidentID != incidentID {
+		t.Errorf("decisions = %+v, want digest_suppressed then new for %s", decisions, incidentID)
+	}
+}
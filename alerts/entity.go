@@ -0,0 +1,53 @@
+package alerts
+
+import "strings"
+
+// Entities of chain-scoped jobs are namespaced as "chain:identifier" (e.g. "base:WETH")
+// so the same token on two chains never merges into one incident. Keys keep the full
+// entity; the chain is only stripped for display.
+const entitySeparator = ":"
+
+// ChainEntity returns the namespaced entity for id on chain
+func ChainEntity(chain, id string) string {
+	return chain + entitySeparator + id
+}
+
+// Chain returns the chain an entity is namespaced under, or "" for bare entities
+func (k AlertKey) Chain() string {
+	chain, _, ok := strings.Cut(k.Entity, entitySeparator)
+	if !ok {
+		return ""
+	}
+	return chain
+}
+
+// DisplayEntity returns the entity without its chain namespace
+func (k AlertKey) DisplayEntity() string {
+	if _, id, ok := strings.Cut(k.Entity, entitySeparator); ok {
+		return id
+	}
+	return k.Entity
+}
+
+// adoptLegacyState moves state recorded under the bare entity of a namespaced key
+// (from before entities carried their chain) to key, so existing incidents keep
+// their ID and cooldowns instead of being orphaned. Called with m.mu held.
+func (m *Manager) adoptLegacyState(key AlertKey) {
+	if key.Chain() == "" {
+		return
+	}
+	if _, exists := m.states[key]; exists {
+		return
+	}
+	legacy := AlertKey{Job: key.Job, Entity: key.DisplayEntity(), Metric: key.Metric}
+	if state, ok := m.states[legacy]; ok {
+		m.states[key] = state
+		delete(m.states, legacy)
+	}
+	if id, ok := m.pendingIDs[legacy]; ok {
+		if _, pending := m.pendingIDs[key]; !pending {
+			m.pendingIDs[key] = id
+		}
+		delete(m.pendingIDs, legacy)
+	}
+}
@@ -0,0 +1,44 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDisplayEntity(t *testing.T) {
+	tests := []struct {
+		entity, chain, display string
+	}{
+		{ChainEntity("base", "WETH"), "base", "WETH"},
+		{"WETH", "", "WETH"},
+		{"0xabc", "", "0xabc"},
+	}
+	for _, tt := range tests {
+		key := AlertKey{Job: "oracle_base", Entity: tt.entity, Metric: "m"}
+		if key.Chain() != tt.chain || key.DisplayEntity() != tt.display {
+			t.Errorf("%q: chain %q, display %q; want %q, %q", tt.entity, key.Chain(), key.DisplayEntity(), tt.chain, tt.display)
+		}
+	}
+}
+
+func TestNamespacedKeyAdoptsLegacyState(t *testing.T) {
+	m, _ := newTestManager()
+	ctx := context.Background()
+	legacy := AlertKey{Job: "oracle_base", Entity: "WETH", Metric: "price_deviation"}
+	m.Observe(ctx, legacy, SeverityWarning, 4, "", "details", false, "")
+	id := m.GetActiveIncidents()[legacy].IncidentID
+
+	key := AlertKey{Job: "oracle_base", Entity: ChainEntity("base", "WETH"), Metric: "price_deviation"}
+	m.Observe(ctx, key, SeverityWarning, 4, "", "details", false, "")
+
+	incidents := m.GetActiveIncidents()
+	if _, ok := incidents[legacy]; ok {
+		t.Error("legacy key still active after namespaced observation")
+	}
+	if got := incidents[key].IncidentID; got != id {
+		t.Errorf("namespaced incident ID = %q, want adopted %q", got, id)
+	}
+	if log := m.DecisionLog("", ""); log[len(log)-1].Outcome == OutcomeNew {
+		t.Error("namespaced observation opened a new incident instead of continuing the legacy one")
+	}
+}
@@ -0,0 +1,138 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// alertGroup tracks new incidents for one job and metric whose policy sets GroupThreshold
+type alertGroup struct {
+	sent      []time.Time // new incidents sent individually within the window
+	held      []heldIncident
+	heldSince time.Time // when the first held incident opened
+}
+
+// heldIncident is a new incident whose message waits for a rolled-up summary
+type heldIncident struct {
+	key        AlertKey
+	incidentID string
+	severity   Severity
+	details    string
+	business   bool
+}
+
+// holdForGroup reports whether a new incident should be held for a rolled-up message
+// instead of being sent now: once GroupThreshold new incidents have been sent within
+// GroupWindow, the rest are held until FlushGroups (called with m.mu held)
+func (m *Manager) holdForGroup(policy AlertPolicy, now time.Time, incident heldIncident) bool {
+	if policy.GroupThreshold <= 0 || policy.GroupWindow <= 0 {
+		return false
+	}
+	if m.groups == nil {
+		m.groups = make(map[string]*alertGroup)
+	}
+	groupKey := PolicyKey(incident.key.Job, incident.key.Metric)
+	group := m.groups[groupKey]
+	if group == nil {
+		group = &alertGroup{}
+		m.groups[groupKey] = group
+	}
+
+	if len(group.held) > 0 {
+		// An unsent incident comes back through the new-incident path; hold it once
+		if i := slices.IndexFunc(group.held, func(h heldIncident) bool { return h.key == incident.key }); i >= 0 {
+			group.held[i] = incident
+		} else {
+			group.held = append(group.held, incident)
+		}
+		return true
+	}
+	recent := group.sent[:0]
+	for _, sentAt := range group.sent {
+		if now.Sub(sentAt) < policy.GroupWindow {
+			recent = append(recent, sentAt)
+		}
+	}
+	group.sent = recent
+	if len(group.sent) < policy.GroupThreshold {
+		group.sent = append(group.sent, now)
+		return false
+	}
+	group.held, group.heldSince = []heldIncident{incident}, now
+	return true
+}
+
+// FlushGroups sends one rolled-up message for each group whose window has passed
+// since its first held incident. Held incidents that cleared in the meantime are
+// left out. The worker calls it after every job run.
+func (m *Manager) FlushGroups(ctx context.Context) {
+	type rollup struct {
+		job, metric string
+		incidents   []heldIncident
+	}
+	var due []rollup
+
+	m.mu.Lock()
+	now := m.clock()
+	for groupKey, group := range m.groups {
+		job, metric, _ := SplitPolicyKey(groupKey)
+		policy, ok := m.policies[groupKey]
+		if len(group.held) == 0 || (ok && now.Sub(group.heldSince) < policy.GroupWindow) {
+			continue
+		}
+		var open []heldIncident
+		for _, incident := range group.held {
+			state, exists := m.states[incident.key]
+			if !exists || state.IncidentID != incident.incidentID {
+				continue
+			}
+			if w, covered := m.maintenanceWindow(incident.key, now); covered {
+				state.Unsent = true
+				log.Printf("[alerts] maintenance window %q withheld grouped %s %s/%s", w.Reason, job, incident.key.Entity, metric)
+				continue
+			}
+			if m.reportedInDigest(incident.key) {
+				state.Unsent = true
+				continue
+			}
+			open = append(open, incident)
+		}
+		group.held, group.sent = nil, []time.Time{now}
+		if len(open) > 0 {
+			due = append(due, rollup{job: job, metric: metric, incidents: open})
+		}
+	}
+	m.mu.Unlock()
+
+	for _, r := range due {
+		business := false
+		for _, incident := range r.incidents {
+			business = business || incident.business
+		}
+		msg := m.formatGroupMessage(r.job, r.metric, r.incidents)
+		if err := m.sendAlert(ctx, r.job, r.metric, "", msg, business, ""); err != nil {
+			log.Printf("[alerts] failed to send %d grouped %s/%s incidents: %v", len(r.incidents), r.job, r.metric, err)
+			if m.onDeliveryFailure != nil {
+				m.onDeliveryFailure(r.incidents[0].key, err)
+			}
+		}
+	}
+}
+
+// formatGroupMessage lists held incidents in one message, CRITICAL first
+func (m *Manager) formatGroupMessage(job, metric string, incidents []heldIncident) string {
+	sort.SliceStable(incidents, func(i, j int) bool {
+		return severityLevel(incidents[i].severity) > severityLevel(incidents[j].severity)
+	})
+	lines := make([]string, len(incidents))
+	for i, incident := range incidents {
+		lines[i] = fmt.Sprintf("[%s] %s %s: %s", incident.incidentID, incident.severity,
+			incident.key.DisplayEntity(), snippet(incident.details))
+	}
+	return fmt.Sprintf("🚨 %d NEW %s\n\n%s", len(incidents), m.getAlertTitle(job, metric), strings.Join(lines, "\n"))
+}
@@ -0,0 +1,77 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroupedIncidentsRollUp(t *testing.T) {
+	var mu sync.Mutex
+	var sent []string // developer chat texts
+	s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ChatID string `json:"chat_id"`
+			Text   string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		if payload.ChatID == "developer-chat" {
+			sent = append(sent, payload.Text)
+		}
+		mu.Unlock()
+	})
+	m := NewManager(s)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.clock = func() time.Time { return now }
+	m.RegisterPolicy("concentration", "whale_supply", AlertPolicy{
+		ConsecutiveOKRequired: 1,
+		GroupThreshold:        2,
+		GroupWindow:           10 * time.Minute,
+	})
+	ctx := context.Background()
+
+	markets := []string{"mUSDC", "mWETH", "mcbBTC", "mDAI", "mAERO"}
+	for _, market := range markets {
+		key := AlertKey{Job: "concentration", Entity: market, Metric: "whale_supply"}
+		m.Observe(ctx, key, SeverityWarning, 40, "", market+" whale", false, "")
+	}
+	if len(sent) != 2 {
+		t.Fatalf("sent %d messages before the window ends, want 2", len(sent))
+	}
+	decisions := m.DecisionLog("concentration", "whale_supply")
+	if got := decisions[len(decisions)-1].Outcome; got != OutcomeGrouped {
+		t.Errorf("last decision = %q, want %q", got, OutcomeGrouped)
+	}
+
+	// One held incident clears before the rollup
+	m.Observe(ctx, AlertKey{Job: "concentration", Entity: "mDAI", Metric: "whale_supply"}, SeverityOK, 0, "", "", false, "")
+
+	m.FlushGroups(ctx)
+	if len(sent) != 2 {
+		t.Fatalf("rollup sent before the window ended")
+	}
+
+	now = now.Add(11 * time.Minute)
+	m.FlushGroups(ctx)
+	if len(sent) != 3 {
+		t.Fatalf("sent %d messages after flush, want 3", len(sent))
+	}
+	rollup := sent[2]
+	if !strings.Contains(rollup, "2 NEW") || !strings.Contains(rollup, "mcbBTC whale") || !strings.Contains(rollup, "mAERO whale") {
+		t.Errorf("rollup = %q, want the two open held incidents", rollup)
+	}
+	if strings.Contains(rollup, "mDAI") {
+		t.Errorf("rollup = %q, includes a cleared incident", rollup)
+	}
+
+	// Nothing left to flush
+	m.FlushGroups(ctx)
+	if len(sent) != 3 {
+		t.Errorf("second flush sent again")
+	}
+}
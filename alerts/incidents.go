@@ -0,0 +1,70 @@
+package alerts
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const snippetLength = 120
+
+// Incident is an active incident as reported by IncidentReport
+type Incident struct {
+	AlertKey
+	IncidentID     string        `json:"incident_id"`
+	Severity       Severity      `json:"severity"`
+	Value          float64       `json:"value"`
+	FirstTriggered time.Time     `json:"first_triggered"`
+	LastSent       time.Time     `json:"last_sent"`
+	Age            time.Duration `json:"-"`
+	AgeSeconds     float64       `json:"age_seconds"`
+	// Snippet is the last message on one line, truncated
+	Snippet string `json:"snippet"`
+}
+
+// IncidentReport returns the active incidents at or above minSeverity (SeverityOK
+// or "" for all), CRITICAL first and then oldest first
+func (m *Manager) IncidentReport(minSeverity Severity) []Incident {
+	m.mu.RLock()
+	now := m.clock()
+	var report []Incident
+	for key, state := range m.states {
+		if state.Severity == SeverityOK || severityLevel(state.Severity) < severityLevel(minSeverity) {
+			continue
+		}
+		age := now.Sub(state.FirstTriggered)
+		report = append(report, Incident{
+			AlertKey:       key,
+			IncidentID:     state.IncidentID,
+			Severity:       state.Severity,
+			Value:          state.LastValue,
+			FirstTriggered: state.FirstTriggered,
+			LastSent:       state.LastSent,
+			Age:            age,
+			AgeSeconds:     age.Seconds(),
+			Snippet:        snippet(state.LastMessage),
+		})
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(report, func(i, j int) bool {
+		a, b := report[i], report[j]
+		if la, lb := severityLevel(a.Severity), severityLevel(b.Severity); la != lb {
+			return la > lb
+		}
+		if !a.FirstTriggered.Equal(b.FirstTriggered) {
+			return a.FirstTriggered.Before(b.FirstTriggered)
+		}
+		return a.Job+a.Entity+a.Metric < b.Job+b.Entity+b.Metric
+	})
+	return report
+}
+
+// snippet collapses message onto one line and truncates it to snippetLength runes
+func snippet(message string) string {
+	line := strings.Join(strings.Fields(message), " ")
+	if runes := []rune(line); len(runes) > snippetLength {
+		return string(runes[:snippetLength-1]) + "…"
+	}
+	return line
+}
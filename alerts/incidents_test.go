@@ -0,0 +1,67 @@
+package alerts
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIncidentReportOrder(t *testing.T) {
+	m, now := newTestManager()
+	ctx := context.Background()
+	observe := func(entity string, severity Severity) {
+		m.Observe(ctx, AlertKey{Job: "oracle_base", Entity: entity, Metric: "price_deviation"}, severity, 5, "", "details", false, "")
+	}
+
+	observe("old-warning", SeverityWarning)
+	*now = now.Add(time.Minute)
+	observe("new-critical", SeverityCritical)
+	*now = now.Add(time.Minute)
+	observe("newest-warning", SeverityWarning)
+	*now = now.Add(time.Minute)
+
+	report := m.IncidentReport(SeverityOK)
+	var order []string
+	for _, incident := range report {
+		order = append(order, incident.Entity)
+	}
+	if got, want := strings.Join(order, ","), "new-critical,old-warning,newest-warning"; got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+	if age := report[1].Age; age != 3*time.Minute {
+		t.Errorf("old-warning age = %v, want 3m", age)
+	}
+	if report[0].Snippet == "" || strings.Contains(report[0].Snippet, "\n") {
+		t.Errorf("snippet %q, want the last message on one line", report[0].Snippet)
+	}
+}
+
+func TestIncidentReportSeverityFilter(t *testing.T) {
+	m, _ := newTestManager()
+	ctx := context.Background()
+	m.Observe(ctx, AlertKey{Job: "j", Entity: "a", Metric: "m"}, SeverityWarning, 1, "", "", false, "")
+	m.Observe(ctx, AlertKey{Job: "j", Entity: "b", Metric: "m"}, SeverityCritical, 1, "", "", false, "")
+
+	tests := []struct {
+		min  Severity
+		want int
+	}{
+		{"", 2},
+		{SeverityOK, 2},
+		{SeverityWarning, 2},
+		{SeverityCritical, 1},
+	}
+	for _, tt := range tests {
+		if got := m.IncidentReport(tt.min); len(got) != tt.want {
+			t.Errorf("IncidentReport(%q) returned %d incidents, want %d", tt.min, len(got), tt.want)
+		}
+	}
+}
+
+func TestSnippetTruncates(t *testing.T) {
+	long := strings.Repeat("x", 200)
+	if got := []rune(snippet("🚨 title\n\n" + long)); len(got) != snippetLength {
+		t.Errorf("snippet length %d, want %d", len(got), snippetLength)
+	}
+}
@@ -0,0 +1,78 @@
+package alerts
+
+import (
+	"log"
+	"path"
+	"time"
+)
+
+// MaintenanceWindow declares a period, such as a scheduled oracle price post, during
+// which matching alerts are not sent. Incident state still advances, so an incident
+// that is still open when the window ends is sent on its next observation.
+type MaintenanceWindow struct {
+	Start, End time.Time
+	// Entities and Metrics are path.Match patterns; empty matches everything. Entity
+	// patterns are tried against both the full ("base:WETH") and display ("WETH") entity.
+	Entities []string
+	Metrics  []string
+	Reason   string
+}
+
+// Matches reports whether the window covers key at now
+func (w MaintenanceWindow) Matches(key AlertKey, now time.Time) bool {
+	if now.Before(w.Start) || !now.Before(w.End) {
+		return false
+	}
+	return matchAny(w.Entities, key.Entity, key.DisplayEntity()) && matchAny(w.Metrics, key.Metric)
+}
+
+// matchAny reports whether any pattern matches any of values; no patterns match everything
+func matchAny(patterns []string, values ...string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		for _, value := range values {
+			if ok, _ := path.Match(pattern, value); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetMaintenanceWindows replaces the configured maintenance windows
+func (m *Manager) SetMaintenanceWindows(windows []MaintenanceWindow) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maintenance = windows
+}
+
+// maintenanceWindow returns the first window covering key at now (called with m.mu held)
+func (m *Manager) maintenanceWindow(key AlertKey, now time.Time) (MaintenanceWindow, bool) {
+	for _, w := range m.maintenance {
+		if w.Matches(key, now) {
+			return w, true
+		}
+	}
+	return MaintenanceWindow{}, false
+}
+
+// suppressForMaintenance withholds action's message when a maintenance window covers
+// key, keeping its state change and marking the state unsent (called with m.mu held)
+func (m *Manager) suppressForMaintenance(key AlertKey, now time.Time, action *alertAction, decision *Decision) {
+	if !action.shouldSend {
+		return
+	}
+	w, ok := m.maintenanceWindow(key, now)
+	if !ok {
+		return
+	}
+	action.shouldSend = false
+	if action.newState != nil {
+		action.newState.Unsent = true
+	}
+	decision.Outcome, decision.Business, decision.Maintenance = OutcomeMaintenanceSuppressed, false, w.Reason
+	log.Printf("[alerts] maintenance window %q (until %s) suppressed %s %s/%s",
+		w.Reason, w.End.Format(time.RFC3339), key.Job, key.Entity, key.Metric)
+}
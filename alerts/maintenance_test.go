@@ -0,0 +1,49 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindowSuppressesAndCatchesUp(t *testing.T) {
+	m, now := newTestManager()
+	m.SetMaintenanceWindows([]MaintenanceWindow{{
+		Start:    *now,
+		End:      now.Add(30 * time.Minute),
+		Entities: []string{"WETH"},
+		Metrics:  []string{"price_deviation_*"},
+		Reason:   "scheduled price post",
+	}})
+	ctx := context.Background()
+	key := AlertKey{Job: "oracle_base", Entity: ChainEntity("base", "WETH"), Metric: "price_deviation_volatile"}
+	other := AlertKey{Job: "oracle_base", Entity: ChainEntity("base", "USDC"), Metric: "price_deviation_stable"}
+
+	m.Observe(ctx, key, SeverityCritical, 8, "", "details", true, "")
+	m.Observe(ctx, other, SeverityCritical, 8, "", "details", true, "")
+
+	log := m.DecisionLog("", "")
+	if log[0].Outcome != OutcomeMaintenanceSuppressed || log[0].Maintenance != "scheduled price post" {
+		t.Errorf("matching alert: outcome %q (%q), want suppressed by the window", log[0].Outcome, log[0].Maintenance)
+	}
+	if log[1].Outcome != OutcomeNew {
+		t.Errorf("unmatched alert: outcome %q, want %q", log[1].Outcome, OutcomeNew)
+	}
+
+	state, ok := m.GetActiveIncidents()[key]
+	if !ok || !state.Unsent {
+		t.Fatalf("suppressed incident state = %+v, %v; want tracked and unsent", state, ok)
+	}
+
+	// Still open after the window: sent once under the same incident
+	*now = now.Add(45 * time.Minute)
+	m.Observe(ctx, key, SeverityCritical, 8, "", "details", true, "")
+	log = m.DecisionLog("oracle_base", "price_deviation_volatile")
+	last := log[len(log)-1]
+	if last.Outcome != OutcomeNew || last.IncidentID != state.IncidentID || !last.Business {
+		t.Errorf("after window: %+v, want business message for %s", last, state.IncidentID)
+	}
+	if got := m.GetActiveIncidents()[key]; got.Unsent || !got.FirstTriggered.Equal(state.FirstTriggered) {
+		t.Errorf("after window: state %+v, want sent and first triggered %v", got, state.FirstTriggered)
+	}
+}
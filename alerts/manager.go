@@ -2,13 +2,27 @@ package alerts
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"expvar"
 	"fmt"
+	"log"
 	"math"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/0x0Glitch/tracing"
+	"github.com/0x0Glitch/version"
 )
 
+// dedupedSends counts updates skipped because they repeated the last message verbatim
+var dedupedSends = expvar.NewInt("alerts_deduped_sends")
+
+// nonFiniteValues counts observations whose NaN or infinite value was replaced, see
+// evaluateObservation
+var nonFiniteValues = expvar.NewInt("alerts_non_finite_values")
+
 // Severity levels for alerts
 type Severity string
 
@@ -20,19 +34,30 @@ const (
 
 // AlertKey uniquely identifies an alert instance
 type AlertKey struct {
-	Job    string // e.g. "oracle_deviation"
-	Entity string // e.g. "WETH", or user address
-	Metric string // e.g. "price_deviation", "health_factor"
+	Job    string `json:"job"`    // e.g. "oracle_deviation"
+	Entity string `json:"entity"` // e.g. "base:WETH" (see ChainEntity), or user address
+	Metric string `json:"metric"` // e.g. "price_deviation", "health_factor"
 }
 
 // AlertState tracks the current state of an alert
 type AlertState struct {
-	Severity       Severity
-	LastSent       time.Time
-	FirstTriggered time.Time
-	LastValue      float64
-	LastMessage    string
-	ConsecutiveOK  int // for hysteresis
+	IncidentID     string    `json:"incident_id"` // short ID shared by every message for this incident
+	Severity       Severity  `json:"severity"`
+	LastSent       time.Time `json:"last_sent"`
+	LastReminder   time.Time `json:"last_reminder,omitzero"` // zero until the first reminder
+	FirstTriggered time.Time `json:"first_triggered"`
+	LastValue      float64   `json:"last_value"`
+	LastMessage    string    `json:"last_message"`
+	ConsecutiveOK  int       `json:"consecutive_ok"` // for hysteresis
+	Unsent         bool      `json:"unsent"`         // last message withheld by a maintenance window or digest
+	// BusinessPending marks a business incident sent to developers only until it has
+	// been open for its policy's MinIncidentDuration
+	BusinessPending bool `json:"business_pending,omitempty"`
+	// Readings withheld by cooldown or MinValueChange since the last message, summarised
+	// in the next update or reminder
+	SuppressedCount int     `json:"suppressed_count,omitempty"`
+	SuppressedMax   float64 `json:"suppressed_max,omitempty"`
+	SuppressedMin   float64 `json:"suppressed_min,omitempty"`
 }
 
 // AlertPolicy defines the behavior for a specific alert type
@@ -49,14 +74,34 @@ type AlertPolicy struct {
 	// Sorted by threshold descending (highest first)
 	DynamicCooldowns []DynamicCooldown
 
-	// Optional periodic reminder while still bad
+	// Optional periodic reminder while still CRITICAL, due every ReminderInterval from
+	// the incident start or the previous reminder; other messages do not move it
 	ReminderInterval time.Duration
 
+	// A due reminder waits until no message has been sent for ReminderCooldown, so it
+	// never directly follows an update. 0 uses the severity cooldown, see calculateCooldown.
+	// A reminder restarts the update cooldown like any other message.
+	ReminderCooldown time.Duration
+
 	// Threshold to trigger the alert
 	TriggerThreshold float64
 
 	// Number of consecutive OK readings before clearing
 	ConsecutiveOKRequired int
+
+	// Also send CRITICAL -> WARNING de-escalations of business alerts to the business
+	// channel; by default they go to developers only
+	DeescalationToBusiness bool
+
+	// Roll up new incidents sharing this job and metric: once GroupThreshold have been
+	// sent within GroupWindow, further ones are held and sent together by FlushGroups
+	// (0 disables)
+	GroupThreshold int
+	GroupWindow    time.Duration
+
+	// Business incidents go to developers only until they have been open this long;
+	// ones that clear sooner never reach business (0 pages at once)
+	MinIncidentDuration time.Duration
 }
 
 type DynamicCooldown struct {
@@ -66,30 +111,101 @@ type DynamicCooldown struct {
 
 // Manager handles stateful alert lifecycle management
 type Manager struct {
-	mu       sync.RWMutex
-	states   map[AlertKey]*AlertState
-	policies map[string]AlertPolicy // keyed by "job:metric"
-	service  *Service
-	clock    func() time.Time // for testability
+	mu        sync.RWMutex
+	states    map[AlertKey]*AlertState
+	policies  map[string]AlertPolicy // effective policies, keyed by "job:metric"
+	defaults  map[string]AlertPolicy // policies registered by jobs
+	overrides map[string]AlertPolicy // policies from config, take precedence over defaults
+	// pendingIDs holds incident IDs whose first message failed to send, so the retry
+	// carries the same ID (and identical content) if the failed send was actually delivered
+	pendingIDs map[AlertKey]string
+	// businessSent records when each incident last reached the business channel, so
+	// developer copies within devCopyWindow can be suppressed (0 disables)
+	businessSent  map[string]time.Time
+	devCopyWindow time.Duration
+	service       *Service
+	clock         func() time.Time // for testability
+	decisions     *decisionLog     // recent Observe decisions, see DecisionLog
+	maintenance   []MaintenanceWindow
+	mute          businessMute
+	groups        map[string]*alertGroup // by "job:metric", see holdForGroup
+	digestMetrics map[string][]string    // by job, see SetDigestMetrics
+	routing       BusinessRouting        // config overrides of jobs' business flags
+
+	// onDeliveryFailure is called when an alert could not be sent, e.g. to report it elsewhere
+	onDeliveryFailure func(key AlertKey, err error)
 }
 
 // NewManager creates a new alert manager
 func NewManager(service *Service) *Manager {
-	return &Manager{
-		states:   make(map[AlertKey]*AlertState),
-		policies: make(map[string]AlertPolicy),
-		service:  service,
-		clock:    time.Now,
+	m := &Manager{
+		states:       make(map[AlertKey]*AlertState),
+		policies:     make(map[string]AlertPolicy),
+		defaults:     make(map[string]AlertPolicy),
+		overrides:    make(map[string]AlertPolicy),
+		pendingIDs:   make(map[AlertKey]string),
+		businessSent: make(map[string]time.Time),
+		service:      service,
+		clock:        time.Now,
+		decisions:    newDecisionLog(DefaultDecisionLogSize),
 	}
+	service.health.mu.Lock()
+	service.health.onChange = m.notifyDeliveryChange
+	service.health.mu.Unlock()
+	return m
 }
 
-// RegisterPolicy registers an alert policy for a job:metric combination
+// SetDeveloperCopyWindow suppresses developer-channel messages for incidents that
+// reached the business channel within window. Zero sends every developer copy.
+func (m *Manager) SetDeveloperCopyWindow(window time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.devCopyWindow = window
+}
+
+// SetClock replaces time.Now for cooldowns, reminders and incident timestamps
+func (m *Manager) SetClock(clock func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = clock
+}
+
+// SetDeliveryFailureHandler registers fn to be called whenever sending an alert fails.
+// fn must not block; it runs on the observing job's goroutine.
+func (m *Manager) SetDeliveryFailureHandler(fn func(key AlertKey, err error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onDeliveryFailure = fn
+}
+
+// RegisterPolicy registers an alert policy for a job:metric combination,
+// replacing any previously registered one. A configured override still takes precedence.
 func (m *Manager) RegisterPolicy(job, metric string, policy AlertPolicy) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	key := fmt.Sprintf("%s:%s", job, metric)
-	m.policies[key] = policy
+	m.registerPolicy(PolicyKey(job, metric), policy)
+}
+
+// RegisterDefaultPolicy registers an alert policy only if none is registered yet
+// for the job:metric combination. It reports whether the policy was registered.
+func (m *Manager) RegisterDefaultPolicy(job, metric string, policy AlertPolicy) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := PolicyKey(job, metric)
+	if _, exists := m.defaults[key]; exists {
+		return false
+	}
+	m.registerPolicy(key, policy)
+	return true
+}
+
+func (m *Manager) registerPolicy(key string, policy AlertPolicy) {
+	m.defaults[key] = policy
+	if _, overridden := m.overrides[key]; !overridden {
+		m.policies[key] = policy
+	}
 }
 
 // alertAction represents what action to take after evaluating an observation
@@ -124,7 +240,24 @@ func (m *Manager) Observe(
 
 	// Send alert outside of lock to prevent blocking
 	if action.shouldSend {
-		if err := m.sendAlert(ctx, action.message, action.isBusinessAlert, action.slackMessage); err != nil {
+		incidentID := ""
+		if action.newState != nil {
+			incidentID = action.newState.IncidentID
+		}
+		sendCtx, span := tracing.Start(ctx, "alert.send",
+			tracing.Job(key.Job), tracing.Metric(key.Metric), tracing.Severity(string(severity)), tracing.Business(action.isBusinessAlert))
+		err := m.sendAlert(sendCtx, key.Job, key.Metric, incidentID, action.message, action.isBusinessAlert, action.slackMessage)
+		tracing.End(span, err)
+		if err != nil {
+			m.mu.Lock()
+			if action.newState != nil && action.newState.IncidentID != "" {
+				m.pendingIDs[key] = action.newState.IncidentID
+			}
+			onFailure := m.onDeliveryFailure
+			m.mu.Unlock()
+			if onFailure != nil {
+				onFailure(key, err)
+			}
 			return err
 		}
 	}
@@ -137,6 +270,7 @@ func (m *Manager) Observe(
 		} else if action.newState != nil {
 			m.states[key] = action.newState
 		}
+		delete(m.pendingIDs, key)
 		m.mu.Unlock()
 	}
 
@@ -152,39 +286,66 @@ func (m *Manager) evaluateObservation(
 	details string,
 	isBusinessAlert bool,
 	slackMessage string,
-) alertAction {
+) (action alertAction) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	now := m.clock()
+	m.adoptLegacyState(key)
 	state, exists := m.states[key]
-	policyKey := fmt.Sprintf("%s:%s", key.Job, key.Metric)
+	policyKey := PolicyKey(key.Job, key.Metric)
 	policy, hasPolicy := m.policies[policyKey]
 
 	// Use default policy if none registered
 	if !hasPolicy {
-		policy = AlertPolicy{
-			MinValueChange:        10.0,
-			CooldownWarning:       15 * time.Minute,
-			CooldownCritical:      5 * time.Minute,
-			ReminderInterval:      60 * time.Minute,
-			ConsecutiveOKRequired: 2,
+		policy = fallbackPolicy
+	}
+
+	// A NaN or infinite value from a bad computation would poison the value-change
+	// check and break state export. The job's severity still stands; the value is
+	// replaced by the incident's last one so only the severity can trigger a message.
+	nonFinite := math.IsNaN(value) || math.IsInf(value, 0)
+	if nonFinite {
+		nonFiniteValues.Add(1)
+		replacement := 0.0
+		if exists {
+			replacement = state.LastValue
 		}
+		log.Printf("[alerts] warning: %s/%s/%s observed with non-finite value %v, using %g",
+			key.Job, key.Entity, key.Metric, value, replacement)
+		value = replacement
+	}
+
+	// Record the outcome in the decision log before the lock is released
+	decision := Decision{Time: now, Job: key.Job, Entity: key.Entity, Metric: key.Metric, Severity: severity, Value: value, NonFiniteValue: nonFinite}
+	if exists {
+		decision.IncidentID = state.IncidentID
 	}
+	defer func() {
+		m.suppressForMaintenance(key, now, &action, &decision)
+		m.suppressForDigest(key, &action, &decision)
+		m.decisions.add(decision)
+	}()
 
 	// 1. Handle OK severity (recovery or clear)
 	if severity == SeverityOK {
 		if !exists {
-			return alertAction{} // nothing to clear
+			decision.Outcome = OutcomeOKNoIncident
+			delete(m.pendingIDs, key) // resolved before the first message got through
+			return alertAction{}      // nothing to clear
 		}
 
 		state.ConsecutiveOK++
+		decision.ConsecutiveOK = state.ConsecutiveOK
+		decision.ConsecutiveOKRequired = policy.ConsecutiveOKRequired
 
 		// Need multiple consecutive OK readings for hysteresis
 		if state.ConsecutiveOK >= policy.ConsecutiveOKRequired && state.Severity != SeverityOK {
 			// Silently clear the alert without sending a recovery notification
+			decision.Outcome = OutcomeCleared
 			return alertAction{deleteState: true}
 		}
+		decision.Outcome = OutcomeOKCounted
 		// Update state with incremented ConsecutiveOK
 		m.states[key] = state
 		return alertAction{}
@@ -195,60 +356,121 @@ func (m *Manager) evaluateObservation(
 		state.ConsecutiveOK = 0
 	}
 
-	// 2. New incident (no previous state or was OK)
-	if !exists || state.Severity == SeverityOK {
-		msg := m.formatNewIncidentMessage(key, severity, value, summary, details)
+	// 2. New incident (no previous state or was OK), or one whose first message a
+	// maintenance window withheld
+	if !exists || state.Severity == SeverityOK || state.Unsent {
+		// Reuse the ID of a first message whose send failed; it may have been delivered anyway
+		incidentID, pending := m.pendingIDs[key]
+		firstTriggered := now
+		if exists && state.Unsent {
+			incidentID, pending, firstTriggered = state.IncidentID, true, state.FirstTriggered
+		}
+		if !pending {
+			incidentID = newIncidentID()
+		}
+		msg := m.formatNewIncidentMessage(key, incidentID, severity, value, summary, details)
+		businessPending := isBusinessAlert && policy.MinIncidentDuration > 0
+		if businessPending {
+			isBusinessAlert, slackMessage = false, ""
+			msg += fmt.Sprintf("\n\nBusiness channel: paged if still open after %v", policy.MinIncidentDuration)
+		}
+		decision.Outcome, decision.IncidentID, decision.Business = OutcomeNew, incidentID, isBusinessAlert
+		newState := &AlertState{
+			IncidentID:      incidentID,
+			Severity:        severity,
+			LastSent:        now,
+			FirstTriggered:  firstTriggered,
+			LastValue:       value,
+			LastMessage:     msg,
+			ConsecutiveOK:   0,
+			BusinessPending: businessPending,
+		}
+		held := heldIncident{key: key, incidentID: incidentID, severity: severity, details: details, business: isBusinessAlert}
+		if m.holdForGroup(policy, now, held) {
+			decision.Outcome, decision.Business = OutcomeGrouped, false
+			return alertAction{newState: newState}
+		}
 		return alertAction{
 			shouldSend:      true,
 			message:         msg,
 			isBusinessAlert: isBusinessAlert,
-			slackMessage:    slackMessage,
-			newState: &AlertState{
-				Severity:       severity,
-				LastSent:       now,
-				FirstTriggered: now,
-				LastValue:      value,
-				LastMessage:    msg,
-				ConsecutiveOK:  0,
-			},
+			slackMessage:    tagSlackMessage(incidentID, slackMessage),
+			newState:        newState,
 		}
 	}
 
+	// Page business once an incident held back by MinIncidentDuration has lasted long
+	// enough; until then its messages go to developers only
+	if state.BusinessPending && isBusinessAlert {
+		if now.Sub(state.FirstTriggered) >= policy.MinIncidentDuration {
+			msg := m.formatNewIncidentMessage(key, state.IncidentID, severity, value, summary, details) +
+				"\n\nOpen since: " + FormatTime(state.FirstTriggered, now)
+			decision.Outcome, decision.Business = OutcomePromoted, true
+			return alertAction{
+				shouldSend:      true,
+				message:         msg,
+				isBusinessAlert: true,
+				slackMessage:    tagSlackMessage(state.IncidentID, slackMessage),
+				newState: &AlertState{
+					IncidentID:     state.IncidentID,
+					Severity:       severity,
+					LastSent:       now,
+					LastReminder:   state.LastReminder,
+					FirstTriggered: state.FirstTriggered,
+					LastValue:      value,
+					LastMessage:    msg,
+					ConsecutiveOK:  0,
+				},
+			}
+		}
+		isBusinessAlert, slackMessage = false, ""
+	}
+
 	// 3. Escalation (WARNING -> CRITICAL)
 	if severityLevel(severity) > severityLevel(state.Severity) {
 		msg := m.formatEscalationMessage(key, state, severity, value, summary, details)
+		decision.Outcome, decision.Business = OutcomeEscalation, isBusinessAlert
 		return alertAction{
 			shouldSend:      true,
 			message:         msg,
 			isBusinessAlert: isBusinessAlert,
-			slackMessage:    slackMessage,
+			slackMessage:    tagSlackMessage(state.IncidentID, slackMessage),
 			newState: &AlertState{
-				Severity:       severity,
-				LastSent:       now,
-				FirstTriggered: state.FirstTriggered,
-				LastValue:      value,
-				LastMessage:    msg,
-				ConsecutiveOK:  0,
+				IncidentID:      state.IncidentID,
+				Severity:        severity,
+				LastSent:        now,
+				LastReminder:    state.LastReminder,
+				FirstTriggered:  state.FirstTriggered,
+				LastValue:       value,
+				LastMessage:     msg,
+				ConsecutiveOK:   0,
+				BusinessPending: state.BusinessPending,
 			},
 		}
 	}
 
 	// 4. De-escalation (CRITICAL -> WARNING)
 	if severityLevel(severity) < severityLevel(state.Severity) {
-		// De-escalation goes to developer channel only, not business (no Slack)
+		// De-escalation goes to the developer channel only unless the policy also routes
+		// it to business (never Slack)
 		msg := m.formatDeescalationMessage(key, state, severity, value, summary, details)
+		toBusiness := isBusinessAlert && policy.DeescalationToBusiness
+		decision.Outcome, decision.Business = OutcomeDeescalation, toBusiness
 		return alertAction{
 			shouldSend:      true,
 			message:         msg,
-			isBusinessAlert: false,
+			isBusinessAlert: toBusiness,
 			slackMessage:    "",
 			newState: &AlertState{
-				Severity:       severity,
-				LastSent:       now,
-				FirstTriggered: state.FirstTriggered,
-				LastValue:      value,
-				LastMessage:    msg,
-				ConsecutiveOK:  0,
+				IncidentID:      state.IncidentID,
+				Severity:        severity,
+				LastSent:        now,
+				LastReminder:    state.LastReminder,
+				FirstTriggered:  state.FirstTriggered,
+				LastValue:       value,
+				LastMessage:     msg,
+				ConsecutiveOK:   0,
+				BusinessPending: state.BusinessPending,
 			},
 		}
 	}
@@ -257,33 +479,48 @@ func (m *Manager) evaluateObservation(
 	cooldown := m.calculateCooldown(policy, severity, value)
 
 	timeSinceLastSent := now.Sub(state.LastSent)
-	timeSinceFirstTriggered := now.Sub(state.FirstTriggered)
+	decision.CooldownSeconds = cooldown.Seconds()
+	decision.SinceLastSentSeconds = timeSinceLastSent.Seconds()
 
 	// Check for periodic reminder
 	// Reminders only go to developer channel, and only for CRITICAL issues (no Slack)
+	reminderCooldown := policy.ReminderCooldown
+	if reminderCooldown <= 0 {
+		reminderCooldown = cooldown
+	}
 	if policy.ReminderInterval > 0 &&
-		timeSinceFirstTriggered >= policy.ReminderInterval &&
-		timeSinceLastSent >= policy.ReminderInterval &&
+		now.Sub(lastReminderAt(state)) >= policy.ReminderInterval &&
+		timeSinceLastSent >= reminderCooldown &&
 		severity == SeverityCritical {
-		msg := m.formatNewIncidentMessage(key, severity, value, summary, details)
+		footer := "Open since: " + FormatTime(state.FirstTriggered, now)
+		if line := state.suppressedLine(); line != "" {
+			footer = line + "\n" + footer
+		}
+		msg := m.formatNewIncidentMessage(key, state.IncidentID, severity, value, summary, details) + "\n\n" + footer
+		decision.Outcome = OutcomeReminder
 		return alertAction{
 			shouldSend:      true,
 			message:         msg,
 			isBusinessAlert: false,
 			slackMessage:    "",
 			newState: &AlertState{
-				Severity:       severity,
-				LastSent:       now,
-				FirstTriggered: state.FirstTriggered,
-				LastValue:      value,
-				LastMessage:    msg,
-				ConsecutiveOK:  0,
+				IncidentID:      state.IncidentID,
+				Severity:        severity,
+				LastSent:        now,
+				LastReminder:    now,
+				FirstTriggered:  state.FirstTriggered,
+				LastValue:       value,
+				LastMessage:     msg,
+				ConsecutiveOK:   0,
+				BusinessPending: state.BusinessPending,
 			},
 		}
 	}
 
 	// Still in cooldown period
 	if timeSinceLastSent < cooldown {
+		decision.Outcome = OutcomeCooldownSuppressed
+		state.recordSuppressed(value)
 		return alertAction{}
 	}
 
@@ -294,31 +531,56 @@ func (m *Manager) evaluateObservation(
 	} else if value != 0 {
 		percentChange = 100.0 // 0 to any non-zero value is considered 100% change
 	}
+	decision.ValueChangePercent = percentChange
+	decision.MinValueChange = policy.MinValueChange
 	if percentChange < policy.MinValueChange {
+		decision.Outcome = OutcomeMinChangeSuppressed
+		state.recordSuppressed(value)
 		return alertAction{} // minor fluctuation, don't resend
 	}
 
 	// Significant change after cooldown
 	msg := m.formatUpdateMessage(key, state, severity, value, summary, details)
+
+	// A move within the details' rounding can still pass MinValueChange; resending the
+	// identical text tells nobody anything, so only restart the cooldown
+	if msg == state.LastMessage {
+		dedupedSends.Add(1)
+		decision.Outcome = OutcomeDuplicateSuppressed
+		refreshed := *state
+		refreshed.LastSent = now
+		refreshed.recordSuppressed(value)
+		return alertAction{newState: &refreshed}
+	}
 	// Updates for CRITICAL go to business, WARNING updates go to developer only
 	sendToBusiness := isBusinessAlert && severity == SeverityCritical
 	slackForUpdate := ""
 	if sendToBusiness {
-		slackForUpdate = slackMessage
+		slackForUpdate = tagSlackMessage(state.IncidentID, slackMessage)
 	}
+	decision.Outcome, decision.Business = OutcomeUpdate, sendToBusiness
 
+	// LastMessage keeps the text without the summary so the duplicate check above
+	// compares what the job reported
+	text := msg
+	if line := state.suppressedLine(); line != "" {
+		text += "\n\n" + line
+	}
 	return alertAction{
 		shouldSend:      true,
-		message:         msg,
+		message:         text,
 		isBusinessAlert: sendToBusiness,
 		slackMessage:    slackForUpdate,
 		newState: &AlertState{
-			Severity:       severity,
-			LastSent:       now,
-			FirstTriggered: state.FirstTriggered,
-			LastValue:      value,
-			LastMessage:    msg,
-			ConsecutiveOK:  0,
+			IncidentID:      state.IncidentID,
+			Severity:        severity,
+			LastSent:        now,
+			LastReminder:    state.LastReminder,
+			FirstTriggered:  state.FirstTriggered,
+			LastValue:       value,
+			LastMessage:     msg,
+			ConsecutiveOK:   0,
+			BusinessPending: state.BusinessPending,
 		},
 	}
 }
@@ -344,6 +606,39 @@ func (m *Manager) ClearAll() {
 	m.states = make(map[AlertKey]*AlertState)
 }
 
+// recordSuppressed counts a reading withheld since the last message
+func (s *AlertState) recordSuppressed(value float64) {
+	if s.SuppressedCount == 0 || value > s.SuppressedMax {
+		s.SuppressedMax = value
+	}
+	if s.SuppressedCount == 0 || value < s.SuppressedMin {
+		s.SuppressedMin = value
+	}
+	s.SuppressedCount++
+}
+
+// suppressedLine summarises the readings withheld since the last message, or is empty
+// when there were none
+func (s *AlertState) suppressedLine() string {
+	if s.SuppressedCount == 0 {
+		return ""
+	}
+	readings := "readings"
+	if s.SuppressedCount == 1 {
+		readings = "reading"
+	}
+	return fmt.Sprintf("Since last message: %d %s, peak %.4g, low %.4g", s.SuppressedCount, readings, s.SuppressedMax, s.SuppressedMin)
+}
+
+// lastReminderAt is when state's reminder interval started: its last reminder, or the
+// incident start before the first one
+func lastReminderAt(state *AlertState) time.Time {
+	if state.LastReminder.After(state.FirstTriggered) {
+		return state.LastReminder
+	}
+	return state.FirstTriggered
+}
+
 func (m *Manager) calculateCooldown(policy AlertPolicy, severity Severity, value float64) time.Duration {
 	// Check for dynamic cooldowns first
 	if len(policy.DynamicCooldowns) > 0 {
@@ -362,11 +657,17 @@ func (m *Manager) calculateCooldown(policy AlertPolicy, severity Severity, value
 	return policy.CooldownWarning
 }
 
-func (m *Manager) sendAlert(ctx context.Context, message string, isBusinessAlert bool, slackMessage string) error {
+func (m *Manager) sendAlert(ctx context.Context, job, metric, incidentID, message string, isBusinessAlert bool, slackMessage string) error {
+	isBusinessAlert = m.routeBusiness(job, metric, isBusinessAlert)
+	if isBusinessAlert && m.suppressBusiness(ctx, job) {
+		// Muted: the developer copy is the only delivery
+		return m.service.SendDeveloperAlertFor(ctx, job, withBuildFooter(message))
+	}
 	if isBusinessAlert {
-		if err := m.service.SendBusinessAlert(ctx, message); err != nil {
+		if err := m.service.SendBusinessAlertFor(ctx, job, message); err != nil {
 			return err
 		}
+		m.recordBusinessSend(incidentID)
 		// Also send to Slack for business alerts if slackMessage is provided
 		if slackMessage != "" {
 			if err := m.service.SendSlackAlert(ctx, slackMessage); err != nil {
@@ -375,13 +676,59 @@ func (m *Manager) sendAlert(ctx context.Context, message string, isBusinessAlert
 			}
 		}
 		// Also send business alerts to developer channel for visibility
-		if err := m.service.SendDeveloperAlert(ctx, message); err != nil {
+		if m.suppressDeveloperCopy(incidentID) {
+			return nil
+		}
+		if err := m.service.SendDeveloperAlertFor(ctx, job, withBuildFooter(message)); err != nil {
 			// Log but don't fail - business channel is primary
 			fmt.Printf("[alerts] developer alert failed: %v\n", err)
 		}
 		return nil
 	}
-	return m.service.SendDeveloperAlert(ctx, message)
+	if m.suppressDeveloperCopy(incidentID) {
+		return nil
+	}
+	return m.service.SendDeveloperAlertFor(ctx, job, withBuildFooter(message))
+}
+
+// withBuildFooter tags developer-channel messages with the build that sent them
+func withBuildFooter(message string) string {
+	return message + "\n\n" + version.Short()
+}
+
+// recordBusinessSend notes that incidentID reached the business channel, pruning
+// entries that have aged out of the suppression window
+func (m *Manager) recordBusinessSend(incidentID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.devCopyWindow <= 0 || incidentID == "" {
+		return
+	}
+	now := m.clock()
+	for id, sentAt := range m.businessSent {
+		if now.Sub(sentAt) > m.devCopyWindow {
+			delete(m.businessSent, id)
+		}
+	}
+	m.businessSent[incidentID] = now
+}
+
+// suppressDeveloperCopy reports whether a developer message for incidentID should be
+// skipped because the business channel received the incident within the window
+func (m *Manager) suppressDeveloperCopy(incidentID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.devCopyWindow <= 0 || incidentID == "" {
+		return false
+	}
+	sentAt, ok := m.businessSent[incidentID]
+	if !ok || m.clock().Sub(sentAt) > m.devCopyWindow {
+		return false
+	}
+	fmt.Printf("[alerts] incident %s already sent to business, skipping developer copy\n", incidentID)
+	return true
 }
 
 func severityLevel(s Severity) int {
@@ -399,34 +746,67 @@ func severityLevel(s Severity) int {
 
 // Message formatting functions
 
-func (m *Manager) getAlertTitle(job, metric string) string {
-	// Use metric-based lookup since job names vary (e.g., oracle_base, oracle_optimism)
-	metricTitles := map[string]string{
-		"price_deviation_stable":   "STABLECOIN DEPEG ALERT",
-		"price_deviation_volatile": "ORACLE PRICE DEVIATION",
-		"system_health":            "ORACLE SYSTEM HEALTH",
-		"data_staleness":           "DATA STALE",
-		"token_error":              "TOKEN PRICE ERROR",
-		"position_risk":            "LOW HEALTH FACTOR POSITION",
-		"risky_count_spike":        "RISKY POSITIONS SPIKE",
-		"avg_hf_drop":              "AVERAGE HEALTH FACTOR DROP",
-		"withdrawal_spike":         "WITHDRAWAL SPIKE ALERT",
-		"borrow_spike":             "BORROW SPIKE ALERT",
-		"whale_supply":             "WHALE POSITION ALERT",
-		"borrow_top10":             "BORROW CONCENTRATION - TOP 10",
-		"borrow_single":            "BORROW CONCENTRATION - SINGLE WALLET",
-	}
+// metricTitles names every metric the monitors emit. Job names vary (e.g.
+// oracle_base, oracle_optimism), so titles are looked up by metric.
+var metricTitles = map[string]string{
+	"price_deviation_stable":      "STABLECOIN DEPEG ALERT",
+	"price_deviation_volatile":    "ORACLE PRICE DEVIATION",
+	"feed_divergence":             "ORACLE FEED DIVERGES FROM MARKET",
+	"oracle_divergence":           "ORACLE DISAGREES WITH ITS FEED",
+	"system_health":               "ORACLE SYSTEM HEALTH",
+	"health_score":                "LOW ORACLE HEALTH SCORE",
+	"consecutive_errors":          "PERSISTENT ORACLE ERRORS",
+	"circuit_breaker":             "ORACLE CIRCUIT BREAKER OPEN",
+	"rpc_unconnected":             "ORACLE MONITOR NOT CONNECTED",
+	"price_precision":             "SUSPICIOUS ORACLE PRICE POST",
+	"data_staleness":              "DATA STALE",
+	"token_error":                 "TOKEN PRICE ERROR",
+	"reference_price_unavailable": "NO REFERENCE PRICE",
+	"token_degraded":              "TOKEN DEGRADED",
+	"is_price_oracle":             "ORACLE CONTRACT CHANGED",
+	"token_count":                 "UNEXPECTED TOKEN COUNT",
+	"oracle_feeds":                "ORACLE FEED CHECK",
+	"decimals_mismatch":           "TOKEN DECIMALS MISMATCH",
+	"slow_run":                    "SLOW MONITOR RUN",
+	"broad_market_move":           "BROAD MARKET MOVE",
+	"position_risk":               "LOW HEALTH FACTOR POSITION",
+	"risky_count_spike":           "RISKY POSITIONS SPIKE",
+	"avg_hf_drop":                 "AVERAGE HEALTH FACTOR DROP",
+	"withdrawal_spike":            "WITHDRAWAL SPIKE ALERT",
+	"borrow_spike":                "BORROW SPIKE ALERT",
+	"protocol_stress":             "PROTOCOL STRESS EVENT",
+	"indexer_drift":               "INDEXER DRIFT",
+	"indexer_missing_market":      "INDEXER MISSING MARKET",
+	"chain_head_age":              "RPC NODE BEHIND",
+	"collateral_collapse":         "TOTAL COLLATERAL COLLAPSE",
+	"borrow_collapse":             "TOTAL BORROW COLLAPSE",
+	"whale_supply":                "WHALE POSITION ALERT",
+	"borrow_top10":                "BORROW CONCENTRATION - TOP 10",
+	"borrow_single":               "BORROW CONCENTRATION - SINGLE WALLET",
+	"borrow_concentration_trend":  "BORROW CONCENTRATION RISING",
+	"reference_price_rejected":    "REFERENCE PRICE REJECTED",
+	"reference_staleness":         "STALE REFERENCE PRICE",
+	"reference_disagreement":      "REFERENCE SOURCES DISAGREE",
+}
 
+func (m *Manager) getAlertTitle(job, metric string) string {
 	if title, ok := metricTitles[metric]; ok {
 		return title
 	}
 	return strings.ToUpper(strings.ReplaceAll(metric, "_", " "))
 }
 
-func (m *Manager) formatNewIncidentMessage(key AlertKey, severity Severity, value float64, summary, details string) string {
+// knownMetric reports whether metric is one the monitors emit
+func knownMetric(metric string) bool {
+	_, ok := metricTitles[metric]
+	return ok
+}
+
+func (m *Manager) formatNewIncidentMessage(key AlertKey, incidentID string, severity Severity, value float64, summary, details string) string {
 	title := m.getAlertTitle(key.Job, key.Metric)
 	return fmt.Sprintf(
-		"🚨 %s\n\n%s",
+		"🚨 [%s] %s\n\n%s",
+		incidentID,
 		title,
 		details,
 	)
@@ -435,7 +815,8 @@ func (m *Manager) formatNewIncidentMessage(key AlertKey, severity Severity, valu
 func (m *Manager) formatEscalationMessage(key AlertKey, state *AlertState, newSeverity Severity, value float64, summary, details string) string {
 	title := m.getAlertTitle(key.Job, key.Metric)
 	return fmt.Sprintf(
-		"🚨 %s\n\n%s",
+		"🚨 [%s] %s\n\n%s",
+		state.IncidentID,
 		title,
 		details,
 	)
@@ -444,8 +825,11 @@ func (m *Manager) formatEscalationMessage(key AlertKey, state *AlertState, newSe
 func (m *Manager) formatDeescalationMessage(key AlertKey, state *AlertState, newSeverity Severity, value float64, summary, details string) string {
 	title := m.getAlertTitle(key.Job, key.Metric)
 	return fmt.Sprintf(
-		"✅ %s\n\n%s",
+		"✅ [%s] %s\n%s → %s\n\n%s",
+		state.IncidentID,
 		title,
+		state.Severity,
+		newSeverity,
 		details,
 	)
 }
@@ -453,8 +837,26 @@ func (m *Manager) formatDeescalationMessage(key AlertKey, state *AlertState, new
 func (m *Manager) formatUpdateMessage(key AlertKey, state *AlertState, severity Severity, value float64, summary, details string) string {
 	title := m.getAlertTitle(key.Job, key.Metric)
 	return fmt.Sprintf(
-		"🚨 %s\n\n%s",
+		"🚨 [%s] %s\n\n%s",
+		state.IncidentID,
 		title,
 		details,
 	)
 }
+
+// newIncidentID returns a short random identifier such as "INC-3f9a"
+func newIncidentID() string {
+	b := make([]byte, 2)
+	if _, err := rand.Read(b); err != nil {
+		return "INC-0000"
+	}
+	return "INC-" + hex.EncodeToString(b)
+}
+
+// tagSlackMessage prefixes a Slack message with its incident ID
+func tagSlackMessage(incidentID, message string) string {
+	if message == "" {
+		return ""
+	}
+	return fmt.Sprintf("[%s] %s", incidentID, message)
+}
@@ -0,0 +1,330 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeescalationRouting(t *testing.T) {
+	for _, toBusiness := range []bool{false, true} {
+		var chats []string
+		var last string
+		s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			var payload struct {
+				ChatID string `json:"chat_id"`
+				Text   string `json:"text"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			chats, last = append(chats, payload.ChatID), payload.Text
+		})
+		m := NewManager(s)
+		m.RegisterPolicy("oracle_base", "price_deviation_volatile", AlertPolicy{
+			CooldownWarning:        time.Hour,
+			CooldownCritical:       time.Hour,
+			ConsecutiveOKRequired:  1,
+			DeescalationToBusiness: toBusiness,
+		})
+		key := AlertKey{Job: "oracle_base", Entity: "WETH", Metric: "price_deviation_volatile"}
+		ctx := context.Background()
+		m.Observe(ctx, key, SeverityCritical, 8, "", "critical details", true, "")
+
+		chats = nil
+		m.Observe(ctx, key, SeverityWarning, 4, "", "warning details", true, "")
+		want := []string{"developer-chat"}
+		if toBusiness {
+			want = []string{"business-chat", "developer-chat"}
+		}
+		if strings.Join(chats, ",") != strings.Join(want, ",") {
+			t.Errorf("DeescalationToBusiness=%v: sent to %v, want %v", toBusiness, chats, want)
+		}
+		if !strings.Contains(last, "CRITICAL → WARNING") {
+			t.Errorf("de-escalation message %q lacks the severity change", last)
+		}
+	}
+}
+
+func TestIdenticalUpdateIsDeduplicated(t *testing.T) {
+	m, now := newTestManager()
+	m.RegisterPolicy("oracle_base", "price_deviation_volatile", AlertPolicy{
+		MinValueChange:        0.01, // percent; far below the 2-decimal rounding of the details
+		CooldownWarning:       10 * time.Minute,
+		CooldownCritical:      10 * time.Minute,
+		ConsecutiveOKRequired: 1,
+	})
+	key := AlertKey{Job: "oracle_base", Entity: "WETH", Metric: "price_deviation_volatile"}
+	ctx := context.Background()
+	before := dedupedSends.Value()
+
+	values := []float64{3.001, 3.004, 3.002, 3.0041}
+	for _, v := range values {
+		m.Observe(ctx, key, SeverityWarning, v, "", fmt.Sprintf("Deviation: %.2f%%", v), false, "")
+		*now = now.Add(11 * time.Minute)
+	}
+
+	log := m.DecisionLog("", "")
+	if log[0].Outcome != OutcomeNew {
+		t.Fatalf("first observation: %q, want %q", log[0].Outcome, OutcomeNew)
+	}
+	for i, d := range log[1:] {
+		if d.Outcome != OutcomeDuplicateSuppressed {
+			t.Errorf("observation %d (%.4f): outcome %q, want %q", i+1, values[i+1], d.Outcome, OutcomeDuplicateSuppressed)
+		}
+	}
+	if got := dedupedSends.Value() - before; got != int64(len(values)-1) {
+		t.Errorf("deduped sends = %d, want %d", got, len(values)-1)
+	}
+	state := m.GetActiveIncidents()[key]
+	if want := now.Add(-11 * time.Minute); !state.LastSent.Equal(want) {
+		t.Errorf("LastSent = %v, want refreshed to %v", state.LastSent, want)
+	}
+
+	// A change visible in the message is sent
+	m.Observe(ctx, key, SeverityWarning, 3.5, "", "Deviation: 3.50%", false, "")
+	if got := m.DecisionLog("", ""); got[len(got)-1].Outcome != OutcomeUpdate {
+		t.Errorf("visible change: outcome %q, want %q", got[len(got)-1].Outcome, OutcomeUpdate)
+	}
+}
+
+func TestReminderShowsIncidentStart(t *testing.T) {
+	m, now := newTestManager()
+	m.RegisterPolicy("oracle_base", "system_health", AlertPolicy{
+		CooldownWarning:       10 * time.Minute,
+		CooldownCritical:      10 * time.Minute,
+		ReminderInterval:      time.Hour,
+		ConsecutiveOKRequired: 1,
+	})
+	key := AlertKey{Job: "oracle_base", Entity: "base:system", Metric: "system_health"}
+	ctx := context.Background()
+
+	m.Observe(ctx, key, SeverityCritical, 60, "", "Success: 40.0%", false, "")
+	*now = now.Add(3*time.Hour + 12*time.Minute)
+	m.Observe(ctx, key, SeverityCritical, 60, "", "Success: 40.0%", false, "")
+
+	state := m.GetActiveIncidents()[key]
+	if want := "Open since: 2026-01-01T00:00:00Z (3h12m ago)"; !strings.HasSuffix(state.LastMessage, want) {
+		t.Errorf("reminder message %q does not end with %q", state.LastMessage, want)
+	}
+}
+
+func TestReminderTiming(t *testing.T) {
+	// An observation after the incident opened, with its expected outcome
+	type step struct {
+		at      time.Duration
+		value   float64
+		outcome string
+	}
+	tests := []struct {
+		name             string
+		reminderCooldown time.Duration
+		steps            []step
+	}{
+		{
+			name:             "separate reminder cooldown",
+			reminderCooldown: 30 * time.Minute,
+			steps: []step{
+				{50 * time.Minute, 80, OutcomeUpdate},
+				{60 * time.Minute, 80, OutcomeMinChangeSuppressed}, // due, but 10m after the update
+				{80 * time.Minute, 80, OutcomeReminder},
+				{2 * time.Hour, 80, OutcomeMinChangeSuppressed}, // 40m after the last reminder
+				{140 * time.Minute, 80, OutcomeReminder},
+			},
+		},
+		{
+			name: "severity cooldown by default",
+			steps: []step{
+				{55 * time.Minute, 80, OutcomeUpdate},
+				{60 * time.Minute, 80, OutcomeCooldownSuppressed},
+				{65 * time.Minute, 80, OutcomeReminder}, // not pushed back to 1h55m by the update
+				{125 * time.Minute, 80, OutcomeReminder},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, now := newTestManager()
+			start := *now
+			m.RegisterPolicy("oracle_base", "system_health", AlertPolicy{
+				MinValueChange:        10,
+				CooldownWarning:       10 * time.Minute,
+				CooldownCritical:      10 * time.Minute,
+				ReminderInterval:      time.Hour,
+				ReminderCooldown:      tt.reminderCooldown,
+				ConsecutiveOKRequired: 1,
+			})
+			key := AlertKey{Job: "oracle_base", Entity: "base:system", Metric: "system_health"}
+			ctx := context.Background()
+
+			m.Observe(ctx, key, SeverityCritical, 60, "", "Success: 40.0%", false, "")
+			for _, step := range tt.steps {
+				*now = start.Add(step.at)
+				m.Observe(ctx, key, SeverityCritical, step.value, "", fmt.Sprintf("Success: %.1f%%", 100-step.value), false, "")
+				log := m.DecisionLog("", "")
+				if got := log[len(log)-1].Outcome; got != step.outcome {
+					t.Errorf("at %v: outcome %q, want %q", step.at, got, step.outcome)
+				}
+			}
+		})
+	}
+}
+
+func TestNonFiniteValueKeepsStateUsable(t *testing.T) {
+	m, now := newTestManager()
+	m.RegisterPolicy("health_aggregate", "borrow_spike", AlertPolicy{
+		MinValueChange:        10,
+		CooldownWarning:       10 * time.Minute,
+		CooldownCritical:      10 * time.Minute,
+		ConsecutiveOKRequired: 1,
+	})
+	key := AlertKey{Job: "health_aggregate", Entity: "base:market", Metric: "borrow_spike"}
+	ctx := context.Background()
+	lastOutcome := func() Decision {
+		log := m.DecisionLog("", "")
+		return log[len(log)-1]
+	}
+
+	// Without an incident the value becomes 0; the severity still opens one
+	m.Observe(ctx, key, SeverityWarning, math.NaN(), "", "spike", false, "")
+	if d := lastOutcome(); d.Outcome != OutcomeNew || !d.NonFiniteValue || d.Value != 0 {
+		t.Fatalf("first NaN observation: %+v, want a new incident with value 0", d)
+	}
+	*now = now.Add(20 * time.Minute)
+	m.Observe(ctx, key, SeverityWarning, 12, "", "spike 12%", false, "")
+
+	// A bad value after the cooldown is not a value change, but escalation still fires
+	*now = now.Add(20 * time.Minute)
+	m.Observe(ctx, key, SeverityWarning, math.Inf(1), "", "spike", false, "")
+	if d := lastOutcome(); d.Outcome != OutcomeMinChangeSuppressed || d.Value != 12 {
+		t.Errorf("Inf at same severity: %+v, want min change suppressed at the last value", d)
+	}
+	m.Observe(ctx, key, SeverityCritical, math.NaN(), "", "spike", false, "")
+	if d := lastOutcome(); d.Outcome != OutcomeEscalation {
+		t.Errorf("NaN with a higher severity: outcome %q, want escalation", d.Outcome)
+	}
+	if state := m.GetActiveIncidents()[key]; state.LastValue != 12 {
+		t.Errorf("last value = %v, want 12 kept", state.LastValue)
+	}
+
+	// State and decisions stay exportable
+	var buf strings.Builder
+	if err := m.Export(&buf); err != nil {
+		t.Errorf("Export: %v", err)
+	}
+	if _, err := json.Marshal(m.DecisionLog("", "")); err != nil {
+		t.Errorf("decision log does not encode: %v", err)
+	}
+}
+
+func TestMinIncidentDurationHoldsBusinessPage(t *testing.T) {
+	var chats []string
+	s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ChatID string `json:"chat_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		chats = append(chats, payload.ChatID)
+	})
+	m := NewManager(s)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.clock = func() time.Time { return now }
+	m.RegisterPolicy("oracle_base", "price_deviation_stable", AlertPolicy{
+		CooldownWarning:       time.Hour,
+		CooldownCritical:      time.Hour,
+		ConsecutiveOKRequired: 1,
+		MinIncidentDuration:   3 * time.Minute,
+	})
+	ctx := context.Background()
+
+	// A spike that clears within the duration never reaches business
+	spike := AlertKey{Job: "oracle_base", Entity: "DAI", Metric: "price_deviation_stable"}
+	m.Observe(ctx, spike, SeverityCritical, 3, "", "spike", true, "")
+	now = now.Add(time.Minute)
+	m.Observe(ctx, spike, SeverityOK, 0, "", "", true, "")
+	if strings.Join(chats, ",") != "developer-chat" {
+		t.Errorf("self-resolving spike sent to %v, want developers only", chats)
+	}
+
+	// A persistent depeg is promoted once it has lasted long enough
+	chats = nil
+	depeg := AlertKey{Job: "oracle_base", Entity: "USDC", Metric: "price_deviation_stable"}
+	m.Observe(ctx, depeg, SeverityCritical, 3, "", "depeg", true, "")
+	now = now.Add(time.Minute)
+	m.Observe(ctx, depeg, SeverityCritical, 3, "", "depeg", true, "")
+	if strings.Join(chats, ",") != "developer-chat" {
+		t.Fatalf("before the duration sent to %v, want developers only", chats)
+	}
+	now = now.Add(3 * time.Minute)
+	m.Observe(ctx, depeg, SeverityCritical, 3, "", "depeg", true, "")
+	if strings.Join(chats, ",") != "developer-chat,business-chat,developer-chat" {
+		t.Errorf("after the duration sent to %v, want a business page", chats)
+	}
+	decisions := m.DecisionLog("", "")
+	if got := decisions[len(decisions)-1].Outcome; got != OutcomePromoted {
+		t.Errorf("outcome %q, want %q", got, OutcomePromoted)
+	}
+	if m.GetActiveIncidents()[depeg].BusinessPending {
+		t.Error("promoted incident still pending")
+	}
+}
+
+func TestSuppressedReadingsSummary(t *testing.T) {
+	var sent []string
+	s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		json.NewDecoder(r.Body).Decode(&payload)
+		sent = append(sent, fmt.Sprint(payload["text"]))
+		w.Write([]byte(`{"ok": true}`))
+	})
+	m := NewManager(s)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	m.clock = func() time.Time { return now }
+	m.RegisterPolicy("oracle_base", "price_deviation_stablecoin", AlertPolicy{
+		MinValueChange:        10,
+		CooldownCritical:      30 * time.Minute,
+		ReminderInterval:      2 * time.Hour,
+		ConsecutiveOKRequired: 1,
+	})
+	key := AlertKey{Job: "oracle_base", Entity: "USDC", Metric: "price_deviation_stablecoin"}
+	ctx := context.Background()
+	observe := func(at time.Duration, value float64) {
+		now = start.Add(at)
+		m.Observe(ctx, key, SeverityCritical, value, "", fmt.Sprintf("Deviation: %.2f%%", value), false, "")
+	}
+
+	observe(0, 3)
+	for i, value := range []float64{3.5, 4.7, 2.1, 3.2, 3.1} {
+		observe(time.Duration(i+1)*5*time.Minute, value) // within the cooldown
+	}
+	observe(40*time.Minute, 3.05) // after the cooldown, but under MinValueChange
+	observe(45*time.Minute, 4.2)
+	if len(sent) != 2 {
+		t.Fatalf("%d messages sent, want the incident and an update", len(sent))
+	}
+	if want := "Since last message: 6 readings, peak 4.7, low 2.1"; !strings.Contains(sent[1], "Deviation: 4.20%\n\n"+want) {
+		t.Errorf("update %q does not end its details with %q", sent[1], want)
+	}
+	state := m.GetActiveIncidents()[key]
+	if state.SuppressedCount != 0 || strings.Contains(state.LastMessage, "Since last message") {
+		t.Errorf("state after the update = %+v, want the summary reset", state)
+	}
+
+	// The reminder covers only the readings since the update
+	observe(60*time.Minute, 4.4)
+	observe(90*time.Minute, 4.3)
+	observe(2*time.Hour, 4.25)
+	if len(sent) != 3 {
+		t.Fatalf("%d messages sent, want a reminder", len(sent))
+	}
+	if want := "Since last message: 2 readings, peak 4.4, low 4.3\nOpen since:"; !strings.Contains(sent[2], want) {
+		t.Errorf("reminder %q does not contain %q", sent[2], want)
+	}
+	if state := m.GetActiveIncidents()[key]; state.SuppressedCount != 0 {
+		t.Errorf("%d suppressed readings after the reminder, want 0", state.SuppressedCount)
+	}
+}
@@ -0,0 +1,103 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// businessMute silences the business channel (Telegram and Slack) during an
+// acknowledged incident. Developer messages are never muted.
+type businessMute struct {
+	active     bool
+	until      time.Time // zero while muted until UnmuteBusiness
+	suppressed int       // business messages withheld during this mute
+}
+
+// MuteStatus describes the business channel mute, see BusinessMute
+type MuteStatus struct {
+	Muted      bool      `json:"muted"`
+	Until      time.Time `json:"until,omitzero"`
+	Suppressed int       `json:"suppressed"`
+}
+
+// MuteBusiness withholds business-channel messages for d, or until UnmuteBusiness
+// when d is zero. Developer copies are still sent.
+func (m *Manager) MuteBusiness(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mute.active = true
+	m.mute.until = time.Time{}
+	if d > 0 {
+		m.mute.until = m.clock().Add(d)
+	}
+	log.Printf("[alerts] business alerts muted %s", muteUntil(m.mute.until))
+}
+
+// UnmuteBusiness lifts the business mute and tells the business channel how many
+// messages it missed
+func (m *Manager) UnmuteBusiness(ctx context.Context) error {
+	m.mu.Lock()
+	wasActive, suppressed := m.mute.active, m.mute.suppressed
+	m.mute = businessMute{}
+	m.mu.Unlock()
+
+	if !wasActive {
+		return nil
+	}
+	log.Printf("[alerts] business alerts unmuted (%d suppressed)", suppressed)
+	return m.sendMuteSummary(ctx, "", suppressed)
+}
+
+// BusinessMute reports the current business channel mute
+func (m *Manager) BusinessMute() MuteStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.mute.active || m.muteExpired() {
+		return MuteStatus{}
+	}
+	return MuteStatus{Muted: true, Until: m.mute.until, Suppressed: m.mute.suppressed}
+}
+
+// suppressBusiness counts a business message withheld by the mute and reports whether
+// to withhold it. A mute that has expired is lifted, and its summary is sent first.
+func (m *Manager) suppressBusiness(ctx context.Context, job string) bool {
+	m.mu.Lock()
+	if !m.mute.active {
+		m.mu.Unlock()
+		return false
+	}
+	if m.muteExpired() {
+		suppressed := m.mute.suppressed
+		m.mute = businessMute{}
+		m.mu.Unlock()
+		log.Printf("[alerts] business mute expired (%d suppressed)", suppressed)
+		if err := m.sendMuteSummary(ctx, job, suppressed); err != nil {
+			log.Printf("[alerts] failed to send mute summary: %v", err)
+		}
+		return false
+	}
+	m.mute.suppressed++
+	m.mu.Unlock()
+	return true
+}
+
+// muteExpired reports whether a timed mute has run out (called with m.mu held)
+func (m *Manager) muteExpired() bool {
+	return !m.mute.until.IsZero() && !m.clock().Before(m.mute.until)
+}
+
+func (m *Manager) sendMuteSummary(ctx context.Context, job string, suppressed int) error {
+	if suppressed == 0 {
+		return nil
+	}
+	return m.service.SendBusinessAlertFor(ctx, job, fmt.Sprintf("🔔 Business alerts unmuted: suppressed %d messages while muted", suppressed))
+}
+
+func muteUntil(until time.Time) string {
+	if until.IsZero() {
+		return "until unmuted"
+	}
+	return "until " + until.Format(time.RFC3339)
+}
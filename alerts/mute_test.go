@@ -0,0 +1,65 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBusinessMute(t *testing.T) {
+	var mu sync.Mutex
+	sent := map[string][]string{} // chat ID -> texts
+	s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ChatID string `json:"chat_id"`
+			Text   string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		sent[payload.ChatID] = append(sent[payload.ChatID], payload.Text)
+		mu.Unlock()
+	})
+	m := NewManager(s)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.clock = func() time.Time { return now }
+	ctx := context.Background()
+
+	m.MuteBusiness(2 * time.Hour)
+	for _, entity := range []string{"WETH", "cbBTC"} {
+		m.Observe(ctx, AlertKey{Job: "oracle_base", Entity: entity, Metric: "price_deviation_volatile"}, SeverityCritical, 8, "", entity+" details", true, "")
+	}
+	if got := m.BusinessMute(); !got.Muted || got.Suppressed != 2 {
+		t.Errorf("BusinessMute() = %+v, want muted with 2 suppressed", got)
+	}
+	if len(sent["business-chat"]) != 0 || len(sent["developer-chat"]) != 2 {
+		t.Fatalf("while muted: %d business, %d developer messages; want 0 and 2", len(sent["business-chat"]), len(sent["developer-chat"]))
+	}
+
+	// The mute expires: the summary goes out before the next business message
+	now = now.Add(3 * time.Hour)
+	m.Observe(ctx, AlertKey{Job: "oracle_base", Entity: "USDC", Metric: "price_deviation_stable"}, SeverityCritical, 3, "", "USDC details", true, "")
+	business := sent["business-chat"]
+	if len(business) != 2 || !strings.Contains(business[0], "suppressed 2 messages") || !strings.Contains(business[1], "USDC details") {
+		t.Errorf("after expiry business messages = %q, want summary then the new alert", business)
+	}
+	if m.BusinessMute().Muted {
+		t.Error("mute still active after expiry")
+	}
+}
+
+func TestUnmuteWithoutSuppressedSendsNothing(t *testing.T) {
+	requests := 0
+	s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) { requests++ })
+	m := NewManager(s)
+	m.MuteBusiness(0)
+	if err := m.UnmuteBusiness(context.Background()); err != nil {
+		t.Fatalf("UnmuteBusiness: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("%d messages sent, want none when nothing was suppressed", requests)
+	}
+}
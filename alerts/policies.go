@@ -0,0 +1,93 @@
+package alerts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fallbackPolicy applies to job:metric combinations without a registered policy
+var fallbackPolicy = AlertPolicy{
+	MinValueChange:        10.0,
+	CooldownWarning:       15 * time.Minute,
+	CooldownCritical:      5 * time.Minute,
+	ReminderInterval:      60 * time.Minute,
+	ConsecutiveOKRequired: 2,
+}
+
+// Policy sources reported by Policies
+const (
+	PolicySourceCode     = "code"
+	PolicySourceConfig   = "config"
+	PolicySourceFallback = "fallback"
+)
+
+// PolicyKey returns the "job:metric" key policies are registered under
+func PolicyKey(job, metric string) string {
+	return fmt.Sprintf("%s:%s", job, metric)
+}
+
+// SplitPolicyKey splits a "job:metric" key into its parts
+func SplitPolicyKey(key string) (job, metric string, ok bool) {
+	job, metric, ok = strings.Cut(key, ":")
+	return job, metric, ok && job != "" && metric != ""
+}
+
+// DefaultPolicy returns the policy registered by code for a job:metric combination,
+// ignoring configured overrides. Unregistered combinations get the fallback policy.
+func (m *Manager) DefaultPolicy(job, metric string) AlertPolicy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if policy, ok := m.defaults[PolicyKey(job, metric)]; ok {
+		return policy
+	}
+	return fallbackPolicy
+}
+
+// SetPolicyOverrides replaces all configured policy overrides, keyed by "job:metric".
+// Overrides take precedence over registered policies; removing one restores the registered policy.
+func (m *Manager) SetPolicyOverrides(overrides map[string]AlertPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.overrides {
+		if policy, ok := m.defaults[key]; ok {
+			m.policies[key] = policy
+		} else {
+			delete(m.policies, key)
+		}
+	}
+
+	m.overrides = make(map[string]AlertPolicy, len(overrides))
+	for key, policy := range overrides {
+		m.overrides[key] = policy
+		m.policies[key] = policy
+	}
+}
+
+// PolicyEntry is an effective policy and where it came from
+type PolicyEntry struct {
+	Key    string
+	Source string
+	Policy AlertPolicy
+}
+
+// Policies returns the effective policy table sorted by key
+func (m *Manager) Policies() []PolicyEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := make([]PolicyEntry, 0, len(m.policies)+1)
+	for key, policy := range m.policies {
+		source := PolicySourceCode
+		if _, ok := m.overrides[key]; ok {
+			source = PolicySourceConfig
+		}
+		entries = append(entries, PolicyEntry{Key: key, Source: source, Policy: policy})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+
+	return append(entries, PolicyEntry{Key: "*", Source: PolicySourceFallback, Policy: fallbackPolicy})
+}
@@ -0,0 +1,57 @@
+package alerts
+
+import (
+	"log"
+	"path"
+)
+
+// BusinessRouting overrides the business flag jobs pass to Observe. Patterns are
+// path.Match globs over "job:metric"; Deny wins when both lists match.
+type BusinessRouting struct {
+	Allow []string
+	Deny  []string
+}
+
+// SetBusinessRouting replaces the business channel allow and deny lists
+func (m *Manager) SetBusinessRouting(routing BusinessRouting) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routing = routing
+}
+
+// routeBusiness reports whether job's metric goes to the business channel, applying
+// the configured lists over requested, the job's choice
+func (m *Manager) routeBusiness(job, metric string, requested bool) bool {
+	m.mu.RLock()
+	routing := m.routing
+	m.mu.RUnlock()
+
+	key := PolicyKey(job, metric)
+	business, list, pattern := requested, "", ""
+	if p, ok := matchPattern(routing.Deny, key); ok {
+		business, list, pattern = false, "business_denylist", p
+	} else if p, ok := matchPattern(routing.Allow, key); ok {
+		business, list, pattern = true, "business_allowlist", p
+	}
+	if business != requested {
+		log.Printf("[alerts] debug: %s routed to %s by %s pattern %q", key, channelName(business), list, pattern)
+	}
+	return business
+}
+
+// matchPattern returns the first of patterns matching value
+func matchPattern(patterns []string, value string) (string, bool) {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+func channelName(business bool) string {
+	if business {
+		return "business"
+	}
+	return "developers only"
+}
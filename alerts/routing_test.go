@@ -0,0 +1,54 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestBusinessRoutingOverridesJob(t *testing.T) {
+	var mu sync.Mutex
+	sent := map[string]int{} // chat ID -> messages
+	s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ChatID string `json:"chat_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		sent[payload.ChatID]++
+		mu.Unlock()
+	})
+	m := NewManager(s)
+	m.SetBusinessRouting(BusinessRouting{
+		Deny:  []string{"concentration:whale_supply", "concentration:borrow_*"},
+		Allow: []string{"concentration:*", "oracle_*:reference_disagreement"},
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		key          AlertKey
+		business     bool
+		wantBusiness bool
+	}{
+		{AlertKey{Job: "concentration", Entity: "WETH", Metric: "whale_supply"}, true, false},
+		{AlertKey{Job: "concentration", Entity: "WETH", Metric: "borrow_top10"}, true, false},
+		{AlertKey{Job: "concentration", Entity: "WETH", Metric: "supply_top10"}, false, true},
+		{AlertKey{Job: "oracle_base", Entity: "base:WETH", Metric: "reference_disagreement"}, false, true},
+		{AlertKey{Job: "oracle_base", Entity: "base:WETH", Metric: "price_deviation_volatile"}, true, true},
+		{AlertKey{Job: "oracle_base", Entity: "base:USDC", Metric: "rpc_unconnected"}, false, false},
+	}
+	for _, tt := range tests {
+		mu.Lock()
+		before := sent["business-chat"]
+		mu.Unlock()
+		m.Observe(ctx, tt.key, SeverityCritical, 1, "", "details", tt.business, "")
+		mu.Lock()
+		gotBusiness := sent["business-chat"] > before
+		mu.Unlock()
+		if gotBusiness != tt.wantBusiness {
+			t.Errorf("%s:%s asked business=%v: sent to business %v, want %v", tt.key.Job, tt.key.Metric, tt.business, gotBusiness, tt.wantBusiness)
+		}
+	}
+}
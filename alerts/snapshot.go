@@ -0,0 +1,80 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// SnapshotVersion is the schema version written by Export and required by Import
+const SnapshotVersion = 1
+
+// snapshot is the exported alert state. Maintenance windows come from config and
+// are not part of it.
+type snapshot struct {
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exported_at"`
+	States     []snapshotEntry `json:"states"`
+}
+
+type snapshotEntry struct {
+	AlertKey
+	State AlertState `json:"state"`
+}
+
+// Export writes the current alert states as a versioned JSON document, for carrying
+// incidents across a planned restart with Import
+func (m *Manager) Export(w io.Writer) error {
+	m.mu.RLock()
+	doc := snapshot{Version: SnapshotVersion, ExportedAt: m.clock().UTC(), States: make([]snapshotEntry, 0, len(m.states))}
+	for key, state := range m.states {
+		doc.States = append(doc.States, snapshotEntry{AlertKey: key, State: *state})
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(doc.States, func(i, j int) bool {
+		a, b := doc.States[i].AlertKey, doc.States[j].AlertKey
+		return a.Job+"\x00"+a.Entity+"\x00"+a.Metric < b.Job+"\x00"+b.Entity+"\x00"+b.Metric
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// Import restores alert states written by Export, replacing any state under the same
+// key. Entries for jobs with no registered policies or for metrics this build does
+// not emit are skipped and returned. Call it after jobs have registered their policies.
+func (m *Manager) Import(r io.Reader) (skipped []AlertKey, err error) {
+	var doc snapshot
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode alert snapshot: %w", err)
+	}
+	if doc.Version != SnapshotVersion {
+		return nil, fmt.Errorf("alert snapshot version %d, want %d", doc.Version, SnapshotVersion)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, entry := range doc.States {
+		if !m.hasJob(entry.Job) || !knownMetric(entry.Metric) {
+			skipped = append(skipped, entry.AlertKey)
+			continue
+		}
+		state := entry.State
+		m.states[entry.AlertKey] = &state
+	}
+	return skipped, nil
+}
+
+// hasJob reports whether any policy is registered for job (called with m.mu held)
+func (m *Manager) hasJob(job string) bool {
+	for key := range m.policies {
+		if registered, _, _ := SplitPolicyKey(key); registered == job {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	policy := AlertPolicy{CooldownWarning: time.Hour, CooldownCritical: time.Hour, ConsecutiveOKRequired: 2}
+	src, _ := newTestManager()
+	src.RegisterPolicy("oracle_base", "price_deviation_volatile", policy)
+	src.RegisterPolicy("oracle_optimism", "price_deviation_volatile", policy)
+	ctx := context.Background()
+	kept := AlertKey{Job: "oracle_base", Entity: ChainEntity("base", "WETH"), Metric: "price_deviation_volatile"}
+	removedJob := AlertKey{Job: "oracle_optimism", Entity: ChainEntity("optimism", "OP"), Metric: "price_deviation_volatile"}
+	removedMetric := AlertKey{Job: "oracle_base", Entity: "system", Metric: "retired_metric"}
+	for _, key := range []AlertKey{kept, removedJob, removedMetric} {
+		src.Observe(ctx, key, SeverityCritical, 8, "", "details", false, "")
+	}
+
+	var buf bytes.Buffer
+	if err := src.Export(&buf); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	dst, _ := newTestManager()
+	dst.RegisterPolicy("oracle_base", "price_deviation_volatile", policy)
+	skipped, err := dst.Import(&buf)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(skipped) != 2 {
+		t.Errorf("skipped %v, want the removed job and metric", skipped)
+	}
+
+	want := src.GetActiveIncidents()[kept]
+	got, ok := dst.GetActiveIncidents()[kept]
+	if !ok || got.IncidentID != want.IncidentID || !got.FirstTriggered.Equal(want.FirstTriggered) || got.Severity != SeverityCritical {
+		t.Errorf("imported state %+v, want %+v", got, want)
+	}
+
+	// The restored incident continues: a repeat within the cooldown stays quiet
+	dst.Observe(ctx, kept, SeverityCritical, 8, "", "details", false, "")
+	if log := dst.DecisionLog("", ""); log[0].Outcome != OutcomeCooldownSuppressed {
+		t.Errorf("observation after import: outcome %q, want %q", log[0].Outcome, OutcomeCooldownSuppressed)
+	}
+}
+
+func TestImportRejectsUnknownVersion(t *testing.T) {
+	m, _ := newTestManager()
+	if _, err := m.Import(strings.NewReader(`{"version": 99, "states": []}`)); err == nil {
+		t.Error("Import of version 99 = nil error, want error")
+	}
+}
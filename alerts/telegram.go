@@ -10,6 +10,15 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/0x0Glitch/internal/httpclient"
+	"github.com/0x0Glitch/internal/retry"
+)
+
+// Delivery attempts per message, with linear backoff between them
+const (
+	sendAttempts   = 3
+	sendRetryDelay = time.Second
 )
 
 type Service struct {
@@ -18,7 +27,11 @@ type Service struct {
 	DeveloperBotToken string
 	DeveloperChatID   string
 	SlackWebhookURL   string
+	TelegramAPIURL    string              // Bot API base URL, overridable for tests
+	chainChannels     map[string]Channels // per-chain overrides, see SetChainChannels
 	httpClient        *http.Client
+	sent              *sentLog
+	health            deliveryHealth // consecutive failures per destination, see DeliveryHealth
 }
 
 func New(businessBot, businessChat, devBot, devChat, slackWebhook string) *Service {
@@ -28,58 +41,99 @@ func New(businessBot, businessChat, devBot, devChat, slackWebhook string) *Servi
 		DeveloperBotToken: devBot,
 		DeveloperChatID:   devChat,
 		SlackWebhookURL:   slackWebhook,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		TelegramAPIURL:    "https://api.telegram.org",
+		httpClient:        httpclient.New(10 * time.Second),
+		sent:              newSentLog(defaultDedupWindow),
+		health:            deliveryHealth{degradedAfter: DefaultDegradedAfter},
 	}
 }
 
 func (s *Service) SendBusinessAlert(ctx context.Context, message string) error {
-	if s.BusinessBotToken == "" || s.BusinessChatID == "" {
+	return s.SendBusinessAlertFor(ctx, "", message)
+}
+
+func (s *Service) SendDeveloperAlert(ctx context.Context, message string) error {
+	return s.SendDeveloperAlertFor(ctx, "", message)
+}
+
+// SendBusinessAlertFor sends to the business channel of job's chain, if overridden
+func (s *Service) SendBusinessAlertFor(ctx context.Context, job, message string) error {
+	channels := s.channelsFor(job)
+	if channels.BusinessBotToken == "" || channels.BusinessChatID == "" {
 		log.Printf("[alerts] business alerts not configured")
 		return nil
 	}
-	return s.sendTelegram(ctx, s.BusinessBotToken, s.BusinessChatID, message)
+	return s.sendTelegram(ctx, businessChannel(channels.BusinessChatID), channels.BusinessBotToken, channels.BusinessChatID, message, "")
 }
 
-func (s *Service) SendDeveloperAlert(ctx context.Context, message string) error {
-	if s.DeveloperBotToken == "" || s.DeveloperChatID == "" {
+// SendDeveloperAlertFor sends to the developer channel of job's chain, if overridden
+func (s *Service) SendDeveloperAlertFor(ctx context.Context, job, message string) error {
+	channels := s.channelsFor(job)
+	if channels.DeveloperBotToken == "" || channels.DeveloperChatID == "" {
 		log.Printf("[alerts] developer alerts not configured")
 		return nil
 	}
-	return s.sendTelegram(ctx, s.DeveloperBotToken, s.DeveloperChatID, message)
+	return s.sendTelegram(ctx, developerChannel(channels.DeveloperChatID), channels.DeveloperBotToken, channels.DeveloperChatID, message, "")
 }
 
-func (s *Service) sendTelegram(ctx context.Context, botToken, chatID, message string) error {
-	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
+// SendBusinessHTMLFor is SendBusinessAlertFor for a message in Telegram's HTML markup
+func (s *Service) SendBusinessHTMLFor(ctx context.Context, job, message string) error {
+	channels := s.channelsFor(job)
+	if channels.BusinessBotToken == "" || channels.BusinessChatID == "" {
+		log.Printf("[alerts] business alerts not configured")
+		return nil
+	}
+	return s.sendTelegram(ctx, businessChannel(channels.BusinessChatID), channels.BusinessBotToken, channels.BusinessChatID, message, "HTML")
+}
+
+// SendDeveloperHTMLFor is SendDeveloperAlertFor for a message in Telegram's HTML markup
+func (s *Service) SendDeveloperHTMLFor(ctx context.Context, job, message string) error {
+	channels := s.channelsFor(job)
+	if channels.DeveloperBotToken == "" || channels.DeveloperChatID == "" {
+		log.Printf("[alerts] developer alerts not configured")
+		return nil
+	}
+	return s.sendTelegram(ctx, developerChannel(channels.DeveloperChatID), channels.DeveloperBotToken, channels.DeveloperChatID, message, "HTML")
+}
+
+// sendTelegram sends message to chatID, interpreted per parseMode ("" for plain text),
+// counting the outcome towards channel's delivery health
+func (s *Service) sendTelegram(ctx context.Context, channel, botToken, chatID, message, parseMode string) error {
+	destination := "telegram:" + chatID
+	if s.sent.recent(destination, message, time.Now()) {
+		log.Printf("[alerts] skipping duplicate telegram message to %s", chatID)
+		return nil
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", s.TelegramAPIURL, botToken)
 
 	payload := map[string]interface{}{
 		"chat_id": chatID,
 		"text":    message,
 	}
+	if parseMode != "" {
+		payload["parse_mode"] = parseMode
+	}
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	err = retry.Do(ctx, sendAttempts, sendRetryDelay, func() error {
+		return s.postJSON(ctx, url, "telegram API", jsonData)
+	})
+	s.health.record(ctx, channel, err, botToken)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10)) // Read first 4KB
-		return fmt.Errorf("telegram API returned status %d: %s", resp.StatusCode, string(body))
+		// A timeout waiting for the response may follow a successful delivery,
+		// so an identical retry within the window is suppressed
+		if isTimeout(err) {
+			s.sent.record(destination, message, time.Now())
+		}
+		return err
 	}
 
+	s.sent.record(destination, message, time.Now())
 	return nil
 }
 
@@ -92,6 +146,11 @@ func (s *Service) SendSlackAlert(ctx context.Context, message string) error {
 }
 
 func (s *Service) sendSlack(ctx context.Context, message string) error {
+	if s.sent.recent("slack", message, time.Now()) {
+		log.Printf("[alerts] skipping duplicate slack message")
+		return nil
+	}
+
 	// Convert HTML tags to Slack mrkdwn format
 	slackMessage := convertHTMLToSlack(message)
 
@@ -104,23 +163,48 @@ func (s *Service) sendSlack(ctx context.Context, message string) error {
 		return fmt.Errorf("failed to marshal slack payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", s.SlackWebhookURL, bytes.NewBuffer(jsonData))
+	err = retry.Do(ctx, sendAttempts, sendRetryDelay, func() error {
+		return s.postJSON(ctx, s.SlackWebhookURL, "slack webhook", jsonData)
+	})
+	s.health.record(ctx, slackChannel, err, s.SlackWebhookURL)
+	if err != nil {
+		if isTimeout(err) {
+			s.sent.record("slack", message, time.Now())
+		}
+		return err
+	}
+
+	s.sent.record("slack", message, time.Now())
+	return nil
+}
+
+// postJSON makes one delivery attempt. Rate limits, 5xx responses and connection
+// errors are retryable; timeouts are not, as the message may already have arrived.
+func (s *Service) postJSON(ctx context.Context, url, service string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("failed to create slack request: %w", err)
+		return retry.Permanent(fmt.Errorf("failed to create %s request: %w", service, err))
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send slack request: %w", err)
+		err = fmt.Errorf("failed to send %s request: %w", service, err)
+		if isTimeout(err) {
+			return retry.Permanent(err)
+		}
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
-		return fmt.Errorf("slack webhook returned status %d: %s", resp.StatusCode, string(body))
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10)) // Read first 4KB
+		err := fmt.Errorf("%s returned status %d: %s", service, resp.StatusCode, string(respBody))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			return err
+		}
+		return retry.Permanent(err)
 	}
-
 	return nil
 }
 
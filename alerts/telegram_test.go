@@ -0,0 +1,57 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTelegramTestService returns a service whose Telegram API is handler
+func newTelegramTestService(t *testing.T, handler http.HandlerFunc) *Service {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	s := New("business-token", "business-chat", "developer-token", "developer-chat", "")
+	s.TelegramAPIURL = server.URL
+	return s
+}
+
+func TestSendTelegramPayload(t *testing.T) {
+	var path, contentType string
+	var payload map[string]any
+	s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		path, contentType = r.URL.Path, r.Header.Get("Content-Type")
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Write([]byte(`{"ok": true}`))
+	})
+
+	if err := s.SendBusinessAlert(context.Background(), "🚨 [INC-1] ALERT\n\ndetails"); err != nil {
+		t.Fatalf("SendBusinessAlert: %v", err)
+	}
+	if path != "/botbusiness-token/sendMessage" || contentType != "application/json" {
+		t.Errorf("request %s (%s), want /botbusiness-token/sendMessage (application/json)", path, contentType)
+	}
+	if payload["chat_id"] != "business-chat" || payload["text"] != "🚨 [INC-1] ALERT\n\ndetails" {
+		t.Errorf("payload = %v", payload)
+	}
+}
+
+func TestSendTelegramAPIError(t *testing.T) {
+	requests := 0
+	s := newTelegramTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"ok": false, "error_code": 400, "description": "Bad Request: chat not found"}`))
+	})
+
+	err := s.SendDeveloperAlert(context.Background(), "message")
+	if err == nil || !strings.Contains(err.Error(), "chat not found") {
+		t.Errorf("SendDeveloperAlert = %v, want the API error description", err)
+	}
+	if requests != 1 {
+		t.Errorf("%d requests, want 1 (client errors are not retried)", requests)
+	}
+}
@@ -0,0 +1,68 @@
+package alerts
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// displayLocation is the timezone timestamps in alert messages are shown in
+var displayLocation atomic.Pointer[time.Location]
+
+// SetDisplayTimezone sets the timezone used by FormatTime (ALERT_TIMEZONE); an empty
+// name selects UTC
+func SetDisplayTimezone(name string) error {
+	if name == "" {
+		displayLocation.Store(time.UTC)
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid alert timezone %q: %w", name, err)
+	}
+	displayLocation.Store(loc)
+	return nil
+}
+
+// FormatTime renders t for alert details as RFC 3339 in the display timezone with its
+// age relative to now, e.g. "2026-03-01T14:00:00Z (3h12m ago)". Every timestamp in an
+// alert message goes through here so readers never have to guess the zone.
+func FormatTime(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	loc := displayLocation.Load()
+	if loc == nil {
+		loc = time.UTC
+	}
+	return fmt.Sprintf("%s (%s)", t.In(loc).Format(time.RFC3339), relativeTime(now.Sub(t)))
+}
+
+// FormatAge renders how long before now t was, e.g. "2m ago", for values compared
+// with an earlier reading where the full timestamp would be noise
+func FormatAge(t, now time.Time) string {
+	return relativeTime(now.Sub(t))
+}
+
+// relativeTime humanizes an age to its two largest units: "45s ago", "3h12m ago",
+// "2d4h ago", or "in 5m" for times in the future
+func relativeTime(age time.Duration) string {
+	if age < 0 {
+		return "in " + shortDuration(-age)
+	}
+	return shortDuration(age) + " ago"
+}
+
+func shortDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
@@ -0,0 +1,43 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTime(t *testing.T) {
+	t.Cleanup(func() { SetDisplayTimezone("") })
+	now := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		timezone string
+		t        time.Time
+		want     string
+	}{
+		{"seconds", "", now.Add(-45 * time.Second), "2026-03-01T13:59:15Z (45s ago)"},
+		{"minutes", "", now.Add(-12*time.Minute - 30*time.Second), "2026-03-01T13:47:30Z (12m ago)"},
+		{"hours", "", now.Add(-3*time.Hour - 12*time.Minute), "2026-03-01T10:48:00Z (3h12m ago)"},
+		{"days", "", now.Add(-52 * time.Hour), "2026-02-27T10:00:00Z (2d4h ago)"},
+		{"future", "", now.Add(5 * time.Minute), "2026-03-01T14:05:00Z (in 5m)"},
+		{"input zone ignored", "", now.In(time.FixedZone("UTC+7", 7*3600)).Add(-2 * time.Hour), "2026-03-01T12:00:00Z (2h0m ago)"},
+		{"display timezone", "America/New_York", now.Add(-2 * time.Hour), "2026-03-01T07:00:00-05:00 (2h0m ago)"},
+		{"zero", "", time.Time{}, "never"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetDisplayTimezone(tt.timezone); err != nil {
+				t.Fatalf("SetDisplayTimezone(%q): %v", tt.timezone, err)
+			}
+			if got := FormatTime(tt.t, now); got != tt.want {
+				t.Errorf("FormatTime = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetDisplayTimezoneRejectsUnknown(t *testing.T) {
+	if err := SetDisplayTimezone("Mars/Olympus_Mons"); err == nil {
+		t.Error("expected an error for an unknown timezone")
+	}
+}
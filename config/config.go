@@ -1,22 +1,335 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"os"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
+	"unicode"
 )
 
 type Config struct {
-	Oracle        OracleConfig        `json:"oracle"`
-	HealthFactor  HealthFactorConfig  `json:"health_factor"`
-	Concentration ConcentrationConfig `json:"concentration"`
+	// MaxGlobalConcurrency caps outstanding onchain and HTTP calls across all monitors (0 = unlimited)
+	MaxGlobalConcurrency int `json:"max_global_concurrency"`
+	// DBSubCheckConcurrency is how many independent sub-checks a DB job (concentration,
+	// health_aggregate) runs at once, sharing its connection pool (1 = one after another)
+	DBSubCheckConcurrency int `json:"db_subcheck_concurrency"`
+	// StartupJitterSeconds delays each job's first run by a random amount up to this
+	// (capped at the job's interval), spreading cold-start load (0 disables)
+	StartupJitterSeconds Duration `json:"startup_jitter_seconds"`
+	// Chains overrides the compiled-in chain settings, keyed by chain ID ("base", "optimism", ...)
+	Chains        map[string]ChainConfig `json:"chains,omitempty"`
+	Oracle        OracleConfig           `json:"oracle"`
+	HealthFactor  HealthFactorConfig     `json:"health_factor"`
+	Concentration ConcentrationConfig    `json:"concentration"`
+	MarketTotals  MarketTotalsConfig     `json:"market_totals"`
+	ChainHead     ChainHeadConfig        `json:"chain_head"`
+	Retention     RetentionConfig        `json:"retention"`
+	SlowRun       SlowRunConfig          `json:"slow_run"`
+	Alerts        AlertsConfig           `json:"alerts"`
+	// ErrorReporting controls what is sent to the error reporter (SENTRY_DSN)
+	ErrorReporting ErrorReportingConfig `json:"error_reporting"`
+	// PriceAPIs points the reference price sources at other endpoints (read at startup)
+	PriceAPIs PriceAPIsConfig `json:"price_apis"`
+	// RPCProviders build chains' RPC endpoints from URL templates, in priority order
+	// (read at startup)
+	RPCProviders []RPCProviderConfig `json:"rpc_providers,omitempty"`
+	// AlertPolicies overrides alert policies keyed by "job:metric" (e.g. "concentration:whale_supply")
+	AlertPolicies map[string]AlertPolicyConfig `json:"alert_policies,omitempty"`
+	// SeverityBands overrides the built-in severity bands of a metric, keyed by
+	// "job:metric" (e.g. "health_aggregate:borrow_spike")
+	SeverityBands map[string][]SeverityBandConfig `json:"severity_bands,omitempty"`
+}
+
+// SeverityBandConfig raises a metric to Severity once its value reaches Threshold.
+// The band with the highest threshold reached wins.
+type SeverityBandConfig struct {
+	Threshold float64 `json:"threshold"`
+	Severity  string  `json:"severity"` // "OK", "WARNING" or "CRITICAL"
+}
+
+// AlertPolicyConfig overrides fields of a registered alert policy. Omitted fields keep
+// the value registered by the job.
+type AlertPolicyConfig struct {
+	MinValueChange         *float64                `json:"min_value_change,omitempty"`
+	TriggerThreshold       *float64                `json:"trigger_threshold,omitempty"`
+	CooldownWarning        *Minutes                `json:"cooldown_warning_minutes,omitempty"`
+	CooldownCritical       *Minutes                `json:"cooldown_critical_minutes,omitempty"`
+	ReminderInterval       *Minutes                `json:"reminder_interval_minutes,omitempty"`
+	ReminderCooldown       *Minutes                `json:"reminder_cooldown_minutes,omitempty"` // 0 uses the severity cooldown
+	ConsecutiveOKRequired  *int                    `json:"consecutive_ok_required,omitempty"`
+	DeescalationToBusiness *bool                   `json:"deescalation_to_business,omitempty"`
+	DynamicCooldowns       []DynamicCooldownConfig `json:"dynamic_cooldowns,omitempty"`
+	// GroupThreshold new incidents are sent individually within GroupWindow; further
+	// ones are rolled up into one message when the window ends
+	GroupThreshold *int     `json:"group_threshold,omitempty"`
+	GroupWindow    *Minutes `json:"group_window_minutes,omitempty"`
+	// MinIncidentDuration keeps business incidents on the developer channel until they
+	// have been open this long
+	MinIncidentDuration *Minutes `json:"min_incident_duration_minutes,omitempty"`
+}
+
+// ChainConfig holds per-chain connection settings. Empty fields keep the compiled-in defaults.
+type ChainConfig struct {
+	Enabled bool     `json:"enabled"`
+	RPCURLs []string `json:"rpc_urls"` // tried in order
+	WSURL   string   `json:"ws_url,omitempty"`
+	// RPCHeaders are sent with every RPC and websocket request, for providers that
+	// take the API key in a header (e.g. {"Authorization": "Bearer ${INFURA_API_KEY}"})
+	RPCHeaders    map[string]string `json:"rpc_headers,omitempty"`
+	OracleAddress string            `json:"oracle_address,omitempty"`
+	PriceNetwork  string            `json:"price_network,omitempty"` // Alchemy prices API network slug
+	// PriceSource routes reference price lookups: "alchemy" (default), "coingecko" or "defillama"
+	PriceSource   string `json:"price_source,omitempty"`
+	PricePlatform string `json:"price_platform,omitempty"` // CoinGecko/DefiLlama platform slug
+	// FeedSymbols overrides the symbol passed to the oracle's getFeed, keyed by token key
+	FeedSymbols map[string]string `json:"feed_symbols,omitempty"`
+	// PriceTokens overrides the price route for individual tokens, keyed by token key (e.g. "xcksm")
+	PriceTokens map[string]PriceRouteConfig `json:"price_tokens,omitempty"`
+	// PegCurrencies compares fiat-referenced stablecoins against the live USD rate of their
+	// currency instead of the static peg, keyed by token key (e.g. {"eurc": "EUR"})
+	PegCurrencies map[string]string `json:"peg_currencies,omitempty"`
+	// PegValues replaces the compiled-in static peg of stablecoins, keyed by token key
+	// (e.g. {"eurc": 1.17}); it is also the fallback when a live FX rate is unavailable
+	PegValues map[string]float64 `json:"peg_values,omitempty"`
+	// DeviationStrategies sets which reference a stablecoin's deviation is classified on,
+	// keyed by token key: "max" (default, the worse of peg and DEX), "min", "peg" or "dex"
+	DeviationStrategies map[string]string `json:"deviation_strategies,omitempty"`
+	// ExpectedTokenCount alerts developers when the number of monitored tokens differs (0 disables)
+	ExpectedTokenCount int `json:"expected_token_count,omitempty"`
+	// ReportMode reports token deviations as per-token incidents ("incident", default),
+	// as one cycle report listing every token ("digest"), or both
+	ReportMode string `json:"report_mode,omitempty"`
+	// DigestEveryCycles sends the cycle report every this many cycles while all tokens
+	// are OK (default 10); it is sent every cycle while any token is not
+	DigestEveryCycles int `json:"digest_every_cycles,omitempty"`
+	// Maintenance caps the chain's oracle alerts at WARNING and keeps them off the
+	// business channel, e.g. during a planned oracle migration
+	Maintenance *ChainMaintenanceConfig `json:"maintenance,omitempty"`
+}
+
+// ChainMaintenanceConfig puts a chain in maintenance mode until Until, after which it
+// clears itself
+type ChainMaintenanceConfig struct {
+	Until  time.Time `json:"until"` // RFC 3339, e.g. "2026-03-01T18:00:00Z"
+	Reason string    `json:"reason,omitempty"`
+}
+
+// PriceRouteConfig prices a token through another source, network or address, such as a
+// bridged asset priced via its representation on another chain. Empty fields keep the chain's route.
+type PriceRouteConfig struct {
+	Source  string `json:"source,omitempty"`
+	Network string `json:"network,omitempty"` // Alchemy network, or platform slug for other sources
+	Address string `json:"address,omitempty"`
+}
+
+// validCurrency reports whether code looks like an ISO 4217 currency code
+func validCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// validHeaderName reports whether name is a non-empty HTTP header field name
+func validHeaderName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, " \t:\r\n")
+}
+
+// PriceSources lists the supported reference price sources
+var PriceSources = []string{"alchemy", "coingecko", "defillama"}
+
+// ReportModes lists how a chain's token deviations are reported
+var ReportModes = []string{"incident", "digest", "both"}
+
+// AvgHFMetrics lists the protocol-wide health factor averages avg_hf_drop can follow
+var AvgHFMetrics = []string{"borrow_weighted", "collateral_weighted", "median"}
+
+// StablecoinStrategies lists how a stablecoin's deviations from peg and DEX combine
+var StablecoinStrategies = []string{"max", "min", "peg", "dex"}
+
+// validPriceSource reports whether source is empty or one of PriceSources
+func validPriceSource(source string) bool {
+	return source == "" || slices.Contains(PriceSources, source)
 }
 
 type OracleConfig struct {
-	CheckIntervalSeconds int                   `json:"check_interval_seconds"`
+	CheckIntervalSeconds Duration              `json:"check_interval_seconds"`
 	Stablecoin           OracleThresholdConfig `json:"stablecoin"`
 	Volatile             OracleThresholdConfig `json:"volatile"`
+	// DisabledTokens lists tokens to skip, either "symbol" for all chains or "chain:symbol"
+	DisabledTokens []string        `json:"disabled_tokens"`
+	BroadMove      BroadMoveConfig `json:"broad_move"`
+	DEXFastPath    FastPathConfig  `json:"dex_fast_path"`
+	// RetryStatusCodes lists 4xx price API statuses worth retrying; 5xx are always retried
+	RetryStatusCodes []int `json:"retry_status_codes"`
+	// VerifyDecimals checks configured token decimals against the contracts at startup
+	VerifyDecimals bool `json:"verify_decimals"`
+	// FeedCheckIntervalHours is how often registered oracle feeds are verified (also at startup)
+	FeedCheckIntervalHours Hours `json:"feed_check_interval_hours"`
+	// MaxReportedDeviationPercent clamps reported deviations; larger values are treated as a
+	// likely data error and sent to developers instead of the business channel (0 disables)
+	MaxReportedDeviationPercent float64 `json:"max_reported_deviation_percent"`
+	// MaxConsecutiveErrors pages once a chain has had token errors in more than this many
+	// consecutive cycles, catching failures too sparse for the error rate check (0 disables)
+	MaxConsecutiveErrors int `json:"max_consecutive_errors"`
+	// MaxMissingReferenceCycles alerts developers once a token has had no reference price
+	// for more than this many consecutive cycles, a coverage gap rather than a transient
+	// error (0 disables)
+	MaxMissingReferenceCycles int `json:"max_missing_reference_cycles"`
+	// StablecoinAbovePeg sets separate thresholds for stablecoins trading above peg, which is
+	// far less dangerous than below; the stablecoin thresholds then apply below peg only.
+	// Zero thresholds keep the check symmetric.
+	StablecoinAbovePeg PegThresholdConfig `json:"stablecoin_above_peg"`
+	// FX is the live exchange rate source for chains' peg_currencies
+	FX FXConfig `json:"fx"`
+	// PrecisionCheck notes oracle posts that look manual or test values
+	PrecisionCheck PrecisionCheckConfig `json:"precision_check"`
+	// FeedComparison reads a deviating volatile token's Chainlink feed to tell feed
+	// problems from oracle problems
+	FeedComparison FeedComparisonConfig `json:"feed_comparison"`
+	// HealthScore combines each cycle's readings into a 0-100 score per chain
+	HealthScore HealthScoreConfig `json:"health_score"`
+	// PriceSanity rejects reference prices that cannot be right before they reach the
+	// deviation check
+	PriceSanity PriceSanityConfig `json:"price_sanity"`
+	// ReferenceMaxAge rejects reference prices the source last updated too long ago
+	ReferenceMaxAge ReferenceMaxAgeConfig `json:"reference_max_age"`
+	// MultiSource combines every weighted price source into one reference per token
+	MultiSource MultiSourceConfig `json:"multi_source"`
+	// PriceStoreMaxAgeMinutes is how long a token's latest successful reading stays in
+	// the shared price store shown to other jobs and /prices/latest
+	PriceStoreMaxAgeMinutes Minutes `json:"price_store_max_age_minutes"`
+	// Chart links deviation alerts to a chart of the token's recent prices
+	Chart ChartConfig `json:"chart"`
+}
+
+// ChartConfig builds a chart link for deviation alerts from URLTemplate, which may
+// reference "{chain}", "{symbol}", "{address}" (the token's price address) and "{from}"
+// and "{to}" (unix seconds spanning the last WindowHours), e.g.
+// "https://charts.example.com/render.png?chain={chain}&token={address}&from={from}&to={to}".
+// An empty template adds no link.
+type ChartConfig struct {
+	URLTemplate string `json:"url_template,omitempty"`
+	WindowHours Hours  `json:"window_hours"`
+}
+
+// MultiSourceConfig queries each price source with a trust weight for every token and
+// uses their weighted mean as the reference. When the sources spread further apart
+// than MaxDispersionPercent, developers are alerted that the references disagree and
+// the token's deviation alert is kept off the business channel.
+type MultiSourceConfig struct {
+	Enabled bool `json:"enabled"`
+	// Weights is the trust in each source ("alchemy", "coingecko", "defillama");
+	// sources without a positive weight are not queried
+	Weights              map[string]float64 `json:"weights"`
+	MaxDispersionPercent float64            `json:"max_dispersion_percent"`
+}
+
+// ReferenceMaxAgeConfig bounds the age of a reference price, as reported by its source,
+// per token class. Prices without a reported update time are not checked.
+type ReferenceMaxAgeConfig struct {
+	StablecoinMinutes Minutes `json:"stablecoin_minutes"` // 0 disables
+	VolatileMinutes   Minutes `json:"volatile_minutes"`   // 0 disables
+	// AlertAfterCycles alerts developers once a token's reference has been too old for
+	// more than this many consecutive cycles (0 disables the alert)
+	AlertAfterCycles int `json:"alert_after_cycles"`
+}
+
+// PriceSanityConfig rejects non-positive or non-finite reference prices and prices that
+// moved implausibly far from the last accepted one. A rejected price is replaced by the
+// last accepted one until EscalateAfter consecutive rejections.
+type PriceSanityConfig struct {
+	// MaxChangeFactor rejects prices more than this many times above or below the last
+	// accepted price (0 disables the comparison)
+	MaxChangeFactor float64 `json:"max_change_factor"`
+	// EscalateAfter consecutive rejections make the alert critical and stop using the
+	// last accepted price, so the token fails instead of pinning to it
+	EscalateAfter int `json:"escalate_after"`
+}
+
+// HealthScoreConfig weighs the components of a chain's health score and sets the
+// scores that alert developers. Weights are relative and need not sum to 1.
+type HealthScoreConfig struct {
+	Enabled         bool    `json:"enabled"`
+	OKWeight        float64 `json:"ok_weight"`        // fraction of tokens OK
+	DeviationWeight float64 `json:"deviation_weight"` // worst deviation against its critical threshold
+	StalenessWeight float64 `json:"staleness_weight"` // age of the oldest reference price
+	RPCLagWeight    float64 `json:"rpc_lag_weight"`   // slowest onchain price read
+	// MaxStalenessMinutes and MaxRPCLagSeconds score zero for their component
+	MaxStalenessMinutes Minutes  `json:"max_staleness_minutes"`
+	MaxRPCLagSeconds    Duration `json:"max_rpc_lag_seconds"`
+	WarningBelow        float64  `json:"warning_below"`
+	CriticalBelow       float64  `json:"critical_below"`
+}
+
+// FeedComparisonConfig controls comparing the oracle price with its own feed when a
+// volatile token deviates from the market
+type FeedComparisonConfig struct {
+	Enabled bool `json:"enabled"`
+	// AgreementPercent is how close the oracle and feed prices must be to count as agreeing
+	AgreementPercent float64 `json:"agreement_percent"`
+}
+
+// PrecisionCheckConfig flags a changed getUnderlyingPrice value that repeats an earlier
+// value exactly or is suspiciously round, to developers only
+type PrecisionCheckConfig struct {
+	Enabled bool `json:"enabled"`
+	// MaxSignificantDigits flags prices with this many significant digits or fewer
+	MaxSignificantDigits int `json:"max_significant_digits"`
+	// History is how many distinct earlier values per token a repeat is checked against
+	History int `json:"history"`
+}
+
+// FXConfig configures the exchange rate source for live stablecoin pegs. The static peg
+// is used while the source is unavailable.
+type FXConfig struct {
+	// URL is the base URL of a Frankfurter-compatible API (GET /latest?from=EUR&to=USD)
+	URL            string  `json:"url"`
+	RefreshMinutes Minutes `json:"refresh_minutes"`
+}
+
+// PegThresholdConfig holds the deviation thresholds for one side of a stablecoin's peg
+type PegThresholdConfig struct {
+	WarningThresholdPercent  float64 `json:"warning_threshold_percent"`
+	CriticalThresholdPercent float64 `json:"critical_threshold_percent"`
+}
+
+// Enabled reports whether the thresholds are set
+func (p PegThresholdConfig) Enabled() bool {
+	return p.WarningThresholdPercent != 0 || p.CriticalThresholdPercent != 0
+}
+
+// FastPathConfig controls reusing the previous DEX price when the onchain price is unchanged
+type FastPathConfig struct {
+	// MinOnchainChangePercent is the onchain move that forces a fresh DEX read
+	MinOnchainChangePercent float64 `json:"min_onchain_change_percent"`
+	// MaxSkippedCycles caps consecutive cycles served from cache (0 disables the fast path)
+	MaxSkippedCycles int `json:"max_skipped_cycles"`
+}
+
+// BroadMoveConfig controls routing volatile alerts to developers only when many
+// volatile tokens on a chain deviate at once (market-wide volatility)
+type BroadMoveConfig struct {
+	Enabled bool `json:"enabled"`
+	// BreadthFraction is the fraction of volatile tokens that must be deviating (0-1]
+	BreadthFraction float64 `json:"breadth_fraction"`
+	// MinTokens is the minimum number of deviating tokens before the mode engages
+	MinTokens int `json:"min_tokens"`
 }
 
 type OracleThresholdConfig struct {
@@ -25,41 +338,45 @@ type OracleThresholdConfig struct {
 }
 
 type DynamicCooldownConfig struct {
-	ThresholdPercent float64 `json:"threshold_percent"`
-	CooldownSeconds  int     `json:"cooldown_seconds"`
+	ThresholdPercent float64  `json:"threshold_percent"`
+	CooldownSeconds  Duration `json:"cooldown_seconds"`
 }
 
 type ThresholdConfig struct {
 	WarningThresholdPercent  float64 `json:"warning_threshold_percent"`
 	CriticalThresholdPercent float64 `json:"critical_threshold_percent"`
 	MinValueChangePercent    float64 `json:"min_value_change_percent"`
-	CooldownWarningMinutes   int     `json:"cooldown_warning_minutes"`
-	CooldownCriticalMinutes  int     `json:"cooldown_critical_minutes"`
+	CooldownWarningMinutes   Minutes `json:"cooldown_warning_minutes"`
+	CooldownCriticalMinutes  Minutes `json:"cooldown_critical_minutes"`
 	ConsecutiveOKRequired    int     `json:"consecutive_ok_required"`
 }
 
 type HealthFactorConfig struct {
-	CheckIntervalSeconds int            `json:"check_interval_seconds"`
-	Position             PositionConfig `json:"position"`
-	RiskyCountSpike      SpikeConfig    `json:"risky_count_spike"`
-	AvgHFDrop            DropConfig     `json:"avg_hf_drop"`
-	WithdrawalSpike      SpikeConfig    `json:"withdrawal_spike"`
-	BorrowSpike          SpikeConfig    `json:"borrow_spike"`
+	CheckIntervalSeconds Duration        `json:"check_interval_seconds"`
+	Position             PositionConfig  `json:"position"`
+	RiskyCountSpike      SpikeConfig     `json:"risky_count_spike"`
+	AvgHFDrop            DropConfig      `json:"avg_hf_drop"`
+	WithdrawalSpike      SpikeConfig     `json:"withdrawal_spike"`
+	BorrowSpike          SpikeConfig     `json:"borrow_spike"`
+	TotalsCollapse       CollapseConfig  `json:"totals_collapse"`
+	DataFreshness        FreshnessConfig `json:"data_freshness"`
+	StressEvent          StressConfig    `json:"stress_event"`
 }
 
 type ConcentrationConfig struct {
-	CheckIntervalSeconds int             `json:"check_interval_seconds"`
+	CheckIntervalSeconds Duration        `json:"check_interval_seconds"`
 	WhaleSupply          ThresholdConfig `json:"whale_supply"`
 	BorrowTop10          ThresholdConfig `json:"borrow_top10"`
 	BorrowSingle         ThresholdConfig `json:"borrow_single"`
+	BorrowTop10Trend     TrendConfig     `json:"borrow_top10_trend"`
 }
 
 type PositionConfig struct {
 	WarningThreshold        float64 `json:"warning_threshold"`
 	CriticalThreshold       float64 `json:"critical_threshold"`
 	MinValueChange          float64 `json:"min_value_change"`
-	CooldownWarningMinutes  int     `json:"cooldown_warning_minutes"`
-	CooldownCriticalMinutes int     `json:"cooldown_critical_minutes"`
+	CooldownWarningMinutes  Minutes `json:"cooldown_warning_minutes"`
+	CooldownCriticalMinutes Minutes `json:"cooldown_critical_minutes"`
 	ConsecutiveOKRequired   int     `json:"consecutive_ok_required"`
 	QueryLimit              int     `json:"query_limit"`
 }
@@ -68,94 +385,933 @@ type SpikeConfig struct {
 	WarningThresholdPercent  float64 `json:"warning_threshold_percent"`
 	CriticalThresholdPercent float64 `json:"critical_threshold_percent"`
 	MinValueChangePercent    float64 `json:"min_value_change_percent"`
-	CooldownWarningMinutes   int     `json:"cooldown_warning_minutes"`
-	CooldownCriticalMinutes  int     `json:"cooldown_critical_minutes"`
+	CooldownWarningMinutes   Minutes `json:"cooldown_warning_minutes"`
+	CooldownCriticalMinutes  Minutes `json:"cooldown_critical_minutes"`
 	ConsecutiveOKRequired    int     `json:"consecutive_ok_required"`
-	CheckIntervalHours       int     `json:"check_interval_hours"`
+	CheckIntervalHours       Hours   `json:"check_interval_hours"`
 }
 
 type DropConfig struct {
 	WarningThreshold        float64 `json:"warning_threshold"`
 	CriticalThreshold       float64 `json:"critical_threshold"`
 	MinValueChange          float64 `json:"min_value_change"`
-	CooldownWarningMinutes  int     `json:"cooldown_warning_minutes"`
-	CooldownCriticalMinutes int     `json:"cooldown_critical_minutes"`
+	CooldownWarningMinutes  Minutes `json:"cooldown_warning_minutes"`
+	CooldownCriticalMinutes Minutes `json:"cooldown_critical_minutes"`
+	ConsecutiveOKRequired   int     `json:"consecutive_ok_required"`
+	CheckIntervalHours      Hours   `json:"check_interval_hours"`
+	// Metric is the health factor average the drop is measured on, one of AvgHFMetrics;
+	// the others are shown in the alert details
+	Metric string `json:"metric"`
+}
+
+// AlertsConfig controls alert delivery across channels
+type AlertsConfig struct {
+	// SuppressDeveloperCopyMinutes skips developer-channel messages for an incident that
+	// reached the business channel within this window, for teams in both channels (0 disables)
+	SuppressDeveloperCopyMinutes Minutes `json:"suppress_developer_copy_minutes"`
+	// DecisionLogSize is how many recent alert decisions are kept for /debug/alerts (0 disables)
+	DecisionLogSize int `json:"decision_log_size"`
+	// DeliveryDegradedAfter is how many consecutive failed sends mark an alert channel
+	// degraded, notifying the other channels and failing /healthz (0 disables)
+	DeliveryDegradedAfter int `json:"delivery_degraded_after"`
+	// MaintenanceWindows declare scheduled periods (e.g. planned oracle price posts)
+	// during which matching alerts are not sent; incidents still track the readings
+	MaintenanceWindows []MaintenanceWindowConfig `json:"maintenance_windows,omitempty"`
+	// BusinessDenylist and BusinessAllowlist are "job:metric" glob patterns ("concentration:whale_supply",
+	// "oracle_*:price_deviation_*") whose alerts are kept off or sent to the business channel
+	// whatever the job asks for. The denylist wins when both match.
+	BusinessDenylist  []string `json:"business_denylist,omitempty"`
+	BusinessAllowlist []string `json:"business_allowlist,omitempty"`
+}
+
+// MaintenanceWindowConfig is one scheduled maintenance window. Tokens and Metrics are
+// glob patterns ("WETH", "base:*", "price_deviation_*"); empty lists match everything.
+type MaintenanceWindowConfig struct {
+	Start   time.Time `json:"start"` // RFC 3339, e.g. "2026-03-01T14:00:00Z"
+	End     time.Time `json:"end"`
+	Tokens  []string  `json:"tokens,omitempty"`
+	Metrics []string  `json:"metrics,omitempty"`
+	Reason  string    `json:"reason"`
+}
+
+// PriceAPIsConfig overrides the reference price API endpoints, e.g. for a caching proxy
+// or a new API version. Empty fields keep the public endpoints; the
+// ALCHEMY_PRICE_API_BASE and ALCHEMY_PRICE_API_PATH environment variables take
+// precedence.
+type PriceAPIsConfig struct {
+	AlchemyBaseURL string `json:"alchemy_base_url,omitempty"`
+	// AlchemyPath is appended to AlchemyBaseURL for by-address lookups; "{api_key}" is
+	// replaced by the API key (default "/{api_key}/tokens/by-address")
+	AlchemyPath      string `json:"alchemy_path,omitempty"`
+	CoinGeckoBaseURL string `json:"coingecko_base_url,omitempty"`
+	DefiLlamaBaseURL string `json:"defillama_base_url,omitempty"`
+}
+
+// RPCProviderConfig is an RPC provider serving some chains. Its endpoints are used for
+// chains without a <CHAIN>_RPC_URL environment variable; a chain's rpc_urls replace them.
+type RPCProviderConfig struct {
+	Provider string `json:"provider"` // e.g. "alchemy", "infura", "quicknode"
+	// URLTemplate may reference "{network}" and "{key}",
+	// e.g. "https://{network}.g.alchemy.com/v2/{key}"
+	URLTemplate string `json:"url_template"`
+	// Networks names each served chain on this provider, keyed by chain ID
+	// (e.g. {"base": "base-mainnet"}); chains not listed are not served
+	Networks map[string]string `json:"networks"`
+	// Keys is the API key pool, tried in order on failover; keys blank after ${VAR}
+	// expansion are skipped
+	Keys []string `json:"keys,omitempty"`
+}
+
+// ErrorReportingConfig controls reports of job failures. Panics and alert-delivery
+// failures are always reported when a reporter is configured.
+type ErrorReportingConfig struct {
+	// ConsecutiveFailures reports a job once it has failed this many runs in a row (0 disables)
+	ConsecutiveFailures int `json:"consecutive_failures"`
+}
+
+// SlowRunConfig flags job runs that take much longer than their recent median
+type SlowRunConfig struct {
+	// Multiplier of the recent median run time that counts as slow (0 disables the alert)
+	Multiplier float64 `json:"multiplier"`
+	WindowSize int     `json:"window_size"` // recent successful runs kept per job
+	MinSamples int     `json:"min_samples"` // runs required before alerting
+}
+
+// FreshnessConfig sets the UserPositions data age thresholds. A raised severity is kept
+// until the age drops below its threshold scaled by ClearFraction, so an ETL updating
+// right around a threshold does not flap the alert.
+type FreshnessConfig struct {
+	WarningAgeHours  Hours   `json:"warning_age_hours"`
+	CriticalAgeHours Hours   `json:"critical_age_hours"`
+	ClearFraction    float64 `json:"clear_fraction"` // 1 disables the hysteresis
+}
+
+// CollapseConfig detects protocol totals falling toward zero within a single cycle,
+// which usually indicates corrupted position data rather than real outflows
+type CollapseConfig struct {
+	// DropFraction is the single-cycle drop (0.5 = 50%) that triggers a critical alert
+	DropFraction            float64 `json:"drop_fraction"`
+	CooldownCriticalMinutes Minutes `json:"cooldown_critical_minutes"`
+}
+
+// StressConfig turns aggregate metrics breaching together into one CRITICAL
+// protocol_stress alert for the business channel. While it is open the individual
+// metrics are sent to developers only.
+type StressConfig struct {
+	Enabled bool `json:"enabled"`
+	// Metrics must all be breaching at once, each one of StressMetrics
+	Metrics []string `json:"metrics"`
+	// MinSeverity is the severity each metric must be at to count as breaching,
+	// "WARNING" or "CRITICAL"
+	MinSeverity             string  `json:"min_severity"`
+	CooldownCriticalMinutes Minutes `json:"cooldown_critical_minutes"`
+}
+
+// StressMetrics lists the health_aggregate metrics a stress event can combine
+var StressMetrics = []string{"risky_count_spike", "avg_hf_drop", "withdrawal_spike", "borrow_spike"}
+
+// TrendConfig alerts on a rapid rise in a percentage metric relative to its recent
+// average, catching moves that are alarming before they reach the level thresholds
+type TrendConfig struct {
+	// Rise over the rolling baseline, in percentage points, that triggers each severity
+	WarningIncreasePoints   float64 `json:"warning_increase_points"`
+	CriticalIncreasePoints  float64 `json:"critical_increase_points"`
+	BaselineWindowMinutes   Minutes `json:"baseline_window_minutes"` // samples averaged into the baseline
+	MinSamples              int     `json:"min_samples"`             // samples required before alerting
+	MinValueChange          float64 `json:"min_value_change"`
+	CooldownWarningMinutes  Minutes `json:"cooldown_warning_minutes"`
+	CooldownCriticalMinutes Minutes `json:"cooldown_critical_minutes"`
 	ConsecutiveOKRequired   int     `json:"consecutive_ok_required"`
-	CheckIntervalHours      int     `json:"check_interval_hours"`
+}
+
+// MarketTotalsConfig cross-checks onchain market totals against the indexer database
+type MarketTotalsConfig struct {
+	CheckIntervalSeconds Duration `json:"check_interval_seconds"`
+	// Query returns (mtoken_address, supplied_usd, borrowed_usd) rows for the chain ID
+	// passed as $1. The cross-check is disabled while it is empty.
+	Query                   string  `json:"query"`
+	WarningDriftPercent     float64 `json:"warning_drift_percent"`
+	CriticalDriftPercent    float64 `json:"critical_drift_percent"`
+	MinMarketUSD            float64 `json:"min_market_usd"` // smaller markets are not compared
+	MinValueChangePercent   float64 `json:"min_value_change_percent"`
+	CooldownWarningMinutes  Minutes `json:"cooldown_warning_minutes"`
+	CooldownCriticalMinutes Minutes `json:"cooldown_critical_minutes"`
+	ConsecutiveOKRequired   int     `json:"consecutive_ok_required"`
+}
+
+// ChainHeadConfig alerts developers when an RPC endpoint's latest block is old, meaning
+// the node has stopped following the chain and is serving stale data
+type ChainHeadConfig struct {
+	CheckIntervalSeconds Duration `json:"check_interval_seconds"`
+	// WarningBlockAgeSeconds and CriticalBlockAgeSeconds compare the latest block's
+	// timestamp against the local clock
+	WarningBlockAgeSeconds  Duration `json:"warning_block_age_seconds"`
+	CriticalBlockAgeSeconds Duration `json:"critical_block_age_seconds"`
+	CooldownWarningMinutes  Minutes  `json:"cooldown_warning_minutes"`
+	CooldownCriticalMinutes Minutes  `json:"cooldown_critical_minutes"`
+	ConsecutiveOKRequired   int      `json:"consecutive_ok_required"`
+}
+
+// RetentionConfig prunes history tables once a day, reporting the rows deleted to
+// developers in a daily digest. Tables missing from the database are skipped, and
+// 0 days keeps a table's rows forever.
+type RetentionConfig struct {
+	AlertEventsDays        int `json:"alert_events_days"`
+	OracleObservationsDays int `json:"oracle_observations_days"`
+	ProtocolSnapshotsDays  int `json:"protocol_snapshots_days"`
+	// BatchSize caps the rows removed per DELETE, keeping each statement's locks short
+	BatchSize int `json:"batch_size"`
+	// VacuumAnalyze runs VACUUM ANALYZE on tables that had rows removed
+	VacuumAnalyze bool `json:"vacuum_analyze"`
 }
 
 // Helper methods
 func (t ThresholdConfig) CooldownWarning() time.Duration {
-	return time.Duration(t.CooldownWarningMinutes) * time.Minute
+	return t.CooldownWarningMinutes.Duration()
 }
 
 func (t ThresholdConfig) CooldownCritical() time.Duration {
-	return time.Duration(t.CooldownCriticalMinutes) * time.Minute
+	return t.CooldownCriticalMinutes.Duration()
 }
 
 func (p PositionConfig) CooldownWarning() time.Duration {
-	return time.Duration(p.CooldownWarningMinutes) * time.Minute
+	return p.CooldownWarningMinutes.Duration()
 }
 
 func (p PositionConfig) CooldownCritical() time.Duration {
-	return time.Duration(p.CooldownCriticalMinutes) * time.Minute
+	return p.CooldownCriticalMinutes.Duration()
 }
 
 func (s SpikeConfig) CooldownWarning() time.Duration {
-	return time.Duration(s.CooldownWarningMinutes) * time.Minute
+	return s.CooldownWarningMinutes.Duration()
 }
 
 func (s SpikeConfig) CooldownCritical() time.Duration {
-	return time.Duration(s.CooldownCriticalMinutes) * time.Minute
+	return s.CooldownCriticalMinutes.Duration()
 }
 
 func (d DropConfig) CooldownWarning() time.Duration {
-	return time.Duration(d.CooldownWarningMinutes) * time.Minute
+	return d.CooldownWarningMinutes.Duration()
 }
 
 func (d DropConfig) CooldownCritical() time.Duration {
-	return time.Duration(d.CooldownCriticalMinutes) * time.Minute
+	return d.CooldownCriticalMinutes.Duration()
+}
+
+func (t TrendConfig) BaselineWindow() time.Duration {
+	return t.BaselineWindowMinutes.Duration()
+}
+
+func (t TrendConfig) CooldownWarning() time.Duration {
+	return t.CooldownWarningMinutes.Duration()
+}
+
+func (t TrendConfig) CooldownCritical() time.Duration {
+	return t.CooldownCriticalMinutes.Duration()
+}
+
+func (m MarketTotalsConfig) CooldownWarning() time.Duration {
+	return m.CooldownWarningMinutes.Duration()
+}
+
+func (m MarketTotalsConfig) CooldownCritical() time.Duration {
+	return m.CooldownCriticalMinutes.Duration()
+}
+
+func (h ChainHeadConfig) CooldownWarning() time.Duration {
+	return h.CooldownWarningMinutes.Duration()
+}
+
+func (h ChainHeadConfig) CooldownCritical() time.Duration {
+	return h.CooldownCriticalMinutes.Duration()
+}
+
+func (o OracleConfig) FeedCheckInterval() time.Duration {
+	return o.FeedCheckIntervalHours.Duration()
+}
+
+func (f FXConfig) Refresh() time.Duration {
+	return f.RefreshMinutes.Duration()
+}
+
+func (a AlertsConfig) SuppressDeveloperCopy() time.Duration {
+	return a.SuppressDeveloperCopyMinutes.Duration()
+}
+
+func (c CollapseConfig) CooldownCritical() time.Duration {
+	return c.CooldownCriticalMinutes.Duration()
+}
+
+func (c StressConfig) CooldownCritical() time.Duration {
+	return c.CooldownCriticalMinutes.Duration()
 }
 
+// TokenDisabled reports whether a token has been disabled for the given chain
+func (o OracleConfig) TokenDisabled(chain, symbol string) bool {
+	for _, entry := range o.DisabledTokens {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == strings.ToLower(symbol) || entry == strings.ToLower(chain+":"+symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// Holder provides concurrency-safe access to the active configuration.
+// Configs stored in a Holder must not be mutated after Set.
+type Holder struct {
+	current atomic.Pointer[Config]
+}
+
+// NewHolder creates a holder initialized with cfg
+func NewHolder(cfg *Config) *Holder {
+	h := &Holder{}
+	h.Set(cfg)
+	return h
+}
+
+// Get returns the active configuration
+func (h *Holder) Get() *Config {
+	return h.current.Load()
+}
+
+// Set atomically replaces the active configuration
+func (h *Holder) Set(cfg *Config) {
+	h.current.Store(cfg)
+}
+
+// Validate checks the configuration for values that would break monitoring
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.MaxGlobalConcurrency < 0 {
+		errs = append(errs, fmt.Errorf("max_global_concurrency must not be negative"))
+	}
+	if c.DBSubCheckConcurrency < 1 {
+		errs = append(errs, fmt.Errorf("db_subcheck_concurrency must be at least 1"))
+	}
+	if c.StartupJitterSeconds.Duration() < 0 {
+		errs = append(errs, fmt.Errorf("startup_jitter_seconds must not be negative"))
+	}
+	for id, chain := range c.Chains {
+		if chain.Enabled && !hasRPCURL(chain.RPCURLs) && !c.providesRPC(id) {
+			errs = append(errs, fmt.Errorf("chains.%s: enabled chain needs at least one rpc_url or rpc_provider", id))
+		}
+		if chain.ExpectedTokenCount < 0 {
+			errs = append(errs, fmt.Errorf("chains.%s.expected_token_count must not be negative", id))
+		}
+		if chain.ReportMode != "" && !slices.Contains(ReportModes, chain.ReportMode) {
+			errs = append(errs, fmt.Errorf("chains.%s.report_mode %q must be one of %s", id, chain.ReportMode, strings.Join(ReportModes, ", ")))
+		}
+		if chain.DigestEveryCycles < 0 {
+			errs = append(errs, fmt.Errorf("chains.%s.digest_every_cycles must not be negative", id))
+		}
+		if chain.Maintenance != nil && chain.Maintenance.Until.IsZero() {
+			errs = append(errs, fmt.Errorf("chains.%s.maintenance.until must be set", id))
+		}
+		for name := range chain.RPCHeaders {
+			if !validHeaderName(name) {
+				errs = append(errs, fmt.Errorf("chains.%s.rpc_headers: invalid header name %q", id, name))
+			}
+		}
+		if !validPriceSource(chain.PriceSource) {
+			errs = append(errs, fmt.Errorf("chains.%s.price_source %q must be one of %s", id, chain.PriceSource, strings.Join(PriceSources, ", ")))
+		}
+		for token, currency := range chain.PegCurrencies {
+			if !validCurrency(currency) {
+				errs = append(errs, fmt.Errorf("chains.%s.peg_currencies.%s: %q is not a 3-letter currency code", id, token, currency))
+			}
+		}
+		for token, peg := range chain.PegValues {
+			if peg <= 0 {
+				errs = append(errs, fmt.Errorf("chains.%s.peg_values.%s must be positive", id, token))
+			}
+		}
+		for token, strategy := range chain.DeviationStrategies {
+			if !slices.Contains(StablecoinStrategies, strategy) {
+				errs = append(errs, fmt.Errorf("chains.%s.deviation_strategies.%s %q must be one of %s", id, token, strategy, strings.Join(StablecoinStrategies, ", ")))
+			}
+		}
+		for token, route := range chain.PriceTokens {
+			if !validPriceSource(route.Source) {
+				errs = append(errs, fmt.Errorf("chains.%s.price_tokens.%s.source %q must be one of %s", id, token, route.Source, strings.Join(PriceSources, ", ")))
+			}
+		}
+	}
+	if c.Oracle.CheckIntervalSeconds.Duration() < 0 {
+		errs = append(errs, fmt.Errorf("oracle.check_interval_seconds must not be negative"))
+	}
+	errs = append(errs, c.Oracle.Stablecoin.validate("oracle.stablecoin")...)
+	errs = append(errs, c.Oracle.Volatile.validate("oracle.volatile")...)
+	if c.Oracle.DEXFastPath.MaxSkippedCycles < 0 || c.Oracle.DEXFastPath.MinOnchainChangePercent < 0 {
+		errs = append(errs, fmt.Errorf("oracle.dex_fast_path values must not be negative"))
+	}
+	if max := c.Oracle.MaxReportedDeviationPercent; max != 0 &&
+		(max < c.Oracle.Stablecoin.CriticalThresholdPercent || max < c.Oracle.Volatile.CriticalThresholdPercent) {
+		errs = append(errs, fmt.Errorf("oracle.max_reported_deviation_percent must be at least the critical thresholds (or 0 to disable)"))
+	}
+	if p := c.Oracle.StablecoinAbovePeg; p.Enabled() && (p.WarningThresholdPercent <= 0 || p.CriticalThresholdPercent < p.WarningThresholdPercent) {
+		errs = append(errs, fmt.Errorf("oracle.stablecoin_above_peg requires 0 < warning_threshold_percent <= critical_threshold_percent (or both 0 to disable)"))
+	}
+	if p := c.Oracle.PrecisionCheck; p.Enabled && (p.MaxSignificantDigits < 0 || p.History < 1) {
+		errs = append(errs, fmt.Errorf("oracle.precision_check requires max_significant_digits >= 0 and history >= 1"))
+	}
+	if f := c.Oracle.FeedComparison; f.Enabled && f.AgreementPercent <= 0 {
+		errs = append(errs, fmt.Errorf("oracle.feed_comparison.agreement_percent must be positive"))
+	}
+	if h := c.Oracle.HealthScore; h.Enabled {
+		if h.OKWeight < 0 || h.DeviationWeight < 0 || h.StalenessWeight < 0 || h.RPCLagWeight < 0 ||
+			h.OKWeight+h.DeviationWeight+h.StalenessWeight+h.RPCLagWeight == 0 {
+			errs = append(errs, fmt.Errorf("oracle.health_score weights must not be negative and must not all be 0"))
+		}
+		if h.MaxStalenessMinutes.Duration() <= 0 || h.MaxRPCLagSeconds.Duration() <= 0 {
+			errs = append(errs, fmt.Errorf("oracle.health_score.max_staleness_minutes and max_rpc_lag_seconds must be positive"))
+		}
+		if h.CriticalBelow > h.WarningBelow || h.WarningBelow > 100 {
+			errs = append(errs, fmt.Errorf("oracle.health_score requires critical_below <= warning_below <= 100"))
+		}
+	}
+	if c.Oracle.FX.RefreshMinutes.Duration() < 0 {
+		errs = append(errs, fmt.Errorf("oracle.fx.refresh_minutes must not be negative"))
+	}
+	if c.Oracle.MaxConsecutiveErrors < 0 {
+		errs = append(errs, fmt.Errorf("oracle.max_consecutive_errors must not be negative"))
+	}
+	if c.Oracle.MaxMissingReferenceCycles < 0 {
+		errs = append(errs, fmt.Errorf("oracle.max_missing_reference_cycles must not be negative"))
+	}
+	if a := c.Oracle.ReferenceMaxAge; a.StablecoinMinutes.Duration() < 0 || a.VolatileMinutes.Duration() < 0 || a.AlertAfterCycles < 0 {
+		errs = append(errs, fmt.Errorf("oracle.reference_max_age values must not be negative"))
+	}
+	if ms := c.Oracle.MultiSource; ms.Enabled {
+		var total float64
+		for source, weight := range ms.Weights {
+			if !slices.Contains(PriceSources, source) {
+				errs = append(errs, fmt.Errorf("oracle.multi_source.weights: unknown price source %q", source))
+			}
+			if weight < 0 {
+				errs = append(errs, fmt.Errorf("oracle.multi_source.weights.%s must not be negative", source))
+			}
+			total += max(weight, 0)
+		}
+		if total <= 0 || ms.MaxDispersionPercent <= 0 {
+			errs = append(errs, fmt.Errorf("oracle.multi_source requires a positive weight and max_dispersion_percent > 0"))
+		}
+	}
+	if c.Oracle.PriceStoreMaxAgeMinutes <= 0 {
+		errs = append(errs, fmt.Errorf("oracle.price_store_max_age_minutes must be positive"))
+	}
+	if ch := c.Oracle.Chart; ch.URLTemplate != "" {
+		if !strings.HasPrefix(ch.URLTemplate, "http://") && !strings.HasPrefix(ch.URLTemplate, "https://") {
+			errs = append(errs, fmt.Errorf("oracle.chart.url_template must be an http(s) URL"))
+		}
+		if ch.WindowHours <= 0 {
+			errs = append(errs, fmt.Errorf("oracle.chart.window_hours must be positive"))
+		}
+	}
+	if s := c.Oracle.PriceSanity; (s.MaxChangeFactor != 0 && s.MaxChangeFactor <= 1) || s.EscalateAfter <= 0 {
+		errs = append(errs, fmt.Errorf("oracle.price_sanity requires max_change_factor of 0 or above 1 and escalate_after > 0"))
+	}
+	for key, policy := range c.AlertPolicies {
+		errs = append(errs, policy.validate("alert_policies."+key)...)
+		if job, metric, ok := strings.Cut(key, ":"); !ok || job == "" || metric == "" {
+			errs = append(errs, fmt.Errorf("alert_policies.%s: key must have the form \"job:metric\"", key))
+		}
+	}
+	for key, bands := range c.SeverityBands {
+		if job, metric, ok := strings.Cut(key, ":"); !ok || job == "" || metric == "" {
+			errs = append(errs, fmt.Errorf("severity_bands.%s: key must have the form \"job:metric\"", key))
+		}
+		if len(bands) == 0 {
+			errs = append(errs, fmt.Errorf("severity_bands.%s must list at least one band", key))
+		}
+		for i, band := range bands {
+			switch band.Severity {
+			case "OK", "WARNING", "CRITICAL":
+			default:
+				errs = append(errs, fmt.Errorf("severity_bands.%s[%d].severity must be OK, WARNING or CRITICAL", key, i))
+			}
+		}
+	}
+	if e := c.HealthFactor.StressEvent; e.Enabled {
+		if len(e.Metrics) < 2 {
+			errs = append(errs, fmt.Errorf("health_factor.stress_event.metrics must list at least two metrics"))
+		}
+		for _, metric := range e.Metrics {
+			if !slices.Contains(StressMetrics, metric) {
+				errs = append(errs, fmt.Errorf("health_factor.stress_event.metrics: %q must be one of %s", metric, strings.Join(StressMetrics, ", ")))
+			}
+		}
+		if e.MinSeverity != "WARNING" && e.MinSeverity != "CRITICAL" {
+			errs = append(errs, fmt.Errorf("health_factor.stress_event.min_severity must be WARNING or CRITICAL"))
+		}
+	}
+	if f := c.HealthFactor.DataFreshness; f.WarningAgeHours <= 0 || f.CriticalAgeHours < f.WarningAgeHours {
+		errs = append(errs, fmt.Errorf("health_factor.data_freshness requires 0 < warning_age_hours <= critical_age_hours"))
+	}
+	if f := c.HealthFactor.DataFreshness.ClearFraction; f <= 0 || f > 1 {
+		errs = append(errs, fmt.Errorf("health_factor.data_freshness.clear_fraction must be in (0, 1]"))
+	}
+	if c.Alerts.SuppressDeveloperCopy() < 0 {
+		errs = append(errs, fmt.Errorf("alerts.suppress_developer_copy_minutes must not be negative"))
+	}
+	if c.Alerts.DecisionLogSize < 0 {
+		errs = append(errs, fmt.Errorf("alerts.decision_log_size must not be negative"))
+	}
+	if c.Alerts.DeliveryDegradedAfter < 0 {
+		errs = append(errs, fmt.Errorf("alerts.delivery_degraded_after must not be negative"))
+	}
+	for i, w := range c.Alerts.MaintenanceWindows {
+		errs = append(errs, w.validate(fmt.Sprintf("alerts.maintenance_windows[%d]", i))...)
+	}
+	for i, p := range c.RPCProviders {
+		errs = append(errs, p.validate(fmt.Sprintf("rpc_providers[%d]", i))...)
+	}
+	for name, patterns := range map[string][]string{
+		"business_denylist":  c.Alerts.BusinessDenylist,
+		"business_allowlist": c.Alerts.BusinessAllowlist,
+	} {
+		for _, pattern := range patterns {
+			if job, metric, ok := strings.Cut(pattern, ":"); !ok || job == "" || metric == "" || !validPattern(pattern) {
+				errs = append(errs, fmt.Errorf("alerts.%s: pattern %q must have the form \"job:metric\"", name, pattern))
+			}
+		}
+	}
+	for name, base := range map[string]string{
+		"alchemy_base_url":   c.PriceAPIs.AlchemyBaseURL,
+		"coingecko_base_url": c.PriceAPIs.CoinGeckoBaseURL,
+		"defillama_base_url": c.PriceAPIs.DefiLlamaBaseURL,
+	} {
+		if base != "" && !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+			errs = append(errs, fmt.Errorf("price_apis.%s must be an http(s) URL", name))
+		}
+	}
+	if p := c.PriceAPIs.AlchemyPath; p != "" && !strings.HasPrefix(p, "/") {
+		errs = append(errs, fmt.Errorf("price_apis.alchemy_path must start with /"))
+	}
+	if c.ErrorReporting.ConsecutiveFailures < 0 {
+		errs = append(errs, fmt.Errorf("error_reporting.consecutive_failures must not be negative"))
+	}
+	if c.SlowRun.Multiplier != 0 && c.SlowRun.Multiplier <= 1 {
+		errs = append(errs, fmt.Errorf("slow_run.multiplier must be greater than 1 (or 0 to disable)"))
+	}
+	if c.SlowRun.MinSamples < 1 || c.SlowRun.WindowSize < c.SlowRun.MinSamples {
+		errs = append(errs, fmt.Errorf("slow_run requires 1 <= min_samples <= window_size"))
+	}
+	if d := c.HealthFactor.AvgHFDrop; d.WarningThreshold <= 0 || d.CriticalThreshold < d.WarningThreshold || d.CheckIntervalHours <= 0 {
+		errs = append(errs, fmt.Errorf("health_factor.avg_hf_drop requires 0 < warning_threshold <= critical_threshold and a positive check_interval_hours"))
+	}
+	if metric := c.HealthFactor.AvgHFDrop.Metric; !slices.Contains(AvgHFMetrics, metric) {
+		errs = append(errs, fmt.Errorf("health_factor.avg_hf_drop.metric %q must be one of %s", metric, strings.Join(AvgHFMetrics, ", ")))
+	}
+	if f := c.HealthFactor.TotalsCollapse.DropFraction; f <= 0 || f > 1 {
+		errs = append(errs, fmt.Errorf("health_factor.totals_collapse.drop_fraction must be in (0, 1]"))
+	}
+	if t := c.Concentration.BorrowTop10Trend; t.WarningIncreasePoints <= 0 || t.CriticalIncreasePoints < t.WarningIncreasePoints {
+		errs = append(errs, fmt.Errorf("concentration.borrow_top10_trend requires 0 < warning_increase_points <= critical_increase_points"))
+	}
+	if t := c.Concentration.BorrowTop10Trend; t.BaselineWindow() <= 0 || t.MinSamples < 1 {
+		errs = append(errs, fmt.Errorf("concentration.borrow_top10_trend requires a positive baseline_window_minutes and min_samples"))
+	}
+	if m := c.MarketTotals; m.WarningDriftPercent <= 0 || m.CriticalDriftPercent < m.WarningDriftPercent {
+		errs = append(errs, fmt.Errorf("market_totals requires 0 < warning_drift_percent <= critical_drift_percent"))
+	}
+	if c.MarketTotals.MinMarketUSD < 0 {
+		errs = append(errs, fmt.Errorf("market_totals.min_market_usd must not be negative"))
+	}
+	if h := c.ChainHead; h.WarningBlockAgeSeconds <= 0 || h.CriticalBlockAgeSeconds < h.WarningBlockAgeSeconds {
+		errs = append(errs, fmt.Errorf("chain_head requires 0 < warning_block_age_seconds <= critical_block_age_seconds"))
+	}
+	if r := c.Retention; r.AlertEventsDays < 0 || r.OracleObservationsDays < 0 || r.ProtocolSnapshotsDays < 0 {
+		errs = append(errs, fmt.Errorf("retention days must not be negative"))
+	}
+	if c.Retention.BatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("retention.batch_size must be positive"))
+	}
+	for _, code := range c.Oracle.RetryStatusCodes {
+		if code < 400 || code > 499 {
+			errs = append(errs, fmt.Errorf("oracle.retry_status_codes: %d is not a 4xx status", code))
+		}
+	}
+	if c.Oracle.BroadMove.Enabled && (c.Oracle.BroadMove.BreadthFraction <= 0 || c.Oracle.BroadMove.BreadthFraction > 1) {
+		errs = append(errs, fmt.Errorf("oracle.broad_move.breadth_fraction must be in (0, 1]"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// providesRPC reports whether an rpc_providers entry serves chain
+func (c *Config) providesRPC(chain string) bool {
+	for _, p := range c.RPCProviders {
+		if p.Networks[chain] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRPCURL reports whether urls contains a non-empty entry, which may be
+// blank after environment expansion
+func hasRPCURL(urls []string) bool {
+	for _, url := range urls {
+		if strings.TrimSpace(url) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// maxReasonableDuration is the longest cooldown or interval accepted without a warning
+const maxReasonableDuration = 24 * time.Hour
+
+// Warnings reports valid but suspicious settings, such as cooldowns longer than 24h
+// that usually indicate a unit mistake
+func (c *Config) Warnings() []string {
+	var warnings []string
+	check := func(path string, d time.Duration) {
+		if d > maxReasonableDuration {
+			warnings = append(warnings, fmt.Sprintf("%s is %v (more than 24h), check the units", path, d))
+		}
+	}
+	checkThreshold := func(path string, t ThresholdConfig) {
+		check(path+".cooldown_warning_minutes", t.CooldownWarning())
+		check(path+".cooldown_critical_minutes", t.CooldownCritical())
+	}
+	checkSpike := func(path string, s SpikeConfig) {
+		check(path+".cooldown_warning_minutes", s.CooldownWarning())
+		check(path+".cooldown_critical_minutes", s.CooldownCritical())
+	}
+
+	check("alerts.suppress_developer_copy_minutes", c.Alerts.SuppressDeveloperCopy())
+	check("startup_jitter_seconds", c.StartupJitterSeconds.Duration())
+	for i, w := range c.Alerts.MaintenanceWindows {
+		check(fmt.Sprintf("alerts.maintenance_windows[%d] (end - start)", i), w.End.Sub(w.Start))
+	}
+	check("oracle.check_interval_seconds", c.Oracle.CheckIntervalSeconds.Duration())
+	if d := c.Oracle.FeedCheckInterval(); d > 7*maxReasonableDuration {
+		warnings = append(warnings, fmt.Sprintf("oracle.feed_check_interval_hours is %v (more than 7 days), check the units", d))
+	}
+	for path, oc := range map[string]OracleThresholdConfig{"oracle.stablecoin": c.Oracle.Stablecoin, "oracle.volatile": c.Oracle.Volatile} {
+		checkThreshold(path, oc.ThresholdConfig)
+		for i, dc := range oc.DynamicCooldowns {
+			check(fmt.Sprintf("%s.dynamic_cooldowns[%d].cooldown_seconds", path, i), dc.CooldownSeconds.Duration())
+		}
+	}
+	if p, below := c.Oracle.StablecoinAbovePeg, c.Oracle.Stablecoin; p.Enabled() &&
+		(p.WarningThresholdPercent < below.WarningThresholdPercent || p.CriticalThresholdPercent < below.CriticalThresholdPercent) {
+		warnings = append(warnings, "oracle.stablecoin_above_peg is tighter than oracle.stablecoin, which now applies below peg only")
+	}
+	check("oracle.fx.refresh_minutes", c.Oracle.FX.Refresh())
+	if c.Oracle.FX.URL == "" {
+		for id, chain := range c.Chains {
+			if len(chain.PegCurrencies) > 0 {
+				warnings = append(warnings, fmt.Sprintf("chains.%s.peg_currencies is set but oracle.fx.url is empty, static pegs are used", id))
+			}
+		}
+	}
+
+	check("health_factor.check_interval_seconds", c.HealthFactor.CheckIntervalSeconds.Duration())
+	check("health_factor.position.cooldown_warning_minutes", c.HealthFactor.Position.CooldownWarning())
+	check("health_factor.position.cooldown_critical_minutes", c.HealthFactor.Position.CooldownCritical())
+	checkSpike("health_factor.risky_count_spike", c.HealthFactor.RiskyCountSpike)
+	checkSpike("health_factor.withdrawal_spike", c.HealthFactor.WithdrawalSpike)
+	checkSpike("health_factor.borrow_spike", c.HealthFactor.BorrowSpike)
+	check("health_factor.avg_hf_drop.cooldown_warning_minutes", c.HealthFactor.AvgHFDrop.CooldownWarning())
+	check("health_factor.avg_hf_drop.cooldown_critical_minutes", c.HealthFactor.AvgHFDrop.CooldownCritical())
+	check("health_factor.totals_collapse.cooldown_critical_minutes", c.HealthFactor.TotalsCollapse.CooldownCritical())
+	check("health_factor.stress_event.cooldown_critical_minutes", c.HealthFactor.StressEvent.CooldownCritical())
+
+	check("concentration.check_interval_seconds", c.Concentration.CheckIntervalSeconds.Duration())
+	checkThreshold("concentration.whale_supply", c.Concentration.WhaleSupply)
+	checkThreshold("concentration.borrow_top10", c.Concentration.BorrowTop10)
+	checkThreshold("concentration.borrow_single", c.Concentration.BorrowSingle)
+	check("concentration.borrow_top10_trend.baseline_window_minutes", c.Concentration.BorrowTop10Trend.BaselineWindow())
+	check("concentration.borrow_top10_trend.cooldown_warning_minutes", c.Concentration.BorrowTop10Trend.CooldownWarning())
+	check("concentration.borrow_top10_trend.cooldown_critical_minutes", c.Concentration.BorrowTop10Trend.CooldownCritical())
+
+	check("market_totals.check_interval_seconds", c.MarketTotals.CheckIntervalSeconds.Duration())
+	check("market_totals.cooldown_warning_minutes", c.MarketTotals.CooldownWarning())
+	check("market_totals.cooldown_critical_minutes", c.MarketTotals.CooldownCritical())
+
+	check("chain_head.check_interval_seconds", c.ChainHead.CheckIntervalSeconds.Duration())
+	check("chain_head.critical_block_age_seconds", c.ChainHead.CriticalBlockAgeSeconds.Duration())
+	check("chain_head.cooldown_warning_minutes", c.ChainHead.CooldownWarning())
+	check("chain_head.cooldown_critical_minutes", c.ChainHead.CooldownCritical())
+
+	sort.Strings(warnings)
+	return warnings
+}
+
+func (o OracleThresholdConfig) validate(path string) []error {
+	errs := o.ThresholdConfig.validate(path)
+	for i, dc := range o.DynamicCooldowns {
+		if dc.CooldownSeconds.Duration() < 0 {
+			errs = append(errs, fmt.Errorf("%s.dynamic_cooldowns[%d].cooldown_seconds must not be negative", path, i))
+		}
+		if i > 0 && dc.ThresholdPercent > o.DynamicCooldowns[i-1].ThresholdPercent {
+			errs = append(errs, fmt.Errorf("%s.dynamic_cooldowns must be sorted by threshold_percent descending", path))
+		}
+	}
+	return errs
+}
+
+func (p AlertPolicyConfig) validate(path string) []error {
+	var errs []error
+	for _, v := range []*float64{p.MinValueChange, p.TriggerThreshold} {
+		if v != nil && *v < 0 {
+			errs = append(errs, fmt.Errorf("%s thresholds must not be negative", path))
+			break
+		}
+	}
+	for _, d := range []*Minutes{p.CooldownWarning, p.CooldownCritical, p.ReminderInterval, p.ReminderCooldown, p.GroupWindow, p.MinIncidentDuration} {
+		if d != nil && d.Duration() < 0 {
+			errs = append(errs, fmt.Errorf("%s durations must not be negative", path))
+			break
+		}
+	}
+	if p.ConsecutiveOKRequired != nil && *p.ConsecutiveOKRequired < 0 {
+		errs = append(errs, fmt.Errorf("%s.consecutive_ok_required must not be negative", path))
+	}
+	if p.GroupThreshold != nil && *p.GroupThreshold < 0 {
+		errs = append(errs, fmt.Errorf("%s.group_threshold must not be negative", path))
+	}
+	for i, dc := range p.DynamicCooldowns {
+		if dc.CooldownSeconds.Duration() < 0 {
+			errs = append(errs, fmt.Errorf("%s.dynamic_cooldowns[%d].cooldown_seconds must not be negative", path, i))
+		}
+		if i > 0 && dc.ThresholdPercent > p.DynamicCooldowns[i-1].ThresholdPercent {
+			errs = append(errs, fmt.Errorf("%s.dynamic_cooldowns must be sorted by threshold_percent descending", path))
+		}
+	}
+	return errs
+}
+
+func (w MaintenanceWindowConfig) validate(path string) []error {
+	var errs []error
+	if w.Start.IsZero() || !w.End.After(w.Start) {
+		errs = append(errs, fmt.Errorf("%s requires start before end", path))
+	}
+	for _, pattern := range append(append([]string{}, w.Tokens...), w.Metrics...) {
+		if !validPattern(pattern) {
+			errs = append(errs, fmt.Errorf("%s: invalid pattern %q", path, pattern))
+		}
+	}
+	return errs
+}
+
+func (p RPCProviderConfig) validate(path string) []error {
+	var errs []error
+	if p.Provider == "" {
+		errs = append(errs, fmt.Errorf("%s.provider must be set", path))
+	}
+	if !strings.HasPrefix(p.URLTemplate, "http://") && !strings.HasPrefix(p.URLTemplate, "https://") {
+		errs = append(errs, fmt.Errorf("%s.url_template must be an http(s) URL", path))
+	}
+	if len(p.Networks) == 0 {
+		errs = append(errs, fmt.Errorf("%s.networks must name at least one chain", path))
+	}
+	if strings.Contains(p.URLTemplate, "{key}") && len(p.Keys) == 0 {
+		errs = append(errs, fmt.Errorf("%s.url_template uses {key} but no keys are set", path))
+	}
+	return errs
+}
+
+// validPattern reports whether pattern is a well-formed path.Match glob
+func validPattern(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+func (t ThresholdConfig) validate(path string) []error {
+	var errs []error
+	if t.WarningThresholdPercent <= 0 {
+		errs = append(errs, fmt.Errorf("%s.warning_threshold_percent must be positive", path))
+	}
+	if t.CriticalThresholdPercent < t.WarningThresholdPercent {
+		errs = append(errs, fmt.Errorf("%s.critical_threshold_percent must be >= warning_threshold_percent", path))
+	}
+	if t.CooldownWarningMinutes.Duration() < 0 || t.CooldownCriticalMinutes.Duration() < 0 {
+		errs = append(errs, fmt.Errorf("%s cooldowns must not be negative", path))
+	}
+	if t.ConsecutiveOKRequired < 0 {
+		errs = append(errs, fmt.Errorf("%s.consecutive_ok_required must not be negative", path))
+	}
+	return errs
+}
+
+// Load reads the JSON or YAML config file at path, expands ${VAR} references,
+// overlays it on DefaultConfig and applies environment overrides.
+// Sections and fields omitted from the file keep their defaults.
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	return LoadBytes(path, data)
+}
+
+// LoadBytes loads a config document like Load; name selects YAML by its extension
+// and otherwise only labels the source
+func LoadBytes(name string, data []byte) (*Config, error) {
+	normalized, err := normalize(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := parse(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	applyEnvOverrides(cfg)
+	return cfg, nil
+}
+
+// parse decodes data over the defaults. Decoding into a pre-populated struct only
+// overwrites fields present in the JSON, so nested sections merge field by field.
+// Arrays and maps such as dynamic_cooldowns and weights replace the default entirely.
+func parse(data []byte) (*Config, error) {
+	var present map[string]json.RawMessage
+	if err := json.Unmarshal(data, &present); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
 
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	cfg := DefaultConfig()
+	// encoding/json merges into existing slice elements and map keys, so clear the
+	// defaults of every collection the document sets
+	clearPresentCollections(reflect.ValueOf(cfg).Elem(), present)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if strictMode() {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	return &cfg, nil
+	var fromFile, fromDefault []string
+	for _, section := range sectionNames() {
+		if raw, ok := present[section]; ok && string(raw) != "null" {
+			fromFile = append(fromFile, section)
+		} else {
+			fromDefault = append(fromDefault, section)
+		}
+	}
+	log.Printf("config sections from file: %v, from defaults: %v", fromFile, fromDefault)
+
+	return cfg, nil
 }
 
+// clearPresentCollections zeroes the slice and map fields of struct v that present, the
+// struct's JSON object, sets, recursing into nested structs
+func clearPresentCollections(v reflect.Value, present map[string]json.RawMessage) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && value.Kind() == reflect.Struct {
+			clearPresentCollections(value, present)
+			continue
+		}
+		raw, ok := presentField(present, field)
+		if !ok || string(raw) == "null" {
+			continue
+		}
+		switch value.Kind() {
+		case reflect.Slice, reflect.Map:
+			value.Set(reflect.Zero(value.Type()))
+		case reflect.Pointer:
+			if value.IsNil() || value.Elem().Kind() != reflect.Struct {
+				continue
+			}
+			value = value.Elem()
+			fallthrough
+		case reflect.Struct:
+			var nested map[string]json.RawMessage
+			if json.Unmarshal(raw, &nested) == nil {
+				clearPresentCollections(value, nested)
+			}
+		}
+	}
+}
+
+// presentField returns the JSON value of field in present, matching keys like
+// encoding/json: by exact name, then case-insensitively
+func presentField(present map[string]json.RawMessage, field reflect.StructField) (json.RawMessage, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return nil, false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	if raw, ok := present[name]; ok {
+		return raw, true
+	}
+	for key, raw := range present {
+		if strings.EqualFold(key, name) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
+
+// sectionNames returns the JSON names of the top-level config sections
+func sectionNames() []string {
+	t := reflect.TypeOf(Config{})
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// LoadOrDefault loads the config file, falling back to defaults (plus environment overrides) on error
 func LoadOrDefault(path string) *Config {
 	cfg, err := Load(path)
 	if err != nil {
 		fmt.Printf("warning: could not load config from %s: %v, using defaults\n", path, err)
-		return DefaultConfig()
+		cfg = DefaultConfig()
+		applyEnvOverrides(cfg)
 	}
 	return cfg
 }
 
 func DefaultConfig() *Config {
 	return &Config{
+		MaxGlobalConcurrency:  20,
+		DBSubCheckConcurrency: 4,
+		StartupJitterSeconds:  Duration(10 * time.Second),
+		Alerts: AlertsConfig{
+			DecisionLogSize:       1000,
+			DeliveryDegradedAfter: 3,
+		},
+		SlowRun: SlowRunConfig{
+			Multiplier: 3,
+			WindowSize: 20,
+			MinSamples: 5,
+		},
+		ErrorReporting: ErrorReportingConfig{
+			ConsecutiveFailures: 3,
+		},
 		Oracle: OracleConfig{
-			CheckIntervalSeconds: 120,
+			CheckIntervalSeconds: Duration(120 * time.Second),
 			Stablecoin: OracleThresholdConfig{
 				ThresholdConfig: ThresholdConfig{
 					WarningThresholdPercent:  1.0,
 					CriticalThresholdPercent: 2.0,
 					MinValueChangePercent:    0.2,
-					CooldownWarningMinutes:   30,
-					CooldownCriticalMinutes:  5,
+					CooldownWarningMinutes:   Minutes(30 * time.Minute),
+					CooldownCriticalMinutes:  Minutes(5 * time.Minute),
 					ConsecutiveOKRequired:    3,
 				},
 				DynamicCooldowns: []DynamicCooldownConfig{
-					{ThresholdPercent: 10.0, CooldownSeconds: 10},
-					{ThresholdPercent: 5.0, CooldownSeconds: 30},
+					{ThresholdPercent: 10.0, CooldownSeconds: Duration(10 * time.Second)},
+					{ThresholdPercent: 5.0, CooldownSeconds: Duration(30 * time.Second)},
 				},
 			},
 			Volatile: OracleThresholdConfig{
@@ -163,24 +1319,76 @@ func DefaultConfig() *Config {
 					WarningThresholdPercent:  3.0,
 					CriticalThresholdPercent: 5.0,
 					MinValueChangePercent:    1.0,
-					CooldownWarningMinutes:   30,
-					CooldownCriticalMinutes:  5,
+					CooldownWarningMinutes:   Minutes(30 * time.Minute),
+					CooldownCriticalMinutes:  Minutes(5 * time.Minute),
 					ConsecutiveOKRequired:    1,
 				},
 				DynamicCooldowns: []DynamicCooldownConfig{
-					{ThresholdPercent: 20.0, CooldownSeconds: 10},
-					{ThresholdPercent: 10.0, CooldownSeconds: 30},
+					{ThresholdPercent: 20.0, CooldownSeconds: Duration(10 * time.Second)},
+					{ThresholdPercent: 10.0, CooldownSeconds: Duration(30 * time.Second)},
 				},
 			},
+			BroadMove: BroadMoveConfig{
+				Enabled:         false,
+				BreadthFraction: 0.5,
+				MinTokens:       3,
+			},
+			DEXFastPath: FastPathConfig{
+				MinOnchainChangePercent: 0.05,
+				MaxSkippedCycles:        0,
+			},
+			RetryStatusCodes:            []int{408, 429},
+			FeedCheckIntervalHours:      Hours(24 * time.Hour),
+			MaxReportedDeviationPercent: 500,
+			MaxConsecutiveErrors:        10,
+			MaxMissingReferenceCycles:   10,
+			FX: FXConfig{
+				URL:            "https://api.frankfurter.app",
+				RefreshMinutes: Minutes(60 * time.Minute),
+			},
+			PrecisionCheck: PrecisionCheckConfig{
+				MaxSignificantDigits: 3,
+				History:              10,
+			},
+			FeedComparison: FeedComparisonConfig{
+				AgreementPercent: 0.5,
+			},
+			HealthScore: HealthScoreConfig{
+				OKWeight:            0.4,
+				DeviationWeight:     0.3,
+				StalenessWeight:     0.15,
+				RPCLagWeight:        0.15,
+				MaxStalenessMinutes: Minutes(60 * time.Minute),
+				MaxRPCLagSeconds:    Duration(10 * time.Second),
+				WarningBelow:        70,
+				CriticalBelow:       40,
+			},
+			PriceSanity: PriceSanityConfig{
+				MaxChangeFactor: 10,
+				EscalateAfter:   3,
+			},
+			ReferenceMaxAge: ReferenceMaxAgeConfig{
+				StablecoinMinutes: Minutes(2 * time.Hour),
+				VolatileMinutes:   Minutes(time.Hour),
+				AlertAfterCycles:  10,
+			},
+			MultiSource: MultiSourceConfig{
+				Weights:              map[string]float64{"alchemy": 1, "coingecko": 0.8, "defillama": 0.5},
+				MaxDispersionPercent: 2,
+			},
+			PriceStoreMaxAgeMinutes: Minutes(30 * time.Minute),
+			Chart: ChartConfig{
+				WindowHours: Hours(6 * time.Hour),
+			},
 		},
 		HealthFactor: HealthFactorConfig{
-			CheckIntervalSeconds: 300,
+			CheckIntervalSeconds: Duration(300 * time.Second),
 			Position: PositionConfig{
 				WarningThreshold:        1.5,
 				CriticalThreshold:       1.02,
 				MinValueChange:          0.05,
-				CooldownWarningMinutes:  30,
-				CooldownCriticalMinutes: 10,
+				CooldownWarningMinutes:  Minutes(30 * time.Minute),
+				CooldownCriticalMinutes: Minutes(10 * time.Minute),
 				ConsecutiveOKRequired:   3,
 				QueryLimit:              100,
 			},
@@ -188,65 +1396,115 @@ func DefaultConfig() *Config {
 				WarningThresholdPercent:  25.0,
 				CriticalThresholdPercent: 50.0,
 				MinValueChangePercent:    5.0,
-				CooldownWarningMinutes:   60,
-				CooldownCriticalMinutes:  30,
+				CooldownWarningMinutes:   Minutes(60 * time.Minute),
+				CooldownCriticalMinutes:  Minutes(30 * time.Minute),
 				ConsecutiveOKRequired:    2,
-				CheckIntervalHours:       24,
+				CheckIntervalHours:       Hours(24 * time.Hour),
 			},
 			AvgHFDrop: DropConfig{
 				WarningThreshold:        0.1,
 				CriticalThreshold:       0.2,
 				MinValueChange:          0.02,
-				CooldownWarningMinutes:  30,
-				CooldownCriticalMinutes: 15,
+				CooldownWarningMinutes:  Minutes(30 * time.Minute),
+				CooldownCriticalMinutes: Minutes(15 * time.Minute),
 				ConsecutiveOKRequired:   2,
-				CheckIntervalHours:      1,
+				CheckIntervalHours:      Hours(1 * time.Hour),
+				Metric:                  "borrow_weighted",
 			},
 			WithdrawalSpike: SpikeConfig{
 				WarningThresholdPercent:  10.0,
 				CriticalThresholdPercent: 20.0,
 				MinValueChangePercent:    2.0,
-				CooldownWarningMinutes:   60,
-				CooldownCriticalMinutes:  30,
+				CooldownWarningMinutes:   Minutes(60 * time.Minute),
+				CooldownCriticalMinutes:  Minutes(30 * time.Minute),
 				ConsecutiveOKRequired:    2,
-				CheckIntervalHours:       24,
+				CheckIntervalHours:       Hours(24 * time.Hour),
 			},
 			BorrowSpike: SpikeConfig{
 				WarningThresholdPercent:  10.0,
 				CriticalThresholdPercent: 20.0,
 				MinValueChangePercent:    2.0,
-				CooldownWarningMinutes:   60,
-				CooldownCriticalMinutes:  30,
+				CooldownWarningMinutes:   Minutes(60 * time.Minute),
+				CooldownCriticalMinutes:  Minutes(30 * time.Minute),
 				ConsecutiveOKRequired:    2,
-				CheckIntervalHours:       24,
+				CheckIntervalHours:       Hours(24 * time.Hour),
+			},
+			TotalsCollapse: CollapseConfig{
+				DropFraction:            0.5,
+				CooldownCriticalMinutes: Minutes(15 * time.Minute),
+			},
+			DataFreshness: FreshnessConfig{
+				WarningAgeHours:  Hours(5 * time.Hour),
+				CriticalAgeHours: Hours(10 * time.Hour),
+				ClearFraction:    0.8,
+			},
+			StressEvent: StressConfig{
+				Enabled:                 true,
+				Metrics:                 []string{"risky_count_spike", "avg_hf_drop", "withdrawal_spike"},
+				MinSeverity:             "WARNING",
+				CooldownCriticalMinutes: Minutes(30 * time.Minute),
 			},
 		},
 		Concentration: ConcentrationConfig{
-			CheckIntervalSeconds: 600,
+			CheckIntervalSeconds: Duration(600 * time.Second),
 			WhaleSupply: ThresholdConfig{
 				WarningThresholdPercent:  10.0,
 				CriticalThresholdPercent: 20.0,
 				MinValueChangePercent:    1.0,
-				CooldownWarningMinutes:   60,
-				CooldownCriticalMinutes:  30,
+				CooldownWarningMinutes:   Minutes(60 * time.Minute),
+				CooldownCriticalMinutes:  Minutes(30 * time.Minute),
 				ConsecutiveOKRequired:    3,
 			},
 			BorrowTop10: ThresholdConfig{
 				WarningThresholdPercent:  80.0,
 				CriticalThresholdPercent: 90.0,
 				MinValueChangePercent:    2.0,
-				CooldownWarningMinutes:   60,
-				CooldownCriticalMinutes:  30,
+				CooldownWarningMinutes:   Minutes(60 * time.Minute),
+				CooldownCriticalMinutes:  Minutes(30 * time.Minute),
 				ConsecutiveOKRequired:    3,
 			},
 			BorrowSingle: ThresholdConfig{
 				WarningThresholdPercent:  40.0,
 				CriticalThresholdPercent: 50.0,
 				MinValueChangePercent:    2.0,
-				CooldownWarningMinutes:   60,
-				CooldownCriticalMinutes:  30,
+				CooldownWarningMinutes:   Minutes(60 * time.Minute),
+				CooldownCriticalMinutes:  Minutes(30 * time.Minute),
 				ConsecutiveOKRequired:    3,
 			},
+			BorrowTop10Trend: TrendConfig{
+				WarningIncreasePoints:   15.0,
+				CriticalIncreasePoints:  25.0,
+				BaselineWindowMinutes:   Minutes(60 * time.Minute),
+				MinSamples:              3,
+				MinValueChange:          5.0,
+				CooldownWarningMinutes:  Minutes(60 * time.Minute),
+				CooldownCriticalMinutes: Minutes(30 * time.Minute),
+				ConsecutiveOKRequired:   2,
+			},
+		},
+		MarketTotals: MarketTotalsConfig{
+			CheckIntervalSeconds:    Duration(15 * time.Minute),
+			WarningDriftPercent:     5.0,
+			CriticalDriftPercent:    20.0,
+			MinMarketUSD:            10000,
+			MinValueChangePercent:   5.0,
+			CooldownWarningMinutes:  Minutes(2 * time.Hour),
+			CooldownCriticalMinutes: Minutes(30 * time.Minute),
+			ConsecutiveOKRequired:   2,
+		},
+		ChainHead: ChainHeadConfig{
+			CheckIntervalSeconds:    Duration(time.Minute),
+			WarningBlockAgeSeconds:  Duration(3 * time.Minute),
+			CriticalBlockAgeSeconds: Duration(10 * time.Minute),
+			CooldownWarningMinutes:  Minutes(30 * time.Minute),
+			CooldownCriticalMinutes: Minutes(15 * time.Minute),
+			ConsecutiveOKRequired:   2,
+		},
+		Retention: RetentionConfig{
+			AlertEventsDays:        90,
+			OracleObservationsDays: 30,
+			ProtocolSnapshotsDays:  180,
+			BatchSize:              5000,
 		},
 	}
 }
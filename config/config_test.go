@@ -0,0 +1,591 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadValidJSON(t *testing.T) {
+	path := writeConfig(t, `{
+		"max_global_concurrency": 8,
+		"oracle": {
+			"check_interval_seconds": 60,
+			"stablecoin": {
+				"warning_threshold_percent": 1.5,
+				"critical_threshold_percent": 3,
+				"cooldown_warning_minutes": 20,
+				"dynamic_cooldowns": [{"threshold_percent": 10, "cooldown_seconds": 15}]
+			}
+		},
+		"concentration": {
+			"whale_supply": {"warning_threshold_percent": 12}
+		}
+	}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if cfg.MaxGlobalConcurrency != 8 {
+		t.Errorf("MaxGlobalConcurrency = %d, want 8", cfg.MaxGlobalConcurrency)
+	}
+	if cfg.Oracle.CheckIntervalSeconds.Duration() != 60*time.Second {
+		t.Errorf("Oracle.CheckIntervalSeconds = %v, want 1m0s", cfg.Oracle.CheckIntervalSeconds)
+	}
+	if cfg.Oracle.Stablecoin.CooldownWarning() != 20*time.Minute {
+		t.Errorf("Stablecoin.CooldownWarning() = %v, want 20m0s", cfg.Oracle.Stablecoin.CooldownWarning())
+	}
+	if cfg.Oracle.Stablecoin.WarningThresholdPercent != 1.5 {
+		t.Errorf("Stablecoin.WarningThresholdPercent = %v, want 1.5", cfg.Oracle.Stablecoin.WarningThresholdPercent)
+	}
+	if cfg.Oracle.Stablecoin.CriticalThresholdPercent != 3 {
+		t.Errorf("Stablecoin.CriticalThresholdPercent = %v, want 3", cfg.Oracle.Stablecoin.CriticalThresholdPercent)
+	}
+	want := []DynamicCooldownConfig{{ThresholdPercent: 10, CooldownSeconds: Duration(15 * time.Second)}}
+	if !reflect.DeepEqual(cfg.Oracle.Stablecoin.DynamicCooldowns, want) {
+		t.Errorf("Stablecoin.DynamicCooldowns = %+v, want %+v", cfg.Oracle.Stablecoin.DynamicCooldowns, want)
+	}
+	if cfg.Concentration.WhaleSupply.WarningThresholdPercent != 12 {
+		t.Errorf("WhaleSupply.WarningThresholdPercent = %v, want 12", cfg.Concentration.WhaleSupply.WarningThresholdPercent)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load of missing file returned nil error")
+	}
+}
+
+func TestLoadMalformedJSON(t *testing.T) {
+	path := writeConfig(t, `{"oracle": {"check_interval_seconds": "sixty"`)
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load of malformed JSON returned nil error")
+	}
+}
+
+func TestLoadOrDefaultFallsBackToDefaults(t *testing.T) {
+	cfg := LoadOrDefault(filepath.Join(t.TempDir(), "missing.json"))
+	if !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Errorf("LoadOrDefault with missing file did not return defaults")
+	}
+
+	cfg = LoadOrDefault(writeConfig(t, `not json`))
+	if !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Errorf("LoadOrDefault with malformed file did not return defaults")
+	}
+}
+
+func TestLoadPartialConfig(t *testing.T) {
+	path := writeConfig(t, `{"oracle": {"check_interval_seconds": 45}}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	def := DefaultConfig()
+	if cfg.Oracle.CheckIntervalSeconds.Duration() != 45*time.Second {
+		t.Errorf("Oracle.CheckIntervalSeconds = %v, want 45s", cfg.Oracle.CheckIntervalSeconds)
+	}
+	// Sections absent from the file keep their defaults
+	if !reflect.DeepEqual(cfg.HealthFactor, def.HealthFactor) {
+		t.Errorf("HealthFactor = %+v, want defaults", cfg.HealthFactor)
+	}
+	if !reflect.DeepEqual(cfg.Concentration, def.Concentration) {
+		t.Errorf("Concentration = %+v, want defaults", cfg.Concentration)
+	}
+	if cfg.MaxGlobalConcurrency != def.MaxGlobalConcurrency {
+		t.Errorf("MaxGlobalConcurrency = %d, want %d", cfg.MaxGlobalConcurrency, def.MaxGlobalConcurrency)
+	}
+	// Fields absent from a present section keep their defaults
+	if !reflect.DeepEqual(cfg.Oracle.Stablecoin, def.Oracle.Stablecoin) {
+		t.Errorf("Oracle.Stablecoin = %+v, want defaults", cfg.Oracle.Stablecoin)
+	}
+}
+
+func TestLoadSparseConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(t *testing.T, cfg, def *Config)
+	}{
+		{
+			name:  "empty object",
+			input: `{}`,
+			check: func(t *testing.T, cfg, def *Config) {
+				if !reflect.DeepEqual(cfg, def) {
+					t.Errorf("config = %+v, want defaults", cfg)
+				}
+			},
+		},
+		{
+			name:  "null section",
+			input: `{"concentration": null}`,
+			check: func(t *testing.T, cfg, def *Config) {
+				if !reflect.DeepEqual(cfg.Concentration, def.Concentration) {
+					t.Errorf("Concentration = %+v, want defaults", cfg.Concentration)
+				}
+			},
+		},
+		{
+			name:  "single nested field",
+			input: `{"concentration": {"borrow_single": {"critical_threshold_percent": 60}}}`,
+			check: func(t *testing.T, cfg, def *Config) {
+				want := def.Concentration.BorrowSingle
+				want.CriticalThresholdPercent = 60
+				if cfg.Concentration.BorrowSingle != want {
+					t.Errorf("BorrowSingle = %+v, want %+v", cfg.Concentration.BorrowSingle, want)
+				}
+				if cfg.Concentration.WhaleSupply != def.Concentration.WhaleSupply {
+					t.Errorf("WhaleSupply = %+v, want defaults", cfg.Concentration.WhaleSupply)
+				}
+			},
+		},
+		{
+			name:  "embedded threshold field",
+			input: `{"oracle": {"volatile": {"warning_threshold_percent": 4}}}`,
+			check: func(t *testing.T, cfg, def *Config) {
+				if cfg.Oracle.Volatile.WarningThresholdPercent != 4 {
+					t.Errorf("Volatile.WarningThresholdPercent = %v, want 4", cfg.Oracle.Volatile.WarningThresholdPercent)
+				}
+				if cfg.Oracle.Volatile.CriticalThresholdPercent != def.Oracle.Volatile.CriticalThresholdPercent {
+					t.Errorf("Volatile.CriticalThresholdPercent = %v, want default", cfg.Oracle.Volatile.CriticalThresholdPercent)
+				}
+				if !reflect.DeepEqual(cfg.Oracle.Volatile.DynamicCooldowns, def.Oracle.Volatile.DynamicCooldowns) {
+					t.Errorf("Volatile.DynamicCooldowns = %+v, want defaults", cfg.Oracle.Volatile.DynamicCooldowns)
+				}
+			},
+		},
+		{
+			name:  "arrays replace defaults",
+			input: `{"oracle": {"stablecoin": {"dynamic_cooldowns": [{"threshold_percent": 3, "cooldown_seconds": "1m"}]}}}`,
+			check: func(t *testing.T, cfg, def *Config) {
+				want := []DynamicCooldownConfig{{ThresholdPercent: 3, CooldownSeconds: Duration(time.Minute)}}
+				if !reflect.DeepEqual(cfg.Oracle.Stablecoin.DynamicCooldowns, want) {
+					t.Errorf("Stablecoin.DynamicCooldowns = %+v, want %+v", cfg.Oracle.Stablecoin.DynamicCooldowns, want)
+				}
+			},
+		},
+		{
+			name:  "partial array element does not inherit defaults",
+			input: `{"oracle": {"stablecoin": {"dynamic_cooldowns": [{"threshold_percent": 3}]}}}`,
+			check: func(t *testing.T, cfg, def *Config) {
+				want := []DynamicCooldownConfig{{ThresholdPercent: 3}}
+				if !reflect.DeepEqual(cfg.Oracle.Stablecoin.DynamicCooldowns, want) {
+					t.Errorf("Stablecoin.DynamicCooldowns = %+v, want %+v", cfg.Oracle.Stablecoin.DynamicCooldowns, want)
+				}
+				if !reflect.DeepEqual(cfg.Oracle.Volatile.DynamicCooldowns, def.Oracle.Volatile.DynamicCooldowns) {
+					t.Errorf("Volatile.DynamicCooldowns = %+v, want defaults", cfg.Oracle.Volatile.DynamicCooldowns)
+				}
+			},
+		},
+		{
+			name:  "maps replace defaults",
+			input: `{"oracle": {"multi_source": {"weights": {"alchemy": 1}}}}`,
+			check: func(t *testing.T, cfg, def *Config) {
+				if want := map[string]float64{"alchemy": 1}; !reflect.DeepEqual(cfg.Oracle.MultiSource.Weights, want) {
+					t.Errorf("MultiSource.Weights = %v, want %v", cfg.Oracle.MultiSource.Weights, want)
+				}
+				if cfg.Oracle.MultiSource.Enabled != def.Oracle.MultiSource.Enabled {
+					t.Errorf("MultiSource.Enabled = %v, want default", cfg.Oracle.MultiSource.Enabled)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, tt.input))
+			if err != nil {
+				t.Fatalf("Load returned error: %v", err)
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("Validate() = %v, want nil", err)
+			}
+			tt.check(t, cfg, DefaultConfig())
+		})
+	}
+}
+
+func TestDefaultConfigSanity(t *testing.T) {
+	cfg := DefaultConfig()
+
+	thresholds := map[string]ThresholdConfig{
+		"oracle.stablecoin":           cfg.Oracle.Stablecoin.ThresholdConfig,
+		"oracle.volatile":             cfg.Oracle.Volatile.ThresholdConfig,
+		"concentration.whale_supply":  cfg.Concentration.WhaleSupply,
+		"concentration.borrow_top10":  cfg.Concentration.BorrowTop10,
+		"concentration.borrow_single": cfg.Concentration.BorrowSingle,
+	}
+	for name, th := range thresholds {
+		if th.WarningThresholdPercent <= 0 || th.CriticalThresholdPercent <= th.WarningThresholdPercent {
+			t.Errorf("%s: warning=%v critical=%v, want 0 < warning < critical",
+				name, th.WarningThresholdPercent, th.CriticalThresholdPercent)
+		}
+		if th.ConsecutiveOKRequired <= 0 {
+			t.Errorf("%s: ConsecutiveOKRequired = %d, want > 0", name, th.ConsecutiveOKRequired)
+		}
+	}
+
+	// Dynamic cooldowns must be sorted by threshold descending
+	for _, oc := range []OracleThresholdConfig{cfg.Oracle.Stablecoin, cfg.Oracle.Volatile} {
+		for i := 1; i < len(oc.DynamicCooldowns); i++ {
+			if oc.DynamicCooldowns[i].ThresholdPercent > oc.DynamicCooldowns[i-1].ThresholdPercent {
+				t.Errorf("dynamic cooldowns not sorted descending: %+v", oc.DynamicCooldowns)
+			}
+		}
+	}
+}
+
+func TestCooldownHelpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		warning  time.Duration
+		critical time.Duration
+		wantWarn time.Duration
+		wantCrit time.Duration
+	}{
+		{
+			name:     "threshold",
+			warning:  ThresholdConfig{CooldownWarningMinutes: Minutes(30 * time.Minute)}.CooldownWarning(),
+			critical: ThresholdConfig{CooldownCriticalMinutes: Minutes(5 * time.Minute)}.CooldownCritical(),
+			wantWarn: 30 * time.Minute,
+			wantCrit: 5 * time.Minute,
+		},
+		{
+			name:     "position",
+			warning:  PositionConfig{CooldownWarningMinutes: Minutes(time.Hour)}.CooldownWarning(),
+			critical: PositionConfig{CooldownCriticalMinutes: Minutes(10 * time.Minute)}.CooldownCritical(),
+			wantWarn: time.Hour,
+			wantCrit: 10 * time.Minute,
+		},
+		{
+			name:     "spike",
+			warning:  SpikeConfig{CooldownWarningMinutes: Minutes(90 * time.Minute)}.CooldownWarning(),
+			critical: SpikeConfig{}.CooldownCritical(),
+			wantWarn: 90 * time.Minute,
+			wantCrit: 0,
+		},
+		{
+			name:     "drop",
+			warning:  DropConfig{CooldownWarningMinutes: Minutes(15 * time.Minute)}.CooldownWarning(),
+			critical: DropConfig{CooldownCriticalMinutes: Minutes(time.Minute)}.CooldownCritical(),
+			wantWarn: 15 * time.Minute,
+			wantCrit: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.warning != tt.wantWarn {
+				t.Errorf("CooldownWarning() = %v, want %v", tt.warning, tt.wantWarn)
+			}
+			if tt.critical != tt.wantCrit {
+				t.Errorf("CooldownCritical() = %v, want %v", tt.critical, tt.wantCrit)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Fatalf("DefaultConfig().Validate() = %v, want nil", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*Config)
+	}{
+		{"negative concurrency", func(c *Config) { c.MaxGlobalConcurrency = -1 }},
+		{"zero db sub-check concurrency", func(c *Config) { c.DBSubCheckConcurrency = 0 }},
+		{"zero warning threshold", func(c *Config) { c.Oracle.Stablecoin.WarningThresholdPercent = 0 }},
+		{"critical below warning", func(c *Config) { c.Oracle.Volatile.CriticalThresholdPercent = 1 }},
+		{"negative cooldown", func(c *Config) { c.Oracle.Volatile.CooldownCriticalMinutes = Minutes(-5 * time.Minute) }},
+		{"unsorted dynamic cooldowns", func(c *Config) {
+			c.Oracle.Stablecoin.DynamicCooldowns = []DynamicCooldownConfig{
+				{ThresholdPercent: 5, CooldownSeconds: Duration(30 * time.Second)},
+				{ThresholdPercent: 10, CooldownSeconds: Duration(10 * time.Second)},
+			}
+		}},
+		{"trend critical below warning", func(c *Config) { c.Concentration.BorrowTop10Trend.CriticalIncreasePoints = 5 }},
+		{"unknown price source", func(c *Config) {
+			c.Chains = map[string]ChainConfig{"base": {RPCURLs: []string{"http://localhost:8545"}, PriceSource: "dexscreener"}}
+		}},
+		{"invalid rpc header name", func(c *Config) {
+			c.Chains = map[string]ChainConfig{"base": {RPCURLs: []string{"http://localhost:8545"}, RPCHeaders: map[string]string{"X Api Key": "secret"}}}
+		}},
+		{"negative consecutive failures", func(c *Config) { c.ErrorReporting.ConsecutiveFailures = -1 }},
+		{"deviation clamp below critical", func(c *Config) { c.Oracle.MaxReportedDeviationPercent = 2 }},
+		{"negative consecutive errors", func(c *Config) { c.Oracle.MaxConsecutiveErrors = -1 }},
+		{"negative missing reference cycles", func(c *Config) { c.Oracle.MaxMissingReferenceCycles = -1 }},
+		{"zero price store max age", func(c *Config) { c.Oracle.PriceStoreMaxAgeMinutes = 0 }},
+		{"chart template not a URL", func(c *Config) { c.Oracle.Chart.URLTemplate = "charts/{symbol}" }},
+		{"chart without window", func(c *Config) {
+			c.Oracle.Chart = ChartConfig{URLTemplate: "https://charts.example.com/{symbol}"}
+		}},
+		{"negative decision log size", func(c *Config) { c.Alerts.DecisionLogSize = -1 }},
+		{"negative delivery degraded after", func(c *Config) { c.Alerts.DeliveryDegradedAfter = -1 }},
+		{"avg hf drop critical below warning", func(c *Config) { c.HealthFactor.AvgHFDrop.CriticalThreshold = 0.05 }},
+		{"unknown avg hf metric", func(c *Config) { c.HealthFactor.AvgHFDrop.Metric = "mean" }},
+		{"single stress metric", func(c *Config) { c.HealthFactor.StressEvent.Metrics = []string{"avg_hf_drop"} }},
+		{"unknown stress metric", func(c *Config) { c.HealthFactor.StressEvent.Metrics = []string{"avg_hf_drop", "whale_supply"} }},
+		{"stress min severity OK", func(c *Config) { c.HealthFactor.StressEvent.MinSeverity = "OK" }},
+		{"negative startup jitter", func(c *Config) { c.StartupJitterSeconds = Duration(-time.Second) }},
+		{"maintenance window ends before start", func(c *Config) {
+			start := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+			c.Alerts.MaintenanceWindows = []MaintenanceWindowConfig{{Start: start, End: start.Add(-time.Minute)}}
+		}},
+		{"maintenance window bad pattern", func(c *Config) {
+			start := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+			c.Alerts.MaintenanceWindows = []MaintenanceWindowConfig{{Start: start, End: start.Add(time.Hour), Tokens: []string{"[WETH"}}}
+		}},
+		{"rpc provider key template without keys", func(c *Config) {
+			c.RPCProviders = []RPCProviderConfig{{Provider: "infura", URLTemplate: "https://{network}.infura.io/v3/{key}", Networks: map[string]string{"base": "base-mainnet"}}}
+		}},
+		{"rpc provider without networks", func(c *Config) {
+			c.RPCProviders = []RPCProviderConfig{{Provider: "public", URLTemplate: "https://rpc.example"}}
+		}},
+		{"business denylist without metric", func(c *Config) { c.Alerts.BusinessDenylist = []string{"concentration"} }},
+		{"business allowlist bad pattern", func(c *Config) { c.Alerts.BusinessAllowlist = []string{"oracle_[base:price_deviation"} }},
+		{"above-peg critical below warning", func(c *Config) {
+			c.Oracle.StablecoinAbovePeg = PegThresholdConfig{WarningThresholdPercent: 3, CriticalThresholdPercent: 2}
+		}},
+		{"trend without baseline window", func(c *Config) { c.Concentration.BorrowTop10Trend.BaselineWindowMinutes = 0 }},
+		{"chain head critical below warning", func(c *Config) { c.ChainHead.CriticalBlockAgeSeconds = Duration(time.Minute) }},
+		{"negative retention", func(c *Config) { c.Retention.OracleObservationsDays = -1 }},
+		{"zero retention batch size", func(c *Config) { c.Retention.BatchSize = 0 }},
+		{"precision check without history", func(c *Config) {
+			c.Oracle.PrecisionCheck = PrecisionCheckConfig{Enabled: true, MaxSignificantDigits: 3}
+		}},
+		{"health score with zero weights", func(c *Config) {
+			c.Oracle.HealthScore = HealthScoreConfig{Enabled: true, MaxStalenessMinutes: Minutes(time.Hour), MaxRPCLagSeconds: Duration(time.Second)}
+		}},
+		{"multi source with unknown source", func(c *Config) {
+			c.Oracle.MultiSource = MultiSourceConfig{Enabled: true, Weights: map[string]float64{"chainlink": 1}, MaxDispersionPercent: 2}
+		}},
+		{"multi source without weights", func(c *Config) {
+			c.Oracle.MultiSource = MultiSourceConfig{Enabled: true, MaxDispersionPercent: 2}
+		}},
+		{"price API base without scheme", func(c *Config) {
+			c.PriceAPIs.AlchemyBaseURL = "api.g.alchemy.com/prices/v1"
+		}},
+		{"relative Alchemy path", func(c *Config) {
+			c.PriceAPIs.AlchemyPath = "{api_key}/tokens/by-address"
+		}},
+		{"negative reference max age", func(c *Config) {
+			c.Oracle.ReferenceMaxAge.VolatileMinutes = Minutes(-time.Minute)
+		}},
+		{"price sanity factor below one", func(c *Config) {
+			c.Oracle.PriceSanity.MaxChangeFactor = 0.5
+		}},
+		{"feed comparison without agreement band", func(c *Config) {
+			c.Oracle.FeedComparison = FeedComparisonConfig{Enabled: true}
+		}},
+		{"invalid peg currency", func(c *Config) {
+			c.Chains = map[string]ChainConfig{"base": {RPCURLs: []string{"http://localhost:8545"}, PegCurrencies: map[string]string{"eurc": "euro"}}}
+		}},
+		{"unknown report mode", func(c *Config) {
+			c.Chains = map[string]ChainConfig{"moonriver": {RPCURLs: []string{"http://localhost:8545"}, ReportMode: "table"}}
+		}},
+		{"unknown deviation strategy", func(c *Config) {
+			c.Chains = map[string]ChainConfig{"base": {RPCURLs: []string{"http://localhost:8545"}, DeviationStrategies: map[string]string{"eurc": "worst"}}}
+		}},
+		{"zero peg value", func(c *Config) {
+			c.Chains = map[string]ChainConfig{"base": {RPCURLs: []string{"http://localhost:8545"}, PegValues: map[string]float64{"eurc": 0}}}
+		}},
+		{"maintenance without until", func(c *Config) {
+			c.Chains = map[string]ChainConfig{"base": {RPCURLs: []string{"http://localhost:8545"}, Maintenance: &ChainMaintenanceConfig{Reason: "oracle migration"}}}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.mutate(cfg)
+			if err := cfg.Validate(); err == nil {
+				t.Error("Validate() = nil, want error")
+			}
+		})
+	}
+}
+
+func TestTokenDisabled(t *testing.T) {
+	cfg := OracleConfig{DisabledTokens: []string{"mamo", "optimism:VELO"}}
+
+	tests := []struct {
+		chain, symbol string
+		want          bool
+	}{
+		{"base", "mamo", true},
+		{"optimism", "mamo", true},
+		{"optimism", "velo", true},
+		{"base", "velo", false},
+		{"base", "weth", false},
+	}
+	for _, tt := range tests {
+		if got := cfg.TokenDisabled(tt.chain, tt.symbol); got != tt.want {
+			t.Errorf("TokenDisabled(%q, %q) = %v, want %v", tt.chain, tt.symbol, got, tt.want)
+		}
+	}
+}
+
+func TestLoadChains(t *testing.T) {
+	t.Setenv("TEST_ALCHEMY_KEY", "secret")
+	path := writeConfig(t, `{
+		"chains": {
+			"base": {"enabled": true, "rpc_urls": ["https://base.example/v2/${TEST_ALCHEMY_KEY}"], "ws_url": "wss://base.example"},
+			"optimism": {"enabled": false}
+		}
+	}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	base := cfg.Chains["base"]
+	if !base.Enabled || len(base.RPCURLs) != 1 || base.RPCURLs[0] != "https://base.example/v2/secret" {
+		t.Errorf("base chain = %+v", base)
+	}
+	if base.WSURL != "wss://base.example" {
+		t.Errorf("ws_url = %q", base.WSURL)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
+
+func TestValidateRequiresRPCForEnabledChain(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Chains = map[string]ChainConfig{
+		"base":     {Enabled: true, RPCURLs: []string{""}},
+		"optimism": {Enabled: false},
+	}
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "chains.base") {
+		t.Fatalf("Validate = %v, want chains.base error", err)
+	}
+	if strings.Contains(err.Error(), "chains.optimism") {
+		t.Errorf("disabled chain should not be validated: %v", err)
+	}
+
+	// An RPC provider serving the chain is enough
+	cfg.RPCProviders = []RPCProviderConfig{{
+		Provider:    "infura",
+		URLTemplate: "https://{network}.infura.io/v3/{key}",
+		Networks:    map[string]string{"base": "base-mainnet"},
+		Keys:        []string{"secret"},
+	}}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate with an rpc_provider for base: %v", err)
+	}
+}
+
+func TestLoadAlertPolicies(t *testing.T) {
+	path := writeConfig(t, `{
+		"alert_policies": {
+			"concentration:whale_supply": {"cooldown_warning_minutes": "2h", "consecutive_ok_required": 4},
+			"oracle_base:price_deviation_volatile": {
+				"dynamic_cooldowns": [{"threshold_percent": 10, "cooldown_seconds": "5m"}]
+			}
+		}
+	}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	whale := cfg.AlertPolicies["concentration:whale_supply"]
+	if whale.CooldownWarning == nil || whale.CooldownWarning.Duration() != 2*time.Hour {
+		t.Errorf("cooldown_warning_minutes = %v, want 2h", whale.CooldownWarning)
+	}
+	if whale.ConsecutiveOKRequired == nil || *whale.ConsecutiveOKRequired != 4 {
+		t.Errorf("consecutive_ok_required = %v, want 4", whale.ConsecutiveOKRequired)
+	}
+	if whale.MinValueChange != nil || whale.CooldownCritical != nil {
+		t.Errorf("omitted fields should stay unset: %+v", whale)
+	}
+
+	volatile := cfg.AlertPolicies["oracle_base:price_deviation_volatile"]
+	if len(volatile.DynamicCooldowns) != 1 || volatile.DynamicCooldowns[0].CooldownSeconds.Duration() != 5*time.Minute {
+		t.Errorf("dynamic_cooldowns = %+v", volatile.DynamicCooldowns)
+	}
+}
+
+func TestValidateAlertPolicies(t *testing.T) {
+	negative := -1
+	cfg := DefaultConfig()
+	cfg.AlertPolicies = map[string]AlertPolicyConfig{
+		"whale_supply":             {},
+		"concentration:borrow_top": {ConsecutiveOKRequired: &negative},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate: expected error")
+	}
+	for _, want := range []string{"alert_policies.whale_supply: key", "alert_policies.concentration:borrow_top.consecutive_ok_required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestValidateSeverityBands(t *testing.T) {
+	path := writeConfig(t, `{
+		"severity_bands": {
+			"health_aggregate:borrow_spike": [
+				{"threshold": 15, "severity": "WARNING"},
+				{"threshold": 30, "severity": "CRITICAL"}
+			]
+		}
+	}`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if bands := cfg.SeverityBands["health_aggregate:borrow_spike"]; len(bands) != 2 || bands[1].Threshold != 30 || bands[1].Severity != "CRITICAL" {
+		t.Errorf("severity_bands = %+v", bands)
+	}
+
+	cfg.SeverityBands = map[string][]SeverityBandConfig{
+		"borrow_spike":                {{Threshold: 10, Severity: "WARNING"}},
+		"concentration:borrow_single": {{Threshold: 10, Severity: "warning"}},
+		"oracle_base:system_health":   {},
+	}
+	err = cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate: expected error")
+	}
+	for _, want := range []string{
+		"severity_bands.borrow_spike: key",
+		"severity_bands.concentration:borrow_single[0].severity",
+		"severity_bands.oracle_base:system_health must list",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Duration is a time span configured as a Go duration string ("30s", "15m", "2h").
+// Legacy integer values are interpreted as seconds.
+type Duration time.Duration
+
+// Minutes is a Duration whose legacy integer values are interpreted as minutes
+type Minutes time.Duration
+
+// Hours is a Duration whose legacy integer values are interpreted as hours
+type Hours time.Duration
+
+func (d Duration) Duration() time.Duration { return time.Duration(d) }
+func (m Minutes) Duration() time.Duration  { return time.Duration(m) }
+func (h Hours) Duration() time.Duration    { return time.Duration(h) }
+
+func (d Duration) String() string { return time.Duration(d).String() }
+func (m Minutes) String() string  { return time.Duration(m).String() }
+func (h Hours) String() string    { return time.Duration(h).String() }
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	return unmarshalDuration(data, time.Second, (*time.Duration)(d))
+}
+
+func (m *Minutes) UnmarshalJSON(data []byte) error {
+	return unmarshalDuration(data, time.Minute, (*time.Duration)(m))
+}
+
+func (h *Hours) UnmarshalJSON(data []byte) error {
+	return unmarshalDuration(data, time.Hour, (*time.Duration)(h))
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) { return json.Marshal(d.String()) }
+func (m Minutes) MarshalJSON() ([]byte, error)  { return json.Marshal(m.String()) }
+func (h Hours) MarshalJSON() ([]byte, error)    { return json.Marshal(h.String()) }
+
+// ParseDuration parses a duration string, treating a bare number as a count of legacyUnit
+func ParseDuration(value string, legacyUnit time.Duration) (time.Duration, error) {
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(n * float64(legacyUnit)), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: expected a number or a duration like \"30s\", \"15m\", \"2h\"", value)
+	}
+	return d, nil
+}
+
+func unmarshalDuration(data []byte, legacyUnit time.Duration, dst *time.Duration) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var value string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: expected a duration like \"30s\", \"15m\", \"2h\"", value)
+		}
+		*dst = d
+		return nil
+	}
+
+	d, err := ParseDuration(string(data), legacyUnit)
+	if err != nil {
+		return err
+	}
+	*dst = d
+	return nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDurationUnmarshal(t *testing.T) {
+	var cfg struct {
+		Interval Duration `json:"interval"`
+		Cooldown Minutes  `json:"cooldown"`
+		Window   Hours    `json:"window"`
+	}
+
+	tests := []struct {
+		name                                   string
+		input                                  string
+		wantInterval, wantCooldown, wantWindow time.Duration
+	}{
+		{
+			name:         "legacy integers",
+			input:        `{"interval": 120, "cooldown": 30, "window": 24}`,
+			wantInterval: 2 * time.Minute,
+			wantCooldown: 30 * time.Minute,
+			wantWindow:   24 * time.Hour,
+		},
+		{
+			name:         "duration strings",
+			input:        `{"interval": "45s", "cooldown": "90s", "window": "30m"}`,
+			wantInterval: 45 * time.Second,
+			wantCooldown: 90 * time.Second,
+			wantWindow:   30 * time.Minute,
+		},
+		{
+			name:         "fractional legacy values",
+			input:        `{"interval": 0.5, "cooldown": 1.5, "window": 0.25}`,
+			wantInterval: 500 * time.Millisecond,
+			wantCooldown: 90 * time.Second,
+			wantWindow:   15 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.input), &cfg); err != nil {
+				t.Fatalf("Unmarshal returned error: %v", err)
+			}
+			if cfg.Interval.Duration() != tt.wantInterval {
+				t.Errorf("Interval = %v, want %v", cfg.Interval, tt.wantInterval)
+			}
+			if cfg.Cooldown.Duration() != tt.wantCooldown {
+				t.Errorf("Cooldown = %v, want %v", cfg.Cooldown, tt.wantCooldown)
+			}
+			if cfg.Window.Duration() != tt.wantWindow {
+				t.Errorf("Window = %v, want %v", cfg.Window, tt.wantWindow)
+			}
+		})
+	}
+}
+
+func TestDurationUnmarshalInvalid(t *testing.T) {
+	for _, input := range []string{`"15 minutes"`, `"30"`, `true`} {
+		var d Minutes
+		if err := json.Unmarshal([]byte(input), &d); err == nil {
+			t.Errorf("Unmarshal(%s) = nil error, want error", input)
+		}
+	}
+}
+
+func TestDurationRoundTrip(t *testing.T) {
+	in := Minutes(15 * time.Minute)
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if string(data) != `"15m0s"` {
+		t.Errorf("Marshal = %s, want \"15m0s\"", data)
+	}
+
+	var out Minutes
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out != in {
+		t.Errorf("round trip = %v, want %v", out, in)
+	}
+}
+
+func TestWarningsFlagLongCooldowns(t *testing.T) {
+	if w := DefaultConfig().Warnings(); len(w) != 0 {
+		t.Errorf("DefaultConfig().Warnings() = %v, want none", w)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Oracle.Stablecoin.CooldownCriticalMinutes = Minutes(300 * time.Hour)
+
+	warnings := cfg.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "oracle.stablecoin.cooldown_critical_minutes") {
+		t.Errorf("Warnings() = %v, want one warning for oracle.stablecoin.cooldown_critical_minutes", warnings)
+	}
+}
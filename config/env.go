@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"time"
+)
+
+// envOverride maps an environment variable onto a config field
+type envOverride struct {
+	name  string
+	apply func(cfg *Config, value string) error
+}
+
+var envOverrides = []envOverride{
+	{"MAX_GLOBAL_CONCURRENCY", func(cfg *Config, v string) error {
+		return setNonNegativeInt(&cfg.MaxGlobalConcurrency, v)
+	}},
+	{"ORACLE_CHECK_INTERVAL_SECONDS", func(cfg *Config, v string) error {
+		return setPositiveDuration((*time.Duration)(&cfg.Oracle.CheckIntervalSeconds), v, time.Second)
+	}},
+	{"ORACLE_STABLE_WARNING_PCT", func(cfg *Config, v string) error {
+		return setPositiveFloat(&cfg.Oracle.Stablecoin.WarningThresholdPercent, v)
+	}},
+	{"ORACLE_STABLE_CRITICAL_PCT", func(cfg *Config, v string) error {
+		return setPositiveFloat(&cfg.Oracle.Stablecoin.CriticalThresholdPercent, v)
+	}},
+	{"ORACLE_VOLATILE_WARNING_PCT", func(cfg *Config, v string) error {
+		return setPositiveFloat(&cfg.Oracle.Volatile.WarningThresholdPercent, v)
+	}},
+	{"ORACLE_VOLATILE_CRITICAL_PCT", func(cfg *Config, v string) error {
+		return setPositiveFloat(&cfg.Oracle.Volatile.CriticalThresholdPercent, v)
+	}},
+}
+
+// applyEnvOverrides applies threshold overrides from the environment.
+// Invalid values are logged and ignored so the file or default value stays in effect.
+func applyEnvOverrides(cfg *Config) {
+	for _, o := range envOverrides {
+		value, ok := os.LookupEnv(o.name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := o.apply(cfg, value); err != nil {
+			log.Printf("warning: ignoring %s=%q: %v", o.name, value, err)
+			continue
+		}
+		log.Printf("config override applied: %s=%s", o.name, value)
+	}
+}
+
+func setPositiveFloat(dst *float64, value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) || f <= 0 {
+		return fmt.Errorf("must be a positive number")
+	}
+	*dst = f
+	return nil
+}
+
+func setPositiveDuration(dst *time.Duration, value string, legacyUnit time.Duration) error {
+	d, err := ParseDuration(value, legacyUnit)
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return fmt.Errorf("must be a positive duration")
+	}
+	*dst = d
+	return nil
+}
+
+func setNonNegativeInt(dst *int, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	if n < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	*dst = n
+	return nil
+}
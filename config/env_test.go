@@ -0,0 +1,84 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestApplyEnvOverrides(t *testing.T) {
+	t.Setenv("ORACLE_STABLE_CRITICAL_PCT", "3.5")
+	t.Setenv("ORACLE_VOLATILE_WARNING_PCT", "4")
+	t.Setenv("ORACLE_CHECK_INTERVAL_SECONDS", "45")
+
+	cfg := DefaultConfig()
+	applyEnvOverrides(cfg)
+
+	if cfg.Oracle.Stablecoin.CriticalThresholdPercent != 3.5 {
+		t.Errorf("Stablecoin.CriticalThresholdPercent = %v, want 3.5", cfg.Oracle.Stablecoin.CriticalThresholdPercent)
+	}
+	if cfg.Oracle.Volatile.WarningThresholdPercent != 4 {
+		t.Errorf("Volatile.WarningThresholdPercent = %v, want 4", cfg.Oracle.Volatile.WarningThresholdPercent)
+	}
+	if cfg.Oracle.CheckIntervalSeconds.Duration() != 45*time.Second {
+		t.Errorf("Oracle.CheckIntervalSeconds = %v, want 45s", cfg.Oracle.CheckIntervalSeconds)
+	}
+	// Untouched values keep their defaults
+	if want := DefaultConfig().Oracle.Stablecoin.WarningThresholdPercent; cfg.Oracle.Stablecoin.WarningThresholdPercent != want {
+		t.Errorf("Stablecoin.WarningThresholdPercent = %v, want %v", cfg.Oracle.Stablecoin.WarningThresholdPercent, want)
+	}
+}
+
+func TestApplyEnvOverridesDurationString(t *testing.T) {
+	t.Setenv("ORACLE_CHECK_INTERVAL_SECONDS", "2m")
+
+	cfg := DefaultConfig()
+	applyEnvOverrides(cfg)
+
+	if cfg.Oracle.CheckIntervalSeconds.Duration() != 2*time.Minute {
+		t.Errorf("Oracle.CheckIntervalSeconds = %v, want 2m0s", cfg.Oracle.CheckIntervalSeconds)
+	}
+}
+
+func TestApplyEnvOverridesRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name, value string
+	}{
+		{"ORACLE_STABLE_CRITICAL_PCT", "abc"},
+		{"ORACLE_STABLE_CRITICAL_PCT", "-1"},
+		{"ORACLE_STABLE_CRITICAL_PCT", "NaN"},
+		{"ORACLE_STABLE_CRITICAL_PCT", "+Inf"},
+		{"ORACLE_CHECK_INTERVAL_SECONDS", "0"},
+		{"ORACLE_CHECK_INTERVAL_SECONDS", "-30s"},
+		{"ORACLE_CHECK_INTERVAL_SECONDS", "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"="+tt.value, func(t *testing.T) {
+			t.Setenv(tt.name, tt.value)
+
+			cfg := DefaultConfig()
+			applyEnvOverrides(cfg)
+
+			def := DefaultConfig()
+			if cfg.Oracle.Stablecoin.CriticalThresholdPercent != def.Oracle.Stablecoin.CriticalThresholdPercent ||
+				cfg.Oracle.CheckIntervalSeconds != def.Oracle.CheckIntervalSeconds {
+				t.Errorf("invalid override %s=%q was applied", tt.name, tt.value)
+			}
+		})
+	}
+}
+
+func TestLoadOrDefaultAppliesEnvOverrides(t *testing.T) {
+	t.Setenv("ORACLE_VOLATILE_CRITICAL_PCT", "12")
+
+	cfg := LoadOrDefault(filepath.Join(t.TempDir(), "missing.json"))
+	if cfg.Oracle.Volatile.CriticalThresholdPercent != 12 {
+		t.Errorf("defaults: Volatile.CriticalThresholdPercent = %v, want 12", cfg.Oracle.Volatile.CriticalThresholdPercent)
+	}
+
+	cfg = LoadOrDefault(writeConfig(t, `{"oracle": {"volatile": {"critical_threshold_percent": 7}}}`))
+	if cfg.Oracle.Volatile.CriticalThresholdPercent != 12 {
+		t.Errorf("file: Volatile.CriticalThresholdPercent = %v, want 12", cfg.Oracle.Volatile.CriticalThresholdPercent)
+	}
+}
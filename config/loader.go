@@ -0,0 +1,134 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/0x0Glitch/internal/httpclient"
+)
+
+// urlFetchTimeout bounds fetching CONFIG_URL
+const urlFetchTimeout = 10 * time.Second
+
+// maxConfigSize caps a config document fetched from CONFIG_URL
+const maxConfigSize = 4 << 20
+
+// Source locates the configuration. Inline JSON takes precedence over the URL, the
+// URL over the file, and the file over the defaults.
+type Source struct {
+	Inline string // CONFIG_JSON
+	URL    string // CONFIG_URL
+	Path   string // CONFIG_PATH, default config.json
+}
+
+// SourceFromEnv reads CONFIG_JSON, CONFIG_URL and CONFIG_PATH
+func SourceFromEnv() Source {
+	path := os.Getenv("CONFIG_PATH")
+	if path == "" {
+		path = "config.json"
+	}
+	return Source{Inline: os.Getenv("CONFIG_JSON"), URL: os.Getenv("CONFIG_URL"), Path: path}
+}
+
+// String describes the source in use for logs and alerts, without URL credentials
+func (s Source) String() string {
+	switch {
+	case s.Inline != "":
+		return "CONFIG_JSON"
+	case s.URL != "":
+		return redactURL(s.URL)
+	default:
+		return s.Path
+	}
+}
+
+// Load reads the configuration at startup. A URL that cannot be fetched falls back to
+// the file, and a missing file to the defaults; a document that fails to parse is an
+// error whatever its source. It returns a description of where the config came from.
+func (s Source) Load(ctx context.Context) (*Config, string, error) {
+	if s.Inline != "" {
+		cfg, err := LoadBytes("CONFIG_JSON", []byte(s.Inline))
+		return cfg, "CONFIG_JSON", err
+	}
+	if s.URL != "" {
+		cfg, err := LoadURL(ctx, s.URL)
+		if err == nil || !errors.Is(err, errFetch) {
+			return cfg, redactURL(s.URL), err
+		}
+		log.Printf("warning: %v, falling back to %s", err, s.Path)
+	}
+	cfg, err := Load(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Printf("warning: config file %s not found, using defaults", s.Path)
+		cfg = DefaultConfig()
+		applyEnvOverrides(cfg)
+		return cfg, "defaults", nil
+	}
+	return cfg, s.Path, err
+}
+
+// Reload re-reads the configuration from its primary source without falling back,
+// so a failed fetch keeps the running config rather than swapping in another source
+func (s Source) Reload(ctx context.Context) (*Config, error) {
+	switch {
+	case s.Inline != "":
+		return LoadBytes("CONFIG_JSON", []byte(s.Inline))
+	case s.URL != "":
+		return LoadURL(ctx, s.URL)
+	default:
+		return Load(s.Path)
+	}
+}
+
+// errFetch marks failures to retrieve a remote config, as opposed to parsing it
+var errFetch = errors.New("failed to fetch config")
+
+// LoadURL fetches a JSON or YAML (by path extension) config document from rawURL
+// and loads it like a file
+func LoadURL(ctx context.Context, rawURL string) (*Config, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("CONFIG_URL must be an http(s) URL")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, urlFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: %v", errFetch, redactURL(rawURL), err)
+	}
+	resp, err := httpclient.New(0).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: %v", errFetch, redactURL(rawURL), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w from %s: HTTP %d", errFetch, redactURL(rawURL), resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxConfigSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: %v", errFetch, redactURL(rawURL), err)
+	}
+	if len(data) > maxConfigSize {
+		return nil, fmt.Errorf("config at %s exceeds %d bytes", redactURL(rawURL), maxConfigSize)
+	}
+	return LoadBytes(u.Path, data)
+}
+
+// redactURL drops credentials and the query string, which may carry tokens
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "(invalid URL)"
+	}
+	u.User, u.RawQuery = nil, ""
+	return u.String()
+}
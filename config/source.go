@@ -0,0 +1,237 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envRefPattern matches ${VAR} and ${VAR:-default}
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// normalize decodes a JSON or YAML document (chosen by file extension), expands
+// environment references in string values, checks for unknown keys and
+// re-encodes the result as JSON for decoding into Config
+func normalize(path string, data []byte) ([]byte, error) {
+	var doc map[string]any
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML config: %w", err)
+		}
+	default:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+	}
+	if doc == nil {
+		doc = map[string]any{}
+	}
+
+	if err := checkUnknownKeys(doc); err != nil {
+		return nil, err
+	}
+
+	expanded, err := json.Marshal(expandEnv(doc, reflect.TypeOf(Config{})))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	return expanded, nil
+}
+
+// strictMode reports whether unknown config keys are rejected. It is on by default;
+// CONFIG_STRICT=false downgrades unknown keys to warnings for emergencies.
+func strictMode() bool {
+	strict, err := strconv.ParseBool(os.Getenv("CONFIG_STRICT"))
+	return err != nil || strict
+}
+
+// checkUnknownKeys rejects keys that do not map to a Config field at any nesting
+// level, reporting each as a JSON pointer. Outside strict mode they are logged and ignored.
+func checkUnknownKeys(doc map[string]any) error {
+	unknown := unknownKeys(doc, reflect.TypeOf(Config{}), "")
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	if strictMode() {
+		return fmt.Errorf("unknown config keys: %s (set CONFIG_STRICT=false to ignore)", strings.Join(unknown, ", "))
+	}
+	log.Printf("warning: ignoring unknown config keys: %s", strings.Join(unknown, ", "))
+	return nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownKeys walks a decoded document alongside the type it will be decoded into
+// and returns the JSON pointers of object keys with no matching field
+func unknownKeys(value any, t reflect.Type, pointer string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// Types with custom decoding (durations) are leaves
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return nil
+	}
+
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		fields := jsonFields(t)
+		for key, v := range obj {
+			// encoding/json matches field names case-insensitively
+			ft, ok := fields[strings.ToLower(key)]
+			if !ok {
+				unknown = append(unknown, pointer+"/"+escapePointer(key))
+				continue
+			}
+			unknown = append(unknown, unknownKeys(v, ft, pointer+"/"+escapePointer(key))...)
+		}
+	case reflect.Map:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		for key, v := range obj {
+			unknown = append(unknown, unknownKeys(v, t.Elem(), pointer+"/"+escapePointer(key))...)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+		for i, v := range items {
+			unknown = append(unknown, unknownKeys(v, t.Elem(), fmt.Sprintf("%s/%d", pointer, i))...)
+		}
+	}
+	return unknown
+}
+
+// jsonFields maps lower-cased JSON names to field types, flattening embedded structs
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embedded, ft := range jsonFields(field.Type) {
+				if _, shadowed := fields[embedded]; !shadowed {
+					fields[embedded] = ft
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
+
+// escapePointer escapes a key for use as a JSON pointer reference token (RFC 6901)
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// expandEnv replaces ${VAR} and ${VAR:-default} references in every string value,
+// walking the document alongside t, the type it will be decoded into. A value
+// consisting of a single reference is converted to a number or boolean only when its
+// field is numeric or boolean, so string fields such as chat IDs and API keys stay
+// strings whatever they expand to. Durations are numeric: "${VAR}" with VAR=60 is 60
+// of the field's legacy unit, while VAR=30s stays a duration string.
+func expandEnv(v any, t reflect.Type) any {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		var fields map[string]reflect.Type
+		if t != nil && t.Kind() == reflect.Struct {
+			fields = jsonFields(t)
+		}
+		for k, item := range val {
+			var ft reflect.Type
+			switch {
+			case fields != nil:
+				ft = fields[strings.ToLower(k)] // nil for unknown keys
+			case t != nil && t.Kind() == reflect.Map:
+				ft = t.Elem()
+			}
+			val[k] = expandEnv(item, ft)
+		}
+		return val
+	case []any:
+		var elem reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elem = t.Elem()
+		}
+		for i, item := range val {
+			val[i] = expandEnv(item, elem)
+		}
+		return val
+	case string:
+		return expandString(val, t)
+	default:
+		return v
+	}
+}
+
+func expandString(s string, t reflect.Type) any {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	expanded := envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		m := envRefPattern.FindStringSubmatch(ref)
+		if value, ok := os.LookupEnv(m[1]); ok && value != "" {
+			return value
+		}
+		return m[2]
+	})
+
+	loc := envRefPattern.FindStringIndex(s)
+	if t == nil || loc == nil || loc[0] != 0 || loc[1] != len(s) {
+		return expanded
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		// A bare number suits durations too, which read it in their legacy unit
+		// NaN and infinities have no JSON form; left as strings they fail to decode
+		if f, err := strconv.ParseFloat(expanded, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return json.Number(expanded)
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(expanded); err == nil {
+			return b
+		}
+	}
+	return expanded
+}
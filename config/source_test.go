@@ -0,0 +1,210 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", `
+# Oracle thresholds
+oracle:
+  check_interval_seconds: 2m
+  volatile:
+    warning_threshold_percent: 4
+    cooldown_warning_minutes: 45   # legacy integer minutes
+concentration:
+  borrow_top10:
+    critical_threshold_percent: 95
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if cfg.Oracle.CheckIntervalSeconds.Duration() != 2*time.Minute {
+		t.Errorf("Oracle.CheckIntervalSeconds = %v, want 2m0s", cfg.Oracle.CheckIntervalSeconds)
+	}
+	if cfg.Oracle.Volatile.WarningThresholdPercent != 4 {
+		t.Errorf("Volatile.WarningThresholdPercent = %v, want 4", cfg.Oracle.Volatile.WarningThresholdPercent)
+	}
+	if cfg.Oracle.Volatile.CooldownWarning() != 45*time.Minute {
+		t.Errorf("Volatile.CooldownWarning() = %v, want 45m0s", cfg.Oracle.Volatile.CooldownWarning())
+	}
+	if cfg.Concentration.BorrowTop10.CriticalThresholdPercent != 95 {
+		t.Errorf("BorrowTop10.CriticalThresholdPercent = %v, want 95", cfg.Concentration.BorrowTop10.CriticalThresholdPercent)
+	}
+	if want := DefaultConfig().Oracle.Stablecoin; cfg.Oracle.Stablecoin.WarningThresholdPercent != want.WarningThresholdPercent {
+		t.Errorf("Stablecoin.WarningThresholdPercent = %v, want default %v", cfg.Oracle.Stablecoin.WarningThresholdPercent, want.WarningThresholdPercent)
+	}
+}
+
+func TestLoadExpandsEnv(t *testing.T) {
+	t.Setenv("TEST_STABLE_WARN", "1.25")
+	t.Setenv("TEST_INTERVAL", "90s")
+	t.Setenv("TEST_TOKEN", "mamo")
+
+	tests := []struct {
+		name, file, content string
+	}{
+		{"json", "config.json", `{
+			"oracle": {
+				"check_interval_seconds": "${TEST_INTERVAL}",
+				"stablecoin": {"warning_threshold_percent": "${TEST_STABLE_WARN}", "critical_threshold_percent": "${TEST_UNSET:-3}"},
+				"disabled_tokens": ["base:${TEST_TOKEN}", "${TEST_UNSET:-velo}"]
+			}
+		}`},
+		{"yaml", "config.yml", `
+oracle:
+  check_interval_seconds: ${TEST_INTERVAL}
+  stablecoin:
+    warning_threshold_percent: ${TEST_STABLE_WARN}
+    critical_threshold_percent: ${TEST_UNSET:-3}
+  disabled_tokens:
+    - base:${TEST_TOKEN}
+    - ${TEST_UNSET:-velo}
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfigFile(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("Load returned error: %v", err)
+			}
+			if cfg.Oracle.CheckIntervalSeconds.Duration() != 90*time.Second {
+				t.Errorf("Oracle.CheckIntervalSeconds = %v, want 1m30s", cfg.Oracle.CheckIntervalSeconds)
+			}
+			if cfg.Oracle.Stablecoin.WarningThresholdPercent != 1.25 {
+				t.Errorf("Stablecoin.WarningThresholdPercent = %v, want 1.25", cfg.Oracle.Stablecoin.WarningThresholdPercent)
+			}
+			if cfg.Oracle.Stablecoin.CriticalThresholdPercent != 3 {
+				t.Errorf("Stablecoin.CriticalThresholdPercent = %v, want 3", cfg.Oracle.Stablecoin.CriticalThresholdPercent)
+			}
+			if len(cfg.Oracle.DisabledTokens) != 2 || cfg.Oracle.DisabledTokens[0] != "base:mamo" || cfg.Oracle.DisabledTokens[1] != "velo" {
+				t.Errorf("DisabledTokens = %v, want [base:mamo velo]", cfg.Oracle.DisabledTokens)
+			}
+		})
+	}
+}
+
+func TestLoadExpandsEnvByFieldType(t *testing.T) {
+	t.Setenv("TEST_SECRET", "12345")
+	t.Setenv("TEST_REASON", "true")
+	t.Setenv("TEST_ENABLED", "true")
+	t.Setenv("TEST_CONCURRENCY", "6")
+
+	cfg, err := Load(writeConfig(t, `{
+		"max_global_concurrency": "${TEST_CONCURRENCY}",
+		"chains": {"base": {
+			"enabled": "${TEST_ENABLED}",
+			"rpc_urls": ["${TEST_SECRET}"],
+			"ws_url": "${TEST_SECRET}",
+			"maintenance": {"until": "2026-03-01T12:00:00Z", "reason": "${TEST_REASON}"}
+		}}
+	}`))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	base := cfg.Chains["base"]
+	if base.WSURL != "12345" || len(base.RPCURLs) != 1 || base.RPCURLs[0] != "12345" {
+		t.Errorf("ws_url = %q, rpc_urls = %q; want numeric-looking strings kept as strings", base.WSURL, base.RPCURLs)
+	}
+	if base.Maintenance == nil || base.Maintenance.Reason != "true" {
+		t.Errorf("maintenance = %+v, want reason \"true\"", base.Maintenance)
+	}
+	if !base.Enabled || cfg.MaxGlobalConcurrency != 6 {
+		t.Errorf("enabled = %v, max_global_concurrency = %d; want typed fields converted", base.Enabled, cfg.MaxGlobalConcurrency)
+	}
+
+	// Durations take a bare number in their legacy unit or a duration string
+	t.Setenv("TEST_INTERVAL", "60")
+	t.Setenv("TEST_FEED_INTERVAL", "90m")
+	cfg, err = Load(writeConfig(t, `{"oracle": {
+		"check_interval_seconds": "${TEST_INTERVAL}",
+		"feed_check_interval_hours": "${TEST_FEED_INTERVAL}"
+	}}`))
+	if err != nil {
+		t.Fatalf("Load with duration references returned error: %v", err)
+	}
+	if cfg.Oracle.CheckIntervalSeconds.Duration() != time.Minute || cfg.Oracle.FeedCheckIntervalHours.Duration() != 90*time.Minute {
+		t.Errorf("check_interval_seconds = %s, feed_check_interval_hours = %s; want 1m0s, 1h30m0s",
+			cfg.Oracle.CheckIntervalSeconds, cfg.Oracle.FeedCheckIntervalHours)
+	}
+
+	// NaN has no JSON form; it is rejected as a value for the number field
+	t.Setenv("TEST_NAN", "nan")
+	_, err = Load(writeConfig(t, `{"oracle": {"stablecoin": {"warning_threshold_percent": "${TEST_NAN}"}}}`))
+	if err == nil || !strings.Contains(err.Error(), "warning_threshold_percent") {
+		t.Errorf("Load with NaN = %v, want an error naming the field", err)
+	}
+}
+
+func TestLoadUnknownKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		pointer string
+	}{
+		{"top level", "config.json", `{"concentation": {}, "oracle": {}}`, "/concentation"},
+		{"nested section", "config.json", `{"oracle": {"stablecoin": {"warning_treshold_percent": 1.5}}}`, "/oracle/stablecoin/warning_treshold_percent"},
+		{"array element", "config.json", `{"oracle": {"volatile": {"dynamic_cooldowns": [{"threshold_percent": 5, "cooldown": "1m"}]}}}`, "/oracle/volatile/dynamic_cooldowns/0/cooldown"},
+		{"map value", "config.json", `{"chains": {"base": {"enabled": true, "rpc_url": "x"}}}`, "/chains/base/rpc_url"},
+		{"yaml", "config.yaml", "health_factor:\n  borrow_spike:\n    warning_threshold_pct: 10\n", "/health_factor/borrow_spike/warning_threshold_pct"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, tt.file, tt.content)
+
+			_, err := Load(path)
+			if err == nil {
+				t.Fatal("strict Load with unknown key returned nil error")
+			}
+			if !strings.Contains(err.Error(), tt.pointer) {
+				t.Errorf("error %q does not mention %s", err, tt.pointer)
+			}
+
+			t.Setenv("CONFIG_STRICT", "false")
+			if _, err := Load(path); err != nil {
+				t.Errorf("non-strict Load returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadKnownKeysStrict(t *testing.T) {
+	// Embedded fields, pointer fields, durations and case-insensitive names are all known
+	path := writeConfigFile(t, "config.json", `{
+		"oracle": {"stablecoin": {"Warning_Threshold_Percent": 1, "critical_threshold_percent": 2, "cooldown_warning_minutes": "30m"}},
+		"alert_policies": {"concentration:whale_supply": {"min_value_change": 2}}
+	}`)
+
+	if _, err := Load(path); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+}
+
+func TestShippedConfigIsStrictlyValid(t *testing.T) {
+	cfg, err := Load(filepath.Join("..", "config.json"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
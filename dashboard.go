@@ -0,0 +1,177 @@
+package main
+
+import (
+	"expvar"
+	"html/template"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/version"
+	"github.com/0x0Glitch/workers"
+)
+
+// dashboardRefresh is how often the dashboard page reloads itself
+const dashboardRefresh = 30 * time.Second
+
+// dashboardChain is one chain's section of the dashboard
+type dashboardChain struct {
+	Name        string
+	Prices      []workers.TokenPrice
+	CircuitOpen bool
+	HealthScore string // empty when oracle.health_score is disabled
+}
+
+// dashboardJob is a job's row in the dashboard, from its most recent run
+type dashboardJob struct {
+	Name     string
+	LastRun  string
+	Duration string
+	Error    string
+	Runs     int
+	Errors   int
+}
+
+type dashboardPage struct {
+	Version   string
+	Generated string
+	Refresh   int
+	Chains    []dashboardChain
+	Incidents []alerts.Incident
+	Jobs      []dashboardJob
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"price":   func(v float64) string { return strconv.FormatFloat(v, 'f', 6, 64) },
+	"percent": func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) + "%" },
+	"age":     func(d time.Duration) string { return d.Round(time.Second).String() },
+	"since": func(t time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+		return time.Since(t).Round(time.Second).String() + " ago"
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.Refresh}}">
+<title>Oracle monitor</title>
+<style>
+body { font-family: sans-serif; margin: 1.5em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { padding: 0.2em 0.8em; text-align: left; border-bottom: 1px solid #ddd; }
+td.num { text-align: right; font-family: monospace; }
+.bad { color: #b00; }
+.warn { color: #b60; }
+</style>
+</head>
+<body>
+<h1>Oracle monitor</h1>
+<p>{{.Version}} · generated {{.Generated}} · refreshes every {{.Refresh}}s</p>
+
+<h2>Active incidents</h2>
+{{if .Incidents}}<table>
+<tr><th>Incident</th><th>Severity</th><th>Job</th><th>Entity</th><th>Metric</th><th>Open for</th><th>Last message</th></tr>
+{{range .Incidents}}<tr class="{{if eq .Severity "CRITICAL"}}bad{{else}}warn{{end}}">
+<td>{{.IncidentID}}</td><td>{{.Severity}}</td><td>{{.Job}}</td><td>{{.DisplayEntity}}</td><td>{{.Metric}}</td><td>{{age .Age}}</td><td>{{.Snippet}}</td></tr>
+{{end}}</table>{{else}}<p>None.</p>{{end}}
+
+{{range .Chains}}<h2>{{.Name}}</h2>
+<p>Circuit breaker: {{if .CircuitOpen}}<span class="bad">OPEN</span>{{else}}closed{{end}}{{if .HealthScore}} · health score {{.HealthScore}}/100{{end}}</p>
+{{if .Prices}}<table>
+<tr><th>Token</th><th>Onchain</th><th>Reference</th><th>Deviation</th><th>Observed</th><th>Error</th></tr>
+{{range .Prices}}<tr{{if .Error}} class="bad"{{end}}>
+<td>{{.Symbol}}</td><td class="num">{{price .OnchainPrice}}</td><td class="num">{{price .DEXPrice}}{{if .DEXCached}} (cached){{end}}</td>
+<td class="num">{{percent .DeviationPercent}}</td><td>{{since .ObservedAt}}</td><td>{{.Error}}</td></tr>
+{{end}}</table>{{end}}
+{{end}}
+
+<h2>Jobs</h2>
+<table>
+<tr><th>Job</th><th>Last run</th><th>Duration</th><th>Status</th><th>Errors</th></tr>
+{{range .Jobs}}<tr{{if .Error}} class="bad"{{end}}>
+<td>{{.Name}}</td><td>{{.LastRun}}</td><td class="num">{{.Duration}}</td><td>{{if .Error}}{{.Error}}{{else if .Runs}}ok{{end}}</td><td class="num">{{.Errors}}/{{.Runs}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// dashboardHandler serves an HTML overview of token prices, active incidents, job
+// runs and circuit breakers, built from the same snapshots as the JSON endpoints
+func dashboardHandler(alertManager *alerts.Manager, worker *Worker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page := dashboardPage{
+			Version:   version.Short(),
+			Generated: time.Now().UTC().Format("2006-01-02 15:04:05 UTC"),
+			Refresh:   int(dashboardRefresh.Seconds()),
+			Incidents: alertManager.IncidentReport(alerts.SeverityOK),
+			Chains:    dashboardChains(worker.Prices()),
+		}
+		for _, summary := range worker.Histories() {
+			job := dashboardJob{Name: summary.Job, LastRun: "not yet", Runs: summary.TotalRuns, Errors: summary.Errors}
+			if h, ok := worker.History(summary.Job); ok && len(h.Runs) > 0 {
+				last := h.Runs[len(h.Runs)-1]
+				job.LastRun = time.Since(last.Start).Round(time.Second).String() + " ago"
+				job.Duration = last.Duration.Round(time.Millisecond).String()
+				job.Error = last.Error
+			}
+			page.Jobs = append(page.Jobs, job)
+		}
+		sort.Slice(page.Jobs, func(i, j int) bool { return page.Jobs[i].Name < page.Jobs[j].Name })
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := dashboardTemplate.Execute(w, page); err != nil {
+			log.Printf("failed to render dashboard: %v", err)
+		}
+	}
+}
+
+// dashboardChains groups prices by chain and adds each chain's circuit breaker and
+// health score from their expvar gauges, which are keyed by chain ID
+func dashboardChains(prices []workers.TokenPrice) []dashboardChain {
+	names := make(map[string]string)
+	for _, c := range workers.AllChains() {
+		names[string(c.ID)] = c.Name
+	}
+	byChain := make(map[string]*dashboardChain)
+	chain := func(name string) *dashboardChain {
+		if byChain[name] == nil {
+			byChain[name] = &dashboardChain{Name: name}
+		}
+		return byChain[name]
+	}
+	byID := func(id string) *dashboardChain {
+		if name, ok := names[id]; ok {
+			return chain(name)
+		}
+		return chain(id)
+	}
+	for _, p := range prices {
+		c := chain(p.Chain)
+		c.Prices = append(c.Prices, p)
+	}
+	if circuits, ok := expvar.Get("oracle_circuit_open").(*expvar.Map); ok {
+		circuits.Do(func(kv expvar.KeyValue) {
+			byID(kv.Key).CircuitOpen = kv.Value.String() == "1"
+		})
+	}
+	if scores, ok := expvar.Get("oracle_health_score").(*expvar.Map); ok {
+		scores.Do(func(kv expvar.KeyValue) {
+			if score, err := strconv.ParseFloat(kv.Value.String(), 64); err == nil {
+				byID(kv.Key).HealthScore = strconv.FormatFloat(score, 'f', 1, 64)
+			}
+		})
+	}
+
+	chains := make([]dashboardChain, 0, len(byChain))
+	for _, c := range byChain {
+		sort.Slice(c.Prices, func(i, j int) bool { return c.Prices[i].Symbol < c.Prices[j].Symbol })
+		chains = append(chains, *c)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].Name < chains[j].Name })
+	return chains
+}
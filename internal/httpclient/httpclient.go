@@ -0,0 +1,70 @@
+// Package httpclient builds the HTTP clients used for RPC, price APIs, alerts and
+// error reports, so proxy and certificate settings apply to every outbound call.
+//
+// Proxies come from HTTPS_PROXY, HTTP_PROXY and NO_PROXY. HTTP_CA_BUNDLE names a
+// PEM file of extra CA certificates trusted alongside the system roots, e.g. for
+// a TLS-intercepting corporate proxy.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// transport is shared by every client so connections are pooled across callers
+var transport = sync.OnceValues(newTransport)
+
+// Init loads the proxy and CA settings, reporting a CA bundle that cannot be used.
+// Clients created after a failed Init fall back to the system roots.
+func Init() error {
+	_, err := transport()
+	return err
+}
+
+// New returns a client with the shared transport and the given overall timeout
+// (0 for none)
+func New(timeout time.Duration) *http.Client {
+	t, err := transport()
+	if err != nil {
+		t = http.DefaultTransport
+	}
+	return &http.Client{Transport: t, Timeout: timeout}
+}
+
+func newTransport() (http.RoundTripper, error) {
+	t, err := buildTransport(os.Getenv("HTTP_CA_BUNDLE"))
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// buildTransport clones the default transport, trusting the certificates in the
+// PEM file at caBundle in addition to the system roots
+func buildTransport(caBundle string) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+
+	path := caBundle
+	if path == "" {
+		return t, nil
+	}
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP_CA_BUNDLE: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("HTTP_CA_BUNDLE: no certificates found in %s", path)
+	}
+	t.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return t, nil
+}
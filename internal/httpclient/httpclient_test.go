@@ -0,0 +1,52 @@
+package httpclient
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildTransportTrustsCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// Without the bundle the test server's self-signed certificate is rejected
+	plain, err := buildTransport("")
+	if err != nil {
+		t.Fatalf("buildTransport: %v", err)
+	}
+	if _, err := (&http.Client{Transport: plain}).Get(server.URL); err == nil {
+		t.Fatal("request to a self-signed server succeeded without a CA bundle")
+	}
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	trusted, err := buildTransport(bundle)
+	if err != nil {
+		t.Fatalf("buildTransport(%s): %v", bundle, err)
+	}
+	resp, err := (&http.Client{Transport: trusted}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with CA bundle: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestBuildTransportInvalidBundle(t *testing.T) {
+	if _, err := buildTransport(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("missing bundle accepted")
+	}
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := buildTransport(empty); err == nil {
+		t.Error("bundle without certificates accepted")
+	}
+}
@@ -0,0 +1,60 @@
+// Package retry runs an operation a bounded number of times with linear backoff
+// between attempts, giving up early on permanent errors or a done context.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// permanentError marks an error that further attempts cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that Do returns it without retrying. Do unwraps it
+// again, so callers see the original error.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Do calls fn up to attempts times, sleeping backoff*n after the n-th failure.
+// It returns nil on the first success, the error passed to Permanent, or the
+// last error once attempts run out. If ctx is done while waiting it returns
+// ctx.Err() joined with the last error.
+func Do(ctx context.Context, attempts int, backoff time.Duration, fn func() error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if attempt == attempts {
+			return err
+		}
+
+		timer := time.NewTimer(backoff * time.Duration(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
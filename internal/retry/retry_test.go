@@ -0,0 +1,77 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	errTransient := errors.New("transient")
+	errFatal := errors.New("fatal")
+
+	tests := []struct {
+		name      string
+		results   []error
+		attempts  int
+		wantErr   error
+		wantCalls int
+	}{
+		{"first try", []error{nil}, 3, nil, 1},
+		{"succeeds after retry", []error{errTransient, nil}, 3, nil, 2},
+		{"exhausted", []error{errTransient, errTransient, errTransient}, 3, errTransient, 3},
+		{"permanent stops", []error{Permanent(errFatal)}, 3, errFatal, 1},
+		{"zero attempts runs once", []error{errTransient}, 0, errTransient, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := Do(context.Background(), tt.attempts, time.Millisecond, func() error {
+				err := tt.results[calls]
+				calls++
+				return err
+			})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Do() = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("fn called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDoPermanentIsUnwrapped(t *testing.T) {
+	errFatal := errors.New("fatal")
+	err := Do(context.Background(), 3, time.Millisecond, func() error { return Permanent(errFatal) })
+	if err != errFatal {
+		t.Errorf("Do() = %#v, want the original error", err)
+	}
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+}
+
+func TestDoStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errTransient := errors.New("transient")
+
+	calls := 0
+	start := time.Now()
+	err := Do(ctx, 5, time.Hour, func() error {
+		calls++
+		cancel()
+		return errTransient
+	})
+	if time.Since(start) > time.Second {
+		t.Fatal("Do waited out the backoff after the context was cancelled")
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errTransient) {
+		t.Errorf("Do() = %v, want context.Canceled wrapping the last error", err)
+	}
+}
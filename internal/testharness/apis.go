@@ -0,0 +1,158 @@
+package testharness
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PriceAPI emulates the Alchemy prices by-address endpoint. Unknown tokens are
+// returned with an error entry, as Alchemy does.
+type PriceAPI struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	prices   map[string]float64 // by lowercase address
+	requests int
+}
+
+// NewPriceAPI starts a fake price API
+func NewPriceAPI() *PriceAPI {
+	p := &PriceAPI{prices: make(map[string]float64)}
+	p.server = httptest.NewServer(http.HandlerFunc(p.serve))
+	return p
+}
+
+// URL is the base URL, in place of https://api.g.alchemy.com/prices/v1
+func (p *PriceAPI) URL() string { return p.server.URL }
+
+// Close stops the server
+func (p *PriceAPI) Close() { p.server.Close() }
+
+// SetPrice sets the USD price returned for a token address
+func (p *PriceAPI) SetPrice(address string, usd float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prices[strings.ToLower(address)] = usd
+}
+
+// Requests returns how many price requests were served
+func (p *PriceAPI) Requests() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.requests
+}
+
+func (p *PriceAPI) serve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/tokens/by-address") {
+		http.NotFound(w, r)
+		return
+	}
+	var req struct {
+		Addresses []struct {
+			Network string `json:"network"`
+			Address string `json:"address"`
+		} `json:"addresses"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	type price struct {
+		Currency string `json:"currency"`
+		Value    string `json:"value"`
+	}
+	type entry struct {
+		Network string  `json:"network"`
+		Address string  `json:"address"`
+		Prices  []price `json:"prices"`
+		Error   *string `json:"error"`
+	}
+
+	p.mu.Lock()
+	p.requests++
+	data := make([]entry, 0, len(req.Addresses))
+	for _, a := range req.Addresses {
+		e := entry{Network: a.Network, Address: a.Address, Prices: []price{}}
+		if usd, ok := p.prices[strings.ToLower(a.Address)]; ok {
+			e.Prices = append(e.Prices, price{Currency: "usd", Value: strconv.FormatFloat(usd, 'f', -1, 64)})
+		} else {
+			msg := "Token not found"
+			e.Error = &msg
+		}
+		data = append(data, e)
+	}
+	p.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+// Message is a Telegram message received by the fake Bot API
+type Message struct {
+	Token  string
+	ChatID string
+	Text   string
+}
+
+// Telegram emulates the Bot API sendMessage method and records every message
+type Telegram struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewTelegram starts a fake Bot API
+func NewTelegram() *Telegram {
+	t := &Telegram{}
+	t.server = httptest.NewServer(http.HandlerFunc(t.serve))
+	return t
+}
+
+// URL is the base URL, in place of https://api.telegram.org
+func (t *Telegram) URL() string { return t.server.URL }
+
+// Close stops the server
+func (t *Telegram) Close() { t.server.Close() }
+
+// Messages returns the messages sent to chatID, oldest first
+func (t *Telegram) Messages(chatID string) []Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var out []Message
+	for _, m := range t.messages {
+		if m.ChatID == chatID {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func (t *Telegram) serve(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.URL.Path, "/bot")
+	token, method, _ := strings.Cut(token, "/")
+	if !ok || method != "sendMessage" {
+		http.NotFound(w, r)
+		return
+	}
+	var req struct {
+		ChatID string `json:"chat_id"`
+		Text   string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t.mu.Lock()
+	t.messages = append(t.messages, Message{Token: token, ChatID: req.ChatID, Text: req.Text})
+	t.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"ok":true}`))
+}
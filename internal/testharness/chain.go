@@ -0,0 +1,203 @@
+package testharness
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/0x0Glitch/contract"
+)
+
+// Chain is a JSON-RPC server standing in for an EVM node with the Oracle contract
+// deployed. It answers eth_chainId, eth_getCode (no code anywhere, so Multicall3 is
+// unavailable) and eth_call to the oracle's getUnderlyingPrice and isPriceOracle.
+type Chain struct {
+	server  *httptest.Server
+	chainID int64
+	oracle  common.Address
+	abi     *abi.ABI
+
+	mu       sync.Mutex
+	prices   map[common.Address]*big.Int
+	isOracle bool
+	calls    map[string]int
+}
+
+// NewChain starts a fake chain serving an oracle at oracle
+func NewChain(chainID int64, oracle common.Address) (*Chain, error) {
+	parsed, err := contract.OracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	c := &Chain{
+		chainID:  chainID,
+		oracle:   oracle,
+		abi:      parsed,
+		prices:   make(map[common.Address]*big.Int),
+		isOracle: true,
+		calls:    make(map[string]int),
+	}
+	c.server = httptest.NewServer(http.HandlerFunc(c.serve))
+	return c, nil
+}
+
+// URL is the RPC endpoint
+func (c *Chain) URL() string { return c.server.URL }
+
+// Close stops the server
+func (c *Chain) Close() { c.server.Close() }
+
+// SetPrice sets the USD price getUnderlyingPrice returns for mToken, scaled by
+// 1e(36-decimals) as the oracle does
+func (c *Chain) SetPrice(mToken string, usd float64, decimals int) {
+	scaled := new(big.Float).Mul(big.NewFloat(usd), big.NewFloat(math.Pow(10, float64(36-decimals))))
+	raw, _ := scaled.Int(nil)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prices[common.HexToAddress(mToken)] = raw
+}
+
+// SetIsPriceOracle sets the value isPriceOracle returns
+func (c *Chain) SetIsPriceOracle(isOracle bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.isOracle = isOracle
+}
+
+// Calls returns how many eth_call requests were made for an oracle method
+func (c *Chain) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+func (c *Chain) serve(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+		var batch []rpcRequest
+		if err := json.Unmarshal(body, &batch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		responses := make([]rpcResponse, len(batch))
+		for i, req := range batch {
+			responses[i] = c.handle(req)
+		}
+		json.NewEncoder(w).Encode(responses)
+		return
+	}
+
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(c.handle(req))
+}
+
+func (c *Chain) handle(req rpcRequest) rpcResponse {
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	result, err := c.dispatch(req)
+	if err != nil {
+		resp.Error = &rpcError{Code: -32000, Message: err.Error()}
+	} else {
+		resp.Result = result
+	}
+	return resp
+}
+
+func (c *Chain) dispatch(req rpcRequest) (interface{}, error) {
+	switch req.Method {
+	case "eth_chainId":
+		return hexutil.Uint64(c.chainID), nil
+	case "eth_getCode":
+		return hexutil.Bytes{}, nil
+	case "eth_call":
+		if len(req.Params) == 0 {
+			return nil, fmt.Errorf("missing call arguments")
+		}
+		var call struct {
+			To    *common.Address `json:"to"`
+			Data  hexutil.Bytes   `json:"data"`
+			Input hexutil.Bytes   `json:"input"`
+		}
+		if err := json.Unmarshal(req.Params[0], &call); err != nil {
+			return nil, err
+		}
+		input := call.Input
+		if len(input) == 0 {
+			input = call.Data
+		}
+		if call.To == nil || *call.To != c.oracle {
+			return nil, fmt.Errorf("execution reverted")
+		}
+		return c.callOracle(input)
+	default:
+		return nil, fmt.Errorf("method %s not supported", req.Method)
+	}
+}
+
+func (c *Chain) callOracle(input []byte) (hexutil.Bytes, error) {
+	if len(input) < 4 {
+		return nil, fmt.Errorf("execution reverted")
+	}
+	method, err := c.abi.MethodById(input[:4])
+	if err != nil {
+		return nil, fmt.Errorf("execution reverted")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[method.Name]++
+
+	switch method.Name {
+	case "getUnderlyingPrice":
+		args, err := method.Inputs.Unpack(input[4:])
+		if err != nil {
+			return nil, err
+		}
+		price, ok := c.prices[args[0].(common.Address)]
+		if !ok {
+			price = new(big.Int)
+		}
+		return method.Outputs.Pack(price)
+	case "isPriceOracle":
+		return method.Outputs.Pack(c.isOracle)
+	default:
+		return nil, fmt.Errorf("execution reverted")
+	}
+}
@@ -0,0 +1,195 @@
+package testharness
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/version"
+	"github.com/0x0Glitch/workers"
+)
+
+var (
+	usdc = workers.TokenMeta{
+		Symbol:       "USDC",
+		MTokAddr:     "0xEdc817A28E8B93B03976FBd4a3dDBc9f7D176c22",
+		Decimals:     6,
+		TableName:    "base_usdc",
+		IsStablecoin: true,
+		PegValue:     1.0,
+		PriceAddress: "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913",
+	}
+	weth = workers.TokenMeta{
+		Symbol:       "WETH",
+		MTokAddr:     "0x628ff693426583D9a7FB391E54366292F509D457",
+		Decimals:     18,
+		TableName:    "base_weth",
+		PriceAddress: "0x4200000000000000000000000000000000000006",
+	}
+)
+
+const cycle = 30 * time.Second
+
+func newBaseHarness(t *testing.T) *Harness {
+	t.Helper()
+	h := New(t, workers.ChainConfig{
+		ID:            "base",
+		Name:          "Base",
+		OracleAddress: "0xEC942bE8A8114bFD0396A5052c36027f2cA6a9d0",
+		PriceNetwork:  "base-mainnet",
+		Tokens:        map[string]workers.TokenMeta{"USDC": usdc, "WETH": weth},
+	}, nil)
+	h.SetPrices(usdc, 1.0, 1.0)
+	h.SetPrices(weth, 3000, 3000)
+	return h
+}
+
+func depegIncident(h *Harness) (alerts.AlertState, bool) {
+	state, ok := h.Manager.GetActiveIncidents()[alerts.AlertKey{Job: "oracle_base", Entity: alerts.ChainEntity("base", usdc.TableName), Metric: "price_deviation_stable"}]
+	return state, ok
+}
+
+func TestDepegSendsOneBusinessCritical(t *testing.T) {
+	h := newBaseHarness(t)
+	h.RunCycle(t, 0)
+	if msgs := h.Telegram.Messages(BusinessChat); len(msgs) != 0 {
+		t.Fatalf("healthy cycle sent %d business messages: %+v", len(msgs), msgs)
+	}
+
+	// USDC onchain drops 5% below its peg
+	h.SetPrices(usdc, 0.95, 0.95)
+	for i := 0; i < 5; i++ {
+		h.RunCycle(t, cycle/3)
+	}
+
+	msgs := h.Telegram.Messages(BusinessChat)
+	if len(msgs) != 1 {
+		t.Fatalf("got %d business messages, want exactly 1: %+v", len(msgs), msgs)
+	}
+	if !strings.Contains(msgs[0].Text, "STABLECOIN DEPEG") || !strings.Contains(msgs[0].Text, "base_usdc") {
+		t.Errorf("unexpected business message:\n%s", msgs[0].Text)
+	}
+	state, ok := depegIncident(h)
+	if !ok || state.Severity != alerts.SeverityCritical {
+		t.Fatalf("incident = %+v (active %v), want CRITICAL", state, ok)
+	}
+
+	// The developer copy carries the build footer; business messages do not
+	dev := h.Telegram.Messages(DeveloperChat)
+	if len(dev) != 1 || !strings.Contains(dev[0].Text, state.IncidentID) {
+		t.Fatalf("developer messages = %+v, want one copy of incident %s", dev, state.IncidentID)
+	}
+	if footer := version.Short(); !strings.HasSuffix(dev[0].Text, footer) || strings.Contains(msgs[0].Text, footer) {
+		t.Errorf("build footer %q should be on the developer copy only", footer)
+	}
+}
+
+func TestRecoveryClearsAfterConsecutiveOK(t *testing.T) {
+	h := newBaseHarness(t)
+	h.SetPrices(usdc, 0.95, 0.95)
+	h.RunCycle(t, 0)
+	if _, ok := depegIncident(h); !ok {
+		t.Fatal("depeg did not open an incident")
+	}
+
+	h.SetPrices(usdc, 1.0, 1.0)
+	required := 3 // default stablecoin consecutive_ok_required
+	for i := 1; i < required; i++ {
+		h.RunCycle(t, cycle)
+		if _, ok := depegIncident(h); !ok {
+			t.Fatalf("incident cleared after %d OK cycles, want %d", i, required)
+		}
+	}
+	h.RunCycle(t, cycle)
+	if state, ok := depegIncident(h); ok {
+		t.Fatalf("incident still active after %d OK cycles: %+v", required, state)
+	}
+
+	// Recovery is silent; the only business message is the original alert
+	if msgs := h.Telegram.Messages(BusinessChat); len(msgs) != 1 {
+		t.Errorf("got %d business messages, want 1", len(msgs))
+	}
+}
+
+func TestCooldownSuppressesDuplicates(t *testing.T) {
+	h := newBaseHarness(t)
+	h.SetPrices(usdc, 0.97, 0.97) // 3% deviation, critical with a 5 minute cooldown
+	h.RunCycle(t, 0)
+
+	// A worsening deviation inside the cooldown is not re-sent
+	h.SetPrices(usdc, 0.965, 0.965)
+	for i := 0; i < 8; i++ {
+		h.RunCycle(t, cycle)
+	}
+	if msgs := h.Telegram.Messages(BusinessChat); len(msgs) != 1 {
+		t.Fatalf("got %d business messages inside the cooldown, want 1", len(msgs))
+	}
+
+	// Once the cooldown has passed the changed value is sent as an update
+	h.RunCycle(t, 2*time.Minute)
+	msgs := h.Telegram.Messages(BusinessChat)
+	if len(msgs) != 2 {
+		t.Fatalf("got %d business messages after the cooldown, want 2", len(msgs))
+	}
+	state, _ := depegIncident(h)
+	if !strings.Contains(msgs[1].Text, state.IncidentID) {
+		t.Errorf("update does not carry incident %s:\n%s", state.IncidentID, msgs[1].Text)
+	}
+
+	decisions := h.Manager.DecisionLog("oracle_base", "price_deviation_stable")
+	suppressed := 0
+	for _, d := range decisions {
+		if d.Outcome == alerts.OutcomeCooldownSuppressed {
+			suppressed++
+		}
+	}
+	if suppressed != 8 {
+		t.Errorf("decision log recorded %d cooldown suppressions, want 8", suppressed)
+	}
+}
+
+func TestCheckSingleForcesFreshReading(t *testing.T) {
+	h := newBaseHarness(t)
+	h.RunCycle(t, 0)
+
+	// The reference moves while the onchain price does not; a scheduled cycle would
+	// reuse the cached reference, an on-demand check reads it again
+	h.Prices.SetPrice(weth.PriceAddress, 3300)
+	check, err := h.Monitor.CheckSingle(context.Background(), "weth")
+	if err != nil {
+		t.Fatalf("CheckSingle: %v", err)
+	}
+	if check.Symbol != "WETH" || check.DEXPrice != 3300 || check.DEXCached {
+		t.Errorf("check = %+v, want a fresh WETH reference of 3300", check)
+	}
+	if check.Severity != alerts.SeverityCritical {
+		t.Errorf("severity = %q for a %.1f%% deviation, want CRITICAL", check.Severity, check.DeviationPercent)
+	}
+	key := alerts.AlertKey{Job: "oracle_base", Entity: alerts.ChainEntity("base", weth.TableName), Metric: "price_deviation_volatile"}
+	if _, ok := h.Manager.GetActiveIncidents()[key]; !ok {
+		t.Error("on-demand check did not open an incident")
+	}
+
+	if _, err := h.Monitor.CheckSingle(context.Background(), "DOGE"); !errors.Is(err, workers.ErrUnknownToken) {
+		t.Errorf("CheckSingle(DOGE) error = %v, want ErrUnknownToken", err)
+	}
+}
+
+func TestCheckSingleConcurrent(t *testing.T) {
+	h := newBaseHarness(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := h.Monitor.CheckSingle(context.Background(), "USDC"); err != nil {
+				t.Errorf("CheckSingle: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,99 @@
+// Package testharness runs an oracle monitor end to end against local fakes: a
+// JSON-RPC chain serving the Oracle contract, the Alchemy price API and the
+// Telegram Bot API, with one fake clock shared by the alert Manager and the job.
+// Nothing leaves the machine and every cycle is run explicitly by the test.
+package testharness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/workers"
+)
+
+// Chat IDs the harness configures for the two Telegram channels
+const (
+	BusinessChat  = "business-chat"
+	DeveloperChat = "developer-chat"
+)
+
+const testChainID = 8453
+
+// Harness wires an OracleMonitor and alert Manager to the fakes
+type Harness struct {
+	Clock    *Clock
+	Chain    *Chain
+	Prices   *PriceAPI
+	Telegram *Telegram
+	Manager  *alerts.Manager
+	Monitor  *workers.OracleMonitor
+}
+
+// New starts the fakes and builds a monitor for chain, replacing its RPC URLs and
+// price routing with the fakes. A nil cfg uses the default configuration. The
+// fakes are shut down when the test ends.
+func New(t testing.TB, chain workers.ChainConfig, cfg *config.Config) *Harness {
+	t.Helper()
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
+	h := &Harness{
+		Clock:    NewClock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)),
+		Prices:   NewPriceAPI(),
+		Telegram: NewTelegram(),
+	}
+	t.Cleanup(h.Prices.Close)
+	t.Cleanup(h.Telegram.Close)
+
+	fakeChain, err := NewChain(testChainID, common.HexToAddress(chain.OracleAddress))
+	if err != nil {
+		t.Fatalf("starting fake chain: %v", err)
+	}
+	h.Chain = fakeChain
+	t.Cleanup(h.Chain.Close)
+
+	chain.RPCURLs = []string{h.Chain.URL()}
+	chain.WSURL = ""
+	chain.EVMChainID = testChainID
+	chain.PriceSource = workers.PriceSourceAlchemy
+
+	client, err := workers.DialChain(context.Background(), chain)
+	if err != nil {
+		t.Fatalf("dialing fake chain: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	service := alerts.New("business-token", BusinessChat, "developer-token", DeveloperChat, "")
+	service.TelegramAPIURL = h.Telegram.URL()
+	h.Manager = alerts.NewManager(service)
+	h.Manager.SetClock(h.Clock.Now)
+
+	monitor := workers.NewOracleMonitor(chain, workers.NewManagedClient(chain, client).Factory(), "test-key",
+		h.Manager, config.NewHolder(cfg), workers.NewLimiter(0))
+	monitor.SetPriceAPIs(workers.PriceAPIs{Alchemy: h.Prices.URL()})
+	monitor.SetClock(h.Clock.Now)
+	h.Monitor = monitor
+
+	return h
+}
+
+// SetPrices sets a token's onchain and reference prices
+func (h *Harness) SetPrices(meta workers.TokenMeta, onchain, reference float64) {
+	h.Chain.SetPrice(meta.MTokAddr, onchain, meta.Decimals)
+	h.Prices.SetPrice(meta.PriceAddress, reference)
+}
+
+// RunCycle advances the clock by interval and runs one monitoring cycle
+func (h *Harness) RunCycle(t testing.TB, interval time.Duration) {
+	t.Helper()
+	h.Clock.Advance(interval)
+	if err := h.Monitor.Run(context.Background()); err != nil {
+		t.Fatalf("cycle at %s: %v", h.Clock.Now().Format(time.TimeOnly), err)
+	}
+}
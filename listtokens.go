@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/workers"
+)
+
+// runListTokens implements "oracle_monitor list-tokens": it prints every configured
+// token of the chain (default: the enabled chains) next to live onchain and reference
+// data, and fails when any token's configuration does not match the chain
+func runListTokens(args []string) error {
+	fs := flag.NewFlagSet("list-tokens", flag.ContinueOnError)
+	configPath := fs.String("config", "", "candidate config `file` (default: the configured source)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return errors.New("usage: list-tokens [-config file] [chain]")
+	}
+
+	var cfg *config.Config
+	var err error
+	if *configPath != "" {
+		cfg, err = config.Load(*configPath)
+	} else {
+		cfg, _, err = config.SourceFromEnv().Load(context.Background())
+	}
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	enabled := os.Getenv("ENABLED_CHAINS")
+	if fs.NArg() == 1 {
+		enabled = fs.Arg(0)
+	}
+	chains, err := workers.GetChainsByEnv(enabled, cfg.Chains, cfg.RPCProviders)
+	if err != nil {
+		return err
+	}
+
+	// Connecting logs and observes like the monitor; only the report goes to stdout
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	configs := config.NewHolder(cfg)
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	limiter := workers.NewLimiter(cfg.MaxGlobalConcurrency)
+	color := os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+
+	failed := 0
+	for _, chain := range chains {
+		monitor := workers.NewOracleMonitor(chain, workers.NewLazyManagedClient(chain).Factory(),
+			os.Getenv("ALCHEMY_PRICE_API_KEY"), manager, configs, limiter)
+		monitor.SetPriceAPIs(workers.ResolvePriceAPIs(cfg.PriceAPIs))
+		verifications, err := monitor.VerifyTokens(context.Background())
+		if err != nil {
+			return fmt.Errorf("%s: %w", chain.Name, err)
+		}
+		n, err := printTokenVerifications(os.Stdout, chain.Name, verifications, color)
+		if err != nil {
+			return err
+		}
+		failed += n
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d tokens failed verification", failed)
+	}
+	return nil
+}
+
+// printTokenVerifications writes a chain's token table, symbols and decimals as
+// configured/onchain, followed by each failed check. It returns how many tokens failed.
+func printTokenVerifications(w io.Writer, chain string, verifications []workers.TokenVerification, color bool) (int, error) {
+	mark := func(ok bool) string {
+		switch {
+		case ok && color:
+			return "\033[32m✓\033[0m"
+		case ok:
+			return "✓"
+		case color:
+			return "\033[31m✗\033[0m"
+		}
+		return "✗"
+	}
+
+	fmt.Fprintf(w, "%s (%d tokens)\n", chain, len(verifications))
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "\tTOKEN\tSYMBOL\tDECIMALS\tFEED\tONCHAIN\tREFERENCE\tDEVIATION")
+	failed := 0
+	for _, v := range verifications {
+		if !v.OK() {
+			failed++
+		}
+		feed := "-"
+		if v.Feed != (common.Address{}) {
+			feed = v.Feed.Hex()
+		}
+		reference, deviation := "-", "-"
+		if v.ReferencePrice > 0 {
+			reference = fmt.Sprintf("$%.6f", v.ReferencePrice)
+			deviation = fmt.Sprintf("%+.2f%%", v.DeviationPercent)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s/%s\t%d/%d\t%s\t$%.6f\t%s\t%s\n", mark(v.OK()), v.Key,
+			v.Meta.Symbol, orDash(v.OnchainSymbol), v.Meta.Decimals, v.OnchainDecimals, feed, v.OnchainPrice, reference, deviation)
+	}
+	if err := tw.Flush(); err != nil {
+		return failed, err
+	}
+	for _, v := range verifications {
+		for _, problem := range v.Problems {
+			fmt.Fprintf(w, "%s %s: %s\n", mark(false), v.Key, problem)
+		}
+	}
+	fmt.Fprintln(w)
+	return failed, nil
+}
+
+func orDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
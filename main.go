@@ -3,30 +3,94 @@ package main
 import (
 	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 
 	"github.com/0x0Glitch/alerts"
 	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/internal/httpclient"
+	"github.com/0x0Glitch/reporter"
+	"github.com/0x0Glitch/state"
+	"github.com/0x0Glitch/tracing"
+	"github.com/0x0Glitch/version"
 	"github.com/0x0Glitch/workers"
 )
 
 func main() {
+	// oracle_monitor replay -input readings.json [-config candidate.json] [-from DATE] [-to DATE]
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		if err := godotenv.Load(); err != nil {
+			log.Printf("warning: .env file not loaded: %v", err)
+		}
+		if err := runReplay(os.Args[2:]); err != nil {
+			log.Fatalf("replay: %v", err)
+		}
+		return
+	}
+
+	// oracle_monitor list-tokens [-config candidate.json] [chain]
+	if len(os.Args) > 1 && os.Args[1] == "list-tokens" {
+		if err := godotenv.Load(); err != nil {
+			log.Printf("warning: .env file not loaded: %v", err)
+		}
+		if err := runListTokens(os.Args[2:]); err != nil {
+			log.Fatalf("list-tokens: %v", err)
+		}
+		return
+	}
+
+	dumpPolicyTable := flag.Bool("dump-policies", false, "print the effective alert policy table and exit")
+	printVersion := flag.Bool("version", false, "print the build version and exit")
+	exportStateFile := flag.String("export-state", "", "save the alert state of the instance running on STATUS_ADDR to `file` and exit")
+	importStateFile := flag.String("import-state", "", "restore alert state from `file` (written by -export-state) at startup")
+	flag.Parse()
+
+	if *printVersion {
+		fmt.Println(version.String())
+		return
+	}
+	log.Printf("starting %s", version.String())
+
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		log.Printf("warning: .env file not loaded: %v", err)
 	}
 
-	// Load configuration
-	cfg := config.LoadOrDefault("config.json")
-	log.Println("loaded configuration")
+	if *exportStateFile != "" {
+		if err := exportState(os.Getenv("STATUS_ADDR"), *exportStateFile); err != nil {
+			log.Fatalf("failed to export alert state: %v", err)
+		}
+		log.Printf("exported alert state to %s", *exportStateFile)
+		return
+	}
+
+	// Load configuration from CONFIG_JSON, CONFIG_URL or the file (JSON or YAML).
+	// Only an unreachable URL or a missing file falls back; a document that fails to
+	// parse, has unknown keys (see CONFIG_STRICT) or fails validation is fatal rather
+	// than silently ignored
+	configSource := config.SourceFromEnv()
+	cfg, loadedFrom, err := configSource.Load(context.Background())
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration from %s: %v", loadedFrom, err)
+	}
+	for _, w := range cfg.Warnings() {
+		log.Printf("warning: config: %s", w)
+	}
+	configs := config.NewHolder(cfg)
+	log.Printf("loaded configuration from %s", loadedFrom)
 
 	// Validate required environment variables
 	alchemyKey := os.Getenv("ALCHEMY_PRICE_API_KEY")
@@ -34,6 +98,19 @@ func main() {
 		log.Fatal("ALCHEMY_PRICE_API_KEY is required")
 	}
 
+	// Outbound HTTP honours HTTP(S)_PROXY and trusts HTTP_CA_BUNDLE in addition to system roots
+	if err := httpclient.Init(); err != nil {
+		log.Fatalf("invalid HTTP client settings: %v", err)
+	}
+
+	// Optional OTLP tracing, enabled by the standard OTEL_EXPORTER_OTLP_* variables
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		log.Printf("warning: tracing disabled: %v", err)
+	} else if tracing.Enabled() {
+		log.Println("exporting traces over OTLP")
+	}
+
 	// Initialize alert service
 	alertService := alerts.New(
 		os.Getenv("TELEGRAM_BUSINESS_BOT_TOKEN"),
@@ -43,6 +120,10 @@ func main() {
 		os.Getenv("SLACK_WEBHOOK_URL"),
 	)
 
+	if base := os.Getenv("TELEGRAM_API_BASE"); base != "" {
+		alertService.TelegramAPIURL = strings.TrimRight(base, "/")
+	}
+
 	if alertService.BusinessBotToken == "" || alertService.BusinessChatID == "" {
 		log.Println("warning: business alerts not configured")
 	}
@@ -53,8 +134,34 @@ func main() {
 		log.Println("warning: slack alerts not configured")
 	}
 
+	// Optional error reporter (SENTRY_DSN) for panics, persistent job failures and
+	// alerts that could not be delivered
+	errReporter, err := reporter.FromEnv()
+	if err != nil {
+		log.Printf("warning: error reporting disabled: %v", err)
+	} else if errReporter != nil {
+		log.Println("error reporting enabled")
+	}
+
+	if err := alerts.SetDisplayTimezone(os.Getenv("ALERT_TIMEZONE")); err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	// Initialize alert manager
 	alertManager := alerts.NewManager(alertService)
+	alertManager.SetDeveloperCopyWindow(cfg.Alerts.SuppressDeveloperCopy())
+	alertManager.SetDecisionLogSize(cfg.Alerts.DecisionLogSize)
+	alertService.SetDegradedAfter(cfg.Alerts.DeliveryDegradedAfter)
+	applyMaintenanceWindows(alertManager, cfg.Alerts.MaintenanceWindows)
+	alertManager.SetBusinessRouting(alerts.BusinessRouting{Allow: cfg.Alerts.BusinessAllowlist, Deny: cfg.Alerts.BusinessDenylist})
+	if muted, _ := strconv.ParseBool(os.Getenv("MUTE_BUSINESS_ALERTS")); muted {
+		alertManager.MuteBusiness(0)
+	}
+	if errReporter != nil {
+		alertManager.SetDeliveryFailureHandler(func(key alerts.AlertKey, err error) {
+			errReporter.ReportError("alert_delivery", err, map[string]string{"job": key.Job, "metric": key.Metric})
+		})
+	}
 	log.Println("initialized alert manager")
 
 	// Create context for graceful shutdown
@@ -62,58 +169,162 @@ func main() {
 	defer cancel()
 
 	// Initialize worker
-	worker := NewWorker()
+	worker := NewWorker(alertManager, configs, errReporter)
+
+	// Resolve enabled chains from ENABLED_CHAINS or the config chains section
+	chainConfigs, err := workers.GetChainsByEnv(os.Getenv("ENABLED_CHAINS"), cfg.Chains, cfg.RPCProviders)
+	if err != nil {
+		log.Fatalf("failed to resolve enabled chains: %v", err)
+	}
 
-	// Get enabled chains from environment
-	enabledChains := os.Getenv("ENABLED_CHAINS")
-	if enabledChains == "" {
-		enabledChains = "base" // Default to Base
+	chainNames := make([]string, 0, len(chainConfigs))
+	for _, chainCfg := range chainConfigs {
+		chainNames = append(chainNames, string(chainCfg.ID))
 	}
+	log.Printf("monitoring %d chains: %s", len(chainConfigs), strings.Join(chainNames, ","))
+	for _, chain := range chainNames {
+		if channels := alerts.ChainChannelsFromEnv(chain); channels != (alerts.Channels{}) {
+			alertService.SetChainChannels(chain, channels)
+			log.Printf("[%s] using chain-specific alert channels", chain)
+		}
+	}
+	for _, chainCfg := range chainConfigs {
+		log.Printf("[%s] price routing: %s", chainCfg.Name, workers.DescribePriceRouting(chainCfg))
+	}
+
+	// Shared limiter capping onchain+HTTP calls across all chains
+	limiter := workers.NewLimiter(cfg.MaxGlobalConcurrency)
+	if cfg.MaxGlobalConcurrency > 0 {
+		log.Printf("global concurrency limited to %d calls", cfg.MaxGlobalConcurrency)
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
 
-	chainConfigs, err := workers.GetChainsByEnv(enabledChains)
+	// State kept across restarts, such as last-known oracle feeds
+	stateFile := os.Getenv("STATE_FILE")
+	if stateFile == "" {
+		stateFile = "oracle_state.json"
+	}
+	store, err := state.Open(stateFile)
 	if err != nil {
-		log.Fatalf("failed to parse enabled chains: %v", err)
+		log.Printf("warning: state store unavailable, changes across restarts will not be detected: %v", err)
 	}
 
-	log.Printf("monitoring %d chains: %s", len(chainConfigs), enabledChains)
+	// Exchange rates for stablecoins pegged to other currencies, shared by all chains
+	fxRates := workers.NewFXRates(configs)
+
+	// Latest successful reading per token, published by the oracle monitors and read by the status server
+	prices := workers.NewPriceStore(configs)
+	// Chains in maintenance, from the config and the status server's /maintenance
+	maintenance := workers.NewMaintenanceModes(configs)
 
 	// Initialize oracle monitors for each chain
 	for _, chainCfg := range chainConfigs {
-		if err := setupOracleMonitor(ctx, chainCfg, alchemyKey, alertManager, &cfg.Oracle, worker); err != nil {
-			log.Printf("failed to setup %s oracle monitor: %v", chainCfg.Name, err)
-			continue
-		}
+		setupOracleMonitor(ctx, chainCfg, alchemyKey, databaseURL, alertManager, configs, store, limiter, fxRates, prices, maintenance, worker)
 		log.Printf("registered oracle monitor for %s (%d tokens)", chainCfg.Name, len(chainCfg.Tokens))
 	}
 
 	// Initialize database-dependent monitors if configured
-	databaseURL := os.Getenv("DATABASE_URL")
 	if databaseURL != "" {
-		if err := setupDatabaseMonitors(databaseURL, alertManager, cfg, worker); err != nil {
+		if err := setupDatabaseMonitors(databaseURL, alertManager, configs, worker); err != nil {
 			log.Printf("warning: database monitors not available: %v", err)
 		}
 	} else {
 		log.Println("DATABASE_URL not configured, database monitors disabled")
 	}
 
+	// Config alert_policies win over the defaults registered by jobs
+	applyPolicyOverrides(alertManager, cfg.AlertPolicies)
+
+	if *dumpPolicyTable {
+		if err := dumpPolicies(os.Stdout, alertManager); err != nil {
+			log.Printf("failed to print alert policies: %v", err)
+		}
+		worker.Close()
+		return
+	}
+
+	// Restore incidents saved before a planned restart, now that jobs have registered
+	if *importStateFile != "" {
+		if err := importState(alertManager, *importStateFile); err != nil {
+			log.Printf("warning: alert state not imported: %v", err)
+		}
+	}
+
+	// Start status server if configured
+	if statusAddr := os.Getenv("STATUS_ADDR"); statusAddr != "" {
+		startStatusServer(ctx, statusAddr, alertManager, worker, prices, maintenance)
+	}
+	// Bot commands such as /mute business 2h, read from the business bot's chats
+	if enabled, _ := strconv.ParseBool(os.Getenv("TELEGRAM_COMMANDS")); enabled {
+		go alertManager.PollCommands(ctx)
+	}
+
 	// Start all workers
 	log.Printf("starting %d monitoring jobs", len(worker.jobs))
 	worker.Start(ctx)
 
+	startupMsg := fmt.Sprintf("✅ ORACLE MONITOR STARTED\n\nVersion: %s\nChains: %s\nJobs: %d",
+		version.Short(), strings.Join(chainNames, ","), len(worker.jobs))
+	if err := alertService.SendDeveloperAlert(ctx, startupMsg); err != nil {
+		log.Printf("failed to send startup notification: %v", err)
+	}
+
+	// Reload config on SIGHUP, and on file change when CONFIG_WATCH is enabled and
+	// the config comes from the file
+	reloadChan := make(chan struct{}, 1)
+	if os.Getenv("CONFIG_WATCH") == "true" {
+		if configSource.String() != configSource.Path {
+			log.Printf("warning: CONFIG_WATCH ignored, config is read from %s", configSource)
+		} else if err := watchConfig(ctx, configSource.Path, reloadChan); err != nil {
+			log.Printf("warning: config file watching disabled: %v", err)
+		} else {
+			log.Printf("watching %s for changes", configSource.Path)
+		}
+	}
+
 	// Wait for shutdown signal
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	sig := <-sigChan
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	var sig os.Signal
+	for sig == nil {
+		select {
+		case s := <-sigChan:
+			if s == syscall.SIGHUP {
+				log.Println("received SIGHUP, reloading configuration")
+				reloadConfig(ctx, configSource, configs, worker, alertManager, alertService)
+				continue
+			}
+			sig = s
+		case <-reloadChan:
+			log.Printf("%s changed, reloading configuration", configSource.Path)
+			reloadConfig(ctx, configSource, configs, worker, alertManager, alertService)
+		}
+	}
 
 	log.Printf("received %s signal, shutting down...", sig)
 	cancel()
 	worker.Wait()
 	worker.Close()
 
-	// Log final alert state
-	activeIncidents := alertManager.GetActiveIncidents()
-	if len(activeIncidents) > 0 {
-		log.Printf("shutting down with %d active incidents", len(activeIncidents))
+	// Flush spans and error reports from the final cycles
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Printf("failed to flush traces: %v", err)
+	}
+	if err := errReporter.Flush(flushCtx); err != nil {
+		log.Printf("failed to flush error reports: %v", err)
+	}
+	flushCancel()
+
+	// Log final alert state, most severe and oldest first
+	if incidents := alertManager.IncidentReport(alerts.SeverityOK); len(incidents) > 0 {
+		log.Printf("shutting down with %d active incidents", len(incidents))
+		for _, incident := range incidents[:min(len(incidents), 5)] {
+			log.Printf("  [%s] %s %s %s/%s value=%g for %v: %s", incident.IncidentID, incident.Severity,
+				incident.Job, incident.DisplayEntity(), incident.Metric, incident.Value, incident.Age.Round(time.Second), incident.Snippet)
+		}
 	}
 
 	log.Println("monitors stopped gracefully")
@@ -124,38 +335,61 @@ func setupOracleMonitor(
 	ctx context.Context,
 	chainCfg workers.ChainConfig,
 	alchemyKey string,
+	databaseURL string,
 	alertManager *alerts.Manager,
-	oracleCfg *config.OracleConfig,
+	configs *config.Holder,
+	store *state.Store,
+	limiter *workers.Limiter,
+	fxRates *workers.FXRates,
+	prices *workers.PriceStore,
+	maintenance *workers.MaintenanceModes,
 	worker *Worker,
-) error {
-	// Get RPC URL for this chain
-	rpcURL := getRPCURL(chainCfg.ID, alchemyKey)
-	if rpcURL == "" {
-		return fmt.Errorf("no RPC URL configured for %s", chainCfg.Name)
+) {
+	// The RPC is dialed on the monitor's first run and retried every cycle until it is
+	// reachable; the other jobs on the chain fail their runs until then. Reads go over
+	// the websocket while it is up when one is configured, otherwise HTTP.
+	rpc := workers.NewLazyManagedClient(chainCfg)
+	if chainCfg.WSURL != "" {
+		go rpc.Run(ctx)
 	}
 
-	// Connect to RPC
-	client, err := ethclient.Dial(rpcURL)
-	if err != nil {
-		return fmt.Errorf("failed to connect to %s RPC: %w", chainCfg.Name, err)
-	}
+	monitor := workers.NewOracleMonitor(chainCfg, rpc.Factory(), alchemyKey, alertManager, configs, limiter)
+	monitor.SetPriceAPIs(workers.ResolvePriceAPIs(configs.Get().PriceAPIs))
+	monitor.SetFXRates(fxRates)
+	monitor.SetPriceStore(prices)
+	monitor.SetMaintenanceModes(maintenance)
+	monitor.SetErrorReporter(worker.reporter)
+	monitor.CheckTokenCount(ctx, configs.Get())
 
-	// Create oracle monitor
-	monitor, err := workers.NewOracleMonitor(chainCfg, client, alchemyKey, alertManager, oracleCfg)
+	worker.Register(monitor)
+
+	// Verify registered oracle feeds at startup and daily
+	feedJob, err := workers.NewFeedCheckJob(chainCfg, rpc, alertManager, configs, store, limiter)
 	if err != nil {
-		client.Close()
-		return fmt.Errorf("failed to create oracle monitor: %w", err)
+		log.Printf("%s oracle feed check disabled: %v", chainCfg.Name, err)
+	} else {
+		worker.Register(feedJob)
 	}
 
-	worker.Register(monitor)
-	return nil
+	// Alert when the RPC node stops following the chain
+	worker.Register(workers.NewChainHeadJob(chainCfg, rpc, alertManager, configs, limiter))
+
+	// Cross-check onchain market totals against the indexer database
+	if databaseURL != "" {
+		totalsJob, err := workers.NewMarketTotalsJob(chainCfg, rpc, databaseURL, alertManager, configs, limiter)
+		if err != nil {
+			log.Printf("%s market totals cross-check disabled: %v", chainCfg.Name, err)
+		} else {
+			worker.Register(totalsJob)
+		}
+	}
 }
 
 // setupDatabaseMonitors initializes database-dependent monitoring jobs
 func setupDatabaseMonitors(
 	databaseURL string,
 	alertManager *alerts.Manager,
-	cfg *config.Config,
+	configs *config.Holder,
 	worker *Worker,
 ) error {
 	// Test database connection
@@ -170,7 +404,7 @@ func setupDatabaseMonitors(
 	db.Close()
 
 	// Individual position monitoring
-	healthJob, err := workers.NewHealthJobV2(databaseURL, alertManager)
+	healthJob, err := workers.NewHealthJobV2(databaseURL, alertManager, configs)
 	if err != nil {
 		log.Printf("health factor monitoring disabled: %v", err)
 	} else {
@@ -179,7 +413,7 @@ func setupDatabaseMonitors(
 	}
 
 	// Aggregate health monitoring
-	healthAggJob, err := workers.NewHealthAggregateJob(databaseURL, alertManager)
+	healthAggJob, err := workers.NewHealthAggregateJob(databaseURL, alertManager, configs)
 	if err != nil {
 		log.Printf("aggregate health monitoring disabled: %v", err)
 	} else {
@@ -188,7 +422,7 @@ func setupDatabaseMonitors(
 	}
 
 	// Concentration risk monitoring
-	concentrationJob, err := workers.NewConcentrationJob(databaseURL, alertManager)
+	concentrationJob, err := workers.NewConcentrationJob(databaseURL, alertManager, configs)
 	if err != nil {
 		log.Printf("concentration monitoring disabled: %v", err)
 	} else {
@@ -196,28 +430,15 @@ func setupDatabaseMonitors(
 		log.Println("registered concentration monitor")
 	}
 
-	return nil
-}
-
-// getRPCURL returns the RPC URL for a specific chain
-func getRPCURL(chainID workers.ChainID, alchemyKey string) string {
-	// Check for chain-specific environment variable first
-	envKey := fmt.Sprintf("%s_RPC_URL", chainID)
-	if url := os.Getenv(envKey); url != "" {
-		return url
-	}
-
-	// Fall back to Alchemy defaults
-	switch chainID {
-	case workers.ChainBase:
-		return fmt.Sprintf("https://base-mainnet.g.alchemy.com/v2/%s", alchemyKey)
-	case workers.ChainOptimism:
-		return fmt.Sprintf("https://opt-mainnet.g.alchemy.com/v2/%s", alchemyKey)
-	case workers.ChainMoonbeam:
-		return os.Getenv("MOONBEAM_RPC_URL")
-	case workers.ChainMoonriver:
-		return os.Getenv("MOONRIVER_RPC_URL")
-	default:
-		return ""
+	// Daily pruning of history tables
+	if disabled, _ := strconv.ParseBool(os.Getenv("RETENTION_DISABLED")); disabled {
+		log.Println("retention disabled by RETENTION_DISABLED")
+	} else if retentionJob, err := workers.NewRetentionJob(databaseURL, alertManager, configs); err != nil {
+		log.Printf("retention disabled: %v", err)
+	} else {
+		worker.Register(retentionJob)
+		log.Println("registered retention job")
 	}
+
+	return nil
 }
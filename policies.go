@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+// applyPolicyOverrides merges the config alert_policies section over the policies
+// registered by jobs. Called after jobs register so file entries win.
+func applyPolicyOverrides(alertManager *alerts.Manager, overrides map[string]config.AlertPolicyConfig) {
+	policies := make(map[string]alerts.AlertPolicy, len(overrides))
+	for key, override := range overrides {
+		job, metric, ok := alerts.SplitPolicyKey(key)
+		if !ok {
+			log.Printf("warning: ignoring alert policy %q: key must have the form \"job:metric\"", key)
+			continue
+		}
+		policy := alertManager.DefaultPolicy(job, metric)
+		mergePolicy(&policy, override)
+		policies[alerts.PolicyKey(job, metric)] = policy
+	}
+
+	alertManager.SetPolicyOverrides(policies)
+	if len(policies) > 0 {
+		log.Printf("applied %d alert policy overrides from config", len(policies))
+	}
+}
+
+// applyMaintenanceWindows installs the config alerts.maintenance_windows section
+func applyMaintenanceWindows(alertManager *alerts.Manager, windows []config.MaintenanceWindowConfig) {
+	converted := make([]alerts.MaintenanceWindow, len(windows))
+	for i, w := range windows {
+		converted[i] = alerts.MaintenanceWindow{
+			Start:    w.Start,
+			End:      w.End,
+			Entities: w.Tokens,
+			Metrics:  w.Metrics,
+			Reason:   w.Reason,
+		}
+		log.Printf("maintenance window %q: %s to %s", w.Reason, w.Start.Format(time.RFC3339), w.End.Format(time.RFC3339))
+	}
+	alertManager.SetMaintenanceWindows(converted)
+}
+
+// mergePolicy copies the fields set in override onto policy
+func mergePolicy(policy *alerts.AlertPolicy, override config.AlertPolicyConfig) {
+	if override.MinValueChange != nil {
+		policy.MinValueChange = *override.MinValueChange
+	}
+	if override.TriggerThreshold != nil {
+		policy.TriggerThreshold = *override.TriggerThreshold
+	}
+	if override.CooldownWarning != nil {
+		policy.CooldownWarning = override.CooldownWarning.Duration()
+	}
+	if override.CooldownCritical != nil {
+		policy.CooldownCritical = override.CooldownCritical.Duration()
+	}
+	if override.ReminderInterval != nil {
+		policy.ReminderInterval = override.ReminderInterval.Duration()
+	}
+	if override.ReminderCooldown != nil {
+		policy.ReminderCooldown = override.ReminderCooldown.Duration()
+	}
+	if override.ConsecutiveOKRequired != nil {
+		policy.ConsecutiveOKRequired = *override.ConsecutiveOKRequired
+	}
+	if override.DeescalationToBusiness != nil {
+		policy.DeescalationToBusiness = *override.DeescalationToBusiness
+	}
+	if override.GroupThreshold != nil {
+		policy.GroupThreshold = *override.GroupThreshold
+	}
+	if override.GroupWindow != nil {
+		policy.GroupWindow = override.GroupWindow.Duration()
+	}
+	if override.MinIncidentDuration != nil {
+		policy.MinIncidentDuration = override.MinIncidentDuration.Duration()
+	}
+	if override.DynamicCooldowns != nil {
+		policy.DynamicCooldowns = make([]alerts.DynamicCooldown, len(override.DynamicCooldowns))
+		for i, dc := range override.DynamicCooldowns {
+			policy.DynamicCooldowns[i] = alerts.DynamicCooldown{
+				Threshold: dc.ThresholdPercent,
+				Cooldown:  dc.CooldownSeconds.Duration(),
+			}
+		}
+	}
+}
+
+// dumpPolicies prints the effective alert policy table
+func dumpPolicies(w io.Writer, alertManager *alerts.Manager) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "POLICY\tSOURCE\tMIN_CHANGE\tTRIGGER\tCOOLDOWN_WARN\tCOOLDOWN_CRIT\tREMINDER\tREMINDER_COOLDOWN\tOK_REQUIRED\tDEESCALATION\tGROUP\tMIN_DURATION\tDYNAMIC_COOLDOWNS")
+	for _, entry := range alertManager.Policies() {
+		p := entry.Policy
+		dynamic := make([]string, len(p.DynamicCooldowns))
+		for i, dc := range p.DynamicCooldowns {
+			dynamic[i] = fmt.Sprintf(">=%g:%v", dc.Threshold, dc.Cooldown)
+		}
+		if len(dynamic) == 0 {
+			dynamic = append(dynamic, "-")
+		}
+		deescalation := "developer"
+		if p.DeescalationToBusiness {
+			deescalation = "both"
+		}
+		group := "-"
+		if p.GroupThreshold > 0 && p.GroupWindow > 0 {
+			group = fmt.Sprintf("%d/%v", p.GroupThreshold, p.GroupWindow)
+		}
+		reminderCooldown := "severity"
+		if p.ReminderCooldown > 0 {
+			reminderCooldown = p.ReminderCooldown.String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%g\t%g\t%v\t%v\t%v\t%s\t%d\t%s\t%s\t%v\t%s\n",
+			entry.Key, entry.Source, p.MinValueChange, p.TriggerThreshold,
+			p.CooldownWarning, p.CooldownCritical, p.ReminderInterval, reminderCooldown,
+			p.ConsecutiveOKRequired, deescalation, group, p.MinIncidentDuration, strings.Join(dynamic, ","))
+	}
+	return tw.Flush()
+}
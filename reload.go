@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+// reloadDebounce coalesces bursts of file events from editors writing the config
+const reloadDebounce = 500 * time.Millisecond
+
+// reloadConfig re-reads and validates the config from source, swapping it in on
+// success. On failure the previous config stays active and developers are notified.
+func reloadConfig(
+	ctx context.Context,
+	source config.Source,
+	holder *config.Holder,
+	worker *Worker,
+	alertManager *alerts.Manager,
+	alertService *alerts.Service,
+) {
+	cfg, err := source.Reload(ctx)
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		log.Printf("config reload failed, keeping previous config: %v", err)
+		msg := fmt.Sprintf("⚠️ CONFIG RELOAD FAILED\n\nSource: %s\nPrevious configuration kept.\n\n%v", source, err)
+		if sendErr := alertService.SendDeveloperAlert(ctx, msg); sendErr != nil {
+			log.Printf("failed to send config reload alert: %v", sendErr)
+		}
+		return
+	}
+
+	for _, w := range cfg.Warnings() {
+		log.Printf("warning: config: %s", w)
+	}
+
+	holder.Set(cfg)
+	worker.Reload(cfg)
+	applyPolicyOverrides(alertManager, cfg.AlertPolicies)
+	alertManager.SetDeveloperCopyWindow(cfg.Alerts.SuppressDeveloperCopy())
+	alertManager.SetDecisionLogSize(cfg.Alerts.DecisionLogSize)
+	alertService.SetDegradedAfter(cfg.Alerts.DeliveryDegradedAfter)
+	applyMaintenanceWindows(alertManager, cfg.Alerts.MaintenanceWindows)
+	alertManager.SetBusinessRouting(alerts.BusinessRouting{Allow: cfg.Alerts.BusinessAllowlist, Deny: cfg.Alerts.BusinessDenylist})
+	log.Printf("reloaded configuration from %s", source)
+}
+
+// watchConfig sends on reload whenever the config file changes
+func watchConfig(ctx context.Context, path string, reload chan<- struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	// Watch the directory so atomic renames by editors are picked up
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		var debounce <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					debounce = time.After(reloadDebounce)
+				}
+			case <-debounce:
+				debounce = nil
+				select {
+				case reload <- struct{}{}:
+				default: // reload already pending
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("config watcher error: %v", err)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
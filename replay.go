@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/workers"
+)
+
+// runReplay implements "oracle_monitor replay": it feeds recorded /prices readings
+// through the alerting of a candidate config and reports what would have been sent
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	input := fs.String("input", "", "recorded readings: `file` of /prices JSON documents, concatenated")
+	configPath := fs.String("config", "", "candidate config `file` (default: the configured source)")
+	from := fs.String("from", "", "first `date` to replay (YYYY-MM-DD or RFC 3339)")
+	to := fs.String("to", "", "last `date` to replay, inclusive for a plain date")
+	verbose := fs.Bool("v", false, "log each alert decision while replaying")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *input == "" {
+		return errors.New("-input is required")
+	}
+	start, err := parseReplayTime(*from, false)
+	if err != nil {
+		return fmt.Errorf("-from: %w", err)
+	}
+	end, err := parseReplayTime(*to, true)
+	if err != nil {
+		return fmt.Errorf("-to: %w", err)
+	}
+
+	var cfg *config.Config
+	if *configPath != "" {
+		cfg, err = config.Load(*configPath)
+	} else {
+		cfg, _, err = config.SourceFromEnv().Load(context.Background())
+	}
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid candidate config: %w", err)
+	}
+
+	observations, err := readObservations(*input, start, end)
+	if err != nil {
+		return err
+	}
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+		defer log.SetOutput(os.Stderr)
+	}
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	chains := workers.AllChains()
+	for _, chain := range chains {
+		workers.RegisterOraclePolicies(manager, &cfg.Oracle, string(chain.ID))
+	}
+	applyPolicyOverrides(manager, cfg.AlertPolicies)
+	applyMaintenanceWindows(manager, cfg.Alerts.MaintenanceWindows)
+
+	report := workers.Replay(context.Background(), manager, chains, cfg, observations)
+	return printReplayReport(os.Stdout, report)
+}
+
+// parseReplayTime parses a -from or -to bound; a plain date as an upper bound
+// covers the whole day. An empty value is unbounded.
+func parseReplayTime(value string, upper bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		if upper {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// readObservations reads concatenated /prices documents (arrays of readings, or
+// single readings) and keeps those observed within [start, end]
+func readObservations(path string, start, end time.Time) ([]workers.TokenPrice, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var observations []workers.TokenPrice
+	keep := func(p workers.TokenPrice) {
+		if (start.IsZero() || !p.ObservedAt.Before(start)) && (end.IsZero() || !p.ObservedAt.After(end)) {
+			observations = append(observations, p)
+		}
+	}
+	dec := json.NewDecoder(f)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("read %s: %w", path, err)
+		}
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+			var batch []workers.TokenPrice
+			if err := json.Unmarshal(raw, &batch); err != nil {
+				return nil, fmt.Errorf("read %s: %w", path, err)
+			}
+			for _, p := range batch {
+				keep(p)
+			}
+			continue
+		}
+		var p workers.TokenPrice
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return nil, fmt.Errorf("read %s: %w", path, err)
+		}
+		keep(p)
+	}
+	return observations, nil
+}
+
+// printReplayReport prints message counts by severity and channel, then each incident
+func printReplayReport(w io.Writer, report workers.ReplayReport) error {
+	fmt.Fprintf(w, "Replayed %d readings (%d skipped)\n\n", report.Observations, report.Skipped)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "MESSAGES\tBUSINESS\tDEVELOPER")
+	for _, severity := range []alerts.Severity{alerts.SeverityCritical, alerts.SeverityWarning, alerts.SeverityOK} {
+		business := report.Sent[workers.ReplayChannel{Severity: severity, Channel: "business"}]
+		developer := report.Sent[workers.ReplayChannel{Severity: severity, Channel: "developer"}]
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", severity, business, developer)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var flaps, open int
+	durations := make([]time.Duration, len(report.Incidents))
+	for i, incident := range report.Incidents {
+		durations[i] = incident.Duration()
+		if incident.Flap {
+			flaps++
+		}
+		if incident.Open {
+			open++
+		}
+	}
+	fmt.Fprintf(w, "\n%d incidents, %d flaps (reopened within an hour), %d still open at the end\n", len(report.Incidents), flaps, open)
+	if len(durations) == 0 {
+		return nil
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	fmt.Fprintf(w, "Duration: median %v, longest %v\n\n", durations[len(durations)/2].Round(time.Second), durations[len(durations)-1].Round(time.Second))
+
+	tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "OPENED\tENTITY\tMETRIC\tPEAK\tDURATION\tNOTE")
+	for _, incident := range report.Incidents {
+		note := ""
+		switch {
+		case incident.Open:
+			note = "open"
+		case incident.Flap:
+			note = "flap"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%v\t%s\n", incident.Opened.UTC().Format(time.RFC3339), incident.Entity,
+			incident.Metric, incident.Peak, incident.Duration().Round(time.Second), note)
+	}
+	return tw.Flush()
+}
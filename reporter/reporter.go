@@ -0,0 +1,166 @@
+// Package reporter forwards recovered panics and persistent failures to an error
+// tracking backend. Reports are queued and delivered in the background so callers
+// never block on the backend.
+package reporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultQueueSize = 100
+
+// Level is the severity of a report
+type Level string
+
+const (
+	LevelError Level = "error"
+	LevelFatal Level = "fatal"
+)
+
+// Event is a single error report
+type Event struct {
+	Message string
+	Kind    string // short error type, e.g. "panic", "job_error", "alert_delivery"
+	Level   Level
+	Stack   string            // stack trace, when known
+	Tags    map[string]string // e.g. job, chain
+	Time    time.Time
+}
+
+// Backend delivers events to an error tracker. Sentry is built in; any other
+// service can be plugged in by implementing Send.
+type Backend interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// Reporter queues events for a Backend. A nil *Reporter discards every report,
+// so callers need not check whether reporting is configured.
+type Reporter struct {
+	backend Backend
+	queue   chan Event
+	done    chan struct{}
+	mu      sync.RWMutex // guards closed against sends on the closed queue
+	closed  bool
+	dropped atomic.Int64
+}
+
+// New starts a reporter delivering to backend with room for queueSize pending events.
+// When the queue is full new events are dropped rather than blocking the caller.
+func New(backend Backend, queueSize int) *Reporter {
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	r := &Reporter{
+		backend: backend,
+		queue:   make(chan Event, queueSize),
+		done:    make(chan struct{}),
+	}
+	go r.deliver()
+	return r
+}
+
+// FromEnv creates a Sentry reporter from SENTRY_DSN (SENTRY_ENVIRONMENT optional).
+// It returns nil when SENTRY_DSN is not set.
+func FromEnv() (*Reporter, error) {
+	dsn := os.Getenv("SENTRY_DSN")
+	if dsn == "" {
+		return nil, nil
+	}
+	backend, err := NewSentry(dsn, os.Getenv("SENTRY_ENVIRONMENT"))
+	if err != nil {
+		return nil, err
+	}
+	return New(backend, defaultQueueSize), nil
+}
+
+// Report queues event without blocking
+func (r *Reporter) Report(event Event) {
+	if r == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if event.Level == "" {
+		event.Level = LevelError
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		r.dropped.Add(1)
+		return
+	}
+	select {
+	case r.queue <- event:
+	default:
+		if r.dropped.Add(1) == 1 {
+			log.Printf("[reporter] queue full, dropping reports")
+		}
+	}
+}
+
+// ReportPanic reports a recovered panic value with the stack captured at recovery
+func (r *Reporter) ReportPanic(recovered any, stack []byte, tags map[string]string) {
+	r.Report(Event{
+		Message: fmt.Sprintf("panic: %v", recovered),
+		Kind:    "panic",
+		Level:   LevelFatal,
+		Stack:   string(stack),
+		Tags:    tags,
+	})
+}
+
+// ReportError reports err under kind
+func (r *Reporter) ReportError(kind string, err error, tags map[string]string) {
+	if err == nil {
+		return
+	}
+	r.Report(Event{Message: err.Error(), Kind: kind, Tags: tags})
+}
+
+// Dropped returns the number of reports discarded because the queue was full
+func (r *Reporter) Dropped() int64 {
+	if r == nil {
+		return 0
+	}
+	return r.dropped.Load()
+}
+
+// Flush stops accepting reports and waits until queued ones are delivered or ctx is done
+func (r *Reporter) Flush(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d reports not delivered: %w", len(r.queue), ctx.Err())
+	}
+}
+
+func (r *Reporter) deliver() {
+	defer close(r.done)
+	for event := range r.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := r.backend.Send(ctx, event); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("[reporter] failed to deliver %s report: %v", event.Kind, err)
+		}
+		cancel()
+	}
+}
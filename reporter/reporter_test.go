@@ -0,0 +1,145 @@
+package reporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingBackend records events and blocks each Send until release is closed
+type blockingBackend struct {
+	mu      sync.Mutex
+	events  []Event
+	release chan struct{}
+}
+
+func (b *blockingBackend) Send(ctx context.Context, event Event) error {
+	<-b.release
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, event)
+	return nil
+}
+
+func TestReportDoesNotBlock(t *testing.T) {
+	backend := &blockingBackend{release: make(chan struct{})}
+	r := New(backend, 2)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			r.ReportError("job_error", errors.New("boom"), map[string]string{"job": "oracle_base"})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Report blocked on a stalled backend")
+	}
+	if r.Dropped() == 0 {
+		t.Error("expected reports beyond the queue size to be dropped")
+	}
+
+	close(backend.release)
+	if err := r.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got := len(backend.events); got == 0 || got > 3 {
+		t.Errorf("delivered %d events, want between 1 and 3 (queue of 2 plus one in flight)", got)
+	}
+
+	// Reports after Flush are dropped, not panics on the closed queue
+	r.ReportError("job_error", errors.New("late"), nil)
+}
+
+func TestFlushTimesOut(t *testing.T) {
+	backend := &blockingBackend{release: make(chan struct{})}
+	defer close(backend.release)
+	r := New(backend, 4)
+	r.ReportPanic("nil map", []byte("goroutine 1 [running]:"), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := r.Flush(ctx); err == nil {
+		t.Fatal("Flush returned nil while the backend was stalled")
+	}
+}
+
+func TestNilReporter(t *testing.T) {
+	var r *Reporter
+	r.ReportPanic("boom", nil, nil)
+	r.ReportError("job_error", errors.New("boom"), nil)
+	if err := r.Flush(context.Background()); err != nil {
+		t.Errorf("Flush on nil reporter = %v", err)
+	}
+}
+
+func TestSentryEnvelope(t *testing.T) {
+	var auth string
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/42/envelope/" {
+			http.NotFound(w, req)
+			return
+		}
+		auth = req.Header.Get("X-Sentry-Auth")
+		scanner := bufio.NewScanner(req.Body)
+		scanner.Buffer(make([]byte, 64*1024), 1<<20)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://publickey@", 1) + "/42"
+	sentry, err := NewSentry(dsn, "test")
+	if err != nil {
+		t.Fatalf("NewSentry: %v", err)
+	}
+	err = sentry.Send(context.Background(), Event{
+		Message: "panic: boom",
+		Kind:    "panic",
+		Level:   LevelFatal,
+		Stack:   "goroutine 1 [running]:",
+		Tags:    map[string]string{"job": "oracle_base", "chain": "base"},
+		Time:    time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if !strings.Contains(auth, "sentry_key=publickey") {
+		t.Errorf("X-Sentry-Auth = %q, want the DSN key", auth)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("envelope has %d lines, want header, item header and event", len(lines))
+	}
+	var event struct {
+		Level       string            `json:"level"`
+		Environment string            `json:"environment"`
+		Tags        map[string]string `json:"tags"`
+		Extra       map[string]string `json:"extra"`
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &event); err != nil {
+		t.Fatalf("event: %v", err)
+	}
+	if event.Level != "fatal" || event.Environment != "test" || event.Tags["chain"] != "base" || event.Extra["stack"] == "" {
+		t.Errorf("event = %+v", event)
+	}
+}
+
+func TestNewSentryRejectsInvalidDSN(t *testing.T) {
+	for _, dsn := range []string{"https://sentry.io/42", "https://key@sentry.io/", "://bad"} {
+		if _, err := NewSentry(dsn, ""); err == nil {
+			t.Errorf("NewSentry(%q) succeeded, want error", dsn)
+		}
+	}
+}
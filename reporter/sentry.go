@@ -0,0 +1,130 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/0x0Glitch/internal/httpclient"
+)
+
+const sentryClient = "oracle_monitor/1.0"
+
+// Sentry sends events to Sentry's envelope endpoint over plain HTTP
+type Sentry struct {
+	endpoint    string
+	key         string
+	environment string
+	httpClient  *http.Client
+}
+
+// NewSentry parses a DSN of the form https://<key>@<host>/<project_id>
+func NewSentry(dsn, environment string) (*Sentry, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SENTRY_DSN: %w", err)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("invalid SENTRY_DSN: missing public key")
+	}
+	path := strings.Trim(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	projectID := path[slash+1:]
+	if projectID == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid SENTRY_DSN: expected https://<key>@<host>/<project_id>")
+	}
+	prefix := ""
+	if slash >= 0 {
+		prefix = "/" + path[:slash]
+	}
+
+	return &Sentry{
+		endpoint:    fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, prefix, projectID),
+		key:         u.User.Username(),
+		environment: environment,
+		httpClient:  httpclient.New(10 * time.Second),
+	}, nil
+}
+
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       Level             `json:"level"`
+	Environment string            `json:"environment,omitempty"`
+	Message     string            `json:"message"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Exception   struct {
+		Values []sentryException `json:"values"`
+	} `json:"exception"`
+	Extra map[string]string `json:"extra,omitempty"`
+}
+
+type sentryException struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func (s *Sentry) Send(ctx context.Context, event Event) error {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+
+	payload := sentryEvent{
+		EventID:     hex.EncodeToString(id),
+		Timestamp:   event.Time.UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       event.Level,
+		Environment: s.environment,
+		Message:     event.Message,
+		Tags:        event.Tags,
+	}
+	payload.Exception.Values = []sentryException{{Type: event.Kind, Value: event.Message}}
+	if event.Stack != "" {
+		// Raw goroutine dump; Sentry shows extra data verbatim
+		payload.Extra = map[string]string{"stack": event.Stack}
+	}
+
+	item, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	header, err := json.Marshal(map[string]string{"event_id": payload.EventID, "sent_at": time.Now().UTC().Format(time.RFC3339)})
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	body.Write(header)
+	body.WriteString("\n{\"type\":\"event\"}\n")
+	body.Write(item)
+	body.WriteString("\n")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", sentryClient, s.key))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("sentry status %d: %s", resp.StatusCode, msg)
+	}
+	return nil
+}
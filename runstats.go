@@ -0,0 +1,165 @@
+package main
+
+import (
+	"expvar"
+	"slices"
+	"sync"
+	"time"
+)
+
+// jobRunSeconds exposes the duration of each job's most recent run
+var jobRunSeconds = expvar.NewMap("job_run_duration_seconds")
+
+// runStats keeps a rolling window of successful run durations, the current streak
+// of failed runs and the recent run history per job
+type runStats struct {
+	mu        sync.Mutex
+	durations map[string][]time.Duration
+	failures  map[string]int
+	recent    map[string]*runRing
+}
+
+func newRunStats() *runStats {
+	return &runStats{
+		durations: make(map[string][]time.Duration),
+		failures:  make(map[string]int),
+		recent:    make(map[string]*runRing),
+	}
+}
+
+// recordOutcome updates the job's failure streak and returns its length (0 after a success)
+func (s *runStats) recordOutcome(job string, failed bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !failed {
+		delete(s.failures, job)
+		return 0
+	}
+	s.failures[job]++
+	return s.failures[job]
+}
+
+// record adds a run to the job's window (keeping at most window runs) and returns
+// the median of the runs before it along with how many there were
+func (s *runStats) record(job string, d time.Duration, window int) (time.Duration, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous := s.durations[job]
+	median := medianDuration(previous)
+
+	next := append(previous, d)
+	if window > 0 && len(next) > window {
+		next = next[len(next)-window:]
+	}
+	s.durations[job] = next
+
+	return median, len(previous)
+}
+
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// runHistorySize is how many recent runs are kept per job for /jobs
+const runHistorySize = 200
+
+// RunRecord is one job run as kept in the run history
+type RunRecord struct {
+	Start           time.Time     `json:"start"`
+	Duration        time.Duration `json:"-"`
+	DurationSeconds float64       `json:"duration_seconds"`
+	Error           string        `json:"error,omitempty"`
+	Panicked        bool          `json:"panicked,omitempty"`
+}
+
+// JobHistory summarises a job's recent runs, see Worker.History
+type JobHistory struct {
+	Job string `json:"job"`
+	// TotalRuns counts every run since startup; the other fields cover the kept runs only
+	TotalRuns  int     `json:"total_runs"`
+	Errors     int     `json:"errors"`
+	Panics     int     `json:"panics"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P95Seconds float64 `json:"p95_seconds"`
+	// Runs is oldest first; omitted from the /jobs overview
+	Runs []RunRecord `json:"runs,omitempty"`
+}
+
+// runRing holds a job's most recent runs in a fixed-size ring
+type runRing struct {
+	runs  []RunRecord
+	next  int // slot the next run overwrites once the ring is full
+	total int
+}
+
+// recordRun appends a run to the job's history, dropping the oldest beyond runHistorySize
+func (s *runStats) recordRun(job string, run RunRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ring := s.recent[job]
+	if ring == nil {
+		ring = &runRing{}
+		s.recent[job] = ring
+	}
+	run.DurationSeconds = run.Duration.Seconds()
+	if len(ring.runs) < runHistorySize {
+		ring.runs = append(ring.runs, run)
+	} else {
+		ring.runs[ring.next] = run
+		ring.next = (ring.next + 1) % runHistorySize
+	}
+	ring.total++
+}
+
+// history returns a copy of the job's kept runs with their summary, and false when
+// the job has not run yet. Safe to call while jobs are recording runs.
+func (s *runStats) history(job string) (JobHistory, bool) {
+	s.mu.Lock()
+	ring := s.recent[job]
+	if ring == nil {
+		s.mu.Unlock()
+		return JobHistory{}, false
+	}
+	runs := make([]RunRecord, 0, len(ring.runs))
+	runs = append(runs, ring.runs[ring.next:]...)
+	runs = append(runs, ring.runs[:ring.next]...)
+	total := ring.total
+	s.mu.Unlock()
+
+	h := JobHistory{Job: job, TotalRuns: total, Runs: runs}
+	durations := make([]time.Duration, len(runs))
+	for i, run := range runs {
+		durations[i] = run.Duration
+		if run.Error != "" {
+			h.Errors++
+		}
+		if run.Panicked {
+			h.Panics++
+		}
+	}
+	slices.Sort(durations)
+	h.P50Seconds = percentileDuration(durations, 50).Seconds()
+	h.P95Seconds = percentileDuration(durations, 95).Seconds()
+	return h, true
+}
+
+// percentileDuration returns the nearest-rank percentile p of sorted durations
+func percentileDuration(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	return sorted[max(rank, 1)-1]
+}
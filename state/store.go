@@ -0,0 +1,101 @@
+// Package state persists small pieces of monitor state across restarts.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store keeps namespaced JSON values in a single file. Every Set rewrites the file
+// atomically. A nil Store keeps nothing and reports every key as missing.
+type Store struct {
+	path string
+	mu   sync.Mutex
+	data map[string]json.RawMessage
+}
+
+// Open loads the store at path. A missing file yields an empty store that is
+// created on the first Set.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, data: make(map[string]json.RawMessage)}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &s.data); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Path returns the file backing the store
+func (s *Store) Path() string {
+	if s == nil {
+		return ""
+	}
+	return s.path
+}
+
+// Get decodes the value stored under key into v, reporting whether it was present
+func (s *Store) Get(key string, v any) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	s.mu.Lock()
+	raw, ok := s.data[key]
+	s.mu.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+// Set stores v under key and writes the store to disk
+func (s *Store) Set(key string, v any) error {
+	if s == nil {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[key] = raw
+	return s.write()
+}
+
+// write replaces the file via a temporary file and rename so a crash never
+// leaves a truncated store (called with s.mu held)
+func (s *Store) write() error {
+	out, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(out); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
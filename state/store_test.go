@@ -0,0 +1,56 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open missing file: %v", err)
+	}
+	want := map[string]string{"usdc": "0x0000000000000000000000000000000000000001"}
+	if err := store.Set("feeds:base", want); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	var got map[string]string
+	if ok, err := reopened.Get("feeds:base", &got); !ok || err != nil {
+		t.Fatalf("Get = %v, %v; want present", ok, err)
+	}
+	if got["usdc"] != want["usdc"] {
+		t.Errorf("Get = %v, want %v", got, want)
+	}
+	if ok, _ := reopened.Get("feeds:optimism", &got); ok {
+		t.Error("Get of unset key reported present")
+	}
+}
+
+func TestStoreCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("Open of corrupt file = nil error")
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var store *Store
+	if err := store.Set("key", 1); err != nil {
+		t.Errorf("nil Set: %v", err)
+	}
+	var v int
+	if ok, err := store.Get("key", &v); ok || err != nil {
+		t.Errorf("nil Get = %v, %v", ok, err)
+	}
+}
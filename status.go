@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/internal/httpclient"
+	"github.com/0x0Glitch/version"
+	"github.com/0x0Glitch/workers"
+)
+
+// startStatusServer serves runtime metrics, build information, active incidents and
+// alert state, recent alert decisions, job run history, the latest token prices and
+// an HTML dashboard of them on addr until ctx is cancelled
+func startStatusServer(ctx context.Context, addr string, alertManager *alerts.Manager, worker *Worker, prices workers.PriceView, maintenance *workers.MaintenanceModes) {
+	mux := http.NewServeMux()
+	// CHECK_API_TOKEN authorizes, as a bearer token, every request that changes state
+	token := os.Getenv("CHECK_API_TOKEN")
+	// On-demand token check, enabled by CHECK_API_TOKEN
+	if token != "" {
+		mux.Handle("POST /check/{chain}/{symbol}", requireBearer(token, checkTokenHandler(worker)))
+	}
+	mux.Handle("/debug/vars", expvar.Handler())
+	// 503 while any alert channel is degraded by consecutive delivery failures
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		status, code := "ok", http.StatusOK
+		if !alertManager.DeliveryHealthy() {
+			status, code = "degraded", http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]any{"status": status, "delivery": alertManager.DeliveryHealth()})
+	})
+	mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(version.Get())
+	})
+	// Recent Observe decisions, oldest first; ?job= and ?metric= filter
+	mux.HandleFunc("/debug/alerts", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(alertManager.DecisionLog(query.Get("job"), query.Get("metric")))
+	})
+	// Active incidents, CRITICAL first then oldest; ?severity=CRITICAL or WARNING sets the minimum
+	mux.HandleFunc("/incidents", func(w http.ResponseWriter, r *http.Request) {
+		minSeverity := alerts.Severity(strings.ToUpper(r.URL.Query().Get("severity")))
+		switch minSeverity {
+		case "", alerts.SeverityOK, alerts.SeverityWarning, alerts.SeverityCritical:
+		default:
+			http.Error(w, "severity must be WARNING or CRITICAL", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		incidents := alertManager.IncidentReport(minSeverity)
+		if incidents == nil {
+			incidents = []alerts.Incident{}
+		}
+		json.NewEncoder(w).Encode(incidents)
+	})
+	// Alert state snapshot for -export-state, restored with -import-state
+	mux.HandleFunc("/alerts/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := alertManager.Export(w); err != nil {
+			log.Printf("failed to export alert state: %v", err)
+		}
+	})
+	// Business channel mute: GET shows it, POST ?channel=business&for=2h mutes (no
+	// duration: until unmuted), DELETE unmutes and reports what was suppressed
+	mux.Handle("/alerts/mute", requireBearerForChanges(token, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			if channel := r.URL.Query().Get("channel"); channel != "business" {
+				http.Error(w, "only channel=business can be muted; the developer channel always receives alerts", http.StatusBadRequest)
+				return
+			}
+			var d time.Duration
+			if v := r.URL.Query().Get("for"); v != "" {
+				parsed, err := time.ParseDuration(v)
+				if err != nil || parsed <= 0 {
+					http.Error(w, "for must be a positive duration such as 2h", http.StatusBadRequest)
+					return
+				}
+				d = parsed
+			}
+			alertManager.MuteBusiness(d)
+		case http.MethodDelete:
+			if err := alertManager.UnmuteBusiness(r.Context()); err != nil {
+				log.Printf("failed to send mute summary: %v", err)
+			}
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(alertManager.BusinessMute())
+	})))
+	// Chain maintenance modes: GET lists them, POST ?chain=base&for=2h&reason=... puts a
+	// chain in maintenance, DELETE ?chain=base ends one set here. Changes take effect on
+	// the chain's next cycle. POST and DELETE need CHECK_API_TOKEN.
+	mux.Handle("/maintenance", requireBearerForChanges(token, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chain := r.URL.Query().Get("chain")
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			d, err := time.ParseDuration(r.URL.Query().Get("for"))
+			if chain == "" || err != nil || d <= 0 {
+				http.Error(w, "chain and for, a positive duration such as 2h, are required", http.StatusBadRequest)
+				return
+			}
+			maintenance.Set(chain, time.Now().Add(d), r.URL.Query().Get("reason"))
+		case http.MethodDelete:
+			if chain == "" {
+				http.Error(w, "chain is required", http.StatusBadRequest)
+				return
+			}
+			maintenance.Clear(chain)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(maintenance.List())
+	})))
+	// Run counts, errors and p50/p95 durations per job; ?name= adds that job's recent runs
+	mux.HandleFunc("/jobs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			json.NewEncoder(w).Encode(worker.Histories())
+			return
+		}
+		history, ok := worker.History(name)
+		if !ok {
+			http.Error(w, "unknown job or no runs yet", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(history)
+	})
+	// HTML overview for a quick look in a browser; reloads itself
+	mux.HandleFunc("/dashboard", dashboardHandler(alertManager, worker))
+	// Latest reading per token across all chains; ?format=csv for a spreadsheet export
+	mux.HandleFunc("/prices", func(w http.ResponseWriter, r *http.Request) {
+		prices := worker.Prices()
+		switch r.URL.Query().Get("format") {
+		case "", "json":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(prices)
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="prices.csv"`)
+			if err := writePricesCSV(w, prices); err != nil {
+				log.Printf("failed to write prices: %v", err)
+			}
+		default:
+			http.Error(w, "format must be json or csv", http.StatusBadRequest)
+		}
+	})
+	// Shared price store: each token's latest successful reading that has not expired
+	mux.HandleFunc("/prices/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(workers.SortedSnapshot(prices))
+	})
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		log.Printf("status server listening on %s", addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("status server error: %v", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+}
+
+// checkTokenHandler forces a fresh reading of one token and returns its prices,
+// deviation and severity
+func checkTokenHandler(worker *Worker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		check, err := worker.CheckToken(r.Context(), r.PathValue("chain"), r.PathValue("symbol"))
+		switch {
+		case errors.Is(err, errUnknownChain), errors.Is(err, workers.ErrUnknownToken):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case err != nil:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+		}
+		json.NewEncoder(w).Encode(check)
+	})
+}
+
+// requireBearer rejects requests without "Authorization: Bearer <token>"
+func requireBearer(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireBearerForChanges serves GET requests and puts the rest behind requireBearer,
+// refusing them while no token is configured
+func requireBearerForChanges(token string, next http.Handler) http.Handler {
+	authorized := requireBearer(token, next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet || r.Method == http.MethodHead:
+			next.ServeHTTP(w, r)
+		case token == "":
+			http.Error(w, "changes need CHECK_API_TOKEN to be set", http.StatusForbidden)
+		default:
+			authorized.ServeHTTP(w, r)
+		}
+	})
+}
+
+// exportState downloads the alert state of the instance serving the status server
+// on addr (STATUS_ADDR) and writes it to path
+func exportState(addr, path string) error {
+	if addr == "" {
+		return fmt.Errorf("STATUS_ADDR is not set")
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid STATUS_ADDR %q: %w", addr, err)
+	}
+	if host == "" {
+		host = "localhost"
+	}
+
+	client := httpclient.New(10 * time.Second)
+	resp, err := client.Get("http://" + net.JoinHostPort(host, port) + "/alerts/state")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status server returned %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, body, 0o600)
+}
+
+// importState restores an alert state snapshot written by -export-state, logging
+// entries that no longer match a job or metric
+func importState(alertManager *alerts.Manager, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	skipped, err := alertManager.Import(f)
+	if err != nil {
+		return err
+	}
+	for _, key := range skipped {
+		log.Printf("import-state: skipped %s %s/%s: job or metric no longer exists", key.Job, key.Entity, key.Metric)
+	}
+	log.Printf("imported alert state from %s (%d active incidents, %d entries skipped)",
+		path, len(alertManager.IncidentReport(alerts.SeverityOK)), len(skipped))
+	return nil
+}
+
+// writePricesCSV writes prices with a header row. Times are RFC 3339 in UTC and
+// empty for tokens that have not been read successfully yet.
+func writePricesCSV(w io.Writer, prices []workers.TokenPrice) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"chain", "symbol", "onchain_price", "dex_price", "deviation_percent", "dex_cached", "observed_at", "error"})
+	for _, p := range prices {
+		observedAt := ""
+		if !p.ObservedAt.IsZero() {
+			observedAt = p.ObservedAt.UTC().Format(time.RFC3339)
+		}
+		cw.Write([]string{
+			p.Chain,
+			p.Symbol,
+			strconv.FormatFloat(p.OnchainPrice, 'f', -1, 64),
+			strconv.FormatFloat(p.DEXPrice, 'f', -1, 64),
+			strconv.FormatFloat(p.DeviationPercent, 'f', -1, 64),
+			strconv.FormatBool(p.DEXCached),
+			observedAt,
+			p.Error,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,112 @@
+// Package tracing provides optional OpenTelemetry tracing of monitoring cycles.
+//
+// Tracing is enabled by the standard OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT variables and exports over OTLP/HTTP. Other
+// OTEL_* variables (service name, headers, sampler) are honoured by the SDK.
+// When tracing is disabled Start returns the context unchanged with a no-op span.
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/0x0Glitch"
+	defaultServiceName  = "oracle_monitor"
+)
+
+var (
+	enabled atomic.Bool
+	// noopSpan is returned while disabled; any span in ctx is left untouched
+	noopSpan = trace.SpanFromContext(context.Background())
+)
+
+// Enabled reports whether the environment asks for trace export
+func Enabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+	if exporter := os.Getenv("OTEL_TRACES_EXPORTER"); exporter != "" && exporter != "otlp" {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs the OTLP exporter when tracing is enabled. The returned shutdown
+// flushes pending spans and is safe to call when tracing is disabled.
+func Setup(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	noop := func(context.Context) error { return nil }
+	if !Enabled() {
+		return noop, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, err
+	}
+
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the default service name
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", defaultServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return noop, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	enabled.Store(true)
+
+	return func(ctx context.Context) error {
+		enabled.Store(false)
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// Start begins a span as a child of any span in ctx. With tracing disabled it
+// returns ctx unchanged and a shared non-recording span.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !enabled.Load() {
+		return ctx, noopSpan
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil && span.IsRecording() {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Attribute helpers shared by the instrumented packages
+
+func Job(name string) attribute.KeyValue          { return attribute.String("job", name) }
+func Chain(name string) attribute.KeyValue        { return attribute.String("chain", name) }
+func Symbol(symbol string) attribute.KeyValue     { return attribute.String("symbol", symbol) }
+func Severity(severity string) attribute.KeyValue { return attribute.String("severity", severity) }
+func Method(method string) attribute.KeyValue     { return attribute.String("rpc.method", method) }
+func Query(name string) attribute.KeyValue        { return attribute.String("db.operation.name", name) }
+func Metric(metric string) attribute.KeyValue     { return attribute.String("metric", metric) }
+func Source(source string) attribute.KeyValue     { return attribute.String("price.source", source) }
+func Business(business bool) attribute.KeyValue   { return attribute.Bool("business", business) }
@@ -0,0 +1,81 @@
+// Package version holds build information embedded at link time:
+//
+//	go build -ldflags "-X github.com/0x0Glitch/version.Version=v1.4.2 -X github.com/0x0Glitch/version.Commit=abc1234"
+//
+// Builds without ldflags fall back to the VCS details recorded by the Go toolchain.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Set via -ldflags -X; see the Makefile
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// Info is the build information served at /version
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+func init() {
+	if Commit != "" {
+		return
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			Commit = setting.Value
+		case "vcs.time":
+			if BuildDate == "" {
+				BuildDate = setting.Value
+			}
+		}
+	}
+}
+
+// Get returns the build information
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// Short returns the version and abbreviated commit, e.g. "v1.4.2 (abc1234)"
+func Short() string {
+	if Commit == "" {
+		return Version
+	}
+	return fmt.Sprintf("%s (%s)", Version, shortCommit(Commit))
+}
+
+// String returns the full build description for logs and -version
+func String() string {
+	s := "oracle_monitor " + Short()
+	if BuildDate != "" {
+		s += ", built " + BuildDate
+	}
+	return s + ", " + runtime.Version()
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
@@ -0,0 +1,22 @@
+package version
+
+import "testing"
+
+func TestShort(t *testing.T) {
+	defer func(version, commit string) { Version, Commit = version, commit }(Version, Commit)
+
+	tests := []struct {
+		version, commit string
+		want            string
+	}{
+		{"v1.4.2", "abc1234def5678", "v1.4.2 (abc1234)"},
+		{"v1.4.2", "abc", "v1.4.2 (abc)"},
+		{"dev", "", "dev"},
+	}
+	for _, tt := range tests {
+		Version, Commit = tt.version, tt.commit
+		if got := Short(); got != tt.want {
+			t.Errorf("Short() with %q/%q = %q, want %q", tt.version, tt.commit, got, tt.want)
+		}
+	}
+}
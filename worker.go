@@ -2,9 +2,21 @@ package main
 
 import (
 	"context"
+	"errors"
+	"expvar"
+	"fmt"
 	"log"
+	"math/rand/v2"
+	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/reporter"
+	"github.com/0x0Glitch/tracing"
+	"github.com/0x0Glitch/workers"
 )
 
 type Job interface {
@@ -18,25 +30,101 @@ type Closer interface {
 	Close() error
 }
 
+// Reloader is an optional interface for jobs that apply configuration changes at runtime
+type Reloader interface {
+	Reload(cfg *config.Config) error
+}
+
+// ChainJob is an optional interface for jobs bound to one chain; the chain tags error reports
+type ChainJob interface {
+	ChainID() string
+}
+
+// TokenChecker is an optional interface for chain jobs that can check one token on
+// demand; see Worker.CheckToken
+type TokenChecker interface {
+	ChainJob
+	CheckSingle(ctx context.Context, symbol string) (workers.TokenCheck, error)
+}
+
+// PriceReporter is an optional interface for jobs that track token prices; see Worker.Prices
+type PriceReporter interface {
+	Prices() []workers.TokenPrice
+}
+
+// Ticker is the part of *time.Ticker the worker uses, so tests can fire runs by hand
+type Ticker interface {
+	Chan() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+// timeTicker adapts *time.Ticker to Ticker
+type timeTicker struct{ *time.Ticker }
+
+func (t timeTicker) Chan() <-chan time.Time { return t.C }
+
 type Worker struct {
-	jobs []Job
-	wg   sync.WaitGroup
+	jobs         []Job
+	resets       []chan struct{} // per-job signal to re-read Interval()
+	wg           sync.WaitGroup
+	alertManager *alerts.Manager
+	configs      *config.Holder
+	runs         *runStats
+	reporter     *reporter.Reporter         // nil when error reporting is not configured
+	clock        func() time.Time           // nil means time.Now
+	newTicker    func(time.Duration) Ticker // nil means time.NewTicker
 }
 
-func NewWorker() *Worker {
+func NewWorker(alertManager *alerts.Manager, configs *config.Holder, errReporter *reporter.Reporter) *Worker {
 	return &Worker{
-		jobs: make([]Job, 0),
+		jobs:         make([]Job, 0),
+		resets:       make([]chan struct{}, 0),
+		alertManager: alertManager,
+		configs:      configs,
+		runs:         newRunStats(),
+		reporter:     errReporter,
+	}
+}
+
+// SetClock replaces time.Now for run timing and time.NewTicker for run intervals and
+// the startup delay. Call it before Start.
+func (w *Worker) SetClock(clock func() time.Time, newTicker func(time.Duration) Ticker) {
+	w.clock = clock
+	w.newTicker = newTicker
+}
+
+func (w *Worker) now() time.Time {
+	if w.clock == nil {
+		return time.Now()
+	}
+	return w.clock()
+}
+
+func (w *Worker) ticker(d time.Duration) Ticker {
+	if w.newTicker == nil {
+		return timeTicker{time.NewTicker(d)}
 	}
+	return w.newTicker(d)
 }
 
 func (w *Worker) Register(job Job) {
 	w.jobs = append(w.jobs, job)
+	w.resets = append(w.resets, make(chan struct{}, 1))
+
+	w.alertManager.RegisterDefaultPolicy(job.Name(), "slow_run", alerts.AlertPolicy{
+		MinValueChange:        50.0, // 50% change in slowdown ratio
+		CooldownWarning:       1 * time.Hour,
+		CooldownCritical:      1 * time.Hour,
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: 3,
+	})
 }
 
 func (w *Worker) Start(ctx context.Context) {
-	for _, job := range w.jobs {
+	for i, job := range w.jobs {
 		w.wg.Add(1)
-		go w.runJob(ctx, job)
+		go w.runJob(ctx, job, w.resets[i])
 	}
 	log.Printf("started %d workers", len(w.jobs))
 }
@@ -45,6 +133,50 @@ func (w *Worker) Wait() {
 	w.wg.Wait()
 }
 
+// Prices returns the latest token prices from every job that tracks them
+func (w *Worker) Prices() []workers.TokenPrice {
+	var prices []workers.TokenPrice
+	for _, job := range w.jobs {
+		if pr, ok := job.(PriceReporter); ok {
+			prices = append(prices, pr.Prices()...)
+		}
+	}
+	return prices
+}
+
+// History returns the recent runs of the named job with error counts and p50/p95
+// durations, and false for an unknown job or one that has not run yet
+func (w *Worker) History(name string) (JobHistory, bool) {
+	return w.runs.history(name)
+}
+
+// Histories summarises every registered job's recent runs, without the runs themselves
+func (w *Worker) Histories() []JobHistory {
+	summaries := make([]JobHistory, 0, len(w.jobs))
+	for _, job := range w.jobs {
+		h, ok := w.runs.history(job.Name())
+		if !ok {
+			h = JobHistory{Job: job.Name()}
+		}
+		h.Runs = nil
+		summaries = append(summaries, h)
+	}
+	return summaries
+}
+
+// errUnknownChain is returned by CheckToken when no job checks tokens on the chain
+var errUnknownChain = errors.New("unknown chain")
+
+// CheckToken runs an immediate check of symbol on chain (a chain ID such as "base")
+func (w *Worker) CheckToken(ctx context.Context, chain, symbol string) (workers.TokenCheck, error) {
+	for _, job := range w.jobs {
+		if checker, ok := job.(TokenChecker); ok && strings.EqualFold(checker.ChainID(), chain) {
+			return checker.CheckSingle(ctx, symbol)
+		}
+	}
+	return workers.TokenCheck{}, errUnknownChain
+}
+
 // Close closes all jobs that implement the Closer interface
 func (w *Worker) Close() {
 	for _, job := range w.jobs {
@@ -58,20 +190,63 @@ func (w *Worker) Close() {
 	}
 }
 
-func (w *Worker) runJob(ctx context.Context, job Job) {
+// Reload applies cfg to all jobs implementing Reloader and resets their tickers
+func (w *Worker) Reload(cfg *config.Config) {
+	for _, job := range w.jobs {
+		if reloader, ok := job.(Reloader); ok {
+			if err := reloader.Reload(cfg); err != nil {
+				log.Printf("[%s] error reloading: %v", job.Name(), err)
+			}
+		}
+	}
+	w.ResetIntervals()
+}
+
+// ResetIntervals signals every running job to re-read its Interval
+func (w *Worker) ResetIntervals() {
+	for _, reset := range w.resets {
+		select {
+		case reset <- struct{}{}:
+		default: // reset already pending
+		}
+	}
+}
+
+func (w *Worker) runJob(ctx context.Context, job Job, reset <-chan struct{}) {
 	defer w.wg.Done()
 
 	log.Printf("[%s] started", job.Name())
 
+	// Stagger first runs so jobs don't all hit RPC, DB and Telegram at boot
+	if delay := w.startupDelay(job); delay > 0 {
+		log.Printf("[%s] first run in %v", job.Name(), delay.Round(time.Millisecond))
+		wait := w.ticker(delay)
+		select {
+		case <-wait.Chan():
+			wait.Stop()
+		case <-ctx.Done():
+			wait.Stop()
+			log.Printf("[%s] stopped", job.Name())
+			return
+		}
+	}
+
 	w.executeJob(ctx, job)
 
-	ticker := time.NewTicker(job.Interval())
+	interval := job.Interval()
+	ticker := w.ticker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.Chan():
 			w.executeJob(ctx, job)
+		case <-reset:
+			if next := job.Interval(); next != interval {
+				log.Printf("[%s] interval changed from %v to %v", job.Name(), interval, next)
+				interval = next
+				ticker.Reset(interval)
+			}
 		case <-ctx.Done():
 			log.Printf("[%s] stopped", job.Name())
 			return
@@ -79,20 +254,103 @@ func (w *Worker) runJob(ctx context.Context, job Job) {
 	}
 }
 
+// startupDelay picks a random delay before job's first run, bounded by the
+// configured startup jitter and the job's interval
+func (w *Worker) startupDelay(job Job) time.Duration {
+	jitter := w.configs.Get().StartupJitterSeconds.Duration()
+	if interval := job.Interval(); interval < jitter {
+		jitter = interval
+	}
+	if jitter <= 0 {
+		return 0
+	}
+	return rand.N(jitter)
+}
+
 func (w *Worker) executeJob(ctx context.Context, job Job) {
+	ctx, span := tracing.Start(ctx, "job.run", tracing.Job(job.Name()))
+	start := w.now()
+	var err error
 	defer func() {
+		run := RunRecord{Start: start, Duration: w.now().Sub(start)}
 		if r := recover(); r != nil {
 			log.Printf("[%s] PANIC RECOVERED: %v", job.Name(), r)
+			err = fmt.Errorf("panic: %v", r)
+			run.Panicked = true
+			w.reporter.ReportPanic(r, debug.Stack(), jobTags(job))
+		}
+		if err != nil {
+			run.Error = err.Error()
 		}
+		w.runs.recordRun(job.Name(), run)
+		if ctx.Err() == nil {
+			w.reportFailures(job, err)
+		}
+		tracing.End(span, err)
 	}()
 
-	start := time.Now()
-	err := job.Run(ctx)
-	duration := time.Since(start)
+	err = job.Run(ctx)
+	duration := w.now().Sub(start)
+
+	seconds := new(expvar.Float)
+	seconds.Set(duration.Seconds())
+	jobRunSeconds.Set(job.Name(), seconds)
 
 	if err != nil {
 		log.Printf("[%s] error after %v: %v", job.Name(), duration, err)
 	} else {
 		log.Printf("[%s] completed in %v", job.Name(), duration)
+		w.checkRunDuration(ctx, job, duration)
+	}
+	w.alertManager.FlushGroups(ctx)
+}
+
+// reportFailures reports a job once its runs have failed more than the configured
+// number of times in a row; the streak resets on the next successful run
+func (w *Worker) reportFailures(job Job, err error) {
+	streak := w.runs.recordOutcome(job.Name(), err != nil)
+	threshold := w.configs.Get().ErrorReporting.ConsecutiveFailures
+	if threshold <= 0 || streak != threshold+1 {
+		return
+	}
+	w.reporter.ReportError("job_error", fmt.Errorf("%d consecutive failed runs, last error: %w", streak, err), jobTags(job))
+}
+
+// jobTags returns the error report tags identifying job
+func jobTags(job Job) map[string]string {
+	tags := map[string]string{"job": job.Name()}
+	if chainJob, ok := job.(ChainJob); ok {
+		tags["chain"] = chainJob.ChainID()
+	}
+	return tags
+}
+
+// checkRunDuration warns developers when a successful run takes much longer than
+// the job's recent median, which usually means a degrading provider
+func (w *Worker) checkRunDuration(ctx context.Context, job Job, duration time.Duration) {
+	cfg := w.configs.Get().SlowRun
+	median, samples := w.runs.record(job.Name(), duration, cfg.WindowSize)
+	if cfg.Multiplier <= 0 || samples < cfg.MinSamples || median <= 0 {
+		return
+	}
+
+	ratio := float64(duration) / float64(median)
+	severity := alerts.SeverityOK
+	if ratio >= cfg.Multiplier {
+		severity = alerts.SeverityWarning
+	}
+
+	key := alerts.AlertKey{
+		Job:    job.Name(),
+		Entity: "worker",
+		Metric: "slow_run",
+	}
+	details := fmt.Sprintf(
+		"Run took %v (%.1fx the median of the last %d runs: %v)",
+		duration.Round(time.Millisecond), ratio, samples, median.Round(time.Millisecond),
+	)
+
+	if err := w.alertManager.Observe(ctx, key, severity, ratio, "", details, false, ""); err != nil {
+		log.Printf("[%s] failed to observe run duration: %v", job.Name(), err)
 	}
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+// fakeTicker fires only when the test sends on c and reports Reset calls on resets
+type fakeTicker struct {
+	c      chan time.Time
+	resets chan time.Duration
+	d      time.Duration
+}
+
+func (t *fakeTicker) Chan() <-chan time.Time { return t.c }
+func (t *fakeTicker) Reset(d time.Duration)  { t.resets <- d }
+func (t *fakeTicker) Stop()                  {}
+
+// fakeClock is a manually advanced clock shared by the test and the job goroutine
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type stubJob struct {
+	interval atomic.Int64
+	clock    *fakeClock
+	runs     chan struct{}
+}
+
+func (j *stubJob) Name() string            { return "stub" }
+func (j *stubJob) Interval() time.Duration { return time.Duration(j.interval.Load()) }
+
+func (j *stubJob) Run(ctx context.Context) error {
+	j.clock.Advance(2 * time.Second)
+	j.runs <- struct{}{}
+	return nil
+}
+
+func TestWorkerRunsOnInjectedTicker(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.StartupJitterSeconds = config.Duration(time.Hour)
+	w := NewWorker(alerts.NewManager(alerts.New("", "", "", "", "")), config.NewHolder(cfg), nil)
+
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	tickers := make(chan *fakeTicker, 1)
+	w.SetClock(clock.Now, func(d time.Duration) Ticker {
+		t := &fakeTicker{c: make(chan time.Time), resets: make(chan time.Duration, 1), d: d}
+		tickers <- t
+		return t
+	})
+
+	job := &stubJob{clock: clock, runs: make(chan struct{})}
+	job.interval.Store(int64(5 * time.Minute))
+	w.Register(job)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.Start(ctx)
+	defer w.Wait()
+	defer cancel()
+
+	// The startup delay waits on its own ticker, bounded by the job's interval
+	delay := <-tickers
+	if delay.d <= 0 || delay.d > 5*time.Minute {
+		t.Fatalf("startup delay = %v, want within the 5m interval", delay.d)
+	}
+	delay.c <- clock.Now()
+	<-job.runs
+
+	interval := <-tickers
+	if interval.d != 5*time.Minute {
+		t.Fatalf("ticker interval = %v, want 5m", interval.d)
+	}
+	for i := 0; i < 2; i++ {
+		interval.c <- clock.Now()
+		<-job.runs
+	}
+
+	job.interval.Store(int64(10 * time.Minute))
+	w.ResetIntervals()
+	if d := <-interval.resets; d != 10*time.Minute {
+		t.Errorf("ticker reset to %v, want 10m", d)
+	}
+
+	interval.c <- clock.Now()
+	<-job.runs
+	cancel()
+	w.Wait()
+
+	history, ok := w.History("stub")
+	if !ok || history.TotalRuns != 4 {
+		t.Fatalf("history = %+v (found %v), want 4 runs", history, ok)
+	}
+	for _, run := range history.Runs {
+		if run.Duration != 2*time.Second {
+			t.Errorf("run duration = %v, want 2s from the injected clock", run.Duration)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/tracing"
+)
+
+// headerReader is the part of ManagedClient the chain head check uses
+type headerReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// ChainHeadJob alerts developers when the latest block served by a chain's RPC
+// endpoint is old. A node that stops syncing keeps answering calls with valid but
+// stale data, which the price and feed checks cannot tell apart from a quiet market.
+type ChainHeadJob struct {
+	chain        ChainConfig
+	client       headerReader
+	alertManager *alerts.Manager
+	configs      *config.Holder
+	limiter      *Limiter
+	clock        func() time.Time
+}
+
+// NewChainHeadJob creates the chain head staleness check for a chain
+func NewChainHeadJob(
+	chain ChainConfig,
+	client *ManagedClient,
+	alertManager *alerts.Manager,
+	configs *config.Holder,
+	limiter *Limiter,
+) *ChainHeadJob {
+	job := &ChainHeadJob{
+		chain:        chain,
+		client:       client,
+		alertManager: alertManager,
+		configs:      configs,
+		limiter:      limiter,
+		clock:        time.Now,
+	}
+	job.registerPolicies(configs.Get().ChainHead)
+	return job
+}
+
+func (j *ChainHeadJob) Name() string {
+	return fmt.Sprintf("chain_head_%s", j.chain.ID)
+}
+
+func (j *ChainHeadJob) ChainID() string {
+	return string(j.chain.ID)
+}
+
+func (j *ChainHeadJob) Interval() time.Duration {
+	if d := j.configs.Get().ChainHead.CheckIntervalSeconds.Duration(); d > 0 {
+		return d
+	}
+	return time.Minute
+}
+
+func (j *ChainHeadJob) Reload(cfg *config.Config) error {
+	j.registerPolicies(cfg.ChainHead)
+	return nil
+}
+
+func (j *ChainHeadJob) registerPolicies(cfg config.ChainHeadConfig) {
+	j.alertManager.RegisterPolicy(j.Name(), "chain_head_age", alerts.AlertPolicy{
+		MinValueChange:        0,
+		CooldownWarning:       cfg.CooldownWarning(),
+		CooldownCritical:      cfg.CooldownCritical(),
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: cfg.ConsecutiveOKRequired,
+	})
+}
+
+func (j *ChainHeadJob) Run(ctx context.Context) error {
+	cfg := j.configs.Get().ChainHead
+
+	header, err := j.latestHeader(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+
+	now := j.clock()
+	blockTime := time.Unix(int64(header.Time), 0)
+	age := now.Sub(blockTime)
+
+	severity := alerts.SeverityOK
+	switch {
+	case age >= cfg.CriticalBlockAgeSeconds.Duration():
+		severity = alerts.SeverityCritical
+	case age >= cfg.WarningBlockAgeSeconds.Duration():
+		severity = alerts.SeverityWarning
+	}
+	if severity != alerts.SeverityOK {
+		log.Printf("[%s][%s] latest block %d is %v old", j.Name(), j.chain.Name, header.Number, age.Round(time.Second))
+	}
+
+	details := fmt.Sprintf(
+		"Chain: %s\nLatest block: %d\nBlock time: %s\nAge: %v (warning at %v, critical at %v)\n\nThe RPC endpoint is not following the chain; onchain reads are stale.",
+		j.chain.Name,
+		header.Number,
+		alerts.FormatTime(blockTime, now),
+		age.Round(time.Second),
+		cfg.WarningBlockAgeSeconds,
+		cfg.CriticalBlockAgeSeconds,
+	)
+
+	key := alerts.AlertKey{
+		Job:    j.Name(),
+		Entity: alerts.ChainEntity(string(j.chain.ID), "rpc"),
+		Metric: "chain_head_age",
+	}
+	if err := j.alertManager.Observe(ctx, key, severity, age.Seconds(), "", details, false, ""); err != nil {
+		log.Printf("[%s][%s] failed to observe chain head: %v", j.Name(), j.chain.Name, err)
+	}
+	return nil
+}
+
+func (j *ChainHeadJob) latestHeader(ctx context.Context) (*types.Header, error) {
+	if err := j.limiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer j.limiter.Release()
+
+	ctx, span := tracing.Start(ctx, "eth_getBlockByNumber", tracing.Chain(j.chain.Name))
+	header, err := j.client.HeaderByNumber(ctx, nil)
+	tracing.End(span, err)
+	return header, err
+}
@@ -0,0 +1,72 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+type stubHeaderReader struct {
+	header *types.Header
+	err    error
+}
+
+func (s stubHeaderReader) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return s.header, s.err
+}
+
+func TestChainHeadJobSeverity(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.DefaultConfig() // warning at 3m, critical at 10m
+	key := alerts.AlertKey{Job: "chain_head_base", Entity: "base:rpc", Metric: "chain_head_age"}
+
+	tests := []struct {
+		name string
+		age  time.Duration
+		want alerts.Severity
+	}{
+		{"following", 4 * time.Second, alerts.SeverityOK},
+		{"behind", 5 * time.Minute, alerts.SeverityWarning},
+		{"stuck", time.Hour, alerts.SeverityCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+			job := NewChainHeadJob(ChainConfig{ID: ChainBase, Name: "Base"}, nil, manager, config.NewHolder(cfg), nil)
+			job.client = stubHeaderReader{header: &types.Header{
+				Number: big.NewInt(1000),
+				Time:   uint64(now.Add(-tt.age).Unix()),
+			}}
+			job.clock = func() time.Time { return now }
+
+			if err := job.Run(context.Background()); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			state, ok := manager.GetActiveIncidents()[key]
+			if got := state.Severity; !ok && tt.want != alerts.SeverityOK || ok && got != tt.want {
+				t.Errorf("severity = %q (tracked %v), want %q", got, ok, tt.want)
+			}
+			if blockTime := alerts.FormatTime(now.Add(-tt.age), now); ok && !strings.Contains(state.LastMessage, "Block time: "+blockTime) {
+				t.Errorf("details %q missing block time %q", state.LastMessage, blockTime)
+			}
+		})
+	}
+}
+
+func TestChainHeadJobRPCError(t *testing.T) {
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	job := NewChainHeadJob(ChainConfig{ID: ChainBase, Name: "Base"}, nil, manager, config.NewHolder(config.DefaultConfig()), nil)
+	job.client = stubHeaderReader{err: errors.New("connection refused")}
+
+	if err := job.Run(context.Background()); err == nil {
+		t.Fatal("expected an error when the latest block cannot be fetched")
+	}
+}
@@ -0,0 +1,168 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+// ChainMaintenance is a chain's active maintenance mode. Its oracle alerts are capped at
+// WARNING and sent to developers only; readings are still checked and logged.
+type ChainMaintenance struct {
+	Chain  string    `json:"chain"`
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason,omitempty"`
+	Source string    `json:"source"` // "config" or "api"
+}
+
+// describe is the line appended to alert details during maintenance
+func (c ChainMaintenance) describe(now time.Time) string {
+	line := "Chain in maintenance until " + alerts.FormatTime(c.Until, now)
+	if c.Reason != "" {
+		line += ": " + c.Reason
+	}
+	return line + " (severity capped at WARNING, developers only)"
+}
+
+// MaintenanceModes holds the chains in maintenance, from chains.<id>.maintenance in
+// the config and from the status API. A chain set through the API uses that setting
+// until it is cleared or expires; expired settings clear themselves.
+type MaintenanceModes struct {
+	configs *config.Holder
+	clock   func() time.Time
+
+	mu  sync.Mutex
+	api map[string]ChainMaintenance
+}
+
+// NewMaintenanceModes creates modes reading the configured ones from configs
+func NewMaintenanceModes(configs *config.Holder) *MaintenanceModes {
+	return &MaintenanceModes{configs: configs, api: make(map[string]ChainMaintenance)}
+}
+
+// SetClock replaces time.Now for expiry
+func (m *MaintenanceModes) SetClock(clock func() time.Time) {
+	m.clock = clock
+}
+
+func (m *MaintenanceModes) now() time.Time {
+	if m.clock == nil {
+		return time.Now()
+	}
+	return m.clock()
+}
+
+// Set puts chain in maintenance until until, replacing any configured mode
+func (m *MaintenanceModes) Set(chain string, until time.Time, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.api[chain] = ChainMaintenance{Chain: chain, Until: until, Reason: reason, Source: "api"}
+}
+
+// Clear removes chain's API setting. A mode in the config stays until it expires or
+// is removed from the config.
+func (m *MaintenanceModes) Clear(chain string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.api, chain)
+}
+
+// Active returns chain's unexpired maintenance mode. A nil MaintenanceModes has none.
+func (m *MaintenanceModes) Active(chain string) (ChainMaintenance, bool) {
+	if m == nil {
+		return ChainMaintenance{}, false
+	}
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if mode, ok := m.api[chain]; ok {
+		if now.Before(mode.Until) {
+			return mode, true
+		}
+		delete(m.api, chain)
+	}
+	if m.configs == nil || m.configs.Get() == nil {
+		return ChainMaintenance{}, false
+	}
+	configured := m.configs.Get().Chains[chain].Maintenance
+	if configured == nil || !now.Before(configured.Until) {
+		return ChainMaintenance{}, false
+	}
+	return ChainMaintenance{Chain: chain, Until: configured.Until, Reason: configured.Reason, Source: "config"}, true
+}
+
+// List returns every chain's active maintenance mode, sorted by chain
+func (m *MaintenanceModes) List() []ChainMaintenance {
+	chains := make(map[string]bool)
+	m.mu.Lock()
+	for chain := range m.api {
+		chains[chain] = true
+	}
+	m.mu.Unlock()
+	if m.configs != nil && m.configs.Get() != nil {
+		for chain, c := range m.configs.Get().Chains {
+			if c.Maintenance != nil {
+				chains[chain] = true
+			}
+		}
+	}
+
+	modes := []ChainMaintenance{}
+	for chain := range chains {
+		if mode, ok := m.Active(chain); ok {
+			modes = append(modes, mode)
+		}
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i].Chain < modes[j].Chain })
+	return modes
+}
+
+// SetMaintenanceModes caps the monitor's alerts while modes puts its chain in maintenance
+func (m *OracleMonitor) SetMaintenanceModes(modes *MaintenanceModes) {
+	m.maintenanceModes = modes
+}
+
+// checkMaintenance refreshes the chain's maintenance mode for this cycle, telling
+// developers when the chain enters or leaves it
+func (m *OracleMonitor) checkMaintenance(ctx context.Context) {
+	mode, active := m.maintenanceModes.Active(string(m.chain.ID))
+	var current *ChainMaintenance
+	if active {
+		current = &mode
+	}
+	was := m.maintenance.Swap(current) != nil
+	if active == was {
+		return
+	}
+
+	var msg string
+	if active {
+		log.Printf("[%s][%s] entered maintenance until %s", m.Name(), m.chain.Name, mode.Until.Format(time.RFC3339))
+		msg = fmt.Sprintf("🔧 %s ENTERED MAINTENANCE\n\n%s", m.chain.Name, mode.describe(m.now()))
+	} else {
+		log.Printf("[%s][%s] left maintenance", m.Name(), m.chain.Name)
+		msg = fmt.Sprintf("✅ %s LEFT MAINTENANCE\n\nOracle alerts are sent at full severity again.", m.chain.Name)
+	}
+	if err := m.alertManager.SendDeveloperNotice(ctx, m.Name(), msg); err != nil {
+		log.Printf("[%s][%s] failed to send maintenance notice: %v", m.Name(), m.chain.Name, err)
+	}
+}
+
+// observe passes an observation to the alert manager, capped at WARNING and kept off
+// the business channel while the chain is in maintenance
+func (m *OracleMonitor) observe(ctx context.Context, key alerts.AlertKey, severity alerts.Severity, value float64, summary, details string, isBusinessAlert bool, slackMsg string) error {
+	if maintenance := m.maintenance.Load(); maintenance != nil && severity != alerts.SeverityOK {
+		if severity == alerts.SeverityCritical {
+			severity = alerts.SeverityWarning
+		}
+		isBusinessAlert, slackMsg = false, ""
+		details += "\n" + maintenance.describe(m.now())
+	}
+	return m.alertManager.Observe(ctx, key, severity, value, summary, details, isBusinessAlert, slackMsg)
+}
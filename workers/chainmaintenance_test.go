@@ -0,0 +1,102 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestMaintenanceModesExpire(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.DefaultConfig()
+	cfg.Chains = map[string]config.ChainConfig{
+		"base": {Maintenance: &config.ChainMaintenanceConfig{Until: now.Add(time.Hour), Reason: "oracle migration"}},
+	}
+	modes := NewMaintenanceModes(config.NewHolder(cfg))
+	modes.SetClock(func() time.Time { return now })
+
+	if mode, ok := modes.Active("base"); !ok || mode.Source != "config" || mode.Reason != "oracle migration" {
+		t.Errorf("Active(base) = %+v, %v; want the configured mode", mode, ok)
+	}
+	modes.Set("base", now.Add(3*time.Hour), "extended")
+	modes.Set("optimism", now.Add(30*time.Minute), "")
+	if mode, _ := modes.Active("base"); mode.Source != "api" || mode.Reason != "extended" {
+		t.Errorf("Active(base) = %+v, want the API setting to replace the config", mode)
+	}
+	if list := modes.List(); len(list) != 2 || list[0].Chain != "base" || list[1].Chain != "optimism" {
+		t.Errorf("List() = %+v, want base and optimism", list)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, ok := modes.Active("optimism"); ok {
+		t.Error("expired optimism maintenance still active")
+	}
+	modes.Clear("base")
+	if _, ok := modes.Active("base"); ok {
+		t.Error("base still in maintenance after the API setting was cleared and the config expired")
+	}
+	if list := modes.List(); len(list) != 0 {
+		t.Errorf("List() = %+v after expiry, want none", list)
+	}
+
+	var nilModes *MaintenanceModes
+	if _, ok := nilModes.Active("base"); ok {
+		t.Error("nil MaintenanceModes reported a chain in maintenance")
+	}
+}
+
+func TestMaintenanceCapsOracleAlerts(t *testing.T) {
+	var developer, business []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["chat_id"] == "business-chat" {
+			business = append(business, fmt.Sprint(payload["text"]))
+		} else {
+			developer = append(developer, fmt.Sprint(payload["text"]))
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+	service := alerts.New("business-token", "business-chat", "developer-token", "developer-chat", "")
+	service.TelegramAPIURL = server.URL
+	manager := alerts.NewManager(service)
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	modes := NewMaintenanceModes(config.NewHolder(config.DefaultConfig()))
+	modes.SetClock(func() time.Time { return now })
+	m := &OracleMonitor{chain: ChainConfig{ID: "base", Name: "Base"}, alertManager: manager, clock: func() time.Time { return now }}
+	m.SetMaintenanceModes(modes)
+	ctx := context.Background()
+
+	modes.Set("base", now.Add(time.Hour), "oracle migration")
+	m.checkMaintenance(ctx)
+	m.checkMaintenance(ctx)
+	if len(developer) != 1 || !strings.Contains(developer[0], "Base ENTERED MAINTENANCE") {
+		t.Fatalf("developer messages = %q, want one maintenance notice", developer)
+	}
+
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("WETH"), Metric: "price_deviation_volatile"}
+	m.observe(ctx, key, alerts.SeverityCritical, 12, "", "Token: WETH", true, "slack")
+	state := manager.GetActiveIncidents()[key]
+	if state.Severity != alerts.SeverityWarning || !strings.Contains(state.LastMessage, "Chain in maintenance until") {
+		t.Errorf("incident = %+v, want WARNING with the maintenance status", state)
+	}
+	if len(business) != 0 {
+		t.Errorf("business messages = %q during maintenance, want none", business)
+	}
+
+	now = now.Add(2 * time.Hour)
+	m.checkMaintenance(ctx)
+	if len(developer) != 3 || !strings.Contains(developer[2], "Base LEFT MAINTENANCE") {
+		t.Errorf("developer messages = %q, want the alert and a notice that maintenance ended", developer)
+	}
+}
@@ -2,7 +2,12 @@ package workers
 
 import (
 	"fmt"
+	"net/http"
+	"os"
+	"sort"
 	"strings"
+
+	"github.com/0x0Glitch/config"
 )
 
 // ChainID represents supported blockchain networks
@@ -23,8 +28,15 @@ type TokenMeta struct {
 	TableName    string  // Database table name
 	IsStablecoin bool    // Whether this is a stablecoin
 	PegValue     float64 // Expected peg value for stablecoins
+	PegCurrency  string  // Fiat currency whose live USD rate replaces PegValue, e.g. "EUR"
 	PriceAddress string  // Underlying token address for price lookups
 	SkipDEXPrice bool    // Skip DEX price check (for native tokens without DEX price source)
+	PriceSource  string  // Overrides the chain's price source for this token
+	PriceNetwork string  // Overrides the chain's price network or platform for this token
+	FeedSymbol   string  // Symbol the oracle's getFeed expects, when it differs from Symbol
+	// Strategy combining a stablecoin's deviations from peg and DEX: "max" (default),
+	// "min", "peg" or "dex"
+	DeviationStrategy string
 }
 
 // ChainConfig holds chain-specific configuration
@@ -33,32 +45,65 @@ type ChainConfig struct {
 	Name          string
 	OracleAddress string
 	Tokens        map[string]TokenMeta
-	PriceNetwork  string
+	PriceNetwork  string      // Alchemy prices API network slug
+	PriceSource   string      // PriceSourceAlchemy, PriceSourceCoinGecko or PriceSourceDefiLlama
+	PricePlatform string      // CoinGecko/DefiLlama platform slug, defaults to the chain ID
+	RPCURLs       []string    // in priority order
+	WSURL         string      // optional websocket endpoint
+	RPCHeaders    http.Header // sent with every RPC and websocket request, nil for none
+	EVMChainID    int64       // expected eth_chainId, verified on connect
+	// ExpectedTokenCount is the number of tokens that should be monitored (0 disables the check)
+	ExpectedTokenCount int
 }
 
-// GetChainsByEnv returns enabled chains based on environment configuration
-func GetChainsByEnv(enabledChains string) ([]ChainConfig, error) {
-	if enabledChains == "" {
-		return []ChainConfig{BaseChain()}, nil
+// GetChainsByEnv returns the enabled chains. enabledChains (ENABLED_CHAINS) takes
+// precedence; otherwise chains marked enabled in the config section are used, and
+// Base alone when neither is set. RPC endpoints come from ResolveRPCURLs with
+// providers; settings from the config section override them and the compiled-in
+// chain defaults field by field.
+func GetChainsByEnv(enabledChains string, overrides map[string]config.ChainConfig, providers []config.RPCProviderConfig) ([]ChainConfig, error) {
+	var chainIDs []string
+	switch {
+	case enabledChains != "":
+		chainIDs = strings.Split(enabledChains, ",")
+	case len(overrides) > 0:
+		for id, override := range overrides {
+			if override.Enabled {
+				chainIDs = append(chainIDs, id)
+			}
+		}
+		sort.Strings(chainIDs)
+		if len(chainIDs) == 0 {
+			return nil, fmt.Errorf("no chains enabled in config")
+		}
+	default:
+		chainIDs = []string{string(ChainBase)}
 	}
 
-	chainIDs := strings.Split(enabledChains, ",")
 	configs := make([]ChainConfig, 0, len(chainIDs))
-
 	for _, id := range chainIDs {
 		id = strings.TrimSpace(strings.ToLower(id))
-		var cfg ChainConfig
-		switch ChainID(id) {
-		case ChainBase:
-			cfg = BaseChain()
-		case ChainOptimism:
-			cfg = OptimismChain()
-		case ChainMoonbeam:
-			cfg = MoonbeamChain()
-		case ChainMoonriver:
-			cfg = MoonriverChain()
-		default:
-			return nil, fmt.Errorf("unsupported chain: %s", id)
+		cfg, err := defaultChain(ChainID(id))
+		if err != nil {
+			return nil, err
+		}
+		cfg.RPCURLs = ResolveRPCURLs(cfg.ID, providers)
+
+		if override, ok := overrides[id]; ok {
+			if err := applyChainOverride(&cfg, override); err != nil {
+				return nil, err
+			}
+		}
+		applyPriceEnv(&cfg)
+		if err := applyRPCHeaderEnv(&cfg); err != nil {
+			return nil, err
+		}
+		if !isPriceSource(cfg.PriceSource) {
+			return nil, fmt.Errorf("unsupported price source for %s: %q", cfg.Name, cfg.PriceSource)
+		}
+
+		if len(cfg.RPCURLs) == 0 {
+			return nil, fmt.Errorf("no RPC URL configured for %s", cfg.Name)
 		}
 		configs = append(configs, cfg)
 	}
@@ -66,12 +111,189 @@ func GetChainsByEnv(enabledChains string) ([]ChainConfig, error) {
 	return configs, nil
 }
 
+// AllChains returns the compiled-in configuration of every supported chain, without
+// RPC endpoints or config overrides, for offline tools such as replay
+func AllChains() []ChainConfig {
+	return []ChainConfig{BaseChain(), OptimismChain(), MoonbeamChain(), MoonriverChain()}
+}
+
+// defaultChain returns the compiled-in configuration for a chain
+func defaultChain(id ChainID) (ChainConfig, error) {
+	switch id {
+	case ChainBase:
+		return BaseChain(), nil
+	case ChainOptimism:
+		return OptimismChain(), nil
+	case ChainMoonbeam:
+		return MoonbeamChain(), nil
+	case ChainMoonriver:
+		return MoonriverChain(), nil
+	default:
+		return ChainConfig{}, fmt.Errorf("unsupported chain: %s", id)
+	}
+}
+
+// applyChainOverride replaces compiled-in chain settings with those set in config
+func applyChainOverride(cfg *ChainConfig, override config.ChainConfig) error {
+	var urls []string
+	for _, url := range override.RPCURLs {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	if len(urls) > 0 {
+		cfg.RPCURLs = urls
+	}
+	if override.WSURL != "" {
+		cfg.WSURL = override.WSURL
+	}
+	for name, value := range override.RPCHeaders {
+		// An unset ${VAR} reference leaves the value empty; skip rather than send a blank header
+		if value == "" {
+			continue
+		}
+		if cfg.RPCHeaders == nil {
+			cfg.RPCHeaders = make(http.Header)
+		}
+		cfg.RPCHeaders.Set(name, value)
+	}
+	if override.OracleAddress != "" {
+		cfg.OracleAddress = override.OracleAddress
+	}
+	if override.PriceNetwork != "" {
+		cfg.PriceNetwork = override.PriceNetwork
+	}
+	if override.PriceSource != "" {
+		cfg.PriceSource = override.PriceSource
+	}
+	if override.PricePlatform != "" {
+		cfg.PricePlatform = override.PricePlatform
+	}
+	if override.ExpectedTokenCount > 0 {
+		cfg.ExpectedTokenCount = override.ExpectedTokenCount
+	}
+
+	if len(override.PriceTokens) == 0 && len(override.FeedSymbols) == 0 && len(override.PegCurrencies) == 0 &&
+		len(override.PegValues) == 0 && len(override.DeviationStrategies) == 0 {
+		return nil
+	}
+
+	// Copy before modifying so the compiled-in token tables stay untouched
+	tokens := make(map[string]TokenMeta, len(cfg.Tokens))
+	for key, meta := range cfg.Tokens {
+		tokens[key] = meta
+	}
+	for key, route := range override.PriceTokens {
+		meta, ok := tokens[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("chains.%s.price_tokens: unknown token %q", cfg.ID, key)
+		}
+		if route.Source != "" {
+			meta.PriceSource = route.Source
+		}
+		if route.Network != "" {
+			meta.PriceNetwork = route.Network
+		}
+		if route.Address != "" {
+			meta.PriceAddress = route.Address
+		}
+		tokens[strings.ToLower(key)] = meta
+	}
+	for key, symbol := range override.FeedSymbols {
+		meta, ok := tokens[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("chains.%s.feed_symbols: unknown token %q", cfg.ID, key)
+		}
+		meta.FeedSymbol = symbol
+		tokens[strings.ToLower(key)] = meta
+	}
+	for key, currency := range override.PegCurrencies {
+		meta, ok := tokens[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("chains.%s.peg_currencies: unknown token %q", cfg.ID, key)
+		}
+		if !meta.IsStablecoin {
+			return fmt.Errorf("chains.%s.peg_currencies: %q is not a stablecoin", cfg.ID, key)
+		}
+		meta.PegCurrency = strings.ToUpper(currency)
+		tokens[strings.ToLower(key)] = meta
+	}
+	for key, peg := range override.PegValues {
+		meta, ok := tokens[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("chains.%s.peg_values: unknown token %q", cfg.ID, key)
+		}
+		if !meta.IsStablecoin {
+			return fmt.Errorf("chains.%s.peg_values: %q is not a stablecoin", cfg.ID, key)
+		}
+		meta.PegValue = peg
+		tokens[strings.ToLower(key)] = meta
+	}
+	for key, strategy := range override.DeviationStrategies {
+		meta, ok := tokens[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("chains.%s.deviation_strategies: unknown token %q", cfg.ID, key)
+		}
+		if !meta.IsStablecoin {
+			return fmt.Errorf("chains.%s.deviation_strategies: %q is not a stablecoin", cfg.ID, key)
+		}
+		meta.DeviationStrategy = strategy
+		tokens[strings.ToLower(key)] = meta
+	}
+	cfg.Tokens = tokens
+	return nil
+}
+
+// applyPriceEnv applies <CHAIN>_PRICE_SOURCE, <CHAIN>_PRICE_NETWORK and
+// <CHAIN>_PRICE_PLATFORM environment overrides, which win over config
+func applyPriceEnv(cfg *ChainConfig) {
+	prefix := strings.ToUpper(string(cfg.ID))
+	if source := os.Getenv(prefix + "_PRICE_SOURCE"); source != "" {
+		cfg.PriceSource = strings.ToLower(source)
+	}
+	if network := os.Getenv(prefix + "_PRICE_NETWORK"); network != "" {
+		cfg.PriceNetwork = network
+	}
+	if platform := os.Getenv(prefix + "_PRICE_PLATFORM"); platform != "" {
+		cfg.PricePlatform = platform
+	}
+}
+
+// applyRPCHeaderEnv adds headers from <CHAIN>_RPC_HEADERS, a semicolon-separated
+// list of "Name: value" pairs. Headers set this way replace those from config.
+func applyRPCHeaderEnv(cfg *ChainConfig) error {
+	env := strings.ToUpper(string(cfg.ID)) + "_RPC_HEADERS"
+	raw := os.Getenv(env)
+	if raw == "" {
+		return nil
+	}
+
+	for _, pair := range strings.Split(raw, ";") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, ":")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			// Never echo the pair itself, the value is usually a credential
+			return fmt.Errorf("%s: expected \"Name: value\" pairs separated by ';'", env)
+		}
+		if cfg.RPCHeaders == nil {
+			cfg.RPCHeaders = make(http.Header)
+		}
+		cfg.RPCHeaders.Set(name, value)
+	}
+	return nil
+}
+
 func BaseChain() ChainConfig {
 	return ChainConfig{
 		ID:            ChainBase,
 		Name:          "Base",
+		EVMChainID:    8453,
 		OracleAddress: "0xEC942bE8A8114bFD0396A5052c36027f2cA6a9d0",
 		PriceNetwork:  "base-mainnet",
+		PriceSource:   PriceSourceAlchemy,
 		Tokens:        BaseTokens(),
 	}
 }
@@ -80,8 +302,10 @@ func OptimismChain() ChainConfig {
 	return ChainConfig{
 		ID:            ChainOptimism,
 		Name:          "Optimism",
+		EVMChainID:    10,
 		OracleAddress: "0x2f1490bD6aD10C9CE42a2829afa13EAc0b746dcf",
 		PriceNetwork:  "opt-mainnet",
+		PriceSource:   PriceSourceAlchemy,
 		Tokens:        OptimismTokens(),
 	}
 }
@@ -90,8 +314,10 @@ func MoonbeamChain() ChainConfig {
 	return ChainConfig{
 		ID:            ChainMoonbeam,
 		Name:          "Moonbeam",
+		EVMChainID:    1284,
 		OracleAddress: "0xED301cd3EB27217BDB05C4E9B820a8A3c8B665f9",
 		PriceNetwork:  "moonbeam-mainnet",
+		PriceSource:   PriceSourceAlchemy,
 		Tokens:        MoonbeamTokens(),
 	}
 }
@@ -100,8 +326,12 @@ func MoonriverChain() ChainConfig {
 	return ChainConfig{
 		ID:            ChainMoonriver,
 		Name:          "Moonriver",
+		EVMChainID:    1285,
 		OracleAddress: "0xED301cd3EB27217BDB05C4E9B820a8A3c8B665f9",
 		PriceNetwork:  "moonriver-mainnet",
+		// Alchemy's price API does not cover Moonriver
+		PriceSource:   PriceSourceDefiLlama,
+		PricePlatform: "moonriver",
 		Tokens:        MoonriverTokens(),
 	}
 }
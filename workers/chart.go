@@ -0,0 +1,26 @@
+package workers
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// formatChartLine links a deviation alert to a chart of the token's recent prices,
+// built from oracle.chart.url_template. It is empty when no template is configured.
+func (m *OracleMonitor) formatChartLine(meta TokenMeta) string {
+	chart := m.oracleConfig().Chart
+	if chart.URLTemplate == "" {
+		return ""
+	}
+	to := m.now()
+	from := to.Add(-chart.WindowHours.Duration())
+	link := strings.NewReplacer(
+		"{chain}", url.QueryEscape(string(m.chain.ID)),
+		"{symbol}", url.QueryEscape(meta.Symbol),
+		"{address}", url.QueryEscape(meta.PriceAddress),
+		"{from}", strconv.FormatInt(from.Unix(), 10),
+		"{to}", strconv.FormatInt(to.Unix(), 10),
+	).Replace(chart.URLTemplate)
+	return "Chart: " + link
+}
@@ -0,0 +1,33 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/config"
+)
+
+func TestFormatChartLine(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.DefaultConfig()
+	m := &OracleMonitor{
+		chain:   ChainConfig{ID: "base", Name: "Base"},
+		configs: config.NewHolder(cfg),
+		clock:   func() time.Time { return now },
+	}
+	meta := TokenMeta{Symbol: "cbETH", PriceAddress: "0x2Ae3F1Ec7F1F5012CFEab0185bfc7aa3cf0DEc22"}
+
+	if line := m.formatChartLine(meta); line != "" {
+		t.Errorf("formatChartLine() = %q without a template, want empty", line)
+	}
+
+	cfg.Oracle.Chart = config.ChartConfig{
+		URLTemplate: "https://charts.example.com/render.png?chain={chain}&token={address}&symbol={symbol}&from={from}&to={to}",
+		WindowHours: config.Hours(6 * time.Hour),
+	}
+	want := "Chart: https://charts.example.com/render.png?chain=base&token=0x2Ae3F1Ec7F1F5012CFEab0185bfc7aa3cf0DEc22&symbol=cbETH" +
+		"&from=1772344800&to=1772366400"
+	if line := m.formatChartLine(meta); line != want {
+		t.Errorf("formatChartLine() = %q, want %q", line, want)
+	}
+}
@@ -0,0 +1,105 @@
+package workers
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+const (
+	// circuitFailureThreshold is the number of consecutive high-error cycles that opens the breaker
+	circuitFailureThreshold = 5
+	// circuitProbeInterval is how long an open breaker waits before letting one cycle
+	// through to test whether the chain has recovered
+	circuitProbeInterval = 5 * time.Minute
+)
+
+// circuitOpen exposes each chain's oracle circuit breaker (1 open, 0 closed)
+var circuitOpen = expvar.NewMap("oracle_circuit_open")
+
+// circuitAllows reports whether a cycle may run. An open breaker skips cycles, letting
+// one through every circuitProbeInterval; that cycle's outcome closes or re-arms it.
+func (m *OracleMonitor) circuitAllows(ctx context.Context) bool {
+	m.mu.Lock()
+	failures, openedAt := m.failures, m.circuitOpenedAt
+	m.mu.Unlock()
+
+	if failures < circuitFailureThreshold {
+		return true
+	}
+	if m.now().Sub(openedAt) >= circuitProbeInterval {
+		log.Printf("[%s][%s] circuit open (%d failures), probing", m.Name(), m.chain.Name, failures)
+		return true
+	}
+	log.Printf("[%s][%s] circuit open (%d failures), skipping check", m.Name(), m.chain.Name, failures)
+	m.observeCircuit(ctx, failures, openedAt)
+	return false
+}
+
+// recordCycleOutcome updates the breaker after a cycle, alerting developers when it
+// opens and when a probe closes it again
+func (m *OracleMonitor) recordCycleOutcome(ctx context.Context, failed bool) {
+	m.mu.Lock()
+	wasOpen := m.failures >= circuitFailureThreshold
+	if failed {
+		m.failures++
+		if m.failures >= circuitFailureThreshold {
+			// Opening, or a failed probe: wait a full interval before the next probe
+			m.circuitOpenedAt = m.now()
+		}
+	} else {
+		m.failures = 0
+		m.circuitOpenedAt = time.Time{}
+	}
+	failures, openedAt := m.failures, m.circuitOpenedAt
+	isOpen := failures >= circuitFailureThreshold
+	m.mu.Unlock()
+
+	switch {
+	case isOpen && !wasOpen:
+		log.Printf("[%s][%s] circuit opened after %d failed cycles", m.Name(), m.chain.Name, failures)
+		m.setCircuitMetric(true)
+		m.observeCircuit(ctx, failures, openedAt)
+	case isOpen:
+		m.observeCircuit(ctx, failures, openedAt)
+	case wasOpen:
+		log.Printf("[%s][%s] circuit closed", m.Name(), m.chain.Name)
+		m.setCircuitMetric(false)
+		key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("circuit"), Metric: "circuit_breaker"}
+		details := fmt.Sprintf("Chain: %s\nCircuit closed: a probe cycle succeeded and monitoring has resumed.", m.chain.Name)
+		m.observe(ctx, key, alerts.SeverityOK, 0, "", details, false, "")
+	}
+}
+
+// observeCircuit reports the open breaker; repeated observations only produce reminders
+func (m *OracleMonitor) observeCircuit(ctx context.Context, failures int, openedAt time.Time) {
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("circuit"), Metric: "circuit_breaker"}
+	details := fmt.Sprintf("Chain: %s\nFailed cycles: %d\nOpen since: %s\nNext probe: %s\n\n"+
+		"More than half the tokens failed in each of the last cycles. Oracle prices on this chain are not being checked.",
+		m.chain.Name, failures, alerts.FormatTime(openedAt, m.now()), alerts.FormatTime(openedAt.Add(circuitProbeInterval), m.now()))
+	m.observe(ctx, key, alerts.SeverityCritical, 1, "", details, false, "")
+}
+
+func (m *OracleMonitor) setCircuitMetric(open bool) {
+	state := new(expvar.Int)
+	if open {
+		state.Set(1)
+	}
+	circuitOpen.Set(string(m.chain.ID), state)
+}
+
+func registerCircuitPolicy(alertManager *alerts.Manager, jobName string) {
+	// Observed every skipped cycle while open; the value never changes, so only
+	// reminders are sent after the first page
+	alertManager.RegisterPolicy(jobName, "circuit_breaker", alerts.AlertPolicy{
+		MinValueChange:        1.0,
+		CooldownWarning:       1 * time.Hour,
+		CooldownCritical:      1 * time.Hour,
+		ReminderInterval:      1 * time.Hour,
+		ConsecutiveOKRequired: 1,
+	})
+}
@@ -0,0 +1,62 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	manager.SetClock(func() time.Time { return now })
+	m := &OracleMonitor{
+		chain:        ChainConfig{ID: "circuit_test", Name: "Circuit Test"},
+		alertManager: manager,
+		clock:        func() time.Time { return now },
+	}
+	registerCircuitPolicy(manager, m.Name())
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("circuit"), Metric: "circuit_breaker"}
+	ctx := context.Background()
+
+	for i := 0; i < circuitFailureThreshold; i++ {
+		if !m.circuitAllows(ctx) {
+			t.Fatalf("circuit open after %d failures", i)
+		}
+		m.recordCycleOutcome(ctx, true)
+	}
+	if state, ok := manager.GetActiveIncidents()[key]; !ok || state.Severity != alerts.SeverityCritical {
+		t.Fatalf("incident = %+v (active %v), want CRITICAL once the circuit opens", state, ok)
+	}
+	if got := circuitOpen.Get("circuit_test").String(); got != "1" {
+		t.Errorf("oracle_circuit_open = %s, want 1", got)
+	}
+	if m.circuitAllows(ctx) {
+		t.Fatal("open circuit allowed a cycle before the probe interval")
+	}
+
+	// A failed probe re-arms the breaker for another interval
+	now = now.Add(circuitProbeInterval)
+	if !m.circuitAllows(ctx) {
+		t.Fatal("open circuit did not allow a probe after the interval")
+	}
+	m.recordCycleOutcome(ctx, true)
+	if m.circuitAllows(ctx) {
+		t.Fatal("failed probe did not re-arm the breaker")
+	}
+
+	// A successful probe closes it and clears the incident
+	now = now.Add(circuitProbeInterval)
+	if !m.circuitAllows(ctx) {
+		t.Fatal("open circuit did not allow the second probe")
+	}
+	m.recordCycleOutcome(ctx, false)
+	if state, ok := manager.GetActiveIncidents()[key]; ok && state.Severity != alerts.SeverityOK {
+		t.Errorf("incident still %s after the circuit closed", state.Severity)
+	}
+	if got := circuitOpen.Get("circuit_test").String(); got != "0" {
+		t.Errorf("oracle_circuit_open = %s, want 0", got)
+	}
+}
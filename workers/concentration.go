@@ -10,13 +10,25 @@ import (
 	_ "github.com/lib/pq"
 
 	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/tracing"
 )
 
 // ConcentrationJob monitors whale positions and borrow concentration
 type ConcentrationJob struct {
 	db             *sql.DB
 	alertManager   *alerts.Manager
+	configs        *config.Holder
 	previousWhales map[string]bool // Track whale addresses from previous run
+	topBorrower    string          // address observed for borrow_single in the previous run
+	formerTop      map[string]bool // earlier top borrowers whose borrow_single alert is still open
+	top10History   []concentrationSample
+}
+
+// concentrationSample is a top 10 borrow concentration reading kept for the trend baseline
+type concentrationSample struct {
+	at         time.Time
+	percentage float64
 }
 
 type whalePosition struct {
@@ -26,7 +38,7 @@ type whalePosition struct {
 }
 
 // NewConcentrationJob creates a new concentration risk monitoring job
-func NewConcentrationJob(databaseURL string, alertManager *alerts.Manager) (*ConcentrationJob, error) {
+func NewConcentrationJob(databaseURL string, alertManager *alerts.Manager, configs *config.Holder) (*ConcentrationJob, error) {
 	if databaseURL == "" {
 		return nil, fmt.Errorf("database URL not configured")
 	}
@@ -42,16 +54,17 @@ func NewConcentrationJob(databaseURL string, alertManager *alerts.Manager) (*Con
 	}
 
 	// Register policies for concentration alerts
-	alertManager.RegisterPolicy("concentration", "whale_supply", alerts.AlertPolicy{
-		MinValueChange:        1.0, // 1% change in concentration
-		CooldownWarning:       1 * time.Hour,
-		CooldownCritical:      30 * time.Minute,
+	whale := configs.Get().Concentration.WhaleSupply
+	alertManager.RegisterDefaultPolicy("concentration", "whale_supply", alerts.AlertPolicy{
+		MinValueChange:        whale.MinValueChangePercent,
+		CooldownWarning:       whale.CooldownWarning(),
+		CooldownCritical:      whale.CooldownCritical(),
 		ReminderInterval:      0,
-		TriggerThreshold:      10.0, // 10% of total supply
-		ConsecutiveOKRequired: 2,
+		TriggerThreshold:      whale.WarningThresholdPercent,
+		ConsecutiveOKRequired: whale.ConsecutiveOKRequired,
 	})
 
-	alertManager.RegisterPolicy("concentration", "borrow_top10", alerts.AlertPolicy{
+	alertManager.RegisterDefaultPolicy("concentration", "borrow_top10", alerts.AlertPolicy{
 		MinValueChange:        2.0, // 2% change
 		CooldownWarning:       1 * time.Hour,
 		CooldownCritical:      30 * time.Minute,
@@ -60,7 +73,7 @@ func NewConcentrationJob(databaseURL string, alertManager *alerts.Manager) (*Con
 		ConsecutiveOKRequired: 2,
 	})
 
-	alertManager.RegisterPolicy("concentration", "borrow_single", alerts.AlertPolicy{
+	alertManager.RegisterDefaultPolicy("concentration", "borrow_single", alerts.AlertPolicy{
 		MinValueChange:        2.0, // 2% change
 		CooldownWarning:       1 * time.Hour,
 		CooldownCritical:      30 * time.Minute,
@@ -69,9 +82,20 @@ func NewConcentrationJob(databaseURL string, alertManager *alerts.Manager) (*Con
 		ConsecutiveOKRequired: 2,
 	})
 
+	trend := configs.Get().Concentration.BorrowTop10Trend
+	alertManager.RegisterDefaultPolicy("concentration", "borrow_concentration_trend", alerts.AlertPolicy{
+		MinValueChange:        trend.MinValueChange,
+		CooldownWarning:       trend.CooldownWarning(),
+		CooldownCritical:      trend.CooldownCritical(),
+		ReminderInterval:      0,
+		TriggerThreshold:      trend.WarningIncreasePoints,
+		ConsecutiveOKRequired: trend.ConsecutiveOKRequired,
+	})
+
 	return &ConcentrationJob{
 		db:             db,
 		alertManager:   alertManager,
+		configs:        configs,
 		previousWhales: make(map[string]bool),
 	}, nil
 }
@@ -84,21 +108,24 @@ func (j *ConcentrationJob) Interval() time.Duration {
 	return 10 * time.Minute
 }
 
+// Run checks whale positions (concentration.whale_supply) and borrow concentration,
+// up to db_subcheck_concurrency at a time. A failed check does not stop the other.
 func (j *ConcentrationJob) Run(ctx context.Context) error {
-	// Check whale positions (>10% of supply)
-	if err := j.checkWhalePositions(ctx); err != nil {
-		log.Printf("[%s] whale check failed: %v", j.Name(), err)
-	}
-
-	// Check borrow concentration
-	if err := j.checkBorrowConcentration(ctx); err != nil {
-		log.Printf("[%s] borrow concentration check failed: %v", j.Name(), err)
-	}
+	return runSubChecks(ctx, j.configs.Get().DBSubCheckConcurrency,
+		subCheck{"whale check", j.checkWhalePositions},
+		subCheck{"borrow concentration check", j.checkBorrowConcentration},
+	)
+}
 
-	return nil
+// classifyWhale returns the severity of a position holding percentage of total supply
+func (j *ConcentrationJob) classifyWhale(percentage float64) alerts.Severity {
+	cfg := j.configs.Get().Concentration.WhaleSupply
+	return classify(percentage, configuredBands(j.configs, j.Name(), "whale_supply",
+		warningCritical(cfg.WarningThresholdPercent, cfg.CriticalThresholdPercent)))
 }
 
 func (j *ConcentrationJob) checkWhalePositions(ctx context.Context) error {
+	cfg := j.configs.Get().Concentration.WhaleSupply
 	query := `
 		WITH total AS (
 			SELECT SUM(total_supplied) as total_supply
@@ -111,11 +138,13 @@ func (j *ConcentrationJob) checkWhalePositions(ctx context.Context) error {
 			(total_supplied / total.total_supply * 100) as percentage
 		FROM public."UserPositions", total
 		WHERE total_supplied > 0
-			AND (total_supplied / total.total_supply * 100) >= 10
+			AND (total_supplied / total.total_supply * 100) >= $1
 		ORDER BY percentage DESC
 	`
 
-	rows, err := j.db.QueryContext(ctx, query)
+	queryCtx, span := tracing.Start(ctx, "db.query", tracing.Job(j.Name()), tracing.Query("whale_positions"))
+	rows, err := j.db.QueryContext(queryCtx, query, cfg.WarningThresholdPercent)
+	tracing.End(span, err)
 	if err != nil {
 		return fmt.Errorf("whale query failed: %w", err)
 	}
@@ -140,15 +169,7 @@ func (j *ConcentrationJob) checkWhalePositions(ctx context.Context) error {
 			Metric: "whale_supply",
 		}
 
-		var severity alerts.Severity
-		switch {
-		case whale.Percentage >= 20:
-			severity = alerts.SeverityCritical
-		case whale.Percentage >= 10:
-			severity = alerts.SeverityWarning
-		default:
-			severity = alerts.SeverityOK
-		}
+		severity := j.classifyWhale(whale.Percentage)
 
 		// Log whale position
 		log.Printf("[%s] whale %s: concentration=%.2f%%, supply=$%s, severity=%s",
@@ -183,7 +204,7 @@ func (j *ConcentrationJob) checkWhalePositions(ctx context.Context) error {
 	j.previousWhales = currentWhales
 
 	if whaleCount > 0 {
-		log.Printf("[%s] found %d whale positions (>10%% supply)", j.Name(), whaleCount)
+		log.Printf("[%s] found %d whale positions (>=%g%% supply)", j.Name(), whaleCount, cfg.WarningThresholdPercent)
 	}
 
 	return rows.Err()
@@ -192,11 +213,13 @@ func (j *ConcentrationJob) checkWhalePositions(ctx context.Context) error {
 func (j *ConcentrationJob) checkBorrowConcentration(ctx context.Context) error {
 	// Get total borrows
 	var totalBorrows float64
-	err := j.db.QueryRowContext(ctx, `
+	queryCtx, span := tracing.Start(ctx, "db.query", tracing.Job(j.Name()), tracing.Query("total_borrows"))
+	err := j.db.QueryRowContext(queryCtx, `
 		SELECT COALESCE(SUM(total_borrowed), 0)
 		FROM public."UserPositions"
 		WHERE total_borrowed > 0
 	`).Scan(&totalBorrows)
+	tracing.End(span, err)
 	if err != nil {
 		return fmt.Errorf("total borrows query failed: %w", err)
 	}
@@ -219,7 +242,9 @@ func (j *ConcentrationJob) checkBorrowConcentration(ctx context.Context) error {
 		LIMIT 10
 	`
 
-	rows, err := j.db.QueryContext(ctx, query, totalBorrows)
+	queryCtx, span = tracing.Start(ctx, "db.query", tracing.Job(j.Name()), tracing.Query("top_borrowers"))
+	rows, err := j.db.QueryContext(queryCtx, query, totalBorrows)
+	tracing.End(span, err)
 	if err != nil {
 		return fmt.Errorf("top borrowers query failed: %w", err)
 	}
@@ -264,15 +289,7 @@ func (j *ConcentrationJob) checkBorrowConcentration(ctx context.Context) error {
 			Metric: "borrow_top10",
 		}
 
-		var severity alerts.Severity
-		switch {
-		case top10Percentage >= 90:
-			severity = alerts.SeverityCritical
-		case top10Percentage >= 80:
-			severity = alerts.SeverityWarning
-		default:
-			severity = alerts.SeverityOK
-		}
+		severity := classify(top10Percentage, configuredBands(j.configs, j.Name(), "borrow_top10", warningCritical(80, 90)))
 
 		summary := ""
 		details := fmt.Sprintf(
@@ -287,40 +304,117 @@ func (j *ConcentrationJob) checkBorrowConcentration(ctx context.Context) error {
 		}
 	}
 
-	// Alert for single wallet concentration
-	{
-		key := alerts.AlertKey{
+	j.observeTopBorrower(ctx, maxAddress, maxSingle, totalBorrows)
+	j.checkTop10Trend(ctx, top10Percentage)
+
+	log.Printf("[%s] top10: %.1f%%, max single: %.1f%%", j.Name(), top10Percentage, maxSinglePercentage)
+	return nil
+}
+
+// observeTopBorrower alerts on the single wallet with the largest share of total
+// borrows. Wallets that lose the top spot are no longer observed by it, so OK is
+// observed for them each run until their alert resolves.
+func (j *ConcentrationJob) observeTopBorrower(ctx context.Context, address string, borrowed, totalBorrows float64) {
+	percentage := (borrowed / totalBorrows) * 100
+	key := alerts.AlertKey{
+		Job:    j.Name(),
+		Entity: address,
+		Metric: "borrow_single",
+	}
+
+	severity := classify(percentage, configuredBands(j.configs, j.Name(), "borrow_single", warningCritical(40, 50)))
+
+	summary := ""
+	details := fmt.Sprintf(
+		"Single Wallet Borrow: %.2f%%\nBorrow: $%s\nTotal Borrows: $%s\nAddress: %s",
+		percentage,
+		formatUSD(borrowed),
+		formatUSD(totalBorrows),
+		address,
+	)
+
+	if err := j.alertManager.Observe(ctx, key, severity, percentage, summary, details, true, ""); err != nil {
+		log.Printf("[%s] failed to observe single wallet alert: %v", j.Name(), err)
+	}
+
+	if previous := j.topBorrower; previous != "" && previous != address {
+		log.Printf("[%s] top borrower changed from %s to %s", j.Name(), previous, address)
+		if j.formerTop == nil {
+			j.formerTop = make(map[string]bool)
+		}
+		j.formerTop[previous] = true
+	}
+	j.topBorrower = address
+	delete(j.formerTop, address)
+
+	if len(j.formerTop) == 0 {
+		return
+	}
+	active := j.alertManager.GetActiveIncidents()
+	for former := range j.formerTop {
+		formerKey := alerts.AlertKey{
 			Job:    j.Name(),
-			Entity: maxAddress,
+			Entity: former,
 			Metric: "borrow_single",
 		}
+		if _, open := active[formerKey]; !open {
+			delete(j.formerTop, former)
+			continue
+		}
+		j.alertManager.Observe(ctx, formerKey, alerts.SeverityOK, 0, "", "", false, "")
+	}
+}
 
-		var severity alerts.Severity
-		switch {
-		case maxSinglePercentage >= 50:
-			severity = alerts.SeverityCritical
-		case maxSinglePercentage >= 40:
-			severity = alerts.SeverityWarning
-		default:
-			severity = alerts.SeverityOK
+// checkTop10Trend alerts when top 10 borrow concentration rises quickly against the
+// average of the readings within the baseline window, even below the level thresholds
+func (j *ConcentrationJob) checkTop10Trend(ctx context.Context, top10Percentage float64) {
+	trend := j.configs.Get().Concentration.BorrowTop10Trend
+	now := time.Now()
+
+	// Drop readings that have aged out of the baseline window
+	cutoff := now.Add(-trend.BaselineWindow())
+	kept := j.top10History[:0]
+	for _, sample := range j.top10History {
+		if sample.at.After(cutoff) {
+			kept = append(kept, sample)
 		}
+	}
+	j.top10History = append(kept, concentrationSample{at: now, percentage: top10Percentage})
 
-		summary := ""
-		details := fmt.Sprintf(
-			"Single Wallet Borrow: %.2f%%\nBorrow: $%s\nTotal Borrows: $%s\nAddress: %s",
-			maxSinglePercentage,
-			formatUSD(maxSingle),
-			formatUSD(totalBorrows),
-			maxAddress,
-		)
+	history := j.top10History[:len(j.top10History)-1]
+	if len(history) < trend.MinSamples {
+		return
+	}
 
-		if err := j.alertManager.Observe(ctx, key, severity, maxSinglePercentage, summary, details, true, ""); err != nil {
-			log.Printf("[%s] failed to observe single wallet alert: %v", j.Name(), err)
-		}
+	var sum float64
+	for _, sample := range history {
+		sum += sample.percentage
 	}
+	baseline := sum / float64(len(history))
+	increase := top10Percentage - baseline
 
-	log.Printf("[%s] top10: %.1f%%, max single: %.1f%%", j.Name(), top10Percentage, maxSinglePercentage)
-	return nil
+	severity := classify(increase, configuredBands(j.configs, j.Name(), "borrow_concentration_trend",
+		warningCritical(trend.WarningIncreasePoints, trend.CriticalIncreasePoints)))
+
+	key := alerts.AlertKey{
+		Job:    j.Name(),
+		Entity: "protocol",
+		Metric: "borrow_concentration_trend",
+	}
+
+	summary := ""
+	details := fmt.Sprintf(
+		"Top 10 Borrow Concentration: %.2f%%\nBaseline (%v avg of %d readings): %.2f%%\nIncrease: %+.2f points",
+		top10Percentage,
+		trend.BaselineWindow(),
+		len(history),
+		baseline,
+		increase,
+	)
+
+	if err := j.alertManager.Observe(ctx, key, severity, increase, summary, details, true, ""); err != nil {
+		log.Printf("[%s] failed to observe top10 trend alert: %v", j.Name(), err)
+	}
 }
 
 func (j *ConcentrationJob) Close() error {
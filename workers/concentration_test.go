@@ -0,0 +1,82 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestClassifyWhaleFollowsConfig(t *testing.T) {
+	defaults := config.DefaultConfig().Concentration.WhaleSupply // 10% / 20%
+	tighter := defaults
+	tighter.WarningThresholdPercent, tighter.CriticalThresholdPercent = 5, 12
+
+	tests := []struct {
+		percentage    float64
+		wantDefault   alerts.Severity
+		wantTightened alerts.Severity
+	}{
+		{4, alerts.SeverityOK, alerts.SeverityOK},
+		{7, alerts.SeverityOK, alerts.SeverityWarning},
+		{15, alerts.SeverityWarning, alerts.SeverityCritical},
+		{25, alerts.SeverityCritical, alerts.SeverityCritical},
+	}
+	configs := config.NewHolder(config.DefaultConfig())
+	j := &ConcentrationJob{configs: configs}
+	tightened := config.DefaultConfig()
+	tightened.Concentration.WhaleSupply = tighter
+	for _, tt := range tests {
+		configs.Set(config.DefaultConfig())
+		if got := j.classifyWhale(tt.percentage); got != tt.wantDefault {
+			t.Errorf("classifyWhale(%v) with defaults = %s, want %s", tt.percentage, got, tt.wantDefault)
+		}
+		configs.Set(tightened)
+		if got := j.classifyWhale(tt.percentage); got != tt.wantTightened {
+			t.Errorf("classifyWhale(%v) with 5/12 = %s, want %s", tt.percentage, got, tt.wantTightened)
+		}
+	}
+
+	// severity_bands replaces the whale_supply thresholds
+	banded := config.DefaultConfig()
+	banded.SeverityBands = map[string][]config.SeverityBandConfig{
+		"concentration:whale_supply": {{Threshold: 30, Severity: "CRITICAL"}},
+	}
+	configs.Set(banded)
+	if got := j.classifyWhale(25); got != alerts.SeverityOK {
+		t.Errorf("classifyWhale(25) with a 30%% CRITICAL band = %s, want OK", got)
+	}
+}
+
+func TestTopBorrowerChangeResolvesPreviousAlert(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	manager.SetClock(func() time.Time { return now })
+	manager.RegisterPolicy("concentration", "borrow_single", alerts.AlertPolicy{ConsecutiveOKRequired: 2})
+	j := &ConcentrationJob{alertManager: manager, configs: config.NewHolder(config.DefaultConfig())}
+	ctx := context.Background()
+	oldKey := alerts.AlertKey{Job: "concentration", Entity: "0xold", Metric: "borrow_single"}
+
+	j.observeTopBorrower(ctx, "0xold", 45, 100)
+	if state, ok := manager.GetActiveIncidents()[oldKey]; !ok || state.Severity != alerts.SeverityWarning {
+		t.Fatalf("incident = %+v (active %v), want WARNING for the top borrower", state, ok)
+	}
+
+	// Another wallet takes the top spot below the threshold; the old alert needs two OKs
+	for run := 1; run <= 2; run++ {
+		now = now.Add(time.Hour)
+		j.observeTopBorrower(ctx, "0xnew", 30, 100)
+	}
+	if state, ok := manager.GetActiveIncidents()[oldKey]; ok {
+		t.Errorf("previous top borrower still %s after the top spot changed", state.Severity)
+	}
+	if len(j.formerTop) != 1 {
+		t.Fatalf("former top borrowers = %v, want the old one until its alert is seen resolved", j.formerTop)
+	}
+	j.observeTopBorrower(ctx, "0xnew", 30, 100)
+	if len(j.formerTop) != 0 {
+		t.Errorf("former top borrowers = %v after resolving, want none", j.formerTop)
+	}
+}
@@ -0,0 +1,106 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/contract"
+	"github.com/0x0Glitch/internal/retry"
+)
+
+// connect dials the chain through the monitor's ClientFactory until it succeeds,
+// retrying transient failures within a run. While the chain is unreachable each run
+// fails and developers are alerted; once connected, token decimals are verified
+// before the first check and the alert clears. Run and CheckSingle call it first.
+func (m *OracleMonitor) connect(ctx context.Context) error {
+	if m.connected.Load() {
+		return nil
+	}
+	m.connectMu.Lock()
+	defer m.connectMu.Unlock()
+	if m.connected.Load() {
+		return nil
+	}
+
+	var client *ManagedClient
+	err := retry.Do(ctx, maxRetries, retryDelay, func() error {
+		c, err := m.dial(ctx)
+		if err != nil {
+			var mismatch *ChainIDMismatchError
+			if errors.As(err, &mismatch) {
+				return retry.Permanent(err)
+			}
+			return err
+		}
+		client = c
+		return nil
+	})
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("system"), Metric: "rpc_unconnected"}
+	if err == nil {
+		err = m.attach(client)
+	}
+	if err != nil {
+		m.mu.Lock()
+		if m.unconnectedSince.IsZero() {
+			m.unconnectedSince = m.now()
+		}
+		since := m.unconnectedSince
+		m.mu.Unlock()
+
+		details := fmt.Sprintf("Chain: %s\nNot connected since: %s\nError: %v\n\n"+
+			"Oracle prices on this chain are not being checked. The monitor retries every cycle.",
+			m.chain.Name, alerts.FormatTime(since, m.now()), err)
+		var mismatch *ChainIDMismatchError
+		if errors.As(err, &mismatch) {
+			details += "\nThe RPC endpoint serves a different chain; check the chain's RPC URLs."
+		}
+		m.observe(ctx, key, alerts.SeverityCritical, 1, "", details, false, "")
+		return fmt.Errorf("not connected: %w", err)
+	}
+
+	m.verifyDecimals(ctx, m.oracleConfig().VerifyDecimals)
+	m.connected.Store(true)
+
+	m.mu.Lock()
+	m.unconnectedSince = time.Time{}
+	m.mu.Unlock()
+	m.observe(ctx, key, alerts.SeverityOK, 0, "", fmt.Sprintf("Chain: %s\nConnected.", m.chain.Name), false, "")
+	log.Printf("[%s][%s] connected", m.Name(), m.chain.Name)
+	return nil
+}
+
+// attach binds the oracle, multicall, feed and token callers to a newly connected client
+func (m *OracleMonitor) attach(client *ManagedClient) error {
+	oracle, err := contract.NewOracleCaller(common.HexToAddress(m.chain.OracleAddress), client)
+	if err != nil {
+		return fmt.Errorf("failed to create oracle caller: %w", err)
+	}
+	multicall, err := NewMulticallCaller(Multicall3Address, client)
+	if err != nil {
+		return fmt.Errorf("failed to create multicall caller: %w", err)
+	}
+	m.client = client
+	m.oracle = oracle
+	m.multicall = multicall
+	m.feeds = newChainlinkFeeds(m.chain, oracle, client, m.limiter)
+	m.tokens = NewERC20Reader(client)
+	return nil
+}
+
+func registerConnectPolicy(alertManager *alerts.Manager, jobName string) {
+	// Observed every cycle until connected; the value never changes, so only
+	// reminders follow the first page
+	alertManager.RegisterPolicy(jobName, "rpc_unconnected", alerts.AlertPolicy{
+		MinValueChange:        1.0,
+		CooldownWarning:       1 * time.Hour,
+		CooldownCritical:      1 * time.Hour,
+		ReminderInterval:      1 * time.Hour,
+		ConsecutiveOKRequired: 1,
+	})
+}
@@ -0,0 +1,62 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+func TestConnectRetriesUntilReachable(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	manager.SetClock(func() time.Time { return now })
+	chain := ChainConfig{ID: "connect_test", Name: "Connect Test"}
+
+	reachable, dials := false, 0
+	m := &OracleMonitor{
+		chain:        chain,
+		alertManager: manager,
+		clock:        func() time.Time { return now },
+		dial: func(ctx context.Context) (*ManagedClient, error) {
+			dials++
+			if !reachable {
+				// Permanent, so the test does not wait out the retry delay
+				return nil, &ChainIDMismatchError{Chain: chain.Name, Endpoint: "rpc.example", Expected: 1, Actual: 2}
+			}
+			return NewManagedClient(chain, nil), nil
+		},
+	}
+	registerConnectPolicy(manager, m.Name())
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("system"), Metric: "rpc_unconnected"}
+	ctx := context.Background()
+
+	if err := m.connect(ctx); err == nil {
+		t.Fatal("connect succeeded with the RPC down")
+	}
+	if state, ok := manager.GetActiveIncidents()[key]; !ok || state.Severity != alerts.SeverityCritical {
+		t.Fatalf("incident = %+v (active %v), want CRITICAL while unconnected", state, ok)
+	}
+	if _, err := m.CheckSingle(ctx, "usdc"); err == nil {
+		t.Error("CheckSingle succeeded while unconnected")
+	}
+
+	reachable = true
+	now = now.Add(time.Minute)
+	if err := m.connect(ctx); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if m.oracle == nil || m.multicall == nil || m.feeds == nil {
+		t.Error("callers not bound after connecting")
+	}
+	if state, ok := manager.GetActiveIncidents()[key]; ok && state.Severity != alerts.SeverityOK {
+		t.Errorf("incident still %s after connecting", state.Severity)
+	}
+
+	// Connected monitors do not dial again
+	dials = 0
+	if err := m.connect(ctx); err != nil || dials != 0 {
+		t.Errorf("second connect = %v after %d dials, want no dial", err, dials)
+	}
+}
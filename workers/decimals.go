@@ -0,0 +1,90 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/tracing"
+)
+
+// verifyDecimals reads decimals() from each token's underlying ERC-20 contract.
+// Tokens configured with zero decimals are populated from chain. When verify is set,
+// configured values that disagree with the contract are replaced by the onchain value
+// and reported to developers. The monitor calls it once connected, before its first check.
+func (m *OracleMonitor) verifyDecimals(ctx context.Context, verify bool) {
+	symbols := make([]string, 0, len(m.chain.Tokens))
+	for symbol, meta := range m.chain.Tokens {
+		if verify || meta.Decimals == 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+
+	for _, symbol := range symbols {
+		meta := m.chain.Tokens[symbol]
+		onchain, err := m.underlyingDecimals(ctx, meta)
+		if err != nil {
+			log.Printf("[%s][%s] could not read decimals for %s: %v", m.Name(), m.chain.Name, meta.Symbol, err)
+			continue
+		}
+
+		switch {
+		case meta.Decimals == 0:
+			log.Printf("[%s][%s] %s decimals discovered from chain: %d", m.Name(), m.chain.Name, meta.Symbol, onchain)
+		case meta.Decimals != onchain:
+			log.Printf("[%s][%s] %s decimals mismatch: configured %d, contract %d; using contract value",
+				m.Name(), m.chain.Name, meta.Symbol, meta.Decimals, onchain)
+			m.reportDecimalsMismatch(ctx, symbol, meta, onchain)
+		default:
+			continue
+		}
+
+		meta.Decimals = onchain
+		m.chain.Tokens[symbol] = meta
+	}
+}
+
+// underlyingDecimals returns decimals() of the mToken's underlying asset.
+// Native-asset markets have no underlying() and report 18 decimals.
+func (m *OracleMonitor) underlyingDecimals(ctx context.Context, meta TokenMeta) (int, error) {
+	if err := m.limiter.Acquire(ctx); err != nil {
+		return 0, err
+	}
+	defer m.limiter.Release()
+
+	ctx, span := tracing.Start(ctx, "eth_call", tracing.Chain(m.chain.Name), tracing.Symbol(meta.Symbol), tracing.Method("decimals"))
+	defer span.End()
+
+	underlying, err := m.tokens.Underlying(ctx, common.HexToAddress(meta.MTokAddr))
+	if err != nil {
+		if meta.SkipDEXPrice {
+			return 18, nil // native asset market
+		}
+		return 0, err
+	}
+	decimals, err := m.tokens.Decimals(ctx, underlying)
+	if err != nil {
+		return 0, err
+	}
+	return int(decimals), nil
+}
+
+func (m *OracleMonitor) reportDecimalsMismatch(ctx context.Context, symbol string, meta TokenMeta, onchain int) {
+	key := alerts.AlertKey{
+		Job:    m.Name(),
+		Entity: m.entity(symbol),
+		Metric: "decimals_mismatch",
+	}
+	details := fmt.Sprintf(
+		"Chain: %s\nToken: %s\nConfigured decimals: %d\nContract decimals: %d\nUsing the contract value; fix the token configuration.",
+		m.chain.Name, meta.Symbol, meta.Decimals, onchain,
+	)
+	if err := m.observe(ctx, key, alerts.SeverityCritical, float64(onchain), "", details, false, ""); err != nil {
+		log.Printf("[%s][%s] failed to report decimals mismatch: %v", m.Name(), m.chain.Name, err)
+	}
+}
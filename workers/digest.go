@@ -0,0 +1,210 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+// defaultDigestEveryCycles is how often an all-OK cycle report is sent when
+// chains.<id>.digest_every_cycles is unset
+const defaultDigestEveryCycles = 10
+
+// digestMetrics are the per-token alerts the cycle report replaces in "digest" mode
+var digestMetrics = []string{"price_deviation_stable", "price_deviation_volatile", "feed_divergence", "oracle_divergence"}
+
+// digestRow is one token's line in the cycle report
+type digestRow struct {
+	token     string
+	deviation float64
+	severity  string // alerts severity, or "ERROR" when the token could not be read
+	flags     []string
+}
+
+func (r digestRow) ok() bool {
+	return r.severity == string(alerts.SeverityOK)
+}
+
+// reportMode returns the chain's chains.<id>.report_mode, "incident" when unset
+func (m *OracleMonitor) reportMode(cfg *config.Config) string {
+	if cfg == nil || cfg.Chains[string(m.chain.ID)].ReportMode == "" {
+		return "incident"
+	}
+	return cfg.Chains[string(m.chain.ID)].ReportMode
+}
+
+// applyReportMode withholds per-token deviation messages while the chain reports in
+// digest mode. Incident state is still tracked, so switching back to incident mode
+// sends the incidents that are still open.
+func (m *OracleMonitor) applyReportMode(cfg *config.Config) {
+	var metrics []string
+	if m.reportMode(cfg) == "digest" {
+		metrics = digestMetrics
+	}
+	m.alertManager.SetDigestMetrics(m.Name(), metrics)
+}
+
+// digestRowFor summarises a token's cycle result for the report
+func (m *OracleMonitor) digestRowFor(result tokenResult, severity alerts.Severity) digestRow {
+	row := digestRow{token: result.symbol, deviation: result.deviation, severity: string(severity)}
+	if meta, ok := m.chain.Tokens[result.symbol]; ok {
+		row.token = meta.TableName
+		if meta.SkipDEXPrice {
+			row.flags = append(row.flags, "no-dex")
+		}
+		if meta.IsStablecoin && result.reference == referenceDEX {
+			row.flags = append(row.flags, "vs-dex")
+		}
+	}
+	if result.err != nil {
+		row.severity = "ERROR"
+		flag := "read-failed"
+		if result.errClass != RPCErrorUnknown {
+			flag = result.errClass.String()
+		}
+		row.flags = append(row.flags, flag)
+		return row
+	}
+	if result.refRejected {
+		row.flags = append(row.flags, "ref-rejected")
+	} else if result.dexCached {
+		row.flags = append(row.flags, "cached")
+	}
+	if clamp := m.oracleConfig().MaxReportedDeviationPercent; clamp > 0 && result.deviation > clamp {
+		row.flags = append(row.flags, "clamped")
+	}
+	m.mu.Lock()
+	relation := m.feedRelations[result.symbol]
+	m.mu.Unlock()
+	switch relation {
+	case feedAgrees:
+		row.flags = append(row.flags, "feed-off-market")
+	case feedDisagrees:
+		row.flags = append(row.flags, "vs-feed")
+	}
+	return row
+}
+
+// sendDigest sends the cycle report when the chain's report mode includes it: every
+// cycle while any token is not OK, otherwise every digest_every_cycles cycles
+func (m *OracleMonitor) sendDigest(ctx context.Context, rows []digestRow) {
+	if m.configs == nil {
+		return
+	}
+	cfg := m.configs.Get()
+	if mode := m.reportMode(cfg); mode != "digest" && mode != "both" {
+		return
+	}
+	every := cfg.Chains[string(m.chain.ID)].DigestEveryCycles
+	if every <= 0 {
+		every = defaultDigestEveryCycles
+	}
+
+	allOK := true
+	for _, row := range rows {
+		allOK = allOK && row.ok()
+	}
+	m.mu.Lock()
+	m.digestCycles++
+	due := !allOK || m.digestCycles >= every
+	if due {
+		m.digestCycles = 0
+	}
+	m.mu.Unlock()
+	if !due {
+		return
+	}
+
+	// The report goes to the business channel, which a chain in maintenance stays off
+	if m.maintenance.Load() != nil {
+		log.Printf("[%s][%s] in maintenance, cycle report not sent", m.Name(), m.chain.Name)
+		return
+	}
+
+	title := fmt.Sprintf("📋 %s oracle report %s", m.chain.Name, m.now().UTC().Format("15:04 UTC"))
+	body := formatDigest(rows, alerts.TelegramMessageLimit-utf8.RuneCountInString(title)-1)
+	if err := m.alertManager.SendDigest(ctx, m.Name(), title, body); err != nil {
+		log.Printf("[%s][%s] failed to send cycle report: %v", m.Name(), m.chain.Name, err)
+	}
+}
+
+// formatDigest lays rows out as a table of at most limit runes, worst tokens first.
+// When the table is too long OK rows are dropped first, then the least severe others.
+func formatDigest(rows []digestRow, limit int) string {
+	rows = append([]digestRow(nil), rows...)
+	sort.SliceStable(rows, func(i, j int) bool {
+		if ri, rj := digestRank(rows[i]), digestRank(rows[j]); ri != rj {
+			return ri > rj
+		}
+		if rows[i].ok() {
+			return rows[i].token < rows[j].token
+		}
+		return rows[i].deviation > rows[j].deviation
+	})
+
+	width := len("TOKEN")
+	notOK := 0
+	for _, row := range rows {
+		width = max(width, utf8.RuneCountInString(row.token))
+		if !row.ok() {
+			notOK++
+		}
+	}
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		deviation := fmt.Sprintf("%.2f%%", row.deviation)
+		if row.severity == "ERROR" {
+			deviation = "-"
+		}
+		lines[i] = strings.TrimRight(fmt.Sprintf("%-*s %8s %-4s %s", width, row.token, deviation,
+			digestSeverity(row.severity), strings.Join(row.flags, ",")), " ")
+	}
+	header := fmt.Sprintf("%-*s %8s %-4s %s", width, "TOKEN", "DEV", "SEV", "FLAGS")
+	summary := fmt.Sprintf("%d tokens, %d not OK", len(rows), notOK)
+
+	for kept := len(lines); ; kept-- {
+		text := strings.Join(append([]string{summary, header}, lines[:kept]...), "\n")
+		if omitted := len(lines) - kept; omitted > 0 {
+			if kept >= notOK {
+				text += fmt.Sprintf("\n… %d OK tokens omitted", omitted)
+			} else {
+				text += fmt.Sprintf("\n… %d OK and %d other tokens omitted", len(lines)-notOK, notOK-kept)
+			}
+		}
+		if utf8.RuneCountInString(text) <= limit || kept == 0 {
+			return text
+		}
+	}
+}
+
+// digestRank orders report rows: errors, then CRITICAL, WARNING and OK
+func digestRank(row digestRow) int {
+	if row.severity == "ERROR" {
+		return 3
+	}
+	switch alerts.Severity(row.severity) {
+	case alerts.SeverityCritical:
+		return 2
+	case alerts.SeverityWarning:
+		return 1
+	}
+	return 0
+}
+
+func digestSeverity(severity string) string {
+	switch severity {
+	case string(alerts.SeverityCritical):
+		return "CRIT"
+	case string(alerts.SeverityWarning):
+		return "WARN"
+	case "ERROR":
+		return "ERR"
+	}
+	return severity
+}
@@ -0,0 +1,34 @@
+package workers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFormatDigestTruncatesOKRowsFirst(t *testing.T) {
+	rows := []digestRow{
+		{token: "xcKSM", deviation: 6.2, severity: "CRITICAL", flags: []string{"vs-feed"}},
+		{token: "FRAX", severity: "ERROR", flags: []string{"rate-limited"}},
+	}
+	for i := 0; i < 40; i++ {
+		rows = append(rows, digestRow{token: fmt.Sprintf("TOKEN%02d", i), deviation: 0.1, severity: "OK"})
+	}
+
+	full := formatDigest(rows, 10000)
+	if lines := strings.Split(full, "\n"); len(lines) != 2+len(rows) || !strings.HasPrefix(lines[2], "FRAX") || !strings.HasPrefix(lines[3], "xcKSM") {
+		t.Fatalf("untruncated digest:\n%s", full)
+	}
+
+	short := formatDigest(rows, 300)
+	if utf8.RuneCountInString(short) > 300 {
+		t.Errorf("digest is %d runes, want at most 300", utf8.RuneCountInString(short))
+	}
+	if !strings.Contains(short, "xcKSM") || !strings.Contains(short, "6.20%") || !strings.Contains(short, "CRIT vs-feed") {
+		t.Errorf("truncated digest lost the CRITICAL row:\n%s", short)
+	}
+	if !strings.Contains(short, "OK tokens omitted") || !strings.HasPrefix(short, "42 tokens, 2 not OK") {
+		t.Errorf("truncated digest lacks its summary or omission note:\n%s", short)
+	}
+}
@@ -0,0 +1,181 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ERC20MetaData is the subset of the ERC-20 ABI read by the workers, plus the mToken's
+// underlying() used to find a market's asset
+var ERC20MetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"underlying\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// AggregatorMetaData is the subset of the Chainlink aggregator ABI used to read feed prices
+var AggregatorMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"latestRoundData\",\"outputs\":[{\"internalType\":\"uint80\",\"name\":\"roundId\",\"type\":\"uint80\"},{\"internalType\":\"int256\",\"name\":\"answer\",\"type\":\"int256\"},{\"internalType\":\"uint256\",\"name\":\"startedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"updatedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint80\",\"name\":\"answeredInRound\",\"type\":\"uint80\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// boundContracts binds one ABI to any number of addresses, reusing each binding
+type boundContracts struct {
+	meta   *bind.MetaData
+	client bind.ContractCaller
+
+	mu    sync.Mutex
+	bound map[common.Address]*bind.BoundContract
+}
+
+func newBoundContracts(meta *bind.MetaData, client bind.ContractCaller) *boundContracts {
+	return &boundContracts{meta: meta, client: client, bound: make(map[common.Address]*bind.BoundContract)}
+}
+
+// call invokes the view method on address and returns its outputs
+func (b *boundContracts) call(ctx context.Context, address common.Address, method string) ([]interface{}, error) {
+	b.mu.Lock()
+	contract, ok := b.bound[address]
+	if !ok {
+		parsed, err := b.meta.GetAbi()
+		if err != nil {
+			b.mu.Unlock()
+			return nil, err
+		}
+		contract = bind.NewBoundContract(address, *parsed, b.client, nil, nil)
+		b.bound[address] = contract
+	}
+	b.mu.Unlock()
+
+	var out []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &out, method); err != nil {
+		return nil, fmt.Errorf("%s(): %w", method, err)
+	}
+	return out, nil
+}
+
+// ERC20Reader reads ERC-20 token metadata. Decimals, symbols and underlying assets
+// never change, so each is read once per address.
+type ERC20Reader struct {
+	contracts *boundContracts
+
+	mu         sync.Mutex
+	decimals   map[common.Address]uint8
+	symbols    map[common.Address]string
+	underlying map[common.Address]common.Address
+}
+
+// NewERC20Reader creates a reader calling token contracts through client
+func NewERC20Reader(client bind.ContractCaller) *ERC20Reader {
+	return &ERC20Reader{
+		contracts:  newBoundContracts(ERC20MetaData, client),
+		decimals:   make(map[common.Address]uint8),
+		symbols:    make(map[common.Address]string),
+		underlying: make(map[common.Address]common.Address),
+	}
+}
+
+// Decimals returns token's decimals()
+func (r *ERC20Reader) Decimals(ctx context.Context, token common.Address) (uint8, error) {
+	return cachedCall(ctx, r.contracts, &r.mu, r.decimals, token, "decimals")
+}
+
+// Symbol returns token's symbol()
+func (r *ERC20Reader) Symbol(ctx context.Context, token common.Address) (string, error) {
+	return cachedCall(ctx, r.contracts, &r.mu, r.symbols, token, "symbol")
+}
+
+// Underlying returns the underlying() asset of an mToken
+func (r *ERC20Reader) Underlying(ctx context.Context, mToken common.Address) (common.Address, error) {
+	return cachedCall(ctx, r.contracts, &r.mu, r.underlying, mToken, "underlying")
+}
+
+// cachedCall returns the single output of method on address from cache, guarded by mu,
+// calling the contract on a miss
+func cachedCall[T any](ctx context.Context, contracts *boundContracts, mu *sync.Mutex, cache map[common.Address]T, address common.Address, method string) (T, error) {
+	mu.Lock()
+	value, ok := cache[address]
+	mu.Unlock()
+	if ok {
+		return value, nil
+	}
+
+	out, err := contracts.call(ctx, address, method)
+	if err != nil {
+		return value, err
+	}
+	value, ok = out[0].(T)
+	if !ok {
+		return value, fmt.Errorf("%s(): unexpected type %T", method, out[0])
+	}
+	mu.Lock()
+	cache[address] = value
+	mu.Unlock()
+	return value, nil
+}
+
+// RoundData is an aggregator's latestRoundData answer
+type RoundData struct {
+	RoundID         *big.Int
+	Answer          *big.Int
+	StartedAt       time.Time
+	UpdatedAt       time.Time // zero when the feed reports none
+	AnsweredInRound *big.Int
+}
+
+// Price scales the answer by the feed's decimals
+func (d RoundData) Price(decimals uint8) float64 {
+	value, _ := new(big.Float).Quo(new(big.Float).SetInt(d.Answer),
+		new(big.Float).SetFloat64(math.Pow(10, float64(decimals)))).Float64()
+	return value
+}
+
+// AggregatorReader reads Chainlink AggregatorV3 feeds, reading each feed's decimals once
+type AggregatorReader struct {
+	contracts *boundContracts
+
+	mu       sync.Mutex
+	decimals map[common.Address]uint8
+}
+
+// NewAggregatorReader creates a reader calling feed contracts through client
+func NewAggregatorReader(client bind.ContractCaller) *AggregatorReader {
+	return &AggregatorReader{
+		contracts: newBoundContracts(AggregatorMetaData, client),
+		decimals:  make(map[common.Address]uint8),
+	}
+}
+
+// Decimals returns feed's decimals()
+func (r *AggregatorReader) Decimals(ctx context.Context, feed common.Address) (uint8, error) {
+	return cachedCall(ctx, r.contracts, &r.mu, r.decimals, feed, "decimals")
+}
+
+// LatestRoundData returns feed's latest round. Answers that are not positive are errors.
+func (r *AggregatorReader) LatestRoundData(ctx context.Context, feed common.Address) (RoundData, error) {
+	out, err := r.contracts.call(ctx, feed, "latestRoundData")
+	if err != nil {
+		return RoundData{}, err
+	}
+	if len(out) != 5 {
+		return RoundData{}, fmt.Errorf("latestRoundData(): %d outputs, want 5", len(out))
+	}
+	data := RoundData{}
+	data.RoundID, _ = out[0].(*big.Int)
+	data.Answer, _ = out[1].(*big.Int)
+	data.AnsweredInRound, _ = out[4].(*big.Int)
+	if data.Answer == nil || data.Answer.Sign() <= 0 {
+		return RoundData{}, fmt.Errorf("latestRoundData returned no positive answer")
+	}
+	if startedAt, ok := out[2].(*big.Int); ok {
+		data.StartedAt = unixTime(startedAt.Int64())
+	}
+	if updatedAt, ok := out[3].(*big.Int); ok {
+		data.UpdatedAt = unixTime(updatedAt.Int64())
+	}
+	return data, nil
+}
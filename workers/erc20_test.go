@@ -0,0 +1,132 @@
+package workers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// abiCaller answers view calls from canned outputs per method and counts the calls
+type abiCaller struct {
+	meta    *bind.MetaData
+	outputs map[string][]interface{}
+	calls   map[string]int
+}
+
+func (c *abiCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *abiCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	parsed, err := c.meta.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	for name, method := range parsed.Methods {
+		if bytes.Equal(call.Data[:4], method.ID) {
+			c.calls[name]++
+			outputs, ok := c.outputs[name]
+			if !ok {
+				return nil, fmt.Errorf("execution reverted")
+			}
+			return method.Outputs.Pack(outputs...)
+		}
+	}
+	return nil, fmt.Errorf("unknown method")
+}
+
+func TestERC20ReaderCachesMetadata(t *testing.T) {
+	underlying := common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+	caller := &abiCaller{
+		meta: ERC20MetaData,
+		outputs: map[string][]interface{}{
+			"underlying": {underlying},
+			"decimals":   {uint8(6)},
+			"symbol":     {"USDC"},
+		},
+		calls: make(map[string]int),
+	}
+	reader := NewERC20Reader(caller)
+	ctx := context.Background()
+	mToken := common.HexToAddress("0xEdc817A28E8B93B03976FBd4a3dDBc9f7D176c22")
+
+	for i := 0; i < 2; i++ {
+		got, err := reader.Underlying(ctx, mToken)
+		if err != nil || got != underlying {
+			t.Fatalf("Underlying() = %v, %v; want %v", got, err, underlying)
+		}
+		decimals, err := reader.Decimals(ctx, underlying)
+		if err != nil || decimals != 6 {
+			t.Fatalf("Decimals() = %d, %v; want 6", decimals, err)
+		}
+		symbol, err := reader.Symbol(ctx, underlying)
+		if err != nil || symbol != "USDC" {
+			t.Fatalf("Symbol() = %q, %v; want USDC", symbol, err)
+		}
+	}
+	for _, method := range []string{"underlying", "decimals", "symbol"} {
+		if caller.calls[method] != 1 {
+			t.Errorf("%s called %d times, want once", method, caller.calls[method])
+		}
+	}
+
+	// Failed reads are not cached
+	delete(caller.outputs, "decimals")
+	other := common.HexToAddress("0x4200000000000000000000000000000000000006")
+	for i := 0; i < 2; i++ {
+		if _, err := reader.Decimals(ctx, other); err == nil {
+			t.Fatal("Decimals() of a reverting token succeeded")
+		}
+	}
+	if caller.calls["decimals"] != 3 {
+		t.Errorf("decimals called %d times, want each failed read retried", caller.calls["decimals"])
+	}
+}
+
+func TestAggregatorReaderLatestRoundData(t *testing.T) {
+	updatedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	caller := &abiCaller{
+		meta: AggregatorMetaData,
+		outputs: map[string][]interface{}{
+			"decimals": {uint8(8)},
+			"latestRoundData": {big.NewInt(7), big.NewInt(312055000000), big.NewInt(updatedAt.Unix() - 60),
+				big.NewInt(updatedAt.Unix()), big.NewInt(7)},
+		},
+		calls: make(map[string]int),
+	}
+	reader := NewAggregatorReader(caller)
+	ctx := context.Background()
+	feed := common.HexToAddress("0x71041dddad3595F9CEd3DcCFBe3D1F4b0a16Bb70")
+
+	decimals, err := reader.Decimals(ctx, feed)
+	if err != nil || decimals != 8 {
+		t.Fatalf("Decimals() = %d, %v; want 8", decimals, err)
+	}
+	reader.Decimals(ctx, feed)
+	if caller.calls["decimals"] != 1 {
+		t.Errorf("decimals called %d times, want once", caller.calls["decimals"])
+	}
+
+	round, err := reader.LatestRoundData(ctx, feed)
+	if err != nil {
+		t.Fatalf("LatestRoundData: %v", err)
+	}
+	if price := round.Price(decimals); price != 3120.55 {
+		t.Errorf("price = %v, want 3120.55", price)
+	}
+	if !round.UpdatedAt.Equal(updatedAt) || !round.StartedAt.Equal(updatedAt.Add(-time.Minute)) || round.RoundID.Int64() != 7 {
+		t.Errorf("round = %+v, want round 7 started a minute before %v", round, updatedAt)
+	}
+
+	caller.outputs["latestRoundData"][1] = big.NewInt(0)
+	if _, err := reader.LatestRoundData(ctx, feed); err == nil {
+		t.Error("LatestRoundData with a zero answer succeeded")
+	}
+}
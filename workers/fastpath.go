@@ -0,0 +1,76 @@
+package workers
+
+import (
+	"math"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+// fastPathState remembers the last readings for a token so an unchanged onchain
+// price can reuse the previous DEX reference instead of calling the price API
+type fastPathState struct {
+	onchainPrice float64
+	dex          ReferencePrice
+	lastOK       bool
+	skipped      int
+}
+
+// cachedDexPrice returns the previous DEX price when the onchain price has moved
+// less than the configured epsilon, the last deviation was OK and the skip budget
+// has not been exhausted
+func (m *OracleMonitor) cachedDexPrice(symbol string, onchainPrice float64) (ReferencePrice, bool) {
+	cfg := m.oracleConfig()
+	if cfg.DEXFastPath.MaxSkippedCycles <= 0 {
+		return ReferencePrice{}, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.fastPath[symbol]
+	if !ok || !state.lastOK || state.dex.Value <= 0 || state.onchainPrice <= 0 {
+		return ReferencePrice{}, false
+	}
+	if state.skipped >= cfg.DEXFastPath.MaxSkippedCycles {
+		return ReferencePrice{}, false
+	}
+
+	change := math.Abs((onchainPrice-state.onchainPrice)/state.onchainPrice) * 100
+	if change > cfg.DEXFastPath.MinOnchainChangePercent {
+		return ReferencePrice{}, false
+	}
+
+	state.skipped++
+	return state.dex, true
+}
+
+// recordFastPath stores the latest readings for a token after classification
+func (m *OracleMonitor) recordFastPath(result tokenResult, severity alerts.Severity) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.fastPath == nil {
+		m.fastPath = make(map[string]*fastPathState)
+	}
+
+	state, ok := m.fastPath[result.symbol]
+	if !ok {
+		state = &fastPathState{}
+		m.fastPath[result.symbol] = state
+	}
+
+	state.lastOK = severity == alerts.SeverityOK
+	if !result.dexCached {
+		// Fresh DEX read: becomes the new baseline and resets the skip budget
+		state.onchainPrice = result.onchainPrice
+		state.dex = ReferencePrice{Value: result.dexPrice, Currency: "usd", UpdatedAt: result.dexUpdatedAt}
+		state.skipped = 0
+	}
+}
+
+// resetFastPath forgets cached readings for a token, forcing a fresh DEX read
+func (m *OracleMonitor) resetFastPath(symbol string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.fastPath, symbol)
+}
@@ -0,0 +1,208 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/contract"
+	"github.com/0x0Glitch/tracing"
+)
+
+// feedAddressTTL is how long a token's feed address from getFeed is reused. Feed
+// replacements are reported separately by FeedCheckJob.
+const feedAddressTTL = time.Hour
+
+// Relationship between a deviating oracle price and its own feed
+type feedRelation int
+
+const (
+	feedUnknown   feedRelation = iota // feed not read or unreadable
+	feedAgrees                        // oracle tracks its feed; both are away from the market
+	feedDisagrees                     // oracle differs from its own feed
+)
+
+// metric returns the alert metric for a deviation with this feed relationship
+func (r feedRelation) metric(meta TokenMeta) string {
+	switch r {
+	case feedAgrees:
+		return "feed_divergence"
+	case feedDisagrees:
+		return "oracle_divergence"
+	}
+	return deviationMetric(meta)
+}
+
+// compareFeed classifies the oracle price against its feed price. Prices within
+// agreementPercent of each other agree.
+func compareFeed(onchain, feed, agreementPercent float64) feedRelation {
+	if feed <= 0 || onchain <= 0 {
+		return feedUnknown
+	}
+	if math.Abs(onchain-feed)/feed*100 <= agreementPercent {
+		return feedAgrees
+	}
+	return feedDisagrees
+}
+
+// FeedPrice is a Chainlink feed's latest answer
+type FeedPrice struct {
+	Value     float64
+	UpdatedAt time.Time
+}
+
+// feedReader reads the price of a token's oracle feed
+type feedReader interface {
+	FeedPrice(ctx context.Context, key string, meta TokenMeta) (FeedPrice, error)
+}
+
+// chainlinkFeeds reads feed prices through the oracle's getFeed and the aggregator's
+// latestRoundData, caching feed addresses and decimals
+type chainlinkFeeds struct {
+	chain       ChainConfig
+	oracle      *contract.OracleCaller
+	aggregators *AggregatorReader
+	limiter     *Limiter
+
+	mu    sync.Mutex
+	feeds map[string]cachedFeed // by token key
+}
+
+type cachedFeed struct {
+	address   common.Address
+	decimals  uint8
+	fetchedAt time.Time
+}
+
+func newChainlinkFeeds(chain ChainConfig, oracle *contract.OracleCaller, client bind.ContractCaller, limiter *Limiter) *chainlinkFeeds {
+	return &chainlinkFeeds{chain: chain, oracle: oracle, aggregators: NewAggregatorReader(client), limiter: limiter, feeds: make(map[string]cachedFeed)}
+}
+
+func (f *chainlinkFeeds) FeedPrice(ctx context.Context, key string, meta TokenMeta) (FeedPrice, error) {
+	feed, err := f.feed(ctx, key, meta)
+	if err != nil {
+		return FeedPrice{}, err
+	}
+
+	if err := f.limiter.Acquire(ctx); err != nil {
+		return FeedPrice{}, err
+	}
+	defer f.limiter.Release()
+
+	ctx, span := tracing.Start(ctx, "eth_call", tracing.Chain(f.chain.Name), tracing.Symbol(meta.Symbol), tracing.Method("latestRoundData"))
+	round, err := f.aggregators.LatestRoundData(ctx, feed.address)
+	tracing.End(span, err)
+	if err != nil {
+		return FeedPrice{}, err
+	}
+	return FeedPrice{Value: round.Price(feed.decimals), UpdatedAt: round.UpdatedAt}, nil
+}
+
+// feed returns the token's feed address and decimals, looking them up when not cached
+func (f *chainlinkFeeds) feed(ctx context.Context, key string, meta TokenMeta) (cachedFeed, error) {
+	f.mu.Lock()
+	cached, ok := f.feeds[key]
+	f.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < feedAddressTTL {
+		return cached, nil
+	}
+
+	if err := f.limiter.Acquire(ctx); err != nil {
+		return cachedFeed{}, err
+	}
+	defer f.limiter.Release()
+
+	address, err := f.oracle.GetFeed(&bind.CallOpts{Context: ctx}, feedSymbol(meta))
+	if err != nil {
+		return cachedFeed{}, fmt.Errorf("getFeed: %w", err)
+	}
+	if address == (common.Address{}) {
+		return cachedFeed{}, fmt.Errorf("no feed registered for %s", feedSymbol(meta))
+	}
+	decimals, err := f.aggregators.Decimals(ctx, address)
+	if err != nil {
+		return cachedFeed{}, fmt.Errorf("feed decimals: %w", err)
+	}
+
+	cached = cachedFeed{address: address, decimals: decimals, fetchedAt: time.Now()}
+	f.mu.Lock()
+	f.feeds[key] = cached
+	f.mu.Unlock()
+	return cached, nil
+}
+
+// compareWithFeed reads the feed of a deviating volatile token and records how the
+// oracle price relates to it. A failed read keeps the token's last relationship so the
+// incident stays under the same metric. Stablecoins are compared with their peg, not
+// the market, so they keep the plain deviation metric.
+func (m *OracleMonitor) compareWithFeed(ctx context.Context, result *tokenResult, meta TokenMeta, severity alerts.Severity) {
+	cfg := m.oracleConfig().FeedComparison
+	if !cfg.Enabled || meta.IsStablecoin || m.feeds == nil {
+		return
+	}
+	if severity == alerts.SeverityOK {
+		m.mu.Lock()
+		delete(m.feedRelations, result.symbol)
+		m.mu.Unlock()
+		return
+	}
+
+	price, err := m.feeds.FeedPrice(ctx, result.symbol, meta)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.feedRelations == nil {
+		m.feedRelations = make(map[string]feedRelation)
+	}
+	if err != nil {
+		result.feedErr = err
+		result.feedRelation = m.feedRelations[result.symbol]
+		return
+	}
+	result.feedPrice = price
+	result.feedRelation = compareFeed(result.onchainPrice, price.Value, cfg.AgreementPercent)
+	m.feedRelations[result.symbol] = result.feedRelation
+}
+
+// formatFeedLine describes the feed reading behind a deviation alert
+func (m *OracleMonitor) formatFeedLine(result tokenResult) string {
+	switch {
+	case result.feedErr != nil:
+		return fmt.Sprintf("Feed: unavailable (%v)", result.feedErr)
+	case result.feedRelation == feedAgrees:
+		return fmt.Sprintf("Feed: $%.6f, agrees with the oracle (updated %s)\nThe feed itself is off market; this is a feed problem, not an oracle admin problem.",
+			result.feedPrice.Value, alerts.FormatTime(result.feedPrice.UpdatedAt, m.now()))
+	case result.feedRelation == feedDisagrees:
+		return fmt.Sprintf("Feed: $%.6f, disagrees with the oracle (updated %s)\nThe oracle is not reporting its own feed's price.",
+			result.feedPrice.Value, alerts.FormatTime(result.feedPrice.UpdatedAt, m.now()))
+	}
+	return ""
+}
+
+// otherDeviationMetrics lists the deviation metrics not used for relation, so their
+// incidents can be cleared when a token's feed relationship changes
+func otherDeviationMetrics(relation feedRelation, meta TokenMeta) []string {
+	var others []string
+	for _, r := range []feedRelation{feedUnknown, feedAgrees, feedDisagrees} {
+		if r != relation {
+			others = append(others, r.metric(meta))
+		}
+	}
+	return others
+}
+
+// slackTitle is the Slack headline for a volatile deviation with this feed relationship
+func (r feedRelation) slackTitle() string {
+	switch r {
+	case feedAgrees:
+		return "ALERT: ORACLE FEED DIVERGES FROM MARKET"
+	case feedDisagrees:
+		return "ALERT: ORACLE DISAGREES WITH ITS FEED"
+	}
+	return "ALERT: ORACLE PRICE DEVIATION"
+}
@@ -0,0 +1,92 @@
+package workers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+type fakeFeeds struct {
+	price FeedPrice
+	err   error
+}
+
+func (f *fakeFeeds) FeedPrice(context.Context, string, TokenMeta) (FeedPrice, error) {
+	return f.price, f.err
+}
+
+func TestFeedComparisonRoutesDeviation(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		onchain    float64
+		feed       float64
+		wantMetric string
+		wantLine   string
+	}{
+		// Oracle tracks a feed that is itself off market
+		{"feed agrees, both off market", 3300, 3301, "feed_divergence", "agrees with the oracle"},
+		// Feed tracks the market but the oracle does not
+		{"feed disagrees", 3300, 3500, "oracle_divergence", "disagrees with the oracle"},
+		// Oracle, feed and market agree: no feed-specific incident
+		{"no deviation", 3500, 3500, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Oracle.FeedComparison.Enabled = true
+			manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+			manager.SetClock(func() time.Time { return now })
+			meta := TokenMeta{Symbol: "WETH", TableName: "WETH", Decimals: 18}
+			m := &OracleMonitor{
+				chain:        ChainConfig{ID: "feed_test", Name: "Feed Test", Tokens: map[string]TokenMeta{"weth": meta}},
+				alertManager: manager,
+				configs:      config.NewHolder(cfg),
+				clock:        func() time.Time { return now },
+				feeds:        &fakeFeeds{price: FeedPrice{Value: tt.feed, UpdatedAt: now.Add(-time.Hour)}},
+			}
+			RegisterOraclePolicies(manager, &cfg.Oracle, string(m.chain.ID))
+
+			dex := 3500.0
+			deviation := (dex - tt.onchain) / dex * 100
+			m.processTokenResult(context.Background(), tokenResult{symbol: "weth", onchainPrice: tt.onchain, dexPrice: dex, deviation: deviation}, false)
+
+			active := manager.GetActiveIncidents()
+			for _, metric := range []string{"price_deviation_volatile", "feed_divergence", "oracle_divergence"} {
+				state, ok := active[alerts.AlertKey{Job: m.Name(), Entity: m.entity("WETH"), Metric: metric}]
+				if ok != (metric == tt.wantMetric) {
+					t.Errorf("%s incident active = %v, want only %q", metric, ok, tt.wantMetric)
+				}
+				if ok && !strings.Contains(state.LastMessage, tt.wantLine) {
+					t.Errorf("message %q does not mention %q", state.LastMessage, tt.wantLine)
+				}
+			}
+		})
+	}
+}
+
+func TestFeedComparisonKeepsRelationOnReadFailure(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Oracle.FeedComparison.Enabled = true
+	feeds := &fakeFeeds{price: FeedPrice{Value: 3300}}
+	m := &OracleMonitor{configs: config.NewHolder(cfg), feeds: feeds}
+	meta := TokenMeta{Symbol: "WETH"}
+
+	result := tokenResult{symbol: "weth", onchainPrice: 3300}
+	m.compareWithFeed(context.Background(), &result, meta, alerts.SeverityCritical)
+	if result.feedRelation != feedAgrees {
+		t.Fatalf("relation = %v, want feedAgrees", result.feedRelation)
+	}
+
+	feeds.err = context.DeadlineExceeded
+	result = tokenResult{symbol: "weth", onchainPrice: 3300}
+	m.compareWithFeed(context.Background(), &result, meta, alerts.SeverityCritical)
+	if result.feedRelation != feedAgrees || result.feedErr == nil {
+		t.Errorf("relation = %v (err %v), want the previous feedAgrees and the read error", result.feedRelation, result.feedErr)
+	}
+}
@@ -0,0 +1,193 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/contract"
+	"github.com/0x0Glitch/state"
+	"github.com/0x0Glitch/tracing"
+)
+
+// FeedCheckJob verifies that every token has a price feed registered in the oracle
+// and detects feeds replaced since the last check. A missing feed otherwise shows
+// up as confusing zero-price deviations.
+type FeedCheckJob struct {
+	chain        ChainConfig
+	oracle       *contract.OracleCaller
+	alertManager *alerts.Manager
+	configs      *config.Holder
+	store        *state.Store
+	limiter      *Limiter
+}
+
+// NewFeedCheckJob creates the feed verification job for a chain. Last-known feeds
+// are kept in store so replacements are detected across restarts.
+func NewFeedCheckJob(
+	chain ChainConfig,
+	client *ManagedClient,
+	alertManager *alerts.Manager,
+	configs *config.Holder,
+	store *state.Store,
+	limiter *Limiter,
+) (*FeedCheckJob, error) {
+	oracle, err := contract.NewOracleCaller(common.HexToAddress(chain.OracleAddress), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oracle caller: %w", err)
+	}
+
+	job := &FeedCheckJob{
+		chain:        chain,
+		oracle:       oracle,
+		alertManager: alertManager,
+		configs:      configs,
+		store:        store,
+		limiter:      limiter,
+	}
+
+	alertManager.RegisterDefaultPolicy(job.Name(), "oracle_feeds", alerts.AlertPolicy{
+		MinValueChange:        1.0, // one more or fewer affected token
+		CooldownWarning:       24 * time.Hour,
+		CooldownCritical:      24 * time.Hour,
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: 1,
+	})
+
+	return job, nil
+}
+
+func (j *FeedCheckJob) Name() string {
+	return fmt.Sprintf("feeds_%s", j.chain.ID)
+}
+
+func (j *FeedCheckJob) ChainID() string {
+	return string(j.chain.ID)
+}
+
+func (j *FeedCheckJob) Interval() time.Duration {
+	if d := j.configs.Get().Oracle.FeedCheckInterval(); d > 0 {
+		return d
+	}
+	return 24 * time.Hour
+}
+
+// stateKey is the state store key holding the chain's last-known feeds
+func (j *FeedCheckJob) stateKey() string {
+	return "oracle_feeds:" + string(j.chain.ID)
+}
+
+// feedSymbol returns the symbol the oracle's getFeed expects for meta
+func feedSymbol(meta TokenMeta) string {
+	if meta.FeedSymbol != "" {
+		return meta.FeedSymbol
+	}
+	return meta.Symbol
+}
+
+func (j *FeedCheckJob) Run(ctx context.Context) error {
+	cfg := j.configs.Get()
+
+	known := make(map[string]string)
+	if _, err := j.store.Get(j.stateKey(), &known); err != nil {
+		log.Printf("[%s][%s] ignoring unreadable stored feeds: %v", j.Name(), j.chain.Name, err)
+		known = make(map[string]string)
+	}
+
+	keys := make([]string, 0, len(j.chain.Tokens))
+	for key := range j.chain.Tokens {
+		if !cfg.Oracle.TokenDisabled(string(j.chain.ID), key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	current := make(map[string]string, len(known))
+	for key, feed := range known {
+		current[key] = feed
+	}
+
+	var missing, changed, failed []string
+	for _, key := range keys {
+		meta := j.chain.Tokens[key]
+		symbol := feedSymbol(meta)
+
+		feed, err := j.getFeed(ctx, symbol)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", meta.Symbol, err))
+			continue
+		}
+
+		address := feed.Hex()
+		if feed == (common.Address{}) {
+			missing = append(missing, fmt.Sprintf("%s (getFeed(%q) is the zero address)", meta.Symbol, symbol))
+		} else if previous, ok := known[key]; ok && previous != "" && !strings.EqualFold(previous, address) {
+			changed = append(changed, fmt.Sprintf("%s: %s → %s", meta.Symbol, previous, address))
+		}
+		current[key] = address
+	}
+
+	if len(failed) == len(keys) && len(keys) > 0 {
+		return fmt.Errorf("getFeed failed for every token: %s", failed[0])
+	}
+	for _, f := range failed {
+		log.Printf("[%s][%s] feed lookup failed: %s", j.Name(), j.chain.Name, f)
+	}
+
+	if err := j.store.Set(j.stateKey(), current); err != nil {
+		log.Printf("[%s][%s] failed to store feeds: %v", j.Name(), j.chain.Name, err)
+	}
+
+	j.report(ctx, len(keys), missing, changed)
+	return nil
+}
+
+func (j *FeedCheckJob) getFeed(ctx context.Context, symbol string) (common.Address, error) {
+	if err := j.limiter.Acquire(ctx); err != nil {
+		return common.Address{}, err
+	}
+	defer j.limiter.Release()
+
+	ctx, span := tracing.Start(ctx, "eth_call", tracing.Chain(j.chain.Name), tracing.Symbol(symbol), tracing.Method("getFeed"))
+	feed, err := j.oracle.GetFeed(&bind.CallOpts{Context: ctx}, symbol)
+	tracing.End(span, err)
+	return feed, err
+}
+
+// report sends developers the list of tokens without a feed or with a replaced feed
+func (j *FeedCheckJob) report(ctx context.Context, checked int, missing, changed []string) {
+	severity := alerts.SeverityOK
+	if len(missing) > 0 || len(changed) > 0 {
+		severity = alerts.SeverityWarning
+		log.Printf("[%s][%s] %d tokens without a feed, %d feeds changed", j.Name(), j.chain.Name, len(missing), len(changed))
+	} else {
+		log.Printf("[%s][%s] all %d tokens have a registered feed", j.Name(), j.chain.Name, checked)
+	}
+
+	var details strings.Builder
+	fmt.Fprintf(&details, "Chain: %s\nOracle: %s\nTokens checked: %d", j.chain.Name, j.chain.OracleAddress, checked)
+	if len(missing) > 0 {
+		fmt.Fprintf(&details, "\n\nNo feed registered:\n- %s", strings.Join(missing, "\n- "))
+	}
+	if len(changed) > 0 {
+		fmt.Fprintf(&details, "\n\nFeed changed since last check:\n- %s", strings.Join(changed, "\n- "))
+	}
+
+	key := alerts.AlertKey{
+		Job:    j.Name(),
+		Entity: alerts.ChainEntity(string(j.chain.ID), "oracle"),
+		Metric: "oracle_feeds",
+	}
+	value := float64(len(missing) + len(changed))
+	if err := j.alertManager.Observe(ctx, key, severity, value, "", details.String(), false, ""); err != nil {
+		log.Printf("[%s][%s] failed to observe feed check: %v", j.Name(), j.chain.Name, err)
+	}
+}
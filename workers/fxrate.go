@@ -0,0 +1,116 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/internal/httpclient"
+)
+
+// FXRates serves the USD value of fiat currencies for stablecoins with a PegCurrency,
+// caching each rate for oracle.fx.refresh_minutes. It is shared by all chains.
+type FXRates struct {
+	configs *config.Holder
+	client  *http.Client
+	clock   func() time.Time // nil means time.Now
+
+	mu    sync.Mutex
+	rates map[string]fxRate // by uppercase currency code
+}
+
+type fxRate struct {
+	value     float64
+	fetchedAt time.Time
+}
+
+// NewFXRates creates an exchange rate cache reading oracle.fx from configs
+func NewFXRates(configs *config.Holder) *FXRates {
+	return &FXRates{
+		configs: configs,
+		client:  httpclient.New(httpTimeout),
+		rates:   make(map[string]fxRate),
+	}
+}
+
+// SetClock replaces time.Now for cache expiry
+func (f *FXRates) SetClock(clock func() time.Time) {
+	f.clock = clock
+}
+
+func (f *FXRates) now() time.Time {
+	if f.clock == nil {
+		return time.Now()
+	}
+	return f.clock()
+}
+
+// USDRate returns the USD value of one unit of currency. Callers fall back to the
+// static peg on error.
+func (f *FXRates) USDRate(ctx context.Context, currency string) (float64, error) {
+	currency = strings.ToUpper(currency)
+	if currency == "USD" {
+		return 1, nil
+	}
+	cfg := f.configs.Get().Oracle.FX
+	if cfg.URL == "" {
+		return 0, fmt.Errorf("no oracle.fx.url configured")
+	}
+
+	// Held across the fetch so concurrent token checks share one request
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if rate, ok := f.rates[currency]; ok && f.now().Sub(rate.fetchedAt) < cfg.Refresh() {
+		return rate.value, nil
+	}
+
+	value, err := f.fetch(ctx, cfg.URL, currency)
+	if err != nil {
+		return 0, fmt.Errorf("%s/USD rate: %w", currency, err)
+	}
+	f.rates[currency] = fxRate{value: value, fetchedAt: f.now()}
+	return value, nil
+}
+
+// fetch reads the latest rate from a Frankfurter-compatible API
+func (f *FXRates) fetch(ctx context.Context, baseURL, currency string) (float64, error) {
+	endpoint := fmt.Sprintf("%s/latest?from=%s&to=USD", strings.TrimRight(baseURL, "/"), url.QueryEscape(currency))
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return 0, fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Amount float64            `json:"amount"`
+		Rates  map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, err
+	}
+	rate := result.Rates["USD"]
+	if result.Amount > 0 {
+		rate /= result.Amount
+	}
+	if rate <= 0 {
+		return 0, fmt.Errorf("no USD rate in response")
+	}
+	return rate, nil
+}
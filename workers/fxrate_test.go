@@ -0,0 +1,74 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/config"
+)
+
+func TestFXRatesCachesAndRefreshes(t *testing.T) {
+	var requests atomic.Int32
+	rate := 1.08
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/latest" || r.URL.Query().Get("from") != "EUR" || r.URL.Query().Get("to") != "USD" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"amount":1.0,"base":"EUR","date":"2026-03-01","rates":{"USD":%g}}`, rate)
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.Oracle.FX.URL = server.URL
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fx := NewFXRates(config.NewHolder(cfg))
+	fx.SetClock(func() time.Time { return now })
+	ctx := context.Background()
+
+	if got, err := fx.USDRate(ctx, "eur"); err != nil || got != 1.08 {
+		t.Fatalf("USDRate = %v, %v; want 1.08", got, err)
+	}
+	rate = 1.10
+	if got, _ := fx.USDRate(ctx, "EUR"); got != 1.08 || requests.Load() != 1 {
+		t.Errorf("cached USDRate = %v after %d requests, want 1.08 from one request", got, requests.Load())
+	}
+
+	now = now.Add(cfg.Oracle.FX.Refresh())
+	if got, _ := fx.USDRate(ctx, "EUR"); got != 1.10 {
+		t.Errorf("USDRate after refresh = %v, want 1.10", got)
+	}
+	if got, err := fx.USDRate(ctx, "USD"); err != nil || got != 1 || requests.Load() != 2 {
+		t.Errorf("USDRate(USD) = %v, %v after %d requests, want 1 without a request", got, err, requests.Load())
+	}
+}
+
+func TestPegFallsBackToStaticValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.Oracle.FX.URL = server.URL
+	m := &OracleMonitor{chain: ChainConfig{ID: "fx_test", Name: "FX Test"}}
+	m.SetFXRates(NewFXRates(config.NewHolder(cfg)))
+	eurc := TokenMeta{Symbol: "EURC", IsStablecoin: true, PegValue: 1.16, PegCurrency: "EUR"}
+
+	peg, live := m.pegValue(context.Background(), eurc)
+	if peg != 1.16 || live {
+		t.Errorf("pegValue = %v (live %v), want the static 1.16", peg, live)
+	}
+	if got := formatPeg(tokenResult{peg: peg, livePeg: live}, eurc); got != "$1.16" {
+		t.Errorf("formatPeg = %q, want $1.16", got)
+	}
+	if got := formatPeg(tokenResult{peg: 1.0842, livePeg: true}, eurc); got != "$1.0842 (live EUR/USD)" {
+		t.Errorf("formatPeg = %q, want the live rate", got)
+	}
+}
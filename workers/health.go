@@ -10,6 +10,8 @@ import (
 	_ "github.com/lib/pq"
 
 	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/tracing"
 )
 
 const (
@@ -26,13 +28,15 @@ type userPosition struct {
 
 // HealthJobV2 implements health factor monitoring with stateful alerting
 type HealthJobV2 struct {
-	db            *sql.DB
-	alertManager  *alerts.Manager
-	lastDataCheck time.Time
+	db                *sql.DB
+	alertManager      *alerts.Manager
+	configs           *config.Holder
+	lastDataCheck     time.Time
+	stalenessSeverity alerts.Severity // last freshness severity, for hysteresis
 }
 
 // NewHealthJobV2 creates a new health factor monitoring job
-func NewHealthJobV2(databaseURL string, alertManager *alerts.Manager) (*HealthJobV2, error) {
+func NewHealthJobV2(databaseURL string, alertManager *alerts.Manager, configs *config.Holder) (*HealthJobV2, error) {
 	if databaseURL == "" {
 		return nil, fmt.Errorf("database URL not configured")
 	}
@@ -49,7 +53,7 @@ func NewHealthJobV2(databaseURL string, alertManager *alerts.Manager) (*HealthJo
 
 	// Register policies for health factor alerts
 	// No reminders for business alerts - only new incidents, escalations, and critical updates
-	alertManager.RegisterPolicy("health_factor", "position_risk", alerts.AlertPolicy{
+	alertManager.RegisterDefaultPolicy("health_factor", "position_risk", alerts.AlertPolicy{
 		MinValueChange:        0.05, // HF change of 0.05
 		CooldownWarning:       30 * time.Minute,
 		CooldownCritical:      10 * time.Minute,
@@ -58,7 +62,7 @@ func NewHealthJobV2(databaseURL string, alertManager *alerts.Manager) (*HealthJo
 		ConsecutiveOKRequired: 2,
 	})
 
-	alertManager.RegisterPolicy("health_factor", "data_staleness", alerts.AlertPolicy{
+	alertManager.RegisterDefaultPolicy("health_factor", "data_staleness", alerts.AlertPolicy{
 		MinValueChange:        60.0, // 60 minutes change
 		CooldownWarning:       1 * time.Hour,
 		CooldownCritical:      30 * time.Minute,
@@ -67,9 +71,11 @@ func NewHealthJobV2(databaseURL string, alertManager *alerts.Manager) (*HealthJo
 	})
 
 	return &HealthJobV2{
-		db:            db,
-		alertManager:  alertManager,
-		lastDataCheck: time.Now(),
+		db:                db,
+		alertManager:      alertManager,
+		configs:           configs,
+		lastDataCheck:     time.Now(),
+		stalenessSeverity: alerts.SeverityOK,
 	}, nil
 }
 
@@ -115,12 +121,15 @@ func (j *HealthJobV2) checkDataFreshness(ctx context.Context) error {
 	var lastUpdate time.Time
 	query := `SELECT MAX(last_updated) FROM public."UserPositions"`
 
-	err := j.db.QueryRowContext(ctx, query).Scan(&lastUpdate)
+	queryCtx, span := tracing.Start(ctx, "db.query", tracing.Job(j.Name()), tracing.Query("data_freshness"))
+	err := j.db.QueryRowContext(queryCtx, query).Scan(&lastUpdate)
+	tracing.End(span, err)
 	if err != nil {
 		return err
 	}
 
-	timeSinceUpdate := time.Since(lastUpdate)
+	now := time.Now()
+	timeSinceUpdate := now.Sub(lastUpdate)
 
 	key := alerts.AlertKey{
 		Job:    j.Name(),
@@ -128,20 +137,13 @@ func (j *HealthJobV2) checkDataFreshness(ctx context.Context) error {
 		Metric: "data_staleness",
 	}
 
-	var severity alerts.Severity
-	switch {
-	case timeSinceUpdate > 10*time.Hour:
-		severity = alerts.SeverityCritical
-	case timeSinceUpdate > 5*time.Hour:
-		severity = alerts.SeverityWarning
-	default:
-		severity = alerts.SeverityOK
-	}
+	severity := freshnessSeverity(timeSinceUpdate, j.stalenessSeverity, j.configs.Get().HealthFactor.DataFreshness)
+	j.stalenessSeverity = severity
 
 	summary := "UserPositions data freshness"
 	details := fmt.Sprintf(
 		"Last update: %s\nAge: %.1f hours",
-		lastUpdate.Format("2006-01-02 15:04:05 UTC"),
+		alerts.FormatTime(lastUpdate, now),
 		timeSinceUpdate.Hours(),
 	)
 
@@ -152,6 +154,30 @@ func (j *HealthJobV2) checkDataFreshness(ctx context.Context) error {
 	return nil
 }
 
+// freshnessSeverity classifies the data age. With hysteresis, a severity that is
+// already raised is kept until the age drops below its threshold scaled by
+// cfg.ClearFraction rather than clearing the moment the age dips under it.
+func freshnessSeverity(age time.Duration, previous alerts.Severity, cfg config.FreshnessConfig) alerts.Severity {
+	clearBelow := func(threshold time.Duration) time.Duration {
+		return time.Duration(float64(threshold) * cfg.ClearFraction)
+	}
+	critical := cfg.CriticalAgeHours.Duration()
+	warning := cfg.WarningAgeHours.Duration()
+
+	switch {
+	case age > critical:
+		return alerts.SeverityCritical
+	case previous == alerts.SeverityCritical && age > clearBelow(critical):
+		return alerts.SeverityCritical
+	case age > warning:
+		return alerts.SeverityWarning
+	case previous != alerts.SeverityOK && age > clearBelow(warning):
+		return alerts.SeverityWarning
+	default:
+		return alerts.SeverityOK
+	}
+}
+
 func (j *HealthJobV2) getRiskyPositions(ctx context.Context) ([]userPosition, error) {
 	query := `
 		SELECT 
@@ -166,7 +192,9 @@ func (j *HealthJobV2) getRiskyPositions(ctx context.Context) ([]userPosition, er
 		LIMIT $2
 	`
 
-	rows, err := j.db.QueryContext(ctx, query, healthFactorThreshold, queryLimit)
+	queryCtx, span := tracing.Start(ctx, "db.query", tracing.Job(j.Name()), tracing.Query("risky_positions"))
+	rows, err := j.db.QueryContext(queryCtx, query, healthFactorThreshold, queryLimit)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -3,40 +3,83 @@ package workers
 import (
 	"context"
 	"database/sql"
+	"expvar"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	_ "github.com/lib/pq"
 
 	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/tracing"
 )
 
+// aggregateHealthFactors exposes the latest protocol-wide health factor averages
+var aggregateHealthFactors = expvar.NewMap("health_aggregate_hf")
+
+// maxAggregateHF caps each position's health factor, and each average, so positions
+// with negligible debt do not swamp the averages
+const maxAggregateHF = 100.0
+
 // HealthAggregateJob monitors systemic health factor metrics
 type HealthAggregateJob struct {
-	db                  *sql.DB
-	alertManager        *alerts.Manager
-	lastAvgHealthFactor float64
-	lastRiskyCountCheck time.Time
-	last24hRiskyCount   int
-	last24hCheckTime    time.Time
-	last24hTotalSupply  float64
-	last24hTotalBorrow  float64
-	last24hSupplyTime   time.Time // Separate timestamp for supply tracking
-	last24hBorrowTime   time.Time // Separate timestamp for borrow tracking
+	db                 *sql.DB
+	alertManager       *alerts.Manager
+	configs            *config.Holder
+	clock              func() time.Time
+	avgHFBaseline      float64   // avg_hf_drop.metric at the start of the avg_hf_drop window
+	avgHFBaselineTime  time.Time // 0 HF until the first cycle
+	avgHFMetric        string    // metric the baseline was taken from
+	last24hRiskyCount  int
+	last24hCheckTime   time.Time
+	last24hTotalSupply float64
+	last24hTotalBorrow float64
+	last24hSupplyTime  time.Time // Separate timestamp for supply tracking
+	last24hBorrowTime  time.Time // Separate timestamp for borrow tracking
+	lastCollateralUSD  float64   // Last healthy cycle totals for collapse detection
+	lastBorrowUSD      float64
+
+	mu             sync.Mutex // guards the fields below; the checks run concurrently
+	breaches       map[string]aggregateBreach
+	collecting     bool // observations are held in pending until finishCycle
+	pending        []pendingObservation
+	cycleStart     time.Time // breaches evaluated before this are from earlier cycles
+	prevCycleStart time.Time
 }
 
 type aggregateMetrics struct {
-	TotalPositions     int
-	RiskyPositions     int
-	AvgHealthFactor    float64
-	WeightedAvgHF      float64
-	TotalCollateralUSD float64
-	TotalBorrowUSD     float64
+	TotalPositions          int
+	RiskyPositions          int
+	AvgHealthFactor         float64
+	WeightedAvgHF           float64 // borrow-weighted
+	CollateralWeightedAvgHF float64
+	MedianHF                float64
+	TotalCollateralUSD      float64
+	TotalBorrowUSD          float64
+}
+
+// avgHF returns the average named by one of config.AvgHFMetrics
+func (m *aggregateMetrics) avgHF(metric string) float64 {
+	switch metric {
+	case "collateral_weighted":
+		return m.CollateralWeightedAvgHF
+	case "median":
+		return m.MedianHF
+	}
+	return m.WeightedAvgHF
+}
+
+// avgHFLabels names config.AvgHFMetrics in logs and alert details
+var avgHFLabels = map[string]string{
+	"borrow_weighted":     "Borrow-weighted avg HF",
+	"collateral_weighted": "Collateral-weighted avg HF",
+	"median":              "Median HF",
 }
 
 // NewHealthAggregateJob creates a new aggregate health monitoring job
-func NewHealthAggregateJob(databaseURL string, alertManager *alerts.Manager) (*HealthAggregateJob, error) {
+func NewHealthAggregateJob(databaseURL string, alertManager *alerts.Manager, configs *config.Holder) (*HealthAggregateJob, error) {
 	if databaseURL == "" {
 		return nil, fmt.Errorf("database URL not configured")
 	}
@@ -52,7 +95,7 @@ func NewHealthAggregateJob(databaseURL string, alertManager *alerts.Manager) (*H
 	}
 
 	// Register policies for aggregate health alerts
-	alertManager.RegisterPolicy("health_aggregate", "risky_count_spike", alerts.AlertPolicy{
+	alertManager.RegisterDefaultPolicy("health_aggregate", "risky_count_spike", alerts.AlertPolicy{
 		MinValueChange:        5.0, // 5% change in risky count
 		CooldownWarning:       1 * time.Hour,
 		CooldownCritical:      30 * time.Minute,
@@ -61,16 +104,7 @@ func NewHealthAggregateJob(databaseURL string, alertManager *alerts.Manager) (*H
 		ConsecutiveOKRequired: 2,
 	})
 
-	alertManager.RegisterPolicy("health_aggregate", "avg_hf_drop", alerts.AlertPolicy{
-		MinValueChange:        0.02, // 0.02 HF change
-		CooldownWarning:       30 * time.Minute,
-		CooldownCritical:      15 * time.Minute,
-		ReminderInterval:      2 * time.Hour,
-		TriggerThreshold:      0.1, // 0.1 HF drop
-		ConsecutiveOKRequired: 2,
-	})
-
-	alertManager.RegisterPolicy("health_aggregate", "withdrawal_spike", alerts.AlertPolicy{
+	alertManager.RegisterDefaultPolicy("health_aggregate", "withdrawal_spike", alerts.AlertPolicy{
 		MinValueChange:        2.0, // 2% change
 		CooldownWarning:       1 * time.Hour,
 		CooldownCritical:      30 * time.Minute,
@@ -79,7 +113,7 @@ func NewHealthAggregateJob(databaseURL string, alertManager *alerts.Manager) (*H
 		ConsecutiveOKRequired: 2,
 	})
 
-	alertManager.RegisterPolicy("health_aggregate", "borrow_spike", alerts.AlertPolicy{
+	alertManager.RegisterDefaultPolicy("health_aggregate", "borrow_spike", alerts.AlertPolicy{
 		MinValueChange:        2.0, // 2% change
 		CooldownWarning:       1 * time.Hour,
 		CooldownCritical:      30 * time.Minute,
@@ -88,15 +122,34 @@ func NewHealthAggregateJob(databaseURL string, alertManager *alerts.Manager) (*H
 		ConsecutiveOKRequired: 2,
 	})
 
-	now := time.Now()
-	return &HealthAggregateJob{
-		db:                  db,
-		alertManager:        alertManager,
-		lastRiskyCountCheck: now,
-		last24hCheckTime:    now.Add(-24 * time.Hour),
-		last24hSupplyTime:   now.Add(-24 * time.Hour),
-		last24hBorrowTime:   now.Add(-24 * time.Hour),
-	}, nil
+	j := &HealthAggregateJob{
+		db:           db,
+		alertManager: alertManager,
+		configs:      configs,
+	}
+	j.registerPolicies(configs.Get().HealthFactor)
+	j.startWindows()
+	return j, nil
+}
+
+// SetClock replaces time.Now for the checks' windows and restarts the 24h windows on it
+func (j *HealthAggregateJob) SetClock(clock func() time.Time) {
+	j.clock = clock
+	j.startWindows()
+}
+
+// startWindows opens the 24h windows a day before the job clock's time, so the first
+// cycle takes their baselines
+func (j *HealthAggregateJob) startWindows() {
+	start := j.now().Add(-24 * time.Hour)
+	j.last24hCheckTime, j.last24hSupplyTime, j.last24hBorrowTime = start, start, start
+}
+
+func (j *HealthAggregateJob) now() time.Time {
+	if j.clock == nil {
+		return time.Now()
+	}
+	return j.clock()
 }
 
 func (j *HealthAggregateJob) Name() string {
@@ -107,29 +160,83 @@ func (j *HealthAggregateJob) Interval() time.Duration {
 	return 5 * time.Minute
 }
 
+func (j *HealthAggregateJob) Reload(cfg *config.Config) error {
+	j.registerPolicies(cfg.HealthFactor)
+	return nil
+}
+
+// registerPolicies registers the policies that follow health_factor settings
+func (j *HealthAggregateJob) registerPolicies(cfg config.HealthFactorConfig) {
+	hfDrop := cfg.AvgHFDrop
+	j.alertManager.RegisterPolicy(j.Name(), "avg_hf_drop", alerts.AlertPolicy{
+		MinValueChange:        hfDrop.MinValueChange,
+		CooldownWarning:       hfDrop.CooldownWarning(),
+		CooldownCritical:      hfDrop.CooldownCritical(),
+		ReminderInterval:      2 * time.Hour,
+		TriggerThreshold:      hfDrop.WarningThreshold,
+		ConsecutiveOKRequired: hfDrop.ConsecutiveOKRequired,
+	})
+
+	collapse := cfg.TotalsCollapse
+	for _, metric := range []string{"collateral_collapse", "borrow_collapse"} {
+		j.alertManager.RegisterPolicy(j.Name(), metric, alerts.AlertPolicy{
+			MinValueChange:        10.0, // 10% change in drop
+			CooldownWarning:       collapse.CooldownCritical(),
+			CooldownCritical:      collapse.CooldownCritical(),
+			ReminderInterval:      1 * time.Hour,
+			TriggerThreshold:      collapse.DropFraction * 100,
+			ConsecutiveOKRequired: 2,
+		})
+	}
+
+	stress := cfg.StressEvent
+	j.alertManager.RegisterPolicy(j.Name(), "protocol_stress", alerts.AlertPolicy{
+		MinValueChange:        1.0, // one more metric breaching
+		CooldownWarning:       stress.CooldownCritical(),
+		CooldownCritical:      stress.CooldownCritical(),
+		ReminderInterval:      1 * time.Hour,
+		TriggerThreshold:      float64(len(stress.Metrics)),
+		ConsecutiveOKRequired: 2,
+	})
+}
+
 func (j *HealthAggregateJob) Run(ctx context.Context) error {
 	metrics, err := j.getAggregateMetrics(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get aggregate metrics: %w", err)
 	}
 
-	// Check 1: Risky position count spike (>25% increase in 24hrs)
-	j.checkRiskyCountSpike(ctx, metrics)
-
-	// Check 2: Average HF drop (>0.1 drop within 1hr)
-	j.checkAvgHealthFactorDrop(ctx, metrics)
-
-	// Check 3: Withdrawal spike (>10% decrease in supply over 24hrs)
-	j.checkWithdrawalSpike(ctx, metrics)
+	// The checks share the metrics and each keeps its own baseline, so they run
+	// independently, up to db_subcheck_concurrency at a time
+	check := func(name string, fn func(context.Context, *aggregateMetrics)) subCheck {
+		return subCheck{name, func(ctx context.Context) error {
+			fn(ctx, metrics)
+			return nil
+		}}
+	}
+	j.startCycle()
+	err = runSubChecks(ctx, j.configs.Get().DBSubCheckConcurrency,
+		// Risky position count spike (>25% increase in 24hrs)
+		check("risky count spike", j.checkRiskyCountSpike),
+		// Average HF drop over health_factor.avg_hf_drop.check_interval_hours
+		check("avg HF drop", j.checkAvgHealthFactorDrop),
+		// Withdrawal spike (>10% decrease in supply over 24hrs)
+		check("withdrawal spike", j.checkWithdrawalSpike),
+		// Borrow spike (>10% increase in borrows over 24hrs)
+		check("borrow spike", j.checkBorrowSpike),
+		// Totals collapsing toward zero within one cycle (data corruption)
+		check("totals collapse", j.checkTotalsCollapse),
+	)
 
-	// Check 4: Borrow spike (>10% increase in borrows over 24hrs)
-	j.checkBorrowSpike(ctx, metrics)
+	// Related metrics breaching together become one protocol stress event
+	j.finishCycle(ctx)
 
-	log.Printf("[%s] risky positions: %d/%d, weighted avg HF: %.4f, supply: $%s, borrow: $%s",
-		j.Name(), metrics.RiskyPositions, metrics.TotalPositions, metrics.WeightedAvgHF,
+	log.Printf("[%s] risky positions: %d/%d, HF borrow-weighted: %.4f, collateral-weighted: %.4f, median: %.4f, supply: $%s, borrow: $%s",
+		j.Name(), metrics.RiskyPositions, metrics.TotalPositions,
+		metrics.WeightedAvgHF, metrics.CollateralWeightedAvgHF, metrics.MedianHF,
 		formatUSD(metrics.TotalCollateralUSD), formatUSD(metrics.TotalBorrowUSD))
 
-	return nil
+	return err
 }
 
 func (j *HealthAggregateJob) getAggregateMetrics(ctx context.Context) (*aggregateMetrics, error) {
@@ -139,21 +246,27 @@ func (j *HealthAggregateJob) getAggregateMetrics(ctx context.Context) (*aggregat
 			COUNT(*) FILTER (WHERE health_factor > 0 AND health_factor < 1.2) as risky_positions,
 			COALESCE(SUM(total_supplied), 0) as total_collateral,
 			COALESCE(SUM(total_borrowed), 0) as total_borrow,
-			COALESCE(SUM(LEAST(health_factor, 100) * total_borrowed), 0) as weighted_hf_sum
+			COALESCE(SUM(LEAST(health_factor, $1) * total_borrowed), 0) as borrow_weighted_hf_sum,
+			COALESCE(SUM(LEAST(health_factor, $1) * total_supplied), 0) as collateral_weighted_hf_sum,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY LEAST(health_factor, $1)) as median_hf
 		FROM public."UserPositions"
 		WHERE health_factor > 0 AND health_factor < 1000
 	`
 
 	var metrics aggregateMetrics
-	var totalCollateral, totalBorrow, weightedHFSum sql.NullFloat64
+	var totalCollateral, totalBorrow, borrowWeightedSum, collateralWeightedSum, median sql.NullFloat64
 
-	err := j.db.QueryRowContext(ctx, query).Scan(
+	queryCtx, span := tracing.Start(ctx, "db.query", tracing.Job(j.Name()), tracing.Query("aggregate_health"))
+	err := j.db.QueryRowContext(queryCtx, query, maxAggregateHF).Scan(
 		&metrics.TotalPositions,
 		&metrics.RiskyPositions,
 		&totalCollateral,
 		&totalBorrow,
-		&weightedHFSum,
+		&borrowWeightedSum,
+		&collateralWeightedSum,
+		&median,
 	)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -161,24 +274,36 @@ func (j *HealthAggregateJob) getAggregateMetrics(ctx context.Context) (*aggregat
 	metrics.TotalCollateralUSD = totalCollateral.Float64
 	metrics.TotalBorrowUSD = totalBorrow.Float64
 
-	// Calculate weighted average health factor (borrow-weighted)
-	// Weighted Avg HF = Σ(HF_i × Borrow_i) / Total_Borrow
-	// This weights users with more debt more heavily, which is appropriate for risk assessment
-	if metrics.TotalBorrowUSD > 0 && weightedHFSum.Valid {
-		metrics.WeightedAvgHF = weightedHFSum.Float64 / metrics.TotalBorrowUSD
-		// Cap the weighted average HF to a reasonable value
-		if metrics.WeightedAvgHF > 100 {
-			metrics.WeightedAvgHF = 100.0
-		}
-	} else {
-		metrics.WeightedAvgHF = 999.0 // No borrows = use large value (no risk)
+	// Borrow-weighted Avg HF = Σ(HF_i × Borrow_i) / Total_Borrow weights users with more
+	// debt more heavily; a few large loopers can dominate it, so the collateral-weighted
+	// average and the median are computed alongside
+	metrics.WeightedAvgHF = weightedAvgHF(borrowWeightedSum, metrics.TotalBorrowUSD)
+	metrics.CollateralWeightedAvgHF = weightedAvgHF(collateralWeightedSum, metrics.TotalCollateralUSD)
+	metrics.MedianHF = 999.0 // No positions = no risk
+	if median.Valid {
+		metrics.MedianHF = min(median.Float64, maxAggregateHF)
+	}
+
+	for metric := range avgHFLabels {
+		value := new(expvar.Float)
+		value.Set(metrics.avgHF(metric))
+		aggregateHealthFactors.Set(metric, value)
 	}
 
 	return &metrics, nil
 }
 
+// weightedAvgHF divides a sum of capped HF × weight by the total weight, capping the
+// result. No weight (e.g. no borrows) means no risk and returns a large value.
+func weightedAvgHF(sum sql.NullFloat64, total float64) float64 {
+	if total <= 0 || !sum.Valid {
+		return 999.0
+	}
+	return min(sum.Float64/total, maxAggregateHF)
+}
+
 func (j *HealthAggregateJob) checkRiskyCountSpike(ctx context.Context, metrics *aggregateMetrics) {
-	now := time.Now()
+	now := j.now()
 
 	// Check if 24 hours have passed since we stored the baseline
 	if now.Sub(j.last24hCheckTime) >= 24*time.Hour {
@@ -196,17 +321,8 @@ func (j *HealthAggregateJob) checkRiskyCountSpike(ctx context.Context, metrics *
 			Metric: "risky_count_spike",
 		}
 
-		var severity alerts.Severity
-		switch {
-		case percentIncrease >= 50:
-			severity = alerts.SeverityCritical
-		case percentIncrease >= 25:
-			severity = alerts.SeverityWarning
-		default:
-			severity = alerts.SeverityOK
-		}
+		severity := classify(percentIncrease, configuredBands(j.configs, j.Name(), "risky_count_spike", warningCritical(25, 50)))
 
-		summary := ""
 		details := fmt.Sprintf(
 			"Risky positions (HF < 1.2): %d (24h ago: %d)\nChange: %.1f%%\nTotal positions: %d",
 			metrics.RiskyPositions,
@@ -215,9 +331,7 @@ func (j *HealthAggregateJob) checkRiskyCountSpike(ctx context.Context, metrics *
 			metrics.TotalPositions,
 		)
 
-		if err := j.alertManager.Observe(ctx, key, severity, percentIncrease, summary, details, true, ""); err != nil {
-			log.Printf("[%s] failed to observe risky count spike: %v", j.Name(), err)
-		}
+		j.observeMetric(ctx, key, severity, percentIncrease, details, 24*time.Hour)
 
 		// Update baseline for next 24h check
 		j.last24hRiskyCount = metrics.RiskyPositions
@@ -225,56 +339,58 @@ func (j *HealthAggregateJob) checkRiskyCountSpike(ctx context.Context, metrics *
 	}
 }
 
+// checkAvgHealthFactorDrop compares the average HF named by avg_hf_drop.metric with its
+// value at the start of the window, then starts a new window from the current value.
+// Changing the metric restarts the window.
 func (j *HealthAggregateJob) checkAvgHealthFactorDrop(ctx context.Context, metrics *aggregateMetrics) {
-	now := time.Now()
-	timeSinceLastCheck := now.Sub(j.lastRiskyCountCheck)
+	now := j.now()
+	cfg := j.configs.Get().HealthFactor.AvgHFDrop
+	current := metrics.avgHF(cfg.Metric)
 
-	// Only check if at least 1 hour has passed and we have a previous value
-	if timeSinceLastCheck >= 1*time.Hour && j.lastAvgHealthFactor > 0 {
-		hfDrop := j.lastAvgHealthFactor - metrics.WeightedAvgHF
+	if j.avgHFBaseline <= 0 || j.avgHFMetric != cfg.Metric {
+		j.avgHFBaseline, j.avgHFBaselineTime, j.avgHFMetric = current, now, cfg.Metric
+		return
+	}
+	if now.Sub(j.avgHFBaselineTime) < cfg.CheckIntervalHours.Duration() {
+		return
+	}
 
-		key := alerts.AlertKey{
-			Job:    j.Name(),
-			Entity: "protocol",
-			Metric: "avg_hf_drop",
-		}
+	hfDrop := j.avgHFBaseline - current
 
-		var severity alerts.Severity
-		switch {
-		case hfDrop >= 0.2:
-			severity = alerts.SeverityCritical
-		case hfDrop >= 0.1:
-			severity = alerts.SeverityWarning
-		case hfDrop >= 0.05:
-			severity = alerts.SeverityWarning
-		default:
-			severity = alerts.SeverityOK
-		}
+	key := alerts.AlertKey{
+		Job:    j.Name(),
+		Entity: "protocol",
+		Metric: "avg_hf_drop",
+	}
 
-		summary := ""
-		details := fmt.Sprintf(
-			"Weighted Avg HF: %.4f (1h ago: %.4f)\nDrop: %.4f\nTotal Collateral: $%s\nTotal Borrow: $%s",
-			metrics.WeightedAvgHF,
-			j.lastAvgHealthFactor,
-			hfDrop,
-			formatUSD(metrics.TotalCollateralUSD),
-			formatUSD(metrics.TotalBorrowUSD),
-		)
+	severity := classify(hfDrop, configuredBands(j.configs, j.Name(), "avg_hf_drop", warningCritical(cfg.WarningThreshold, cfg.CriticalThreshold)))
 
-		if err := j.alertManager.Observe(ctx, key, severity, hfDrop, summary, details, true, ""); err != nil {
-			log.Printf("[%s] failed to observe avg HF drop: %v", j.Name(), err)
+	details := fmt.Sprintf(
+		"%s: %.4f (%s: %.4f)\nDrop: %.4f\n",
+		avgHFLabels[cfg.Metric],
+		current,
+		alerts.FormatAge(j.avgHFBaselineTime, now),
+		j.avgHFBaseline,
+		hfDrop,
+	)
+	for _, metric := range config.AvgHFMetrics {
+		if metric != cfg.Metric {
+			details += fmt.Sprintf("%s: %.4f\n", avgHFLabels[metric], metrics.avgHF(metric))
 		}
-
-		// Update for next check
-		j.lastRiskyCountCheck = now
 	}
+	details += fmt.Sprintf(
+		"Total Collateral: $%s\nTotal Borrow: $%s",
+		formatUSD(metrics.TotalCollateralUSD),
+		formatUSD(metrics.TotalBorrowUSD),
+	)
+
+	j.observeMetric(ctx, key, severity, hfDrop, details, cfg.CheckIntervalHours.Duration())
 
-	// Always update last value
-	j.lastAvgHealthFactor = metrics.WeightedAvgHF
+	j.avgHFBaseline, j.avgHFBaselineTime = current, now
 }
 
 func (j *HealthAggregateJob) checkWithdrawalSpike(ctx context.Context, metrics *aggregateMetrics) {
-	now := time.Now()
+	now := j.now()
 
 	// Check if 24 hours have passed since baseline
 	if now.Sub(j.last24hSupplyTime) >= 24*time.Hour && j.last24hTotalSupply > 0 {
@@ -291,17 +407,8 @@ func (j *HealthAggregateJob) checkWithdrawalSpike(ctx context.Context, metrics *
 			Metric: "withdrawal_spike",
 		}
 
-		var severity alerts.Severity
-		switch {
-		case percentDecrease >= 20:
-			severity = alerts.SeverityCritical
-		case percentDecrease >= 10:
-			severity = alerts.SeverityWarning
-		default:
-			severity = alerts.SeverityOK
-		}
+		severity := classify(percentDecrease, configuredBands(j.configs, j.Name(), "withdrawal_spike", warningCritical(10, 20)))
 
-		summary := ""
 		details := fmt.Sprintf(
 			"Supply Change: %.2f%% (24h)\nCurrent Supply: $%s\n24h ago: $%s\nChange: $%s",
 			percentChange,
@@ -310,9 +417,7 @@ func (j *HealthAggregateJob) checkWithdrawalSpike(ctx context.Context, metrics *
 			formatUSD(change),
 		)
 
-		if err := j.alertManager.Observe(ctx, key, severity, percentDecrease, summary, details, true, ""); err != nil {
-			log.Printf("[%s] failed to observe withdrawal spike: %v", j.Name(), err)
-		}
+		j.observeMetric(ctx, key, severity, percentDecrease, details, 24*time.Hour)
 
 		// Update baseline
 		j.last24hTotalSupply = metrics.TotalCollateralUSD
@@ -325,7 +430,7 @@ func (j *HealthAggregateJob) checkWithdrawalSpike(ctx context.Context, metrics *
 }
 
 func (j *HealthAggregateJob) checkBorrowSpike(ctx context.Context, metrics *aggregateMetrics) {
-	now := time.Now()
+	now := j.now()
 
 	// Check if 24 hours have passed since baseline
 	if now.Sub(j.last24hBorrowTime) >= 24*time.Hour && j.last24hTotalBorrow > 0 {
@@ -339,17 +444,8 @@ func (j *HealthAggregateJob) checkBorrowSpike(ctx context.Context, metrics *aggr
 			Metric: "borrow_spike",
 		}
 
-		var severity alerts.Severity
-		switch {
-		case percentChange >= 20:
-			severity = alerts.SeverityCritical
-		case percentChange >= 10:
-			severity = alerts.SeverityWarning
-		default:
-			severity = alerts.SeverityOK
-		}
+		severity := classify(percentChange, configuredBands(j.configs, j.Name(), "borrow_spike", warningCritical(10, 20)))
 
-		summary := ""
 		details := fmt.Sprintf(
 			"Borrow Change: %.2f%% (24h)\nCurrent Borrow: $%s\n24h ago: $%s\nChange: $%s",
 			percentChange,
@@ -358,9 +454,7 @@ func (j *HealthAggregateJob) checkBorrowSpike(ctx context.Context, metrics *aggr
 			formatUSD(change),
 		)
 
-		if err := j.alertManager.Observe(ctx, key, severity, percentChange, summary, details, true, ""); err != nil {
-			log.Printf("[%s] failed to observe borrow spike: %v", j.Name(), err)
-		}
+		j.observeMetric(ctx, key, severity, percentChange, details, 24*time.Hour)
 
 		// Update baseline
 		j.last24hTotalBorrow = metrics.TotalBorrowUSD
@@ -372,6 +466,54 @@ func (j *HealthAggregateJob) checkBorrowSpike(ctx context.Context, metrics *aggr
 	}
 }
 
+func (j *HealthAggregateJob) checkTotalsCollapse(ctx context.Context, metrics *aggregateMetrics) {
+	threshold := j.configs.Get().HealthFactor.TotalsCollapse.DropFraction
+
+	j.lastCollateralUSD = j.observeCollapse(ctx, "collateral_collapse", "Total Collateral",
+		j.lastCollateralUSD, metrics.TotalCollateralUSD, threshold)
+	j.lastBorrowUSD = j.observeCollapse(ctx, "borrow_collapse", "Total Borrow",
+		j.lastBorrowUSD, metrics.TotalBorrowUSD, threshold)
+}
+
+// observeCollapse alerts developers when current has dropped from previous by at least
+// threshold in one cycle. It returns the baseline for the next cycle, which is held at
+// the last healthy value while collapsed so the alert persists until the data recovers.
+func (j *HealthAggregateJob) observeCollapse(ctx context.Context, metric, label string, previous, current, threshold float64) float64 {
+	if previous <= 0 {
+		return current
+	}
+
+	drop := (previous - current) / previous
+	severity := alerts.SeverityOK
+	if drop >= threshold {
+		severity = alerts.SeverityCritical
+	}
+
+	key := alerts.AlertKey{
+		Job:    j.Name(),
+		Entity: "protocol",
+		Metric: metric,
+	}
+
+	summary := ""
+	details := fmt.Sprintf(
+		"%s: $%s (last cycle: $%s)\nDrop: %.1f%% in one cycle\nLikely a data pipeline issue - check UserPositions",
+		label,
+		formatUSD(current),
+		formatUSD(previous),
+		drop*100,
+	)
+
+	if err := j.alertManager.Observe(ctx, key, severity, drop*100, summary, details, false, ""); err != nil {
+		log.Printf("[%s] failed to observe %s: %v", j.Name(), metric, err)
+	}
+
+	if severity == alerts.SeverityCritical {
+		return previous
+	}
+	return current
+}
+
 func (j *HealthAggregateJob) Close() error {
 	if j.db != nil {
 		return j.db.Close()
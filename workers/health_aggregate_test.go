@@ -0,0 +1,192 @@
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestAvgHealthFactorDropWindow(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.DefaultConfig()
+	cfg.HealthFactor.AvgHFDrop.WarningThreshold = 0.1
+	cfg.HealthFactor.AvgHFDrop.CriticalThreshold = 0.3
+	cfg.HealthFactor.AvgHFDrop.CheckIntervalHours = config.Hours(time.Hour)
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	j := &HealthAggregateJob{alertManager: manager, configs: config.NewHolder(cfg)}
+	j.SetClock(func() time.Time { return now })
+	key := alerts.AlertKey{Job: "health_aggregate", Entity: "protocol", Metric: "avg_hf_drop"}
+	ctx := context.Background()
+
+	// A slow slide of 0.05 per 20m is measured against the window start, not the
+	// previous cycle
+	for i, hf := range []float64{2.0, 1.95, 1.9, 1.85} {
+		now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).Add(time.Duration(i) * 20 * time.Minute)
+		j.checkAvgHealthFactorDrop(ctx, &aggregateMetrics{WeightedAvgHF: hf})
+	}
+	state, ok := manager.GetActiveIncidents()[key]
+	if !ok || state.Severity != alerts.SeverityWarning {
+		t.Fatalf("incident = %+v, %v; want WARNING for a 0.15 drop over the hour", state, ok)
+	}
+	if j.avgHFBaseline != 1.85 || !j.avgHFBaselineTime.Equal(now) {
+		t.Errorf("baseline = %v at %v, want the window restarted from 1.85", j.avgHFBaseline, j.avgHFBaselineTime)
+	}
+
+	// Within the new window nothing is compared, even after a large drop
+	now = now.Add(30 * time.Minute)
+	j.checkAvgHealthFactorDrop(ctx, &aggregateMetrics{WeightedAvgHF: 1.4})
+	if j.avgHFBaseline != 1.85 {
+		t.Errorf("baseline moved to %v inside the window", j.avgHFBaseline)
+	}
+	now = now.Add(30 * time.Minute)
+	j.checkAvgHealthFactorDrop(ctx, &aggregateMetrics{WeightedAvgHF: 1.4})
+	if state := manager.GetActiveIncidents()[key]; state.Severity != alerts.SeverityCritical {
+		t.Errorf("severity = %s, want CRITICAL for a 0.45 drop", state.Severity)
+	}
+}
+
+func TestAggregateCheckWindowsAdvanceIndependently(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	j := &HealthAggregateJob{
+		alertManager:     manager,
+		configs:          config.NewHolder(config.DefaultConfig()),
+		last24hCheckTime: start.Add(-24 * time.Hour),
+	}
+	j.SetClock(func() time.Time { return now })
+	ctx := context.Background()
+
+	// 25 hours of 5 minute cycles
+	for cycle := 0; cycle <= 25*12; cycle++ {
+		now = start.Add(time.Duration(cycle) * 5 * time.Minute)
+		metrics := &aggregateMetrics{RiskyPositions: 10, WeightedAvgHF: 1.8}
+		j.checkRiskyCountSpike(ctx, metrics)
+		j.checkAvgHealthFactorDrop(ctx, metrics)
+	}
+
+	// The HF window starts on the first cycle and is compared hourly; the risky count
+	// is compared on the first cycle and once a day
+	if got := len(manager.DecisionLog("health_aggregate", "avg_hf_drop")); got != 25 {
+		t.Errorf("avg_hf_drop observed %d times, want 25", got)
+	}
+	if got := len(manager.DecisionLog("health_aggregate", "risky_count_spike")); got != 2 {
+		t.Errorf("risky_count_spike observed %d times, want 2", got)
+	}
+	if !j.avgHFBaselineTime.Equal(start.Add(25*time.Hour)) || !j.last24hCheckTime.Equal(start.Add(24*time.Hour)) {
+		t.Errorf("windows started at %v (HF) and %v (risky count)", j.avgHFBaselineTime, j.last24hCheckTime)
+	}
+}
+
+func TestAggregateWindowsStartOnJobClock(t *testing.T) {
+	// Years before wall time, so windows started on time.Now would not open for years
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	j := &HealthAggregateJob{alertManager: manager, configs: config.NewHolder(config.DefaultConfig())}
+	j.startWindows() // as NewHealthAggregateJob does, on the wall clock
+	j.SetClock(func() time.Time { return now })
+	ctx := context.Background()
+
+	for _, metrics := range []*aggregateMetrics{
+		{RiskyPositions: 10, TotalCollateralUSD: 1000, TotalBorrowUSD: 500},
+		{RiskyPositions: 20, TotalCollateralUSD: 500, TotalBorrowUSD: 1000},
+	} {
+		j.checkRiskyCountSpike(ctx, metrics)
+		j.checkWithdrawalSpike(ctx, metrics)
+		j.checkBorrowSpike(ctx, metrics)
+		now = now.Add(24 * time.Hour)
+	}
+
+	for _, metric := range []string{"risky_count_spike", "withdrawal_spike", "borrow_spike"} {
+		key := alerts.AlertKey{Job: "health_aggregate", Entity: "protocol", Metric: metric}
+		if state := manager.GetActiveIncidents()[key]; state.Severity != alerts.SeverityCritical {
+			t.Errorf("%s = %s after a day on the job clock, want CRITICAL", metric, state.Severity)
+		}
+	}
+}
+
+func TestHealthAggregateReloadReregistersPolicies(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	configs := config.NewHolder(config.DefaultConfig())
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	j := &HealthAggregateJob{alertManager: manager, configs: configs}
+	j.registerPolicies(configs.Get().HealthFactor)
+	j.SetClock(func() time.Time { return now })
+	key := alerts.AlertKey{Job: "health_aggregate", Entity: "protocol", Metric: "avg_hf_drop"}
+	ctx := context.Background()
+
+	// A reload dropping consecutive_ok_required to 1 resolves the drop on its first OK
+	reloaded := config.DefaultConfig()
+	reloaded.HealthFactor.AvgHFDrop.ConsecutiveOKRequired = 1
+	configs.Set(reloaded)
+	if err := j.Reload(reloaded); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	for _, hf := range []float64{2.0, 1.85, 1.85} {
+		j.checkAvgHealthFactorDrop(ctx, &aggregateMetrics{WeightedAvgHF: hf})
+		now = now.Add(time.Hour)
+	}
+	if state, ok := manager.GetActiveIncidents()[key]; ok {
+		t.Errorf("incident still open at %s after one OK, want it resolved under the reloaded policy", state.Severity)
+	}
+}
+
+func TestAvgHealthFactorDropFollowsConfiguredMetric(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.DefaultConfig()
+	cfg.HealthFactor.AvgHFDrop.Metric = "median"
+	configs := config.NewHolder(cfg)
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	j := &HealthAggregateJob{alertManager: manager, configs: configs}
+	j.SetClock(func() time.Time { return now })
+	key := alerts.AlertKey{Job: "health_aggregate", Entity: "protocol", Metric: "avg_hf_drop"}
+	ctx := context.Background()
+
+	// Large loopers hold the borrow-weighted average up while the median slides
+	j.checkAvgHealthFactorDrop(ctx, &aggregateMetrics{WeightedAvgHF: 3, CollateralWeightedAvgHF: 2.5, MedianHF: 1.9})
+	now = now.Add(time.Hour)
+	j.checkAvgHealthFactorDrop(ctx, &aggregateMetrics{WeightedAvgHF: 3, CollateralWeightedAvgHF: 2.4, MedianHF: 1.6})
+	state, ok := manager.GetActiveIncidents()[key]
+	if !ok || state.Severity != alerts.SeverityCritical {
+		t.Fatalf("incident = %+v, %v; want CRITICAL for a 0.3 median drop", state, ok)
+	}
+	for _, line := range []string{"Median HF: 1.6000 (", "Borrow-weighted avg HF: 3.0000", "Collateral-weighted avg HF: 2.4000"} {
+		if !strings.Contains(state.LastMessage, line) {
+			t.Errorf("details missing %q:\n%s", line, state.LastMessage)
+		}
+	}
+
+	// Switching the metric restarts the window rather than comparing across averages
+	switched := config.DefaultConfig()
+	configs.Set(switched)
+	now = now.Add(time.Hour)
+	j.checkAvgHealthFactorDrop(ctx, &aggregateMetrics{WeightedAvgHF: 3, MedianHF: 1.6})
+	if j.avgHFMetric != "borrow_weighted" || j.avgHFBaseline != 3 {
+		t.Errorf("baseline = %v from %q, want a new window from the borrow-weighted average", j.avgHFBaseline, j.avgHFMetric)
+	}
+}
+
+func TestWeightedAvgHFCapped(t *testing.T) {
+	tests := []struct {
+		sum   sql.NullFloat64
+		total float64
+		want  float64
+	}{
+		{sql.NullFloat64{Float64: 300, Valid: true}, 200, 1.5},
+		{sql.NullFloat64{Float64: 1e6, Valid: true}, 100, maxAggregateHF},
+		{sql.NullFloat64{Float64: 300, Valid: true}, 0, 999},
+		{sql.NullFloat64{}, 100, 999},
+	}
+	for _, tt := range tests {
+		if got := weightedAvgHF(tt.sum, tt.total); got != tt.want {
+			t.Errorf("weightedAvgHF(%v, %v) = %v, want %v", tt.sum, tt.total, got, tt.want)
+		}
+	}
+}
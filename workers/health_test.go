@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestFreshnessSeverityHysteresis(t *testing.T) {
+	cfg := config.FreshnessConfig{
+		WarningAgeHours:  config.Hours(5 * time.Hour),
+		CriticalAgeHours: config.Hours(10 * time.Hour),
+		ClearFraction:    0.8, // clear below 4h / downgrade below 8h
+	}
+
+	tests := []struct {
+		name     string
+		age      time.Duration
+		previous alerts.Severity
+		want     alerts.Severity
+	}{
+		{"fresh", 1 * time.Hour, alerts.SeverityOK, alerts.SeverityOK},
+		{"crosses warning", 5*time.Hour + time.Minute, alerts.SeverityOK, alerts.SeverityWarning},
+		{"dips just under warning", 4*time.Hour + 50*time.Minute, alerts.SeverityWarning, alerts.SeverityWarning},
+		{"well under warning clears", 3*time.Hour + 59*time.Minute, alerts.SeverityWarning, alerts.SeverityOK},
+		{"below warning without prior alert", 4*time.Hour + 50*time.Minute, alerts.SeverityOK, alerts.SeverityOK},
+		{"crosses critical", 10*time.Hour + time.Minute, alerts.SeverityWarning, alerts.SeverityCritical},
+		{"dips just under critical", 9 * time.Hour, alerts.SeverityCritical, alerts.SeverityCritical},
+		{"well under critical downgrades", 7 * time.Hour, alerts.SeverityCritical, alerts.SeverityWarning},
+		{"recovers fully from critical", 1 * time.Hour, alerts.SeverityCritical, alerts.SeverityOK},
+	}
+	for _, tt := range tests {
+		if got := freshnessSeverity(tt.age, tt.previous, cfg); got != tt.want {
+			t.Errorf("%s: freshnessSeverity(%v, %s) = %s, want %s", tt.name, tt.age, tt.previous, got, tt.want)
+		}
+	}
+
+	// A clear fraction of 1 disables the hysteresis
+	cfg.ClearFraction = 1
+	if got := freshnessSeverity(4*time.Hour+50*time.Minute, alerts.SeverityWarning, cfg); got != alerts.SeverityOK {
+		t.Errorf("without hysteresis: got %s, want OK", got)
+	}
+}
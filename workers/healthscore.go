@@ -0,0 +1,134 @@
+package workers
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+// healthScoreGauge exposes each chain's latest health score (0-100)
+var healthScoreGauge = expvar.NewMap("oracle_health_score")
+
+// healthInputs are the cycle readings a chain's health score is computed from
+type healthInputs struct {
+	tokens         int           // active tokens
+	ok             int           // tokens read and classified OK
+	worstDeviation float64       // largest deviation as a fraction of its critical threshold
+	staleness      time.Duration // age of the oldest reference price
+	rpcLag         time.Duration // slowest onchain price read
+}
+
+// add folds one token's result into the inputs. critical is the token's critical
+// deviation threshold; failed reads only contribute their RPC latency.
+func (in *healthInputs) add(result tokenResult, severity alerts.Severity, critical float64, now time.Time) {
+	in.rpcLag = max(in.rpcLag, result.rpcLatency)
+	if result.err != nil {
+		return
+	}
+	if severity == alerts.SeverityOK {
+		in.ok++
+	}
+	if critical > 0 {
+		in.worstDeviation = max(in.worstDeviation, result.deviation/critical)
+	}
+	if !result.dexUpdatedAt.IsZero() {
+		in.staleness = max(in.staleness, now.Sub(result.dexUpdatedAt))
+	}
+}
+
+// healthScore is a chain's score with its components, each from 0 (worst) to 1
+type healthScore struct {
+	total     float64 // 0-100
+	ok        float64
+	deviation float64
+	staleness float64
+	rpcLag    float64
+}
+
+// scoreHealth combines the inputs into a weighted score from 0 to 100. A deviation
+// at its critical threshold, staleness at max_staleness_minutes or RPC lag at
+// max_rpc_lag_seconds scores zero for that component.
+func scoreHealth(in healthInputs, cfg config.HealthScoreConfig) healthScore {
+	s := healthScore{ok: 1}
+	if in.tokens > 0 {
+		s.ok = float64(in.ok) / float64(in.tokens)
+	}
+	s.deviation = 1 - clamp01(in.worstDeviation)
+	if limit := cfg.MaxStalenessMinutes.Duration(); limit > 0 {
+		s.staleness = 1 - clamp01(float64(in.staleness)/float64(limit))
+	}
+	if limit := cfg.MaxRPCLagSeconds.Duration(); limit > 0 {
+		s.rpcLag = 1 - clamp01(float64(in.rpcLag)/float64(limit))
+	}
+
+	weights := cfg.OKWeight + cfg.DeviationWeight + cfg.StalenessWeight + cfg.RPCLagWeight
+	if weights <= 0 {
+		return s
+	}
+	s.total = 100 * (cfg.OKWeight*s.ok + cfg.DeviationWeight*s.deviation +
+		cfg.StalenessWeight*s.staleness + cfg.RPCLagWeight*s.rpcLag) / weights
+	return s
+}
+
+func clamp01(x float64) float64 {
+	return math.Max(0, math.Min(1, x))
+}
+
+// criticalThreshold is the critical deviation threshold classifyDeviation applied to result
+func (m *OracleMonitor) criticalThreshold(result tokenResult) float64 {
+	meta, ok := m.chain.Tokens[result.symbol]
+	if !ok {
+		return 0
+	}
+	_, critical := deviationThresholds(meta, m.oracleConfig(), result.signedDeviation())
+	return critical
+}
+
+// updateHealthScore logs the chain's health score, publishes it and alerts developers
+// when it falls below oracle.health_score.warning_below
+func (m *OracleMonitor) updateHealthScore(ctx context.Context, in healthInputs) {
+	cfg := m.oracleConfig().HealthScore
+	if !cfg.Enabled || in.tokens == 0 {
+		return
+	}
+	score := scoreHealth(in, cfg)
+	log.Printf("[%s][%s] health score %.1f (ok %.2f, deviation %.2f, staleness %.2f, rpc lag %.2f)",
+		m.Name(), m.chain.Name, score.total, score.ok, score.deviation, score.staleness, score.rpcLag)
+	gauge := new(expvar.Float)
+	gauge.Set(score.total)
+	healthScoreGauge.Set(string(m.chain.ID), gauge)
+
+	severity := alerts.SeverityOK
+	if score.total < cfg.CriticalBelow {
+		severity = alerts.SeverityCritical
+	} else if score.total < cfg.WarningBelow {
+		severity = alerts.SeverityWarning
+	}
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("system"), Metric: "health_score"}
+	details := fmt.Sprintf("Chain: %s\nScore: %.1f/100 (warning below %g, critical below %g)\n\n"+
+		"Tokens OK: %d/%d → %.2f × %g\nWorst deviation: %.0f%% of critical → %.2f × %g\n"+
+		"Oldest reference price: %s → %.2f × %g\nSlowest RPC read: %s → %.2f × %g",
+		m.chain.Name, score.total, cfg.WarningBelow, cfg.CriticalBelow,
+		in.ok, in.tokens, score.ok, cfg.OKWeight,
+		in.worstDeviation*100, score.deviation, cfg.DeviationWeight,
+		in.staleness.Round(time.Second), score.staleness, cfg.StalenessWeight,
+		in.rpcLag.Round(time.Millisecond), score.rpcLag, cfg.RPCLagWeight)
+	m.observe(ctx, key, severity, score.total, "", details, false, "")
+}
+
+func registerHealthScorePolicy(alertManager *alerts.Manager, jobName string) {
+	// The score moves a little every cycle; re-alert only on larger swings
+	alertManager.RegisterPolicy(jobName, "health_score", alerts.AlertPolicy{
+		MinValueChange:        10.0,
+		CooldownWarning:       30 * time.Minute,
+		CooldownCritical:      15 * time.Minute,
+		ReminderInterval:      1 * time.Hour,
+		ConsecutiveOKRequired: 2,
+	})
+}
@@ -0,0 +1,54 @@
+package workers
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestScoreHealth(t *testing.T) {
+	cfg := config.DefaultConfig().Oracle.HealthScore
+	tests := []struct {
+		name string
+		in   healthInputs
+		want float64
+	}{
+		{"all healthy", healthInputs{tokens: 10, ok: 10}, 100},
+		{"nothing works", healthInputs{tokens: 10, worstDeviation: 2, staleness: 2 * time.Hour, rpcLag: time.Minute}, 0},
+		{"half the tokens deviate", healthInputs{tokens: 10, ok: 5, worstDeviation: 0.5}, 100 - 40*0.5 - 30*0.5},
+		{"stale references and slow RPC", healthInputs{tokens: 4, ok: 4, staleness: 30 * time.Minute, rpcLag: 10 * time.Second}, 100 - 15*0.5 - 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scoreHealth(tt.in, cfg).total; math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("scoreHealth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreHealthWeights(t *testing.T) {
+	// Only the OK fraction counts; weights need not sum to 1
+	cfg := config.DefaultConfig().Oracle.HealthScore
+	cfg.OKWeight, cfg.DeviationWeight, cfg.StalenessWeight, cfg.RPCLagWeight = 2, 0, 0, 0
+	got := scoreHealth(healthInputs{tokens: 4, ok: 3, worstDeviation: 5, rpcLag: time.Hour}, cfg)
+	if got.total != 75 || got.deviation != 0 || got.rpcLag != 0 {
+		t.Errorf("scoreHealth() = %+v, want total 75 with zeroed deviation and RPC components", got)
+	}
+}
+
+func TestHealthInputsAdd(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var in healthInputs
+	in.add(tokenResult{deviation: 1, dexUpdatedAt: now.Add(-5 * time.Minute), rpcLatency: time.Second}, alerts.SeverityOK, 4, now)
+	in.add(tokenResult{deviation: 3, rpcLatency: 200 * time.Millisecond}, alerts.SeverityWarning, 4, now)
+	in.add(tokenResult{err: errors.New("timeout"), rpcLatency: 3 * time.Second}, "", 0, now)
+
+	if in.ok != 1 || in.worstDeviation != 0.75 || in.staleness != 5*time.Minute || in.rpcLag != 3*time.Second {
+		t.Errorf("inputs = %+v", in)
+	}
+}
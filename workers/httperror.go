@@ -0,0 +1,40 @@
+package workers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// httpStatusError is a non-200 response from a price API
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+	Retryable  bool
+}
+
+func (e *httpStatusError) Error() string {
+	kind := "permanent"
+	if e.Retryable {
+		kind = "transient"
+	}
+	return fmt.Sprintf("API status %d (%s): %s", e.StatusCode, kind, e.Body)
+}
+
+// newHTTPStatusError classifies a response status. 5xx responses and the 4xx codes
+// listed in retryStatuses (429 and 408 by default) are retryable; other 4xx fail fast.
+func newHTTPStatusError(statusCode int, body string, retryStatuses []int) *httpStatusError {
+	retryable := statusCode >= http.StatusInternalServerError || slices.Contains(retryStatuses, statusCode)
+	return &httpStatusError{StatusCode: statusCode, Body: body, Retryable: retryable}
+}
+
+// isRetryable reports whether err may succeed on retry. Errors other than
+// permanent HTTP statuses (network failures, timeouts, bad payloads) are retried.
+func isRetryable(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Retryable
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"expvar"
+	"sync/atomic"
+)
+
+// inFlightCalls exposes the number of outstanding onchain and HTTP calls across all monitors
+var inFlightCalls = expvar.NewInt("oracle_inflight_calls")
+
+// Limiter caps the number of outstanding onchain and HTTP calls shared by all monitors.
+// A nil Limiter imposes no limit but still tracks in-flight calls.
+type Limiter struct {
+	sem      chan struct{}
+	inFlight atomic.Int64
+}
+
+// NewLimiter creates a limiter allowing at most max concurrent calls.
+// A non-positive max disables limiting.
+func NewLimiter(max int) *Limiter {
+	if max <= 0 {
+		return nil
+	}
+	return &Limiter{sem: make(chan struct{}, max)}
+}
+
+// Acquire blocks until a slot is available or ctx is done
+func (l *Limiter) Acquire(ctx context.Context) error {
+	if l != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		l.inFlight.Add(1)
+	}
+	inFlightCalls.Add(1)
+	return nil
+}
+
+// Release frees a slot acquired with Acquire
+func (l *Limiter) Release() {
+	inFlightCalls.Add(-1)
+	if l == nil {
+		return
+	}
+	l.inFlight.Add(-1)
+	<-l.sem
+}
+
+// InFlight returns the number of calls currently holding a slot
+func (l *Limiter) InFlight() int64 {
+	if l == nil {
+		return 0
+	}
+	return l.inFlight.Load()
+}
@@ -0,0 +1,96 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+// marketBreadth summarizes how many volatile tokens on a chain are deviating in a cycle
+type marketBreadth struct {
+	deviating []string
+	total     int
+}
+
+func (b marketBreadth) fraction() float64 {
+	if b.total == 0 {
+		return 0
+	}
+	return float64(len(b.deviating)) / float64(b.total)
+}
+
+// measureBreadth counts volatile tokens whose deviation is at WARNING or above
+func (m *OracleMonitor) measureBreadth(results []tokenResult) marketBreadth {
+	var breadth marketBreadth
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		meta, ok := m.chain.Tokens[result.symbol]
+		if !ok || meta.IsStablecoin || meta.SkipDEXPrice {
+			continue
+		}
+		breadth.total++
+		if m.classifyDeviation(result, meta) != alerts.SeverityOK {
+			breadth.deviating = append(breadth.deviating, meta.TableName)
+		}
+	}
+	sort.Strings(breadth.deviating)
+	return breadth
+}
+
+// updateBroadMove decides whether volatile alerts should be routed to developers only this cycle.
+// While a broad move is active a single business notice is sent instead of per-token pages.
+func (m *OracleMonitor) updateBroadMove(ctx context.Context, breadth marketBreadth) bool {
+	cfg := m.oracleConfig()
+	if !cfg.BroadMove.Enabled {
+		return false
+	}
+
+	active := breadth.total > 0 &&
+		len(breadth.deviating) >= cfg.BroadMove.MinTokens &&
+		breadth.fraction() >= cfg.BroadMove.BreadthFraction
+
+	m.mu.Lock()
+	changed := active != m.broadMove
+	m.broadMove = active
+	m.mu.Unlock()
+
+	if changed {
+		if active {
+			log.Printf("[%s][%s] broad market move: %d/%d volatile tokens deviating, routing volatile alerts to developers",
+				m.Name(), m.chain.Name, len(breadth.deviating), breadth.total)
+		} else {
+			log.Printf("[%s][%s] broad market move subsided, volatile alerts routed normally", m.Name(), m.chain.Name)
+		}
+	}
+
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("market"), Metric: "broad_market_move"}
+	if !active {
+		m.observe(ctx, key, alerts.SeverityOK, 0, "", "", false, "")
+		return false
+	}
+
+	details := fmt.Sprintf("Chain: %s\nDeviating: %d/%d volatile tokens (%.0f%%)\nTokens: %v\nPer-token volatile alerts are routed to the developer channel until breadth subsides.",
+		m.chain.Name, len(breadth.deviating), breadth.total, breadth.fraction()*100, breadth.deviating)
+	slackMsg := fmt.Sprintf("ALERT: BROAD MARKET MOVE\nChain: %s\nDeviating: %d/%d volatile tokens",
+		m.chain.Name, len(breadth.deviating), breadth.total)
+
+	m.observe(ctx, key, alerts.SeverityWarning, breadth.fraction()*100, "", details, true, slackMsg)
+	return true
+}
+
+func registerBroadMovePolicy(alertManager *alerts.Manager, jobName string) {
+	// One notice per move: large MinValueChange prevents updates while breadth fluctuates
+	alertManager.RegisterPolicy(jobName, "broad_market_move", alerts.AlertPolicy{
+		MinValueChange:        100.0,
+		CooldownWarning:       1 * time.Hour,
+		CooldownCritical:      1 * time.Hour,
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: 1,
+	})
+}
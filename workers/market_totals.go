@@ -0,0 +1,392 @@
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	_ "github.com/lib/pq"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/contract"
+	"github.com/0x0Glitch/tracing"
+)
+
+// mTokenTotalsCalls are the mToken reads batched per market, in result order
+var mTokenTotalsCalls = []string{"exchangeRateStored", "totalSupply", "totalBorrows", "getCash"}
+
+// MarketTotalsJob cross-checks each market's onchain supply and borrow totals against
+// the indexer database. When the indexer breaks, the database-driven spike checks go
+// quiet; this job alerts on the divergence instead.
+type MarketTotalsJob struct {
+	chain        ChainConfig
+	client       *ManagedClient
+	multicall    *MulticallCaller
+	db           *sql.DB
+	alertManager *alerts.Manager
+	configs      *config.Holder
+	limiter      *Limiter
+}
+
+// marketTotals holds a market's supply and borrow totals in USD
+type marketTotals struct {
+	SupplyUSD float64
+	BorrowUSD float64
+	CashUSD   float64
+}
+
+// NewMarketTotalsJob creates the onchain/indexer cross-check for a chain
+func NewMarketTotalsJob(
+	chain ChainConfig,
+	client *ManagedClient,
+	databaseURL string,
+	alertManager *alerts.Manager,
+	configs *config.Holder,
+	limiter *Limiter,
+) (*MarketTotalsJob, error) {
+	if databaseURL == "" {
+		return nil, fmt.Errorf("database URL not configured")
+	}
+
+	multicall, err := NewMulticallCaller(Multicall3Address, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multicall caller: %w", err)
+	}
+
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	job := &MarketTotalsJob{
+		chain:        chain,
+		client:       client,
+		multicall:    multicall,
+		db:           db,
+		alertManager: alertManager,
+		configs:      configs,
+		limiter:      limiter,
+	}
+	job.registerPolicies(configs.Get().MarketTotals)
+	return job, nil
+}
+
+func (j *MarketTotalsJob) Name() string {
+	return fmt.Sprintf("market_totals_%s", j.chain.ID)
+}
+
+func (j *MarketTotalsJob) ChainID() string {
+	return string(j.chain.ID)
+}
+
+func (j *MarketTotalsJob) Interval() time.Duration {
+	if d := j.configs.Get().MarketTotals.CheckIntervalSeconds.Duration(); d > 0 {
+		return d
+	}
+	return 15 * time.Minute
+}
+
+func (j *MarketTotalsJob) Reload(cfg *config.Config) error {
+	j.registerPolicies(cfg.MarketTotals)
+	return nil
+}
+
+func (j *MarketTotalsJob) registerPolicies(cfg config.MarketTotalsConfig) {
+	j.alertManager.RegisterPolicy(j.Name(), "indexer_drift", alerts.AlertPolicy{
+		MinValueChange:        cfg.MinValueChangePercent,
+		CooldownWarning:       cfg.CooldownWarning(),
+		CooldownCritical:      cfg.CooldownCritical(),
+		ReminderInterval:      0,
+		TriggerThreshold:      cfg.WarningDriftPercent,
+		ConsecutiveOKRequired: cfg.ConsecutiveOKRequired,
+	})
+
+	j.alertManager.RegisterPolicy(j.Name(), "indexer_missing_market", alerts.AlertPolicy{
+		MinValueChange:        0,
+		CooldownWarning:       cfg.CooldownWarning(),
+		CooldownCritical:      cfg.CooldownCritical(),
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: cfg.ConsecutiveOKRequired,
+	})
+}
+
+func (j *MarketTotalsJob) Run(ctx context.Context) error {
+	cfg := j.configs.Get()
+	if strings.TrimSpace(cfg.MarketTotals.Query) == "" {
+		return nil
+	}
+
+	tokens := make(map[string]TokenMeta, len(j.chain.Tokens))
+	for symbol, meta := range j.chain.Tokens {
+		if !cfg.Oracle.TokenDisabled(string(j.chain.ID), symbol) {
+			tokens[symbol] = meta
+		}
+	}
+
+	onchain, err := j.onchainTotals(ctx, tokens)
+	if err != nil {
+		return fmt.Errorf("onchain totals: %w", err)
+	}
+
+	indexed, err := j.indexedTotals(ctx, cfg.MarketTotals.Query)
+	if err != nil {
+		return fmt.Errorf("database totals: %w", err)
+	}
+
+	symbols := make([]string, 0, len(onchain))
+	for symbol := range onchain {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	for _, symbol := range symbols {
+		meta := tokens[symbol]
+		chainTotals := onchain[symbol]
+		dbTotals, found := indexed[strings.ToLower(common.HexToAddress(meta.MTokAddr).Hex())]
+		j.observeMissing(ctx, meta, chainTotals, found, cfg.MarketTotals)
+		if found {
+			j.observeDrift(ctx, meta, chainTotals, dbTotals, cfg.MarketTotals)
+		}
+	}
+
+	log.Printf("[%s][%s] cross-checked %d markets against the database (%d indexed)",
+		j.Name(), j.chain.Name, len(onchain), len(indexed))
+	return nil
+}
+
+// onchainTotals reads every market's mToken totals and oracle price in a single
+// Multicall3 batch. Markets whose reads failed are omitted.
+func (j *MarketTotalsJob) onchainTotals(ctx context.Context, tokens map[string]TokenMeta) (map[string]marketTotals, error) {
+	mTokenABI, err := contract.MTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	oracleABI, err := contract.OracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]string, 0, len(tokens))
+	for symbol := range tokens {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	oracleAddr := common.HexToAddress(j.chain.OracleAddress)
+	perMarket := len(mTokenTotalsCalls) + 1
+	calls := make([]Multicall3Call, 0, len(symbols)*perMarket)
+	for _, symbol := range symbols {
+		mToken := common.HexToAddress(tokens[symbol].MTokAddr)
+		for _, method := range mTokenTotalsCalls {
+			callData, err := mTokenABI.Pack(method)
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, Multicall3Call{Target: mToken, AllowFailure: true, CallData: callData})
+		}
+		callData, err := oracleABI.Pack("getUnderlyingPrice", mToken)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, Multicall3Call{Target: oracleAddr, AllowFailure: true, CallData: callData})
+	}
+	if len(calls) == 0 {
+		return map[string]marketTotals{}, nil
+	}
+
+	if err := j.limiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	callCtx, span := tracing.Start(ctx, "eth_call", tracing.Chain(j.chain.Name), tracing.Method("aggregate3"))
+	results, err := j.multicall.Aggregate3(&bind.CallOpts{Context: callCtx}, calls)
+	tracing.End(span, err)
+	j.limiter.Release()
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(results), len(calls))
+	}
+
+	totals := make(map[string]marketTotals, len(symbols))
+	for i, symbol := range symbols {
+		batch := results[i*perMarket : (i+1)*perMarket]
+		values := make([]*big.Int, perMarket)
+		ok := true
+		for k, result := range batch {
+			method, parsed := "getUnderlyingPrice", oracleABI
+			if k < len(mTokenTotalsCalls) {
+				method, parsed = mTokenTotalsCalls[k], mTokenABI
+			}
+			if values[k], ok = unpackUint(parsed, method, result); !ok {
+				break
+			}
+		}
+		if !ok {
+			log.Printf("[%s][%s] %s: mToken totals unavailable", j.Name(), j.chain.Name, symbol)
+			continue
+		}
+		totals[symbol] = mTokenTotalsUSD(values[0], values[1], values[2], values[3], values[4])
+	}
+	return totals, nil
+}
+
+// unpackUint decodes a successful single uint256 return value
+func unpackUint(parsed *abi.ABI, method string, result Multicall3Result) (*big.Int, bool) {
+	if !result.Success {
+		return nil, false
+	}
+	out, err := parsed.Unpack(method, result.ReturnData)
+	if err != nil || len(out) == 0 {
+		return nil, false
+	}
+	value, ok := out[0].(*big.Int)
+	return value, ok && value != nil
+}
+
+// mTokenTotalsUSD converts raw mToken totals to USD. The oracle price is scaled by
+// 1e(36 - underlying decimals), so multiplying raw underlying amounts by it and
+// dividing by 1e36 yields USD regardless of the token's decimals.
+func mTokenTotalsUSD(exchangeRate, totalSupply, totalBorrows, cash, price *big.Int) marketTotals {
+	scale := new(big.Float).SetFloat64(1e36)
+	toUSD := func(amount *big.Float) float64 {
+		usd, _ := new(big.Float).Quo(new(big.Float).Mul(amount, new(big.Float).SetInt(price)), scale).Float64()
+		return usd
+	}
+
+	// Exchange rates are scaled by 1e18: underlying = mTokens * exchangeRate / 1e18
+	supplied := new(big.Float).Mul(new(big.Float).SetInt(totalSupply), new(big.Float).SetInt(exchangeRate))
+	supplied.Quo(supplied, new(big.Float).SetFloat64(1e18))
+
+	return marketTotals{
+		SupplyUSD: toUSD(supplied),
+		BorrowUSD: toUSD(new(big.Float).SetInt(totalBorrows)),
+		CashUSD:   toUSD(new(big.Float).SetInt(cash)),
+	}
+}
+
+// indexedTotals runs the configured query, which returns (mtoken_address, supplied_usd,
+// borrowed_usd) rows for the chain ID passed as $1. Results are keyed by lowercase address.
+func (j *MarketTotalsJob) indexedTotals(ctx context.Context, query string) (map[string]marketTotals, error) {
+	queryCtx, span := tracing.Start(ctx, "db.query", tracing.Job(j.Name()), tracing.Chain(j.chain.Name), tracing.Query("indexed_totals"))
+	rows, err := j.db.QueryContext(queryCtx, query, string(j.chain.ID))
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make(map[string]marketTotals)
+	for rows.Next() {
+		var address string
+		var supplied, borrowed sql.NullFloat64
+		if err := rows.Scan(&address, &supplied, &borrowed); err != nil {
+			return nil, err
+		}
+		if !common.IsHexAddress(address) {
+			log.Printf("[%s][%s] ignoring database row with invalid market address %q", j.Name(), j.chain.Name, address)
+			continue
+		}
+		totals[strings.ToLower(common.HexToAddress(address).Hex())] = marketTotals{
+			SupplyUSD: supplied.Float64,
+			BorrowUSD: borrowed.Float64,
+		}
+	}
+	return totals, rows.Err()
+}
+
+// observeDrift alerts when the database totals for a market diverge from the onchain
+// totals by more than the configured thresholds
+func (j *MarketTotalsJob) observeDrift(ctx context.Context, meta TokenMeta, onchain, indexed marketTotals, cfg config.MarketTotalsConfig) {
+	if onchain.SupplyUSD < cfg.MinMarketUSD {
+		return
+	}
+
+	supplyDrift := driftPercent(onchain.SupplyUSD, indexed.SupplyUSD)
+	borrowDrift := 0.0
+	if onchain.BorrowUSD >= cfg.MinMarketUSD {
+		borrowDrift = driftPercent(onchain.BorrowUSD, indexed.BorrowUSD)
+	}
+	drift := math.Max(supplyDrift, borrowDrift)
+
+	severity := classify(drift, configuredBands(j.configs, j.Name(), "indexer_drift",
+		warningCritical(cfg.WarningDriftPercent, cfg.CriticalDriftPercent)))
+
+	key := alerts.AlertKey{
+		Job:    j.Name(),
+		Entity: alerts.ChainEntity(string(j.chain.ID), meta.TableName),
+		Metric: "indexer_drift",
+	}
+
+	summary := ""
+	details := fmt.Sprintf(
+		"Chain: %s\nMarket: %s\nSupply: onchain $%s, database $%s (%.1f%% drift)\nBorrow: onchain $%s, database $%s (%.1f%% drift)\nOnchain cash: $%s\nCheck the indexer",
+		j.chain.Name,
+		meta.Symbol,
+		formatUSD(onchain.SupplyUSD), formatUSD(indexed.SupplyUSD), supplyDrift,
+		formatUSD(onchain.BorrowUSD), formatUSD(indexed.BorrowUSD), borrowDrift,
+		formatUSD(onchain.CashUSD),
+	)
+
+	if err := j.alertManager.Observe(ctx, key, severity, drift, summary, details, false, ""); err != nil {
+		log.Printf("[%s][%s] failed to observe indexer drift: %v", j.Name(), j.chain.Name, err)
+	}
+}
+
+// observeMissing alerts when a market with meaningful onchain supply has no database entry
+func (j *MarketTotalsJob) observeMissing(ctx context.Context, meta TokenMeta, onchain marketTotals, found bool, cfg config.MarketTotalsConfig) {
+	severity := alerts.SeverityOK
+	if !found && onchain.SupplyUSD >= cfg.MinMarketUSD {
+		severity = alerts.SeverityWarning
+	}
+
+	key := alerts.AlertKey{
+		Job:    j.Name(),
+		Entity: alerts.ChainEntity(string(j.chain.ID), meta.TableName),
+		Metric: "indexer_missing_market",
+	}
+
+	summary := ""
+	details := fmt.Sprintf(
+		"Chain: %s\nMarket: %s (%s)\nOnchain supply: $%s\nOnchain borrows: $%s\nThe database has no entry for this market",
+		j.chain.Name,
+		meta.Symbol,
+		meta.MTokAddr,
+		formatUSD(onchain.SupplyUSD),
+		formatUSD(onchain.BorrowUSD),
+	)
+
+	if err := j.alertManager.Observe(ctx, key, severity, onchain.SupplyUSD, summary, details, false, ""); err != nil {
+		log.Printf("[%s][%s] failed to observe missing market: %v", j.Name(), j.chain.Name, err)
+	}
+}
+
+// driftPercent returns how far indexed is from onchain, as a percentage of onchain
+func driftPercent(onchain, indexed float64) float64 {
+	if onchain == 0 {
+		return 0
+	}
+	return math.Abs(indexed-onchain) / onchain * 100
+}
+
+func (j *MarketTotalsJob) Close() error {
+	if j.db != nil {
+		return j.db.Close()
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package workers
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestMTokenTotalsUSD(t *testing.T) {
+	pow10 := func(n int64) *big.Int { return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil) }
+
+	// USDC market: 6 underlying decimals, so the oracle price is scaled by 1e30.
+	// 500k mTokens (8 decimals) at 0.02 USDC each supply 10,000 USDC.
+	totals := mTokenTotalsUSD(
+		new(big.Int).Mul(big.NewInt(2), pow10(14)),   // exchangeRateStored
+		new(big.Int).Mul(big.NewInt(5), pow10(13)),   // totalSupply
+		new(big.Int).Mul(big.NewInt(4000), pow10(6)), // totalBorrows
+		new(big.Int).Mul(big.NewInt(6000), pow10(6)), // getCash
+		pow10(30), // $1
+	)
+
+	for name, got := range map[string][2]float64{
+		"supply": {totals.SupplyUSD, 10000},
+		"borrow": {totals.BorrowUSD, 4000},
+		"cash":   {totals.CashUSD, 6000},
+	} {
+		if math.Abs(got[0]-got[1]) > 1e-6 {
+			t.Errorf("%s = %v, want %v", name, got[0], got[1])
+		}
+	}
+}
+
+func TestDriftPercent(t *testing.T) {
+	if got := driftPercent(1000, 900); math.Abs(got-10) > 1e-9 {
+		t.Errorf("driftPercent(1000, 900) = %v, want 10", got)
+	}
+	if got := driftPercent(0, 900); got != 0 {
+		t.Errorf("driftPercent(0, 900) = %v, want 0", got)
+	}
+}
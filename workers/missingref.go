@@ -0,0 +1,66 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+// trackMissingReference counts another cycle in which the price source had no price
+// for symbol, alerting developers once the streak exceeds
+// oracle.max_missing_reference_cycles
+func (m *OracleMonitor) trackMissingReference(ctx context.Context, symbol string, err error) {
+	m.mu.Lock()
+	if m.missingRef == nil {
+		m.missingRef = make(map[string]int)
+	}
+	m.missingRef[symbol]++
+	cycles := m.missingRef[symbol]
+	m.mu.Unlock()
+
+	limit := m.oracleConfig().MaxMissingReferenceCycles
+	if limit <= 0 || cycles <= limit {
+		return
+	}
+	if cycles == limit+1 {
+		log.Printf("[%s][%s] %s has had no reference price for %d cycles", m.Name(), m.chain.Name, symbol, cycles)
+	}
+
+	meta := m.chain.Tokens[symbol]
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(symbol), Metric: "reference_price_unavailable"}
+	details := fmt.Sprintf("Chain: %s\nToken: %s\nSource: %s\nCycles without a price: %d (limit %d)\nError: %v\n\n"+
+		"The price source keeps returning no price for this token, so its deviation is not checked. "+
+		"Check the token's price address and source, or skip the reference price for it.",
+		m.chain.Name, symbol, m.chain.PriceRoute(meta), cycles, limit, err)
+	m.observe(ctx, key, alerts.SeverityWarning, 1, "", details, false, "")
+}
+
+// clearMissingReference resets symbol's streak once a reference price returns,
+// resolving its alert if one was raised
+func (m *OracleMonitor) clearMissingReference(ctx context.Context, symbol string) {
+	m.mu.Lock()
+	cycles := m.missingRef[symbol]
+	delete(m.missingRef, symbol)
+	m.mu.Unlock()
+
+	if limit := m.oracleConfig().MaxMissingReferenceCycles; limit <= 0 || cycles <= limit {
+		return
+	}
+	log.Printf("[%s][%s] %s reference price available again after %d cycles", m.Name(), m.chain.Name, symbol, cycles)
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(symbol), Metric: "reference_price_unavailable"}
+	m.observe(ctx, key, alerts.SeverityOK, 0, "", fmt.Sprintf("Chain: %s\nToken: %s", m.chain.Name, symbol), false, "")
+}
+
+func registerMissingReferencePolicy(alertManager *alerts.Manager, jobName string) {
+	// Observed every cycle while the gap lasts with a constant value, so it is sent once
+	alertManager.RegisterPolicy(jobName, "reference_price_unavailable", alerts.AlertPolicy{
+		MinValueChange:        1.0,
+		CooldownWarning:       24 * time.Hour,
+		CooldownCritical:      24 * time.Hour,
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: 1,
+	})
+}
@@ -0,0 +1,50 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestMissingReferenceAlertsPastLimit(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	manager.SetClock(func() time.Time { return now })
+	cfg := config.DefaultConfig()
+	cfg.Oracle.MaxMissingReferenceCycles = 3
+	m := &OracleMonitor{
+		chain:        ChainConfig{ID: "missing_ref_test", Name: "Missing Ref Test", PriceSource: PriceSourceAlchemy},
+		alertManager: manager,
+		configs:      config.NewHolder(cfg),
+		clock:        func() time.Time { return now },
+	}
+	registerMissingReferencePolicy(manager, m.Name())
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("aero"), Metric: "reference_price_unavailable"}
+	ctx := context.Background()
+	err := fmt.Errorf("dex price: %w from alchemy", errNoPriceData)
+
+	for i := 0; i < 3; i++ {
+		m.trackMissingReference(ctx, "aero", err)
+	}
+	if _, ok := manager.GetActiveIncidents()[key]; ok {
+		t.Fatal("alerted at the limit, want only past it")
+	}
+	m.trackMissingReference(ctx, "aero", err)
+	if state, ok := manager.GetActiveIncidents()[key]; !ok || state.Severity != alerts.SeverityWarning {
+		t.Fatalf("incident = %+v (active %v), want WARNING past the limit", state, ok)
+	}
+
+	// A price returning resolves it and restarts the count
+	m.clearMissingReference(ctx, "aero")
+	if state, ok := manager.GetActiveIncidents()[key]; ok && state.Severity != alerts.SeverityOK {
+		t.Errorf("incident still %s after a price returned", state.Severity)
+	}
+	m.trackMissingReference(ctx, "aero", err)
+	if got := m.missingRef["aero"]; got != 1 {
+		t.Errorf("streak = %d after clearing, want 1", got)
+	}
+}
@@ -0,0 +1,157 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0x0Glitch/contract"
+	"github.com/0x0Glitch/tracing"
+)
+
+// Multicall3Address is the canonical Multicall3 deployment, identical on every supported chain
+var Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// Multicall availability, probed once per monitor
+const (
+	multicallUnknown int32 = iota
+	multicallAvailable
+	multicallUnavailable
+)
+
+// Multicall3Call is a single call in an aggregate3 batch
+type Multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// Multicall3Result is the outcome of a single call in an aggregate3 batch
+type Multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+type MulticallCaller struct {
+	contract *bind.BoundContract
+}
+
+func NewMulticallCaller(address common.Address, caller bind.ContractCaller) (*MulticallCaller, error) {
+	parsed, err := MulticallMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	contract := bind.NewBoundContract(address, *parsed, caller, nil, nil)
+	return &MulticallCaller{contract: contract}, nil
+}
+
+// Aggregate3 executes calls in a single eth_call. Calls with AllowFailure set
+// report failure in their result instead of reverting the whole batch.
+func (c *MulticallCaller) Aggregate3(opts *bind.CallOpts, calls []Multicall3Call) ([]Multicall3Result, error) {
+	var out []interface{}
+	err := c.contract.Call(opts, &out, "aggregate3", calls)
+	if err != nil {
+		return nil, err
+	}
+	results := *abi.ConvertType(out[0], new([]Multicall3Result)).(*[]Multicall3Result)
+	return results, nil
+}
+
+var MulticallMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"allowFailure\",\"type\":\"bool\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"}],\"internalType\":\"structMulticall3.Call3[]\",\"name\":\"calls\",\"type\":\"tuple[]\"}],\"name\":\"aggregate3\",\"outputs\":[{\"components\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"},{\"internalType\":\"bytes\",\"name\":\"returnData\",\"type\":\"bytes\"}],\"internalType\":\"structMulticall3.Result[]\",\"name\":\"returnData\",\"type\":\"tuple[]\"}],\"stateMutability\":\"payable\",\"type\":\"function\"}]",
+}
+
+// getOnchainPricesBatch reads getUnderlyingPrice for all tokens in one Multicall3
+// aggregate call and returns the raw prices keyed by mToken address. Tokens whose call
+// failed are omitted so the caller can fall back to a per-token read. An error
+// means the batch as a whole is unavailable.
+func (m *OracleMonitor) getOnchainPricesBatch(ctx context.Context, tokens map[string]TokenMeta) (map[common.Address]*big.Int, error) {
+	if !m.multicallAvailable(ctx) {
+		return nil, fmt.Errorf("multicall not available")
+	}
+
+	oracleABI, err := contract.OracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	oracleAddr := common.HexToAddress(m.chain.OracleAddress)
+	calls := make([]Multicall3Call, 0, len(tokens))
+	metas := make([]TokenMeta, 0, len(tokens))
+	for _, meta := range tokens {
+		if meta.Decimals > 36 {
+			continue
+		}
+		callData, err := oracleABI.Pack("getUnderlyingPrice", common.HexToAddress(meta.MTokAddr))
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, Multicall3Call{Target: oracleAddr, AllowFailure: true, CallData: callData})
+		metas = append(metas, meta)
+	}
+	if len(calls) == 0 {
+		return map[common.Address]*big.Int{}, nil
+	}
+
+	if err := m.limiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer m.limiter.Release()
+
+	callCtx, span := tracing.Start(ctx, "eth_call", tracing.Chain(m.chain.Name), tracing.Method("aggregate3"))
+	results, err := m.multicall.Aggregate3(&bind.CallOpts{Context: callCtx}, calls)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(results), len(calls))
+	}
+
+	prices := make(map[common.Address]*big.Int, len(results))
+	for i, result := range results {
+		if !result.Success {
+			continue
+		}
+		out, err := oracleABI.Unpack("getUnderlyingPrice", result.ReturnData)
+		if err != nil || len(out) == 0 {
+			continue
+		}
+		price, ok := out[0].(*big.Int)
+		if !ok || price == nil {
+			continue
+		}
+		prices[common.HexToAddress(metas[i].MTokAddr)] = price
+	}
+	return prices, nil
+}
+
+// multicallAvailable reports whether Multicall3 is deployed on the chain.
+// The check runs once; RPC errors leave it unknown so it is retried next cycle.
+func (m *OracleMonitor) multicallAvailable(ctx context.Context) bool {
+	switch m.multicallState.Load() {
+	case multicallAvailable:
+		return true
+	case multicallUnavailable:
+		return false
+	}
+
+	code, err := m.client.CodeAt(ctx, Multicall3Address, nil)
+	if err != nil {
+		return false
+	}
+	if len(code) == 0 {
+		log.Printf("[%s][%s] Multicall3 not deployed at %s, using per-token price reads",
+			m.Name(), m.chain.Name, strings.ToLower(Multicall3Address.Hex()))
+		m.multicallState.Store(multicallUnavailable)
+		return false
+	}
+	m.multicallState.Store(multicallAvailable)
+	return true
+}
@@ -0,0 +1,166 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/internal/retry"
+)
+
+// sourceQuote is one price source's answer for a token under oracle.multi_source
+type sourceQuote struct {
+	source string
+	weight float64
+	price  ReferencePrice
+	err    error // fetch failed, or the price was too old to use
+}
+
+func (q sourceQuote) usable() bool {
+	v := q.price.Value
+	return q.err == nil && q.weight > 0 && v > 0 && !math.IsInf(v, 0)
+}
+
+// sortQuotes orders quotes by weight, heaviest first, breaking ties by source name so
+// the reference and the details are the same whatever order the sources answered in
+func sortQuotes(quotes []sourceQuote) {
+	sort.SliceStable(quotes, func(i, j int) bool {
+		if quotes[i].weight != quotes[j].weight {
+			return quotes[i].weight > quotes[j].weight
+		}
+		return quotes[i].source < quotes[j].source
+	})
+}
+
+// weightedReference combines the usable quotes into their weighted mean and returns it
+// with the dispersion: the spread between the highest and lowest usable price as a
+// percentage of the mean. The reference carries the oldest contributing update time
+// and the heaviest source's raw response. It reports false when no quote is usable.
+func weightedReference(quotes []sourceQuote) (ReferencePrice, float64, bool) {
+	used := make([]sourceQuote, 0, len(quotes))
+	for _, q := range quotes {
+		if q.usable() {
+			used = append(used, q)
+		}
+	}
+	if len(used) == 0 {
+		return ReferencePrice{}, 0, false
+	}
+	sortQuotes(used)
+
+	var sum, weights float64
+	low, high := math.Inf(1), math.Inf(-1)
+	reference := ReferencePrice{Currency: "usd", Raw: used[0].price.Raw}
+	for _, q := range used {
+		sum += q.weight * q.price.Value
+		weights += q.weight
+		low, high = math.Min(low, q.price.Value), math.Max(high, q.price.Value)
+		if at := q.price.UpdatedAt; !at.IsZero() && (reference.UpdatedAt.IsZero() || at.Before(reference.UpdatedAt)) {
+			reference.UpdatedAt = at
+		}
+	}
+	reference.Value = sum / weights
+	return reference, (high - low) / reference.Value * 100, true
+}
+
+// getWeightedReference fetches meta's price from every source weighted in
+// oracle.multi_source and combines them, see weightedReference. Stale prices are
+// left out. With no usable source it fails with the heaviest source's error.
+func (m *OracleMonitor) getWeightedReference(ctx context.Context, meta TokenMeta) (ReferencePrice, []sourceQuote, float64, error) {
+	var quotes []sourceQuote
+	for source, weight := range m.oracleConfig().MultiSource.Weights {
+		if weight > 0 {
+			quotes = append(quotes, sourceQuote{source: source, weight: weight})
+		}
+	}
+	sortQuotes(quotes)
+
+	for i := range quotes {
+		q := &quotes[i]
+		route := m.chain.PriceRouteVia(meta, q.source)
+		q.err = retry.Do(ctx, maxRetries, retryDelay, func() error {
+			price, err := m.getReferencePriceVia(ctx, meta, route)
+			if err != nil {
+				if !isRetryable(err) {
+					return retry.Permanent(err)
+				}
+				return err
+			}
+			q.price = price
+			return nil
+		})
+		if q.err == nil {
+			q.err = m.checkReferenceAge(meta, q.price)
+		}
+	}
+
+	reference, dispersion, ok := weightedReference(quotes)
+	if !ok {
+		if len(quotes) == 0 {
+			return ReferencePrice{}, nil, 0, fmt.Errorf("no weighted price source")
+		}
+		return ReferencePrice{}, quotes, 0, fmt.Errorf("no usable reference from %d sources: %w", len(quotes), quotes[0].err)
+	}
+	return reference, quotes, dispersion, nil
+}
+
+// referencesDisagree reports whether result's sources spread further apart than
+// oracle.multi_source.max_dispersion_percent
+func (m *OracleMonitor) referencesDisagree(result tokenResult) bool {
+	return len(result.sources) > 1 && result.dispersion > m.oracleConfig().MultiSource.MaxDispersionPercent
+}
+
+// observeDisagreement alerts developers while a token's reference sources disagree with
+// each other, which points at the market or a source rather than the oracle
+func (m *OracleMonitor) observeDisagreement(ctx context.Context, result tokenResult, meta TokenMeta) {
+	usable := 0
+	for _, q := range result.sources {
+		if q.usable() {
+			usable++
+		}
+	}
+	if usable < 2 {
+		return
+	}
+	severity := alerts.SeverityOK
+	if m.referencesDisagree(result) {
+		severity = alerts.SeverityWarning
+	}
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(meta.TableName), Metric: "reference_disagreement"}
+	details := fmt.Sprintf("Chain: %s\nToken: %s\nOnchain: $%.6f\n%s\n\n"+
+		"The reference sources disagree with each other, likely a market dislocation or a bad source. "+
+		"The token's deviation alerts are kept off the business channel while this lasts.",
+		m.chain.Name, meta.TableName, result.onchainPrice, m.formatSources(result))
+	m.observe(ctx, key, severity, result.dispersion, "", details, false, "")
+}
+
+// formatSources lists every source's price, age and weight behind a weighted reference
+func (m *OracleMonitor) formatSources(result tokenResult) string {
+	lines := []string{fmt.Sprintf("Sources (weighted $%.6f, %.2f%% apart):", result.dexPrice, result.dispersion)}
+	for _, q := range result.sources {
+		if q.err != nil {
+			lines = append(lines, fmt.Sprintf("  %s: unavailable (%v), weight %g", q.source, q.err, q.weight))
+			continue
+		}
+		line := fmt.Sprintf("  %s: $%.6f", q.source, q.price.Value)
+		if !q.price.UpdatedAt.IsZero() {
+			line += ", updated " + alerts.FormatAge(q.price.UpdatedAt, m.now())
+		}
+		lines = append(lines, fmt.Sprintf("%s, weight %g", line, q.weight))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func registerReferenceDisagreementPolicy(alertManager *alerts.Manager, jobName string) {
+	alertManager.RegisterPolicy(jobName, "reference_disagreement", alerts.AlertPolicy{
+		MinValueChange:        50.0, // 50% change in dispersion
+		CooldownWarning:       1 * time.Hour,
+		CooldownCritical:      1 * time.Hour,
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: 2,
+	})
+}
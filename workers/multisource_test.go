@@ -0,0 +1,201 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestWeightedReference(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	quote := func(source string, weight, value float64) sourceQuote {
+		return sourceQuote{source: source, weight: weight, price: ReferencePrice{Value: value, Raw: source, UpdatedAt: now}}
+	}
+	failed := sourceQuote{source: "defillama", weight: 0.5, err: errNoPriceData}
+
+	tests := []struct {
+		name           string
+		quotes         []sourceQuote
+		wantValue      float64
+		wantDispersion float64
+		wantRaw        string
+		wantOK         bool
+	}{
+		{"single source", []sourceQuote{quote("alchemy", 1, 100)}, 100, 0, "alchemy", true},
+		{"weighted mean", []sourceQuote{quote("alchemy", 1, 100), quote("coingecko", 0.5, 106)}, 102, 6 / 102.0 * 100, "alchemy", true},
+		{"failed source left out", []sourceQuote{quote("coingecko", 0.8, 100), failed}, 100, 0, "coingecko", true},
+		{"zero weight left out", []sourceQuote{quote("alchemy", 1, 100), quote("coingecko", 0, 500)}, 100, 0, "alchemy", true},
+		{"invalid price left out", []sourceQuote{quote("alchemy", 1, 100), quote("coingecko", 1, math.Inf(1))}, 100, 0, "alchemy", true},
+		{"sources far apart", []sourceQuote{quote("alchemy", 1, 100), quote("coingecko", 1, 130)}, 115, 30 / 115.0 * 100, "alchemy", true},
+		{"no usable source", []sourceQuote{failed}, 0, 0, "", false},
+		{"no sources", nil, 0, 0, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dispersion, ok := weightedReference(tt.quotes)
+			if ok != tt.wantOK || math.Abs(got.Value-tt.wantValue) > 1e-9 || math.Abs(dispersion-tt.wantDispersion) > 1e-9 || got.Raw != tt.wantRaw {
+				t.Errorf("weightedReference() = %v (raw %q), %v, %v; want %v (raw %q), %v, %v",
+					got.Value, got.Raw, dispersion, ok, tt.wantValue, tt.wantRaw, tt.wantDispersion, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestWeightedReferenceDeterministic(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	quotes := []sourceQuote{
+		{source: "defillama", weight: 1, price: ReferencePrice{Value: 0.1 + 0.2, Raw: "defillama", UpdatedAt: now}},
+		{source: "alchemy", weight: 1, price: ReferencePrice{Value: 0.3, Raw: "alchemy", UpdatedAt: now.Add(-time.Minute)}},
+		{source: "coingecko", weight: 1, price: ReferencePrice{Value: 0.30000001, Raw: "coingecko", UpdatedAt: now}},
+	}
+	first, _, _ := weightedReference(quotes)
+	// Equal weights fall back to source name order however the sources answered
+	reversed := []sourceQuote{quotes[2], quotes[0], quotes[1]}
+	second, _, _ := weightedReference(reversed)
+	if first != second {
+		t.Errorf("reference depends on quote order: %+v vs %+v", first, second)
+	}
+	if first.Raw != "alchemy" {
+		t.Errorf("raw = %q, want the alphabetically first of the equally weighted sources", first.Raw)
+	}
+	if !first.UpdatedAt.Equal(now.Add(-time.Minute)) {
+		t.Errorf("updated at = %v, want the oldest contributing update", first.UpdatedAt)
+	}
+}
+
+func TestMultiSourceDisagreement(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	weth := "0x4200000000000000000000000000000000000006"
+	coingeckoPrice := 3300.0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/alchemy/"):
+			fmt.Fprintf(w, `{"data": [{"address": %q, "prices": [{"currency": "usd", "value": "3000"}]}]}`, weth)
+		case strings.HasPrefix(r.URL.Path, "/coingecko/"):
+			fmt.Fprintf(w, `{%q: {"usd": %g, "last_updated_at": %d}}`, weth, coingeckoPrice, now.Add(-2*time.Minute).Unix())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	manager.SetClock(func() time.Time { return now })
+	cfg := config.DefaultConfig()
+	cfg.Oracle.MultiSource = config.MultiSourceConfig{
+		Enabled:              true,
+		Weights:              map[string]float64{"alchemy": 1, "coingecko": 1, "defillama": 0},
+		MaxDispersionPercent: 2,
+	}
+	mToken := "0x628ff693426583D9a7FB391E54366292F509D457"
+	meta := TokenMeta{Symbol: "weth", MTokAddr: mToken, Decimals: 18, TableName: "WETH", PriceAddress: weth}
+	m := &OracleMonitor{
+		chain: ChainConfig{ID: "multi_test", Name: "Multi Test", PriceSource: PriceSourceAlchemy, PriceNetwork: "base-mainnet",
+			Tokens: map[string]TokenMeta{"weth": meta}},
+		alchemyKey:   "test-key",
+		priceAPIs:    PriceAPIs{Alchemy: server.URL + "/alchemy", CoinGecko: server.URL + "/coingecko"},
+		httpClient:   server.Client(),
+		alertManager: manager,
+		configs:      config.NewHolder(cfg),
+		clock:        func() time.Time { return now },
+	}
+	RegisterOraclePolicies(manager, &cfg.Oracle, m.Name())
+	batched := map[common.Address]*big.Int{common.HexToAddress(mToken): new(big.Int).Mul(big.NewInt(3000), big.NewInt(1e18))}
+	ctx := context.Background()
+
+	result := m.checkToken(ctx, "weth", meta, batched)
+	if result.err != nil {
+		t.Fatalf("checkToken: %v", result.err)
+	}
+	if result.dexPrice != 3150 || len(result.sources) != 2 {
+		t.Fatalf("reference = %v from %d sources, want 3150 from 2", result.dexPrice, len(result.sources))
+	}
+	m.processTokenResult(ctx, result, false)
+
+	incidents := manager.GetActiveIncidents()
+	disagreement, ok := incidents[alerts.AlertKey{Job: m.Name(), Entity: m.entity("WETH"), Metric: "reference_disagreement"}]
+	if !ok || disagreement.Severity != alerts.SeverityWarning {
+		t.Fatalf("disagreement incident = %+v (active %v), want WARNING", disagreement, ok)
+	}
+	for _, want := range []string{"alchemy: $3000.000000, weight 1", "coingecko: $3300.000000, updated 2m ago, weight 1"} {
+		if !strings.Contains(disagreement.LastMessage, want) {
+			t.Errorf("disagreement details missing %q:\n%s", want, disagreement.LastMessage)
+		}
+	}
+
+	// Agreeing sources resolve it
+	coingeckoPrice = 3010
+	now = now.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		result = m.checkToken(ctx, "weth", meta, batched)
+		m.processTokenResult(ctx, result, false)
+	}
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("WETH"), Metric: "reference_disagreement"}
+	if state, ok := manager.GetActiveIncidents()[key]; ok && state.Severity != alerts.SeverityOK {
+		t.Errorf("disagreement still %s once the sources agree", state.Severity)
+	}
+
+	// Without a usable source the token fails
+	m.priceAPIs = PriceAPIs{Alchemy: server.URL + "/missing", CoinGecko: server.URL + "/missing"}
+	if _, _, _, err := m.getWeightedReference(ctx, meta); err == nil || errors.Is(err, errStaleReference) {
+		t.Errorf("getWeightedReference without sources = %v, want an error", err)
+	}
+}
+
+func TestMultiSourceQueriesOnlyConfiguredWeights(t *testing.T) {
+	weth := "0x4200000000000000000000000000000000000006"
+	var queried []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		source, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		queried = append(queried, source)
+		switch source {
+		case "coingecko":
+			fmt.Fprintf(w, `{%q: {"usd": 3000, "last_updated_at": %d}}`, weth, time.Now().Unix())
+		case "alchemy":
+			fmt.Fprintf(w, `{"data": [{"address": %q, "prices": [{"currency": "usd", "value": "3000"}]}]}`, weth)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// The configured weights replace the default alchemy/coingecko/defillama weights
+	cfg, err := config.LoadBytes("config.json", []byte(`{"oracle": {"multi_source": {"enabled": true, "weights": {"coingecko": 1}}}}`))
+	if err != nil {
+		t.Fatalf("LoadBytes: %v", err)
+	}
+	if len(cfg.Oracle.MultiSource.Weights) != 1 {
+		t.Fatalf("weights = %v, want only coingecko", cfg.Oracle.MultiSource.Weights)
+	}
+	meta := TokenMeta{Symbol: "weth", Decimals: 18, TableName: "WETH", PriceAddress: weth}
+	m := &OracleMonitor{
+		chain: ChainConfig{ID: "multi_test", Name: "Multi Test", PriceSource: PriceSourceAlchemy, PriceNetwork: "base-mainnet",
+			Tokens: map[string]TokenMeta{"weth": meta}},
+		alchemyKey: "test-key",
+		priceAPIs:  PriceAPIs{Alchemy: server.URL + "/alchemy", CoinGecko: server.URL + "/coingecko", DefiLlama: server.URL + "/defillama"},
+		httpClient: server.Client(),
+		configs:    config.NewHolder(cfg),
+	}
+
+	_, quotes, _, err := m.getWeightedReference(context.Background(), meta)
+	if err != nil {
+		t.Fatalf("getWeightedReference: %v", err)
+	}
+	if len(quotes) != 1 || quotes[0].source != "coingecko" {
+		t.Errorf("quotes = %+v, want coingecko only", quotes)
+	}
+	if len(queried) != 1 || queried[0] != "coingecko" {
+		t.Errorf("queried %q, want only coingecko", queried)
+	}
+}
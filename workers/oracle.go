@@ -11,16 +11,24 @@ import (
 	"math"
 	"math/big"
 	"net/http"
+	"runtime/debug"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/0x0Glitch/alerts"
 	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/contract"
+	"github.com/0x0Glitch/internal/httpclient"
+	"github.com/0x0Glitch/internal/retry"
+	"github.com/0x0Glitch/reporter"
+	"github.com/0x0Glitch/tracing"
 )
 
 const (
@@ -32,114 +40,361 @@ const (
 
 // OracleMonitor monitors oracle prices for a specific chain
 type OracleMonitor struct {
-	chain          ChainConfig
-	client         *ethclient.Client
-	oracle         *OracleCaller
-	alchemyKey     string
-	alertManager   *alerts.Manager
-	httpClient     *http.Client
-	config         *config.OracleConfig
-	mu             sync.Mutex
-	lastSuccess    time.Time
-	consecutiveErr int
-	failures       int
+	chain            ChainConfig
+	dial             ClientFactory
+	connectMu        sync.Mutex     // serializes connect
+	connected        atomic.Bool    // set once the client is dialed and the callers below are ready
+	client           *ManagedClient // nil until connected, see connect
+	oracle           *contract.OracleCaller
+	multicall        *MulticallCaller
+	multicallState   atomic.Int32 // multicallUnknown, multicallAvailable or multicallUnavailable
+	oracleFlag       atomic.Int32 // last isPriceOracle() reading: oracleFlagUnknown, oracleFlagTrue or oracleFlagFalse
+	alchemyKey       string
+	alertManager     *alerts.Manager
+	httpClient       *http.Client
+	configs          *config.Holder
+	limiter          *Limiter
+	mu               sync.Mutex
+	lastSuccess      time.Time
+	consecutiveErr   int
+	errorStreak      int       // consecutive cycles with token errors not covered by their own alert
+	failures         int       // consecutive high-error cycles, see circuitAllows
+	circuitOpenedAt  time.Time // when the circuit last opened or a probe failed
+	unconnectedSince time.Time // first failed connect while not yet connected, see connect
+	broadMove        bool      // volatile alerts routed to developers during a market-wide move
+	fastPath         map[string]*fastPathState
+	degraded         map[string]string          // tokens whose onchain read fails permanently, with the last error
+	missingRef       map[string]int             // consecutive cycles without a reference price per token
+	staleRef         map[string]int             // consecutive cycles with a too old reference price per token
+	previous         map[string]previousReading // last successful reading per token, see trendFrom
+	acceptedRefs     map[string]ReferencePrice  // last reference price that passed screenReference per token
+	refRejections    map[string]int             // consecutive rejected reference prices per token
+	rateLimited      bool                       // provider rate limited this cycle; remaining onchain reads are deferred
+	rpcBackoffUntil  time.Time                  // provider-requested backoff for onchain reads
+	reporter         *reporter.Reporter
+	latest           map[string]TokenPrice            // most recent reading per token, see Prices
+	prices           *PriceStore                      // shared with other jobs; nil publishes nowhere
+	maintenanceModes *MaintenanceModes                // nil never puts the chain in maintenance
+	maintenance      atomic.Pointer[ChainMaintenance] // this cycle's maintenance mode, see observe
+	priceAPIs        PriceAPIs
+	fx               *FXRates                // live pegs for tokens with a PegCurrency; nil uses PegValue
+	mantissas        map[string][]*big.Int   // recent distinct raw prices per token, see checkPrecision
+	feeds            feedReader              // feed prices for oracle.feed_comparison
+	tokens           *ERC20Reader            // underlying asset decimals, see verifyDecimals
+	feedRelations    map[string]feedRelation // last feed relationship of deviating tokens
+	digestCycles     int                     // cycles since the last cycle report, see sendDigest
+	clock            func() time.Time        // nil means time.Now
+	checkLocks       sync.Map                // token key -> chan struct{}, see tokenLock
 }
 
 type tokenResult struct {
 	symbol       string
 	onchainPrice float64
+	mantissa     *big.Int // raw getUnderlyingPrice value behind onchainPrice
 	dexPrice     float64
 	deviation    float64
+	dexCached    bool          // dexPrice reused from a previous cycle (fast path or rejected reference)
+	refRejected  bool          // the fetched reference was rejected and the last accepted one used
+	sources      []sourceQuote // every source behind a weighted reference, see oracle.multi_source
+	dispersion   float64       // spread between the sources' prices, % of the weighted reference
+	dexUpdatedAt time.Time     // when the reference source last updated dexPrice; zero if not reported
+	rpcLatency   time.Duration // time taken by the onchain price read, batched or individual
 	err          error
+	errClass     RPCErrorClass // classification of an onchain read error
+	pegDeviation float64       // signed % from peg for stablecoins, negative below peg
+	dexDeviation float64       // signed % from DEX for stablecoins
+	reference    string        // stablecoins: referencePeg or referenceDEX, see stableDeviation
+	peg          float64       // peg the stablecoin was compared against
+	livePeg      bool          // peg is the live rate of the token's PegCurrency
+	feedPrice    FeedPrice     // oracle feed price, read only for deviating volatile tokens
+	feedRelation feedRelation
+	feedErr      error // feed read failed
 }
 
-// NewOracleMonitor creates a new oracle monitor for a specific chain
+// NewOracleMonitor creates a new oracle monitor for a specific chain. The chain's RPC
+// is dialed through dial on the first run, see connect.
 func NewOracleMonitor(
 	chain ChainConfig,
-	client *ethclient.Client,
+	dial ClientFactory,
 	alchemyKey string,
 	alertManager *alerts.Manager,
-	cfg *config.OracleConfig,
-) (*OracleMonitor, error) {
-	oracle, err := NewOracleCaller(common.HexToAddress(chain.OracleAddress), client)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create oracle caller: %w", err)
-	}
-
+	configs *config.Holder,
+	limiter *Limiter,
+) *OracleMonitor {
 	// Register alert policies
-	registerOraclePolicies(alertManager, cfg, string(chain.ID))
+	RegisterOraclePolicies(alertManager, &configs.Get().Oracle, string(chain.ID))
 
-	return &OracleMonitor{
+	m := &OracleMonitor{
 		chain:        chain,
-		client:       client,
-		oracle:       oracle,
+		dial:         dial,
 		alchemyKey:   alchemyKey,
+		priceAPIs:    DefaultPriceAPIs,
 		alertManager: alertManager,
-		httpClient: &http.Client{
-			Timeout: httpTimeout,
-		},
-		config:      cfg,
-		lastSuccess: time.Now(),
-	}, nil
+		httpClient:   httpclient.New(httpTimeout),
+		configs:      configs,
+		limiter:      limiter,
+		lastSuccess:  time.Now(),
+	}
+	m.logThresholdWarnings(configs.Get().Oracle)
+	m.applyReportMode(configs.Get())
+	m.setCircuitMetric(false)
+	return m
 }
 
 func (m *OracleMonitor) Name() string {
 	return fmt.Sprintf("oracle_%s", m.chain.ID)
 }
 
+func (m *OracleMonitor) ChainID() string {
+	return string(m.chain.ID)
+}
+
+// entity namespaces an alert entity with the monitor's chain
+func (m *OracleMonitor) entity(id string) string {
+	return alerts.ChainEntity(string(m.chain.ID), id)
+}
+
+// SetErrorReporter sends panics recovered while checking tokens to r
+func (m *OracleMonitor) SetErrorReporter(r *reporter.Reporter) {
+	m.reporter = r
+}
+
+// SetPriceAPIs overrides the reference price API base URLs
+func (m *OracleMonitor) SetPriceAPIs(apis PriceAPIs) {
+	m.priceAPIs = apis
+}
+
+// SetFXRates prices stablecoins with a PegCurrency against live exchange rates
+func (m *OracleMonitor) SetFXRates(fx *FXRates) {
+	m.fx = fx
+}
+
+// SetPriceStore publishes each successful token check to store
+func (m *OracleMonitor) SetPriceStore(store *PriceStore) {
+	m.prices = store
+}
+
+// SetClock replaces time.Now for rate-limit backoffs, health tracking and price
+// timestamps. It must be called before the monitor runs.
+func (m *OracleMonitor) SetClock(clock func() time.Time) {
+	m.clock = clock
+	m.lastSuccess = clock()
+}
+
+func (m *OracleMonitor) now() time.Time {
+	if m.clock == nil {
+		return time.Now()
+	}
+	return m.clock()
+}
+
 func (m *OracleMonitor) Interval() time.Duration {
-	if m.config != nil && m.config.CheckIntervalSeconds > 0 {
-		return time.Duration(m.config.CheckIntervalSeconds) * time.Second
+	if cfg := m.oracleConfig(); cfg.CheckIntervalSeconds.Duration() > 0 {
+		return cfg.CheckIntervalSeconds.Duration()
 	}
 	return 30 * time.Second
 }
 
+// Reload re-registers alert policies after the active configuration has been replaced
+func (m *OracleMonitor) Reload(cfg *config.Config) error {
+	RegisterOraclePolicies(m.alertManager, &cfg.Oracle, string(m.chain.ID))
+	m.logThresholdWarnings(cfg.Oracle)
+	m.applyReportMode(cfg)
+	log.Printf("[%s][%s] configuration reloaded (%d active tokens)", m.Name(), m.chain.Name, len(m.activeTokens()))
+	m.CheckTokenCount(context.Background(), cfg)
+	return nil
+}
+
+// CheckTokenCount alerts developers when the number of active tokens differs from the
+// chain's expected count, catching tokens silently dropped by a config regression
+func (m *OracleMonitor) CheckTokenCount(ctx context.Context, cfg *config.Config) {
+	expected := m.chain.ExpectedTokenCount
+	if override := cfg.Chains[string(m.chain.ID)].ExpectedTokenCount; override > 0 {
+		expected = override
+	}
+	if expected == 0 {
+		return
+	}
+
+	active := len(m.activeTokens())
+	severity := alerts.SeverityOK
+	if active != expected {
+		severity = alerts.SeverityWarning
+		log.Printf("[%s][%s] monitoring %d tokens, expected %d", m.Name(), m.chain.Name, active, expected)
+	}
+
+	key := alerts.AlertKey{
+		Job:    m.Name(),
+		Entity: m.entity("chain"),
+		Metric: "token_count",
+	}
+	details := fmt.Sprintf(
+		"Chain: %s\nActive tokens: %d\nExpected: %d\nConfigured: %d (%d disabled)",
+		m.chain.Name, active, expected, len(m.chain.Tokens), len(m.chain.Tokens)-active,
+	)
+	if err := m.observe(ctx, key, severity, float64(active), "", details, false, ""); err != nil {
+		log.Printf("[%s][%s] failed to observe token count: %v", m.Name(), m.chain.Name, err)
+	}
+}
+
+// defaultOracleConfig backs missing oracle settings, see oracleConfig
+var defaultOracleConfig = config.DefaultConfig().Oracle
+
+// oracleConfig returns the currently active oracle configuration with unusable
+// thresholds replaced by defaults. It is never nil: without a configuration the
+// defaults apply rather than silently never alerting.
+func (m *OracleMonitor) oracleConfig() *config.OracleConfig {
+	cfg := defaultOracleConfig
+	if m.configs != nil && m.configs.Get() != nil {
+		cfg = m.configs.Get().Oracle
+	}
+	cfg, _ = resolveThresholds(cfg)
+	return &cfg
+}
+
+// resolveThresholds replaces zero or negative deviation thresholds with the
+// defaults, which would otherwise classify every reading as critical, and
+// describes each substitution
+func resolveThresholds(cfg config.OracleConfig) (config.OracleConfig, []string) {
+	var warnings []string
+	resolve := func(name string, t *config.ThresholdConfig, def config.ThresholdConfig) {
+		if t.WarningThresholdPercent <= 0 {
+			warnings = append(warnings, fmt.Sprintf("oracle.%s.warning_threshold_percent is %g, using default %g",
+				name, t.WarningThresholdPercent, def.WarningThresholdPercent))
+			t.WarningThresholdPercent = def.WarningThresholdPercent
+		}
+		if t.CriticalThresholdPercent <= 0 {
+			warnings = append(warnings, fmt.Sprintf("oracle.%s.critical_threshold_percent is %g, using default %g",
+				name, t.CriticalThresholdPercent, def.CriticalThresholdPercent))
+			t.CriticalThresholdPercent = def.CriticalThresholdPercent
+		}
+		if t.CriticalThresholdPercent < t.WarningThresholdPercent {
+			warnings = append(warnings, fmt.Sprintf("oracle.%s.critical_threshold_percent %g is below warning, using %g",
+				name, t.CriticalThresholdPercent, t.WarningThresholdPercent))
+			t.CriticalThresholdPercent = t.WarningThresholdPercent
+		}
+	}
+	resolve("stablecoin", &cfg.Stablecoin.ThresholdConfig, defaultOracleConfig.Stablecoin.ThresholdConfig)
+	resolve("volatile", &cfg.Volatile.ThresholdConfig, defaultOracleConfig.Volatile.ThresholdConfig)
+	return cfg, warnings
+}
+
+// logThresholdWarnings reports thresholds that resolveThresholds had to replace
+func (m *OracleMonitor) logThresholdWarnings(cfg config.OracleConfig) {
+	_, warnings := resolveThresholds(cfg)
+	for _, warning := range warnings {
+		log.Printf("[%s][%s] %s", m.Name(), m.chain.Name, warning)
+	}
+}
+
+// activeTokens returns the chain's tokens excluding those disabled in config
+func (m *OracleMonitor) activeTokens() map[string]TokenMeta {
+	cfg := m.oracleConfig()
+	if len(cfg.DisabledTokens) == 0 {
+		return m.chain.Tokens
+	}
+
+	tokens := make(map[string]TokenMeta, len(m.chain.Tokens))
+	for symbol, meta := range m.chain.Tokens {
+		if cfg.TokenDisabled(string(m.chain.ID), symbol) {
+			continue
+		}
+		tokens[symbol] = meta
+	}
+	return tokens
+}
+
 func (m *OracleMonitor) Run(ctx context.Context) error {
-	log.Printf("[%s][%s] checking %d tokens", m.Name(), m.chain.Name, len(m.chain.Tokens))
+	tokens := m.activeTokens()
+	log.Printf("[%s][%s] checking %d tokens", m.Name(), m.chain.Name, len(tokens))
+	m.checkMaintenance(ctx)
+
+	if err := m.connect(ctx); err != nil {
+		return err
+	}
+
+	// Circuit breaker - skip cycles after repeated failures, probing periodically
+	if !m.circuitAllows(ctx) {
+		return errors.New("circuit breaker open")
+	}
 
-	// Simple circuit breaker - skip if too many recent failures
 	m.mu.Lock()
-	currentFailures := m.failures
+	m.rateLimited = false
+	backoffUntil := m.rpcBackoffUntil
 	m.mu.Unlock()
 
-	if currentFailures >= 5 {
-		log.Printf("[%s][%s] circuit open (%d failures), skipping check", m.Name(), m.chain.Name, currentFailures)
-		return errors.New("circuit breaker open")
+	if m.now().Before(backoffUntil) {
+		log.Printf("[%s][%s] RPC provider backoff until %s, deferring check", m.Name(), m.chain.Name, backoffUntil.Format("15:04:05"))
+		return nil
+	}
+
+	m.checkIsPriceOracle(ctx)
+
+	// On-demand checks of these tokens wait for the cycle to finish feeding the alerts
+	unlock, err := m.lockTokens(ctx, tokens)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
-	results := m.checkAllTokens(ctx)
+	results := m.checkAllTokens(ctx, tokens)
 
 	var errorResults []tokenResult
+	var digest []digestRow
+	health := healthInputs{tokens: len(tokens)}
 	successCount := 0
 
+	// Downgrade volatile alerts when many tokens deviate together
+	broadMove := m.updateBroadMove(ctx, m.measureBreadth(results))
+
 	for _, result := range results {
+		m.recordPrice(result)
 		if result.err != nil {
 			errorResults = append(errorResults, result)
 			log.Printf("[%s][%s] %s: %v", m.Name(), m.chain.Name, result.symbol, result.err)
-			m.observeTokenError(ctx, result.symbol, result.err)
+			switch result.errClass {
+			case RPCErrorPermanent:
+				m.markDegraded(ctx, result.symbol, result.err)
+			case RPCErrorRateLimited:
+				// Provider-wide throttling, not a token problem; the read is retried next cycle
+			default:
+				m.observeTokenError(ctx, result.symbol, result.err)
+			}
+			if errors.Is(result.err, errNoPriceData) {
+				m.trackMissingReference(ctx, result.symbol, result.err)
+			}
+			if errors.Is(result.err, errStaleReference) {
+				m.trackStaleReference(ctx, result)
+			}
+			m.resetFastPath(result.symbol)
+			m.forgetReading(result.symbol)
+			digest = append(digest, m.digestRowFor(result, ""))
+			health.add(result, "", 0, m.now())
 			continue
 		}
 
 		successCount++
-		m.processTokenResult(ctx, result)
+		m.clearDegraded(ctx, result.symbol)
+		m.clearMissingReference(ctx, result.symbol)
+		m.clearStaleReference(ctx, result.symbol)
+		severity := m.processTokenResult(ctx, result, broadMove)
+		digest = append(digest, m.digestRowFor(result, severity))
+		health.add(result, severity, m.criticalThreshold(result), m.now())
 	}
+	m.sendDigest(ctx, digest)
+	m.updateHealthScore(ctx, health)
 
 	// Update health
-	m.updateSystemHealth(ctx, successCount, errorResults)
+	m.updateSystemHealth(ctx, len(tokens), successCount, errorResults)
+	m.checkErrorStreak(ctx, errorResults)
 
 	// Update circuit breaker
-	tokenCount := len(m.chain.Tokens)
+	tokenCount := len(tokens)
 	if tokenCount == 0 {
 		return nil // No tokens to check
 	}
 	errorRate := float64(len(errorResults)) / float64(tokenCount)
-	m.mu.Lock()
-	if errorRate > 0.5 {
-		m.failures++
-	} else {
-		m.failures = 0
-	}
-	m.mu.Unlock()
+	m.recordCycleOutcome(ctx, errorRate > 0.5)
 
 	if errorRate > 0.5 {
 		return fmt.Errorf("high error rate: %.1f%%", errorRate*100)
@@ -148,12 +403,23 @@ func (m *OracleMonitor) Run(ctx context.Context) error {
 	return nil
 }
 
-func (m *OracleMonitor) checkAllTokens(ctx context.Context) []tokenResult {
+func (m *OracleMonitor) checkAllTokens(ctx context.Context, tokens map[string]TokenMeta) []tokenResult {
+	// Read all onchain prices in one round-trip; tokens missing from the batch are read individually
+	batchStart := time.Now()
+	batched, err := m.getOnchainPricesBatch(ctx, tokens)
+	batchLatency := time.Since(batchStart)
+	if err != nil && ClassifyRPCError(err) == RPCErrorRateLimited {
+		m.deferRPC(err)
+	}
+	if err != nil && m.multicallState.Load() == multicallAvailable {
+		log.Printf("[%s][%s] batched price read failed, using per-token reads: %v", m.Name(), m.chain.Name, err)
+	}
+
 	sem := make(chan struct{}, maxConcurrentTokens)
-	resultChan := make(chan tokenResult, len(m.chain.Tokens))
+	resultChan := make(chan tokenResult, len(tokens))
 	var wg sync.WaitGroup
 
-	for symbol, meta := range m.chain.Tokens {
+	for symbol, meta := range tokens {
 		wg.Add(1)
 		go func(sym string, token TokenMeta) {
 			sem <- struct{}{} // Acquire semaphore first
@@ -161,12 +427,20 @@ func (m *OracleMonitor) checkAllTokens(ctx context.Context) []tokenResult {
 				<-sem // Release semaphore in defer
 				if r := recover(); r != nil {
 					log.Printf("[%s][%s] panic checking %s: %v", m.Name(), m.chain.Name, sym, r)
+					m.reporter.ReportPanic(r, debug.Stack(), map[string]string{
+						"job": m.Name(), "chain": string(m.chain.ID), "symbol": sym,
+					})
 					resultChan <- tokenResult{symbol: sym, err: fmt.Errorf("panic: %v", r)}
 				}
 				wg.Done()
 			}()
 
-			result := m.checkToken(ctx, sym, token)
+			tokenCtx, span := tracing.Start(ctx, "oracle.check_token", tracing.Chain(m.chain.Name), tracing.Symbol(sym))
+			result := m.checkToken(tokenCtx, sym, token, batched)
+			if result.rpcLatency == 0 {
+				result.rpcLatency = batchLatency // price came from the batch
+			}
+			tracing.End(span, result.err)
 			resultChan <- result
 		}(symbol, meta)
 	}
@@ -183,7 +457,7 @@ func (m *OracleMonitor) checkAllTokens(ctx context.Context) []tokenResult {
 	return results
 }
 
-func (m *OracleMonitor) checkToken(ctx context.Context, symbol string, meta TokenMeta) tokenResult {
+func (m *OracleMonitor) checkToken(ctx context.Context, symbol string, meta TokenMeta, batched map[common.Address]*big.Int) tokenResult {
 	result := tokenResult{symbol: symbol}
 
 	if meta.Decimals > 36 {
@@ -191,43 +465,113 @@ func (m *OracleMonitor) checkToken(ctx context.Context, symbol string, meta Toke
 		return result
 	}
 
-	// Get onchain price with retry
-	var onchainPrice float64
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		price, err := m.getOnchainPrice(ctx, meta.MTokAddr, meta.Decimals)
-		if err == nil {
-			onchainPrice = price
-			break
-		}
-		if attempt == maxRetries-1 {
-			result.err = fmt.Errorf("onchain price: %w", err)
+	// Get onchain price from the batch, or individually retrying transient errors.
+	// Rate limits defer the read to the next cycle; permanent errors fail at once.
+	mantissa, ok := batched[common.HexToAddress(meta.MTokAddr)]
+	if !ok {
+		readStart := time.Now()
+		err := retry.Do(ctx, maxRetries, retryDelay, func() error {
+			if m.rpcDeferred() {
+				return retry.Permanent(errRPCDeferred)
+			}
+			price, err := m.getOnchainPrice(ctx, meta.MTokAddr)
+			if err != nil {
+				class := ClassifyRPCError(err)
+				if class == RPCErrorRateLimited {
+					m.deferRPC(err)
+				}
+				if !class.Retryable() {
+					return retry.Permanent(err)
+				}
+				return err
+			}
+			mantissa = price
+			return nil
+		})
+		result.rpcLatency = time.Since(readStart)
+		if err != nil {
+			result.errClass = ClassifyRPCError(err)
+			result.err = fmt.Errorf("onchain price (%s): %w", result.errClass, err)
 			return result
 		}
-		time.Sleep(retryDelay * time.Duration(attempt+1))
 	}
+	onchainPrice := scalePrice(mantissa, meta.Decimals)
 	result.onchainPrice = onchainPrice
+	result.mantissa = mantissa
 
-	// Get DEX price with retry (skip for tokens without DEX price source)
+	// Get DEX price with retry (skip for tokens without DEX price source).
+	// When the onchain price is unchanged and the last reading was OK, reuse the previous reference.
 	var dexPrice float64
+	var cached ReferencePrice
+	useCache := false
 	if !meta.SkipDEXPrice {
-		for attempt := 0; attempt < maxRetries; attempt++ {
-			price, err := m.getAlchemyPrice(ctx, meta)
-			if err == nil {
-				dexPrice = price
-				break
-			}
-			if attempt == maxRetries-1 {
-				result.err = fmt.Errorf("dex price: %w", err)
-				return result
-			}
-			time.Sleep(retryDelay * time.Duration(attempt+1))
+		cached, useCache = m.cachedDexPrice(symbol, onchainPrice)
+		// A cached reference that has aged past the limit is fetched again
+		useCache = useCache && m.checkReferenceAge(meta, cached) == nil
+	}
+	if useCache {
+		dexPrice = cached.Value
+		result.dexPrice = cached.Value
+		result.dexUpdatedAt = cached.UpdatedAt
+		result.dexCached = true
+	} else if !meta.SkipDEXPrice {
+		var reference ReferencePrice
+		var err error
+		if m.oracleConfig().MultiSource.Enabled {
+			reference, result.sources, result.dispersion, err = m.getWeightedReference(ctx, meta)
+		} else {
+			err = retry.Do(ctx, maxRetries, retryDelay, func() error {
+				price, err := m.getReferencePrice(ctx, meta)
+				if err != nil {
+					if !isRetryable(err) {
+						return retry.Permanent(err)
+					}
+					return err
+				}
+				reference = price
+				return nil
+			})
 		}
-		result.dexPrice = dexPrice
+		if err != nil {
+			result.err = fmt.Errorf("dex price: %w", err)
+			return result
+		}
+		if err := m.checkReferenceAge(meta, reference); err != nil {
+			result.dexUpdatedAt = reference.UpdatedAt
+			result.err = fmt.Errorf("dex price: %w", err)
+			return result
+		}
+		reference, result.refRejected, err = m.screenReference(ctx, meta, reference)
+		if err == nil && result.refRejected {
+			// The last accepted price stands in for the rejected one only while fresh
+			err = m.checkReferenceAge(meta, reference)
+		}
+		if err != nil {
+			result.dexUpdatedAt = reference.UpdatedAt
+			result.err = fmt.Errorf("dex price: %w", err)
+			return result
+		}
+		dexPrice = reference.Value
+		result.dexPrice = reference.Value
+		result.dexUpdatedAt = reference.UpdatedAt
+		result.dexCached = result.refRejected
 	}
 
-	// Calculate deviation
-	if meta.IsStablecoin && meta.PegValue > 0 {
-		result.deviation = math.Abs((onchainPrice-meta.PegValue)/meta.PegValue) * 100
+	// Calculate deviation. Stablecoins are measured against both peg and DEX and
+	// classified on one of them per their DeviationStrategy.
+	if meta.IsStablecoin {
+		result.peg, result.livePeg = m.pegValue(ctx, meta)
+	}
+	if result.peg > 0 || (meta.IsStablecoin && dexPrice > 0) {
+		if result.peg > 0 {
+			result.pegDeviation = (onchainPrice - result.peg) / result.peg * 100
+		}
+		if dexPrice > 0 {
+			result.dexDeviation = (onchainPrice - dexPrice) / dexPrice * 100
+		}
+		var signed float64
+		signed, result.reference = stableDeviation(meta.DeviationStrategy, result.pegDeviation, result.peg > 0, result.dexDeviation, dexPrice > 0)
+		result.deviation = math.Abs(signed)
 	} else if dexPrice > 0 {
 		result.deviation = math.Abs((onchainPrice-dexPrice)/dexPrice) * 100
 	} else if meta.SkipDEXPrice {
@@ -242,149 +586,310 @@ func (m *OracleMonitor) checkToken(ctx context.Context, symbol string, meta Toke
 	return result
 }
 
-func (m *OracleMonitor) processTokenResult(ctx context.Context, result tokenResult) {
+// pegValue returns the peg a stablecoin is compared against: the live USD rate of its
+// PegCurrency when available, otherwise the static PegValue
+func (m *OracleMonitor) pegValue(ctx context.Context, meta TokenMeta) (float64, bool) {
+	if meta.PegCurrency == "" || m.fx == nil {
+		return meta.PegValue, false
+	}
+	rate, err := m.fx.USDRate(ctx, meta.PegCurrency)
+	if err != nil {
+		log.Printf("[%s][%s] %s: using static peg $%.4f: %v", m.Name(), m.chain.Name, meta.Symbol, meta.PegValue, err)
+		return meta.PegValue, false
+	}
+	return rate, true
+}
+
+// formatPeg describes the peg a stablecoin result was compared against
+func formatPeg(result tokenResult, meta TokenMeta) string {
+	if result.livePeg {
+		return fmt.Sprintf("$%.4f (live %s/USD)", result.peg, meta.PegCurrency)
+	}
+	return fmt.Sprintf("$%.2f", result.peg)
+}
+
+func (m *OracleMonitor) processTokenResult(ctx context.Context, result tokenResult, broadMove bool) alerts.Severity {
 	meta, exists := m.chain.Tokens[result.symbol]
 	if !exists {
 		log.Printf("[%s][%s] token %s not found in config", m.Name(), m.chain.Name, result.symbol)
-		return
+		return alerts.SeverityOK
 	}
-	severity := m.classifyDeviation(result.deviation, meta)
+	severity := m.classifyDeviation(result, meta)
+	m.recordFastPath(result, severity)
+	m.observeDisagreement(ctx, result, meta)
+	m.checkPrecision(ctx, result, meta)
+	previous := m.previousReading(result.symbol)
+	trend := trendFrom(previous, result.deviation)
+	m.recordReading(result, severity)
 
 	if meta.IsStablecoin {
-		log.Printf("[%s][%s] %s: dev=%.4f%%, onchain=$%.6f, peg=$%.2f, dex=$%.6f, sev=%s",
-			m.Name(), m.chain.Name, result.symbol, result.deviation, result.onchainPrice, meta.PegValue, result.dexPrice, severity)
+		log.Printf("[%s][%s] %s: dev=%.4f%%%s (vs %s), onchain=$%.6f, peg=%s, dex=$%.6f, sev=%s",
+			m.Name(), m.chain.Name, result.symbol, result.deviation, trend.arrow(), result.referenceLabel(), result.onchainPrice, formatPeg(result, meta), result.dexPrice, severity)
 	} else {
-		log.Printf("[%s][%s] %s: dev=%.4f%%, onchain=$%.6f, dex=$%.6f, sev=%s",
-			m.Name(), m.chain.Name, result.symbol, result.deviation, result.onchainPrice, result.dexPrice, severity)
+		log.Printf("[%s][%s] %s: dev=%.4f%%%s, onchain=$%.6f, dex=$%.6f, sev=%s",
+			m.Name(), m.chain.Name, result.symbol, result.deviation, trend.arrow(), result.onchainPrice, result.dexPrice, severity)
 	}
 
+	// A deviating volatile token's feed tells a bad feed from an oracle ignoring its feed
+	m.compareWithFeed(ctx, &result, meta, severity)
 	key := alerts.AlertKey{
 		Job:    m.Name(),
-		Entity: meta.TableName,
-		Metric: m.getMetricName(meta),
+		Entity: m.entity(meta.TableName),
+		Metric: result.feedRelation.metric(meta),
+	}
+	if m.oracleConfig().FeedComparison.Enabled && !meta.IsStablecoin {
+		for _, metric := range otherDeviationMetrics(result.feedRelation, meta) {
+			other := key
+			other.Metric = metric
+			m.observe(ctx, other, alerts.SeverityOK, 0, "", "", false, "")
+		}
+	}
+
+	// Absurd deviations are almost always a bad reference price or onchain read:
+	// report them clamped, to developers only
+	reported := result
+	var clamped bool
+	reported.deviation, clamped = clampDeviation(result.deviation, m.oracleConfig().MaxReportedDeviationPercent)
+
+	details := m.formatAlertDetails(reported, meta)
+	if line := m.formatPreviousLine(previous, trend); line != "" {
+		details += "\n" + line
+	}
+	if severity != alerts.SeverityOK {
+		if line := m.formatChartLine(meta); line != "" {
+			details += "\n" + line
+		}
 	}
+	slackMsg := m.formatSlackAlert(reported, meta, severity)
 
-	details := m.formatAlertDetails(result, meta)
-	slackMsg := m.formatSlackAlert(result, meta, severity)
+	// During a broad market move volatile alerts go to developers only
+	isBusinessAlert := true
+	if broadMove && !meta.IsStablecoin {
+		isBusinessAlert = false
+		slackMsg = ""
+	}
+	if m.referencesDisagree(result) {
+		details += fmt.Sprintf("\nReference sources disagree (%.2f%% apart), so the deviation may not be the oracle's", result.dispersion)
+		isBusinessAlert = false
+		slackMsg = ""
+	}
+	if clamped {
+		log.Printf("[%s][%s] %s: deviation %.0f%% exceeds the %.0f%% clamp, reporting as a likely data error",
+			m.Name(), m.chain.Name, result.symbol, result.deviation, reported.deviation)
+		details += fmt.Sprintf("\nClamped: deviation exceeded %.0f%%, likely a data error (bad reference price or onchain read) rather than a depeg",
+			reported.deviation)
+		isBusinessAlert = false
+		slackMsg = ""
+	}
 
-	m.alertManager.Observe(ctx, key, severity, result.deviation, "", details, true, slackMsg)
+	m.observe(ctx, key, severity, reported.deviation, "", details, isBusinessAlert, slackMsg)
+	return severity
+}
+
+// clampDeviation caps deviation at max (when positive) and reports whether it was clamped
+func clampDeviation(deviation, max float64) (float64, bool) {
+	if max <= 0 || deviation <= max {
+		return deviation, false
+	}
+	return max, true
 }
 
 func (m *OracleMonitor) formatAlertDetails(result tokenResult, meta TokenMeta) string {
+	updated := ""
+	if !result.dexUpdatedAt.IsZero() {
+		updated = "\nDEX updated: " + alerts.FormatTime(result.dexUpdatedAt, m.now())
+	}
+	var details string
 	if meta.IsStablecoin {
-		return fmt.Sprintf("Token: %s\nChain: %s\nDeviation: %.2f%%\nOnchain: $%.6f\nPeg: $%.2f\nDEX: $%.6f",
-			meta.TableName, m.chain.Name, result.deviation, result.onchainPrice, meta.PegValue, result.dexPrice)
+		details = fmt.Sprintf("Token: %s\nChain: %s\nDeviation: %.2f%% %s\nOnchain: $%.6f\n%s%s",
+			meta.TableName, m.chain.Name, result.deviation, pegDirection(result), result.onchainPrice, formatStableReferences(result, meta), updated)
+	} else {
+		details = fmt.Sprintf("Token: %s\nChain: %s\nDeviation: %.2f%%\nOnchain: $%.6f\nDEX: $%.6f%s",
+			meta.TableName, m.chain.Name, result.deviation, result.onchainPrice, result.dexPrice, updated)
+		if feed := m.formatFeedLine(result); feed != "" {
+			details += "\n" + feed
+		}
 	}
-	return fmt.Sprintf("Token: %s\nChain: %s\nDeviation: %.2f%%\nOnchain: $%.6f\nDEX: $%.6f",
-		meta.TableName, m.chain.Name, result.deviation, result.onchainPrice, result.dexPrice)
+	if len(result.sources) > 0 {
+		details += "\n" + m.formatSources(result)
+	}
+	return details
+}
+
+// pegDirection describes which side of its reference (peg or DEX) a stablecoin is on
+func pegDirection(result tokenResult) string {
+	switch deviation := result.signedDeviation(); {
+	case deviation < 0:
+		return "below " + result.referenceLabel()
+	case deviation > 0:
+		return "above " + result.referenceLabel()
+	}
+	return "at " + result.referenceLabel()
 }
 
 func (m *OracleMonitor) formatSlackAlert(result tokenResult, meta TokenMeta, severity alerts.Severity) string {
 	if meta.IsStablecoin {
-		return fmt.Sprintf("ALERT: STABLECOIN DEPEG\nToken: %s\nChain: %s\nDeviation: %.2f%%\nOnchain: $%.6f\nDEX: $%.6f",
-			meta.TableName, m.chain.Name, result.deviation, result.onchainPrice, result.dexPrice)
+		return fmt.Sprintf("ALERT: STABLECOIN DEPEG (%s)\nToken: %s\nChain: %s\nDeviation: %.2f%% vs %s\nOnchain: $%.6f\nPeg: %s\nDEX: $%.6f",
+			strings.ToUpper(pegDirection(result)), meta.TableName, m.chain.Name, result.deviation, result.referenceLabel(),
+			result.onchainPrice, formatPeg(result, meta), result.dexPrice)
 	}
-	return fmt.Sprintf("ALERT: ORACLE PRICE DEVIATION\nToken: %s\nChain: %s\nDeviation: %.2f%%\nOnchain: $%.6f\nDEX: $%.6f",
-		meta.TableName, m.chain.Name, result.deviation, result.onchainPrice, result.dexPrice)
+	return fmt.Sprintf("%s\nToken: %s\nChain: %s\nDeviation: %.2f%%\nOnchain: $%.6f\nDEX: $%.6f",
+		result.feedRelation.slackTitle(), meta.TableName, m.chain.Name, result.deviation, result.onchainPrice, result.dexPrice)
 }
 
-func (m *OracleMonitor) getOnchainPrice(ctx context.Context, mTokenAddr string, decimals int) (float64, error) {
+// getOnchainPrice reads the raw getUnderlyingPrice value for an mToken, see scalePrice
+func (m *OracleMonitor) getOnchainPrice(ctx context.Context, mTokenAddr string) (*big.Int, error) {
+	if err := m.limiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer m.limiter.Release()
+
+	ctx, span := tracing.Start(ctx, "eth_call", tracing.Chain(m.chain.Name), tracing.Method("getUnderlyingPrice"))
 	addr := common.HexToAddress(mTokenAddr)
 	price, err := m.oracle.GetUnderlyingPrice(&bind.CallOpts{Context: ctx}, addr)
+	tracing.End(span, err)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	if price == nil {
+		return nil, fmt.Errorf("getUnderlyingPrice returned no value")
+	}
+	return price, nil
+}
 
+// scalePrice converts a raw getUnderlyingPrice value (scaled by 1e(36-decimals)) to USD
+func scalePrice(price *big.Int, decimals int) float64 {
 	priceFloat := new(big.Float).SetInt(price)
 	exponent := 36 - decimals
 	divisor := new(big.Float).SetFloat64(math.Pow(10, float64(exponent)))
 	priceFloat.Quo(priceFloat, divisor)
 
 	result, _ := priceFloat.Float64()
-	return result, nil
+	return result
 }
 
-func (m *OracleMonitor) getAlchemyPrice(ctx context.Context, meta TokenMeta) (float64, error) {
-	if meta.PriceAddress == "" {
-		return 0, fmt.Errorf("no price address")
+// getAlchemyPrices fetches USD prices for addresses on an Alchemy network in one
+// request. Results are keyed by lowercase address; addresses Alchemy returned no
+// USD price for are omitted so only those tokens fail.
+func (m *OracleMonitor) getAlchemyPrices(ctx context.Context, network string, addresses []string) (map[string]ReferencePrice, error) {
+	if err := m.limiter.Acquire(ctx); err != nil {
+		return nil, err
 	}
+	defer m.limiter.Release()
 
-	url := fmt.Sprintf("https://api.g.alchemy.com/prices/v1/%s/tokens/by-address", m.alchemyKey)
+	url := m.priceAPIs.alchemyURL(m.alchemyKey)
+	requested := make([]map[string]string, len(addresses))
+	for i, address := range addresses {
+		requested[i] = map[string]string{"network": network, "address": address}
+	}
 	payload := map[string]interface{}{
-		"addresses": []map[string]string{
-			{"network": m.chain.PriceNetwork, "address": meta.PriceAddress},
-		},
+		"addresses": requested,
 	}
 
 	jsonData, _ := json.Marshal(payload)
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := m.httpClient.Do(req)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		return 0, fmt.Errorf("API status %d: %s", resp.StatusCode, string(body))
+		return nil, newHTTPStatusError(resp.StatusCode, string(body), m.oracleConfig().RetryStatusCodes)
 	}
 
-	var result struct {
-		Data []struct {
-			Prices []struct {
-				Currency string `json:"currency"`
-				Value    string `json:"value"`
-			} `json:"prices"`
-		} `json:"data"`
-	}
+	return parseAlchemyPrices(resp.Body)
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, err
+// parseAlchemyPrices decodes a by-address price response into USD prices keyed by
+// lowercase address. Entries with an error, no address or no parseable USD price
+// are skipped rather than failing the whole response; an unparseable lastUpdatedAt
+// only leaves UpdatedAt zero. Parsed values are not checked, see screenReference.
+func parseAlchemyPrices(r io.Reader) (map[string]ReferencePrice, error) {
+	var result struct {
+		Data []json.RawMessage `json:"data"`
 	}
 
-	if len(result.Data) == 0 || len(result.Data[0].Prices) == 0 {
-		return 0, fmt.Errorf("no price data")
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return nil, err
 	}
 
-	for _, p := range result.Data[0].Prices {
-		if p.Currency == "usd" {
-			return strconv.ParseFloat(p.Value, 64)
+	prices := make(map[string]ReferencePrice, len(result.Data))
+	for _, raw := range result.Data {
+		var entry struct {
+			Address string `json:"address"`
+			Prices  []struct {
+				Currency      string `json:"currency"`
+				Value         string `json:"value"`
+				LastUpdatedAt string `json:"lastUpdatedAt"`
+			} `json:"prices"`
+			Error json.RawMessage `json:"error"`
+		}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil, err
+		}
+		if entry.Address == "" || (len(entry.Error) > 0 && string(entry.Error) != "null") {
+			continue
+		}
+		for _, p := range entry.Prices {
+			if !strings.EqualFold(p.Currency, "usd") {
+				continue
+			}
+			if value, err := strconv.ParseFloat(p.Value, 64); err == nil {
+				updatedAt, _ := time.Parse(time.RFC3339, p.LastUpdatedAt)
+				prices[strings.ToLower(entry.Address)] = ReferencePrice{
+					Value:     value,
+					Currency:  strings.ToLower(p.Currency),
+					UpdatedAt: updatedAt,
+					Raw:       string(raw),
+				}
+			}
+			break
 		}
 	}
-
-	return 0, fmt.Errorf("no USD price")
+	return prices, nil
 }
 
-func (m *OracleMonitor) classifyDeviation(deviation float64, meta TokenMeta) alerts.Severity {
-	if m.config == nil {
-		return alerts.SeverityOK
-	}
+// classifyDeviation applies the token's thresholds to its deviation
+func (m *OracleMonitor) classifyDeviation(result tokenResult, meta TokenMeta) alerts.Severity {
+	warning, critical := deviationThresholds(meta, m.oracleConfig(), result.signedDeviation())
+	return classify(result.deviation, configuredBands(m.configs, m.Name(), deviationMetric(meta), warningCritical(warning, critical)))
+}
 
-	if meta.IsStablecoin {
-		if deviation >= m.config.Stablecoin.CriticalThresholdPercent {
-			return alerts.SeverityCritical
-		}
-		if deviation >= m.config.Stablecoin.WarningThresholdPercent {
-			return alerts.SeverityWarning
-		}
-		return alerts.SeverityOK
-	}
+// ClassifyDeviation returns the severity of a token's absolute deviation (percent)
+// under cfg, whose thresholds must already be resolved (see oracleConfig).
+// pegDeviation is the signed deviation of a stablecoin from the reference it is
+// classified on (peg or DEX); above it, oracle.stablecoin_above_peg applies when it is
+// configured. severity_bands overrides are not applied here.
+func ClassifyDeviation(meta TokenMeta, cfg *config.OracleConfig, deviation, pegDeviation float64) alerts.Severity {
+	warning, critical := deviationThresholds(meta, cfg, pegDeviation)
+	return classify(deviation, warningCritical(warning, critical))
+}
 
-	if deviation >= m.config.Volatile.CriticalThresholdPercent {
-		return alerts.SeverityCritical
+// deviationThresholds returns the warning and critical thresholds ClassifyDeviation
+// applies to a token
+func deviationThresholds(meta TokenMeta, cfg *config.OracleConfig, pegDeviation float64) (warning, critical float64) {
+	if !meta.IsStablecoin {
+		return cfg.Volatile.WarningThresholdPercent, cfg.Volatile.CriticalThresholdPercent
 	}
-	if deviation >= m.config.Volatile.WarningThresholdPercent {
-		return alerts.SeverityWarning
+	if above := cfg.StablecoinAbovePeg; above.Enabled() && pegDeviation > 0 {
+		return above.WarningThresholdPercent, above.CriticalThresholdPercent
 	}
-	return alerts.SeverityOK
+	return cfg.Stablecoin.WarningThresholdPercent, cfg.Stablecoin.CriticalThresholdPercent
 }
 
 func (m *OracleMonitor) getMetricName(meta TokenMeta) string {
+	return deviationMetric(meta)
+}
+
+// deviationMetric is the alert metric for a token's price deviation
+func deviationMetric(meta TokenMeta) string {
 	if meta.IsStablecoin {
 		return "price_deviation_stable"
 	}
@@ -392,15 +897,15 @@ func (m *OracleMonitor) getMetricName(meta TokenMeta) string {
 }
 
 func (m *OracleMonitor) observeTokenError(ctx context.Context, symbol string, err error) {
-	key := alerts.AlertKey{Job: m.Name(), Entity: symbol, Metric: "token_error"}
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(symbol), Metric: "token_error"}
 	details := fmt.Sprintf("Chain: %s\nToken: %s\nError: %v", m.chain.Name, symbol, err)
-	m.alertManager.Observe(ctx, key, alerts.SeverityWarning, 1.0, "", details, false, "")
+	m.observe(ctx, key, alerts.SeverityWarning, 1.0, "", details, false, "")
 }
 
-func (m *OracleMonitor) updateSystemHealth(ctx context.Context, successCount int, errors []tokenResult) {
+func (m *OracleMonitor) updateSystemHealth(ctx context.Context, tokenCount, successCount int, errors []tokenResult) {
 	m.mu.Lock()
 	if successCount > 0 {
-		m.lastSuccess = time.Now()
+		m.lastSuccess = m.now()
 		m.consecutiveErr = 0
 	} else {
 		m.consecutiveErr++
@@ -409,29 +914,69 @@ func (m *OracleMonitor) updateSystemHealth(ctx context.Context, successCount int
 	consecutiveErr := m.consecutiveErr
 	m.mu.Unlock()
 
-	tokenCount := len(m.chain.Tokens)
 	if tokenCount == 0 {
 		return // No tokens to report on
 	}
 	errorRate := float64(len(errors)) / float64(tokenCount) * 100
 
-	var severity alerts.Severity
-	if errorRate >= 50 {
-		severity = alerts.SeverityCritical
-	} else if errorRate >= 30 {
-		severity = alerts.SeverityWarning
+	severity := classify(errorRate, configuredBands(m.configs, m.Name(), "system_health", warningCritical(30, 50)))
+
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("system"), Metric: "system_health"}
+	details := fmt.Sprintf("Chain: %s\nSuccess: %.1f%%\nFailed: %d/%d\nConsecutive errors: %d\nLast success: %s\nThresholds: %s",
+		m.chain.Name, 100-errorRate, len(errors), tokenCount, consecutiveErr, alerts.FormatTime(lastSuccess, m.now()),
+		formatThresholds(m.oracleConfig()))
+
+	m.observe(ctx, key, severity, errorRate, "", details, false, "")
+}
+
+// formatThresholds summarises the effective deviation thresholds as warning/critical
+func formatThresholds(cfg *config.OracleConfig) string {
+	stable := fmt.Sprintf("stable %g%%/%g%%", cfg.Stablecoin.WarningThresholdPercent, cfg.Stablecoin.CriticalThresholdPercent)
+	if above := cfg.StablecoinAbovePeg; above.Enabled() {
+		stable += fmt.Sprintf(" (above peg %g%%/%g%%)", above.WarningThresholdPercent, above.CriticalThresholdPercent)
+	}
+	return fmt.Sprintf("%s, volatile %g%%/%g%%", stable, cfg.Volatile.WarningThresholdPercent, cfg.Volatile.CriticalThresholdPercent)
+}
+
+// checkErrorStreak pages when token errors persist across more consecutive cycles
+// than configured, even if each cycle's error rate stays low. Degraded tokens and
+// rate-limit deferrals have their own handling and do not extend the streak.
+func (m *OracleMonitor) checkErrorStreak(ctx context.Context, errorResults []tokenResult) {
+	var symbols []string
+	for _, result := range errorResults {
+		if result.errClass != RPCErrorPermanent && result.errClass != RPCErrorRateLimited {
+			symbols = append(symbols, result.symbol)
+		}
+	}
+
+	m.mu.Lock()
+	if len(symbols) > 0 {
+		m.errorStreak++
 	} else {
-		severity = alerts.SeverityOK
+		m.errorStreak = 0
 	}
+	streak := m.errorStreak
+	m.mu.Unlock()
 
-	key := alerts.AlertKey{Job: m.Name(), Entity: "system", Metric: "system_health"}
-	details := fmt.Sprintf("Chain: %s\nSuccess: %.1f%%\nFailed: %d/%d\nConsecutive errors: %d\nLast success: %s",
-		m.chain.Name, 100-errorRate, len(errors), tokenCount, consecutiveErr, lastSuccess.Format("15:04:05"))
+	limit := m.oracleConfig().MaxConsecutiveErrors
+	if limit <= 0 {
+		return
+	}
 
-	m.alertManager.Observe(ctx, key, severity, errorRate, "", details, false, "")
+	severity := alerts.SeverityOK
+	if streak > limit {
+		severity = alerts.SeverityCritical
+	}
+	sort.Strings(symbols)
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("system"), Metric: "consecutive_errors"}
+	details := fmt.Sprintf("Chain: %s\nCycles with errors: %d in a row (limit %d)\nFailing now: %s",
+		m.chain.Name, streak, limit, strings.Join(symbols, ", "))
+	m.observe(ctx, key, severity, float64(streak), "", details, false, "")
 }
 
-func registerOraclePolicies(alertManager *alerts.Manager, cfg *config.OracleConfig, chainID string) {
+// RegisterOraclePolicies registers the oracle monitor's alert policies for a chain.
+// NewOracleMonitor and Reload call it; Replay calls it without a monitor.
+func RegisterOraclePolicies(alertManager *alerts.Manager, cfg *config.OracleConfig, chainID string) {
 	jobName := fmt.Sprintf("oracle_%s", chainID)
 
 	// Stablecoin policy
@@ -439,14 +984,14 @@ func registerOraclePolicies(alertManager *alerts.Manager, cfg *config.OracleConf
 	for i, dc := range cfg.Stablecoin.DynamicCooldowns {
 		stableDynamic[i] = alerts.DynamicCooldown{
 			Threshold: dc.ThresholdPercent,
-			Cooldown:  time.Duration(dc.CooldownSeconds) * time.Second,
+			Cooldown:  dc.CooldownSeconds.Duration(),
 		}
 	}
 
 	alertManager.RegisterPolicy(jobName, "price_deviation_stable", alerts.AlertPolicy{
 		MinValueChange:        cfg.Stablecoin.MinValueChangePercent,
-		CooldownWarning:       time.Duration(cfg.Stablecoin.CooldownWarningMinutes) * time.Minute,
-		CooldownCritical:      time.Duration(cfg.Stablecoin.CooldownCriticalMinutes) * time.Minute,
+		CooldownWarning:       cfg.Stablecoin.CooldownWarning(),
+		CooldownCritical:      cfg.Stablecoin.CooldownCritical(),
 		DynamicCooldowns:      stableDynamic,
 		ConsecutiveOKRequired: cfg.Stablecoin.ConsecutiveOKRequired,
 	})
@@ -456,18 +1001,47 @@ func registerOraclePolicies(alertManager *alerts.Manager, cfg *config.OracleConf
 	for i, dc := range cfg.Volatile.DynamicCooldowns {
 		volatileDynamic[i] = alerts.DynamicCooldown{
 			Threshold: dc.ThresholdPercent,
-			Cooldown:  time.Duration(dc.CooldownSeconds) * time.Second,
+			Cooldown:  dc.CooldownSeconds.Duration(),
 		}
 	}
 
 	alertManager.RegisterPolicy(jobName, "price_deviation_volatile", alerts.AlertPolicy{
 		MinValueChange:        cfg.Volatile.MinValueChangePercent,
-		CooldownWarning:       time.Duration(cfg.Volatile.CooldownWarningMinutes) * time.Minute,
-		CooldownCritical:      time.Duration(cfg.Volatile.CooldownCriticalMinutes) * time.Minute,
+		CooldownWarning:       cfg.Volatile.CooldownWarning(),
+		CooldownCritical:      cfg.Volatile.CooldownCritical(),
 		DynamicCooldowns:      volatileDynamic,
 		ConsecutiveOKRequired: cfg.Volatile.ConsecutiveOKRequired,
 	})
 
+	// Volatile deviations classified against the oracle's own feed. A feed off market
+	// tends to persist until the feed operator acts, so it is reminded of hourly.
+	alertManager.RegisterPolicy(jobName, "feed_divergence", alerts.AlertPolicy{
+		MinValueChange:        cfg.Volatile.MinValueChangePercent,
+		CooldownWarning:       cfg.Volatile.CooldownWarning(),
+		CooldownCritical:      cfg.Volatile.CooldownCritical(),
+		ReminderInterval:      1 * time.Hour,
+		ConsecutiveOKRequired: cfg.Volatile.ConsecutiveOKRequired,
+	})
+	alertManager.RegisterPolicy(jobName, "oracle_divergence", alerts.AlertPolicy{
+		MinValueChange:        cfg.Volatile.MinValueChangePercent,
+		CooldownWarning:       cfg.Volatile.CooldownWarning(),
+		CooldownCritical:      cfg.Volatile.CooldownCritical(),
+		DynamicCooldowns:      volatileDynamic,
+		ConsecutiveOKRequired: cfg.Volatile.ConsecutiveOKRequired,
+	})
+
+	registerBroadMovePolicy(alertManager, jobName)
+	registerRPCErrorPolicy(alertManager, jobName)
+	registerOracleFlagPolicy(alertManager, jobName)
+	registerCircuitPolicy(alertManager, jobName)
+	registerPrecisionPolicy(alertManager, jobName)
+	registerHealthScorePolicy(alertManager, jobName)
+	registerConnectPolicy(alertManager, jobName)
+	registerMissingReferencePolicy(alertManager, jobName)
+	registerPriceSanityPolicy(alertManager, jobName)
+	registerReferenceStalenessPolicy(alertManager, jobName)
+	registerReferenceDisagreementPolicy(alertManager, jobName)
+
 	alertManager.RegisterPolicy(jobName, "system_health", alerts.AlertPolicy{
 		MinValueChange:        10.0,
 		CooldownWarning:       15 * time.Minute,
@@ -475,4 +1049,13 @@ func registerOraclePolicies(alertManager *alerts.Manager, cfg *config.OracleConf
 		ReminderInterval:      30 * time.Minute,
 		ConsecutiveOKRequired: 1,
 	})
+
+	// The streak grows every cycle; update only when it has doubled
+	alertManager.RegisterPolicy(jobName, "consecutive_errors", alerts.AlertPolicy{
+		MinValueChange:        100.0,
+		CooldownWarning:       1 * time.Hour,
+		CooldownCritical:      1 * time.Hour,
+		ReminderInterval:      2 * time.Hour,
+		ConsecutiveOKRequired: 1,
+	})
 }
@@ -0,0 +1,134 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/tracing"
+)
+
+// ErrUnknownToken is returned by CheckSingle for a symbol the chain does not monitor
+var ErrUnknownToken = errors.New("unknown token")
+
+// TokenCheck is the outcome of an on-demand token check
+type TokenCheck struct {
+	TokenPrice
+	Severity alerts.Severity `json:"severity,omitempty"` // empty when the read failed
+}
+
+// CheckSingle reads one token's prices immediately, bypassing the DEX fast path, and
+// runs the result through the same alerting as a scheduled cycle. symbol matches a
+// token key or symbol case-insensitively; disabled tokens are unknown. It waits for
+// other checks of the token, on-demand or scheduled, to finish first, giving up with
+// ctx's error if ctx is done before they do.
+func (m *OracleMonitor) CheckSingle(ctx context.Context, symbol string) (TokenCheck, error) {
+	if err := m.connect(ctx); err != nil {
+		return TokenCheck{TokenPrice: TokenPrice{Chain: m.chain.Name, Symbol: symbol, Error: err.Error()}}, err
+	}
+	key, meta, ok := m.findToken(symbol)
+	if !ok {
+		return TokenCheck{}, ErrUnknownToken
+	}
+
+	unlock, err := m.lockToken(ctx, key)
+	if err != nil {
+		return TokenCheck{TokenPrice: TokenPrice{Chain: m.chain.Name, Symbol: key, Error: err.Error()}}, err
+	}
+	defer unlock()
+
+	log.Printf("[%s][%s] on-demand check of %s", m.Name(), m.chain.Name, key)
+	m.resetFastPath(key)
+
+	tokenCtx, span := tracing.Start(ctx, "oracle.check_token", tracing.Chain(m.chain.Name), tracing.Symbol(key))
+	result := m.checkToken(tokenCtx, key, meta, nil)
+	tracing.End(span, result.err)
+	m.recordPrice(result)
+
+	check := TokenCheck{TokenPrice: TokenPrice{
+		Chain:            m.chain.Name,
+		Symbol:           key,
+		OnchainPrice:     result.onchainPrice,
+		DEXPrice:         result.dexPrice,
+		DeviationPercent: result.deviation,
+		ObservedAt:       m.now(),
+	}}
+	if result.err != nil {
+		m.forgetReading(key)
+		check.Error = result.err.Error()
+		return check, result.err
+	}
+
+	m.mu.Lock()
+	broadMove := m.broadMove
+	m.mu.Unlock()
+
+	m.clearDegraded(ctx, key)
+	check.Severity = m.processTokenResult(ctx, result, broadMove)
+	return check, nil
+}
+
+// tokenLock returns the lock serializing checks of the token key, held by CheckSingle
+// and by Run for each token it checks, so a token's readings reach the alert manager
+// from one check at a time. It is a channel with room for one holder, so waiting for
+// it can end with the caller's context.
+func (m *OracleMonitor) tokenLock(key string) chan struct{} {
+	lock, _ := m.checkLocks.LoadOrStore(key, make(chan struct{}, 1))
+	return lock.(chan struct{})
+}
+
+// lockToken takes the lock of the token key and returns a function releasing it, or
+// ctx's error if ctx is done first
+func (m *OracleMonitor) lockToken(ctx context.Context, key string) (func(), error) {
+	lock := m.tokenLock(key)
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// lockTokens takes the lock of every token in tokens, in key order, and returns a
+// function releasing them. If ctx is done first it releases those already taken and
+// returns ctx's error.
+func (m *OracleMonitor) lockTokens(ctx context.Context, tokens map[string]TokenMeta) (func(), error) {
+	keys := make([]string, 0, len(tokens))
+	for key := range tokens {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	unlocks := make([]func(), 0, len(keys))
+	unlockAll := func() {
+		for _, unlock := range unlocks {
+			unlock()
+		}
+	}
+	for _, key := range keys {
+		unlock, err := m.lockToken(ctx, key)
+		if err != nil {
+			unlockAll()
+			return nil, err
+		}
+		unlocks = append(unlocks, unlock)
+	}
+	return unlockAll, nil
+}
+
+// findToken looks up an active token by key or symbol, ignoring case
+func (m *OracleMonitor) findToken(symbol string) (string, TokenMeta, bool) {
+	return lookupToken(m.activeTokens(), symbol)
+}
+
+// lookupToken finds a token in tokens by key or symbol, ignoring case
+func lookupToken(tokens map[string]TokenMeta, symbol string) (string, TokenMeta, bool) {
+	for key, meta := range tokens {
+		if strings.EqualFold(key, symbol) || strings.EqualFold(meta.Symbol, symbol) {
+			return key, meta, true
+		}
+	}
+	return "", TokenMeta{}, false
+}
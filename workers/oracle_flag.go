@@ -0,0 +1,80 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/tracing"
+)
+
+// Last isPriceOracle() reading, cached so alerts fire only on change
+const (
+	oracleFlagUnknown int32 = iota
+	oracleFlagTrue
+	oracleFlagFalse
+)
+
+// checkIsPriceOracle reads isPriceOracle() and pages both channels when it reports
+// false, which means the contract behind the oracle address is no longer the
+// expected oracle (compromised or mis-upgraded proxy). Read errors keep the cached value.
+func (m *OracleMonitor) checkIsPriceOracle(ctx context.Context) {
+	isOracle, err := m.readIsPriceOracle(ctx)
+	if err != nil {
+		log.Printf("[%s][%s] isPriceOracle() failed: %v", m.Name(), m.chain.Name, err)
+		return
+	}
+
+	current := oracleFlagTrue
+	if !isOracle {
+		current = oracleFlagFalse
+	}
+	previous := m.oracleFlag.Swap(current)
+	if previous == current || (previous == oracleFlagUnknown && current == oracleFlagTrue) {
+		return
+	}
+
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("oracle"), Metric: "is_price_oracle"}
+	if isOracle {
+		log.Printf("[%s][%s] isPriceOracle() is true again", m.Name(), m.chain.Name)
+		details := fmt.Sprintf("Chain: %s\nOracle: %s\nisPriceOracle(): true", m.chain.Name, m.chain.OracleAddress)
+		m.observe(ctx, key, alerts.SeverityOK, 0, "", details, true, "")
+		return
+	}
+
+	log.Printf("[%s][%s] isPriceOracle() returned false", m.Name(), m.chain.Name)
+	details := fmt.Sprintf("Chain: %s\nOracle: %s\nisPriceOracle(): false\n\n"+
+		"The contract at the oracle address no longer identifies as a price oracle. "+
+		"It may have been upgraded incorrectly or compromised; prices read from it cannot be trusted.",
+		m.chain.Name, m.chain.OracleAddress)
+	slackMsg := fmt.Sprintf("ALERT: ORACLE CONTRACT NOT A PRICE ORACLE\nChain: %s\nOracle: %s\nisPriceOracle() returned false",
+		m.chain.Name, m.chain.OracleAddress)
+	m.observe(ctx, key, alerts.SeverityCritical, 1.0, "", details, true, slackMsg)
+}
+
+func (m *OracleMonitor) readIsPriceOracle(ctx context.Context) (bool, error) {
+	if err := m.limiter.Acquire(ctx); err != nil {
+		return false, err
+	}
+	defer m.limiter.Release()
+
+	ctx, span := tracing.Start(ctx, "eth_call", tracing.Chain(m.chain.Name), tracing.Method("isPriceOracle"))
+	isOracle, err := m.oracle.IsPriceOracle(&bind.CallOpts{Context: ctx})
+	tracing.End(span, err)
+	return isOracle, err
+}
+
+func registerOracleFlagPolicy(alertManager *alerts.Manager, jobName string) {
+	// Observed only when the flag changes; no reminders while it stays false
+	alertManager.RegisterPolicy(jobName, "is_price_oracle", alerts.AlertPolicy{
+		MinValueChange:        1.0,
+		CooldownWarning:       1 * time.Hour,
+		CooldownCritical:      1 * time.Hour,
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: 1,
+	})
+}
@@ -0,0 +1,280 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestParseAlchemyPricesPartialResponse(t *testing.T) {
+	f, err := os.Open("testdata/alchemy_prices_partial.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	prices, err := parseAlchemyPrices(f)
+	if err != nil {
+		t.Fatalf("parseAlchemyPrices: %v", err)
+	}
+
+	// Requested addresses in the checksummed form used by the token tables
+	tests := []struct {
+		address string
+		want    float64
+		found   bool
+	}{
+		{"0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913", 0.9998, true},  // USDC
+		{"0x4200000000000000000000000000000000000006", 3120.55, true}, // WETH, USD among several currencies
+		{"0x7300B37DfdfAb110d83290A29DfB31B1740219fE", 0, false},      // MAMO, returned with an error
+		{"0xA88594D404727625A9437C3f886C7643872296AE", 0, false},      // WELL, omitted from the response
+	}
+	for _, tt := range tests {
+		got, ok := prices[strings.ToLower(tt.address)]
+		if ok != tt.found || got.Value != tt.want {
+			t.Errorf("price for %s = %v, %v; want %v, %v", tt.address, got.Value, ok, tt.want, tt.found)
+		}
+	}
+
+	usdc := prices[strings.ToLower("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")]
+	if want := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC); usdc.Currency != "usd" || !usdc.UpdatedAt.Equal(want) {
+		t.Errorf("USDC metadata = %q, %v; want usd, %v", usdc.Currency, usdc.UpdatedAt, want)
+	}
+}
+
+func TestParseAlchemyPricesMalformed(t *testing.T) {
+	if _, err := parseAlchemyPrices(strings.NewReader(`{"data": [`)); err == nil {
+		t.Error("parseAlchemyPrices on truncated JSON = nil error, want error")
+	}
+}
+
+func TestClampDeviation(t *testing.T) {
+	tests := []struct {
+		deviation, max float64
+		want           float64
+		clamped        bool
+	}{
+		{3.5, 500, 3.5, false},
+		{500, 500, 500, false},
+		{400000, 500, 500, true},
+		{math.Inf(1), 500, 500, true},
+		{400000, 0, 400000, false}, // disabled
+	}
+	for _, tt := range tests {
+		got, clamped := clampDeviation(tt.deviation, tt.max)
+		if got != tt.want || clamped != tt.clamped {
+			t.Errorf("clampDeviation(%v, %v) = %v, %v; want %v, %v", tt.deviation, tt.max, got, clamped, tt.want, tt.clamped)
+		}
+	}
+}
+
+func TestClassifyStablecoinDirection(t *testing.T) {
+	cfg := config.DefaultConfig() // stablecoin warning 1%, critical 2%
+	cfg.Oracle.StablecoinAbovePeg = config.PegThresholdConfig{WarningThresholdPercent: 3, CriticalThresholdPercent: 5}
+	m := &OracleMonitor{configs: config.NewHolder(cfg)}
+	stable := TokenMeta{IsStablecoin: true, PegValue: 1}
+
+	tests := []struct {
+		pegDeviation float64
+		want         alerts.Severity
+	}{
+		{-1.5, alerts.SeverityWarning},
+		{-2.5, alerts.SeverityCritical},
+		{1.5, alerts.SeverityOK},
+		{2.5, alerts.SeverityOK},
+		{3.5, alerts.SeverityWarning},
+		{6, alerts.SeverityCritical},
+	}
+	for _, tt := range tests {
+		result := tokenResult{pegDeviation: tt.pegDeviation, deviation: math.Abs(tt.pegDeviation)}
+		if got := m.classifyDeviation(result, stable); got != tt.want {
+			t.Errorf("deviation %+.1f%%: got %s, want %s", tt.pegDeviation, got, tt.want)
+		}
+	}
+
+	// Without above-peg thresholds both directions use the stablecoin thresholds
+	cfg.Oracle.StablecoinAbovePeg = config.PegThresholdConfig{}
+	if got := m.classifyDeviation(tokenResult{pegDeviation: 2.5, deviation: 2.5}, stable); got != alerts.SeverityCritical {
+		t.Errorf("symmetric +2.5%%: got %s, want CRITICAL", got)
+	}
+}
+
+func TestClassifyDeviationUsesSeverityBands(t *testing.T) {
+	cfg := config.DefaultConfig() // volatile warning 3%, critical 5%
+	cfg.SeverityBands = map[string][]config.SeverityBandConfig{
+		"oracle_base:price_deviation_volatile": {
+			{Threshold: 8, Severity: "WARNING"},
+			{Threshold: 12, Severity: "CRITICAL"},
+		},
+	}
+	m := &OracleMonitor{chain: ChainConfig{ID: "base"}, configs: config.NewHolder(cfg)}
+
+	if got := m.classifyDeviation(tokenResult{deviation: 6}, TokenMeta{}); got != alerts.SeverityOK {
+		t.Errorf("volatile 6%% with an 8%% warning band: got %s, want OK", got)
+	}
+	if got := m.classifyDeviation(tokenResult{deviation: 12}, TokenMeta{}); got != alerts.SeverityCritical {
+		t.Errorf("volatile 12%%: got %s, want CRITICAL", got)
+	}
+	// Stablecoins keep their thresholds
+	if got := m.classifyDeviation(tokenResult{deviation: 2.5, pegDeviation: -2.5}, TokenMeta{IsStablecoin: true, PegValue: 1}); got != alerts.SeverityCritical {
+		t.Errorf("stablecoin -2.5%%: got %s, want CRITICAL", got)
+	}
+}
+
+func TestResolveThresholds(t *testing.T) {
+	cfg := config.DefaultConfig().Oracle // stablecoin 1%/2%, volatile 3%/5%
+	cfg.Stablecoin.WarningThresholdPercent = 0
+	cfg.Stablecoin.CriticalThresholdPercent = -1
+	cfg.Volatile.WarningThresholdPercent = 8
+
+	resolved, warnings := resolveThresholds(cfg)
+	if got := resolved.Stablecoin.ThresholdConfig; got.WarningThresholdPercent != 1 || got.CriticalThresholdPercent != 2 {
+		t.Errorf("stablecoin = %g/%g, want defaults 1/2", got.WarningThresholdPercent, got.CriticalThresholdPercent)
+	}
+	if got := resolved.Volatile.ThresholdConfig; got.WarningThresholdPercent != 8 || got.CriticalThresholdPercent != 8 {
+		t.Errorf("volatile = %g/%g, want 8/8", got.WarningThresholdPercent, got.CriticalThresholdPercent)
+	}
+	if len(warnings) != 3 {
+		t.Errorf("got %d warnings, want 3: %q", len(warnings), warnings)
+	}
+
+	if _, warnings := resolveThresholds(config.DefaultConfig().Oracle); len(warnings) != 0 {
+		t.Errorf("defaults produced warnings: %q", warnings)
+	}
+}
+
+func TestClassifyWithoutConfigUsesDefaults(t *testing.T) {
+	m := &OracleMonitor{}
+	if got := m.classifyDeviation(tokenResult{deviation: 6}, TokenMeta{}); got != alerts.SeverityCritical {
+		t.Errorf("volatile 6%% without config: got %s, want CRITICAL", got)
+	}
+	if got := m.classifyDeviation(tokenResult{deviation: 0.5}, TokenMeta{IsStablecoin: true}); got != alerts.SeverityOK {
+		t.Errorf("stablecoin 0.5%% without config: got %s, want OK", got)
+	}
+}
+
+// newAlchemyTestMonitor returns a monitor whose Alchemy price API is handler
+func newAlchemyTestMonitor(t *testing.T, handler http.HandlerFunc) *OracleMonitor {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &OracleMonitor{
+		chain:      ChainConfig{ID: "base", Name: "Base", PriceSource: PriceSourceAlchemy, PriceNetwork: "base-mainnet"},
+		alchemyKey: "test-key",
+		priceAPIs:  PriceAPIs{Alchemy: server.URL},
+		httpClient: server.Client(),
+	}
+}
+
+func TestGetAlchemyPricesRequest(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/alchemy_prices_partial.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotPath string
+	var gotBody struct {
+		Addresses []struct {
+			Network string `json:"network"`
+			Address string `json:"address"`
+		} `json:"addresses"`
+	}
+	m := newAlchemyTestMonitor(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Write(fixture)
+	})
+
+	usdc := "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"
+	prices, err := m.getAlchemyPrices(context.Background(), "base-mainnet", []string{usdc})
+	if err != nil {
+		t.Fatalf("getAlchemyPrices: %v", err)
+	}
+	if gotPath != "POST /test-key/tokens/by-address" {
+		t.Errorf("request = %s, want POST /test-key/tokens/by-address", gotPath)
+	}
+	if len(gotBody.Addresses) != 1 || gotBody.Addresses[0].Network != "base-mainnet" || gotBody.Addresses[0].Address != usdc {
+		t.Errorf("request body addresses = %+v", gotBody.Addresses)
+	}
+	if got := prices[strings.ToLower(usdc)]; got.Value != 0.9998 || got.Currency != "usd" {
+		t.Errorf("USDC = %+v, want 0.9998 usd", got)
+	}
+}
+
+func TestGetReferencePriceNoData(t *testing.T) {
+	m := newAlchemyTestMonitor(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": []}`))
+	})
+	_, err := m.getReferencePrice(context.Background(), TokenMeta{Symbol: "USDC", PriceAddress: "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"})
+	if err == nil || !strings.Contains(err.Error(), "no price data") {
+		t.Errorf("getReferencePrice with empty response = %v, want no price data error", err)
+	}
+}
+
+func TestGetAlchemyPricesHTTPStatus(t *testing.T) {
+	tests := []struct {
+		status    int
+		retryable bool
+	}{
+		{http.StatusUnauthorized, false},
+		{http.StatusNotFound, false},
+		{http.StatusServiceUnavailable, true},
+	}
+	for _, tt := range tests {
+		m := newAlchemyTestMonitor(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "upstream said no", tt.status)
+		})
+		_, err := m.getAlchemyPrices(context.Background(), "base-mainnet", []string{"0x0"})
+		var statusErr *httpStatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.status {
+			t.Errorf("status %d: err = %v, want httpStatusError", tt.status, err)
+			continue
+		}
+		if isRetryable(err) != tt.retryable || !strings.Contains(statusErr.Body, "upstream said no") {
+			t.Errorf("status %d: retryable %v body %q, want retryable %v with response body", tt.status, isRetryable(err), statusErr.Body, tt.retryable)
+		}
+	}
+}
+
+func TestScheduledCycleHoldsOnDemandTokenLocks(t *testing.T) {
+	m := &OracleMonitor{}
+	ctx := context.Background()
+	unlock, err := m.lockTokens(ctx, map[string]TokenMeta{"USDC": {}, "WETH": {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An on-demand check gives up when its request does, rather than waiting out the cycle
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := m.lockToken(waitCtx, "USDC"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("lockToken during the scheduled cycle = %v, want the context's deadline", err)
+	}
+
+	unlock()
+	unlockUSDC, err := m.lockToken(ctx, "USDC")
+	if err != nil {
+		t.Fatal("token locks still held after the cycle")
+	}
+
+	// A cycle that cannot take every lock releases those it took
+	_, err = m.lockTokens(waitCtx, map[string]TokenMeta{"DAI": {}, "USDC": {}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("lockTokens with USDC held = %v, want the context's deadline", err)
+	}
+	unlockUSDC()
+	retryCtx, cancelRetry := context.WithTimeout(ctx, time.Second)
+	defer cancelRetry()
+	if _, err := m.lockTokens(retryCtx, map[string]TokenMeta{"DAI": {}, "USDC": {}}); err != nil {
+		t.Errorf("locks not released after a cancelled lockTokens: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+// checkPrecision notes a changed onchain price whose raw value repeats an earlier value
+// exactly or has very few significant digits, which can indicate a manual or test post
+// the deviation check misses. Only changes are judged; the first reading seeds history.
+func (m *OracleMonitor) checkPrecision(ctx context.Context, result tokenResult, meta TokenMeta) {
+	cfg := m.oracleConfig().PrecisionCheck
+	if !cfg.Enabled || result.mantissa == nil {
+		return
+	}
+
+	m.mu.Lock()
+	if m.mantissas == nil {
+		m.mantissas = make(map[string][]*big.Int)
+	}
+	recent := m.mantissas[result.symbol]
+	reason := suspiciousPrecision(recent, result.mantissa, cfg.MaxSignificantDigits)
+	if len(recent) == 0 || recent[len(recent)-1].Cmp(result.mantissa) != 0 {
+		recent = append(recent, result.mantissa)
+		if len(recent) > cfg.History {
+			recent = recent[len(recent)-cfg.History:]
+		}
+		m.mantissas[result.symbol] = recent
+	}
+	m.mu.Unlock()
+
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(meta.TableName), Metric: "price_precision"}
+	if reason == "" {
+		m.observe(ctx, key, alerts.SeverityOK, 0, "", "", false, "")
+		return
+	}
+
+	log.Printf("[%s][%s] %s: suspicious oracle price %s: %s", m.Name(), m.chain.Name, result.symbol, result.mantissa, reason)
+	details := fmt.Sprintf("Token: %s\nChain: %s\nOnchain: $%.6f\nRaw price: %s\nReason: %s\n\n"+
+		"This may be a manual or test post. Check the oracle's recent transactions.",
+		meta.TableName, m.chain.Name, result.onchainPrice, result.mantissa, reason)
+	m.observe(ctx, key, alerts.SeverityWarning, 1, "", details, false, "")
+}
+
+// suspiciousPrecision explains why current, a changed raw price following recent
+// (oldest first), looks like a manual post, or returns "" when it does not
+func suspiciousPrecision(recent []*big.Int, current *big.Int, maxDigits int) string {
+	if len(recent) == 0 || current.Sign() <= 0 {
+		return ""
+	}
+	last := len(recent) - 1
+	if recent[last].Cmp(current) == 0 {
+		return ""
+	}
+	for i := last - 1; i >= 0; i-- {
+		if recent[i].Cmp(current) == 0 {
+			return fmt.Sprintf("returned exactly to a value posted %d changes ago", last-i+1)
+		}
+	}
+	if digits := significantDigits(current); digits <= maxDigits {
+		return fmt.Sprintf("only %d significant digits", digits)
+	}
+	return ""
+}
+
+// significantDigits counts the digits of a positive integer, ignoring trailing zeros
+func significantDigits(n *big.Int) int {
+	return len(strings.TrimRight(n.String(), "0"))
+}
+
+func registerPrecisionPolicy(alertManager *alerts.Manager, jobName string) {
+	// One note per suspicious post; the next ordinary reading clears it silently
+	alertManager.RegisterPolicy(jobName, "price_precision", alerts.AlertPolicy{
+		MinValueChange:        1.0,
+		CooldownWarning:       1 * time.Hour,
+		CooldownCritical:      1 * time.Hour,
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: 1,
+	})
+}
@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func mantissa(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+func TestSuspiciousPrecision(t *testing.T) {
+	a, b := mantissa("3512345678900000000000"), mantissa("3498765432100000000000")
+	tests := []struct {
+		name    string
+		recent  []*big.Int
+		current *big.Int
+		want    bool
+	}{
+		{"first reading", nil, mantissa("3500000000000000000000"), false},
+		{"unchanged", []*big.Int{a}, a, false},
+		{"ordinary change", []*big.Int{a}, b, false},
+		{"returns to earlier value", []*big.Int{a, b}, a, true},
+		{"clean integer", []*big.Int{a}, mantissa("3500000000000000000000"), true},
+		{"three significant digits", []*big.Int{a}, mantissa("3510000000000000000000"), true},
+		{"four significant digits", []*big.Int{a}, mantissa("3512000000000000000000"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suspiciousPrecision(tt.recent, tt.current, 3); (got != "") != tt.want {
+				t.Errorf("suspiciousPrecision = %q, want suspicious %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPrecisionNotesDevelopers(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Oracle.PrecisionCheck.Enabled = true
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	m := &OracleMonitor{
+		chain:        ChainConfig{ID: "precision_test", Name: "Precision Test"},
+		alertManager: manager,
+		configs:      config.NewHolder(cfg),
+	}
+	registerPrecisionPolicy(manager, m.Name())
+	meta := TokenMeta{Symbol: "WETH", TableName: "WETH", Decimals: 18}
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("WETH"), Metric: "price_precision"}
+	ctx := context.Background()
+
+	for _, raw := range []string{"3512345678900000000000", "3498765432100000000000", "3512345678900000000000"} {
+		m.checkPrecision(ctx, tokenResult{symbol: "weth", mantissa: mantissa(raw)}, meta)
+	}
+	state, ok := manager.GetActiveIncidents()[key]
+	if !ok || state.Severity != alerts.SeverityWarning {
+		t.Fatalf("incident = %+v (active %v), want WARNING after a repeated value", state, ok)
+	}
+
+	m.checkPrecision(ctx, tokenResult{symbol: "weth", mantissa: mantissa("3523456789100000000000")}, meta)
+	if _, ok := manager.GetActiveIncidents()[key]; ok {
+		t.Error("incident still active after an ordinary reading")
+	}
+}
@@ -0,0 +1,132 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+// maxRawSnippet bounds the response snippet quoted in rejection alerts
+const maxRawSnippet = 500
+
+var (
+	// errInvalidPrice is a reference price that is not a positive finite number
+	errInvalidPrice = errors.New("invalid reference price")
+	// errAnomalousPrice is a reference price too far from the last accepted one to be real
+	errAnomalousPrice = errors.New("anomalous reference price")
+)
+
+// checkReference validates a reference price against the last accepted one, nil when
+// there is none. maxFactor 0 skips the comparison.
+func checkReference(price ReferencePrice, accepted *ReferencePrice, maxFactor float64) error {
+	v := price.Value
+	if math.IsNaN(v) || math.IsInf(v, 0) || v <= 0 {
+		return fmt.Errorf("%w %v", errInvalidPrice, v)
+	}
+	if accepted == nil || maxFactor <= 0 {
+		return nil
+	}
+	factor := v / accepted.Value
+	if factor < 1 {
+		factor = 1 / factor
+	}
+	if factor > maxFactor {
+		return fmt.Errorf("%w: $%g is %.1fx the last accepted $%g", errAnomalousPrice, v, factor, accepted.Value)
+	}
+	return nil
+}
+
+// screenReference accepts a fetched reference price or rejects it per
+// oracle.price_sanity. A rejected price is replaced by the last accepted one
+// (reported as cached) and alerts developers; once rejections reach escalate_after
+// the alert turns critical and the token fails instead of pinning to the cache.
+func (m *OracleMonitor) screenReference(ctx context.Context, meta TokenMeta, price ReferencePrice) (ReferencePrice, bool, error) {
+	cfg := m.oracleConfig().PriceSanity
+	symbol := meta.Symbol
+
+	m.mu.Lock()
+	accepted, hasAccepted := m.acceptedRefs[symbol]
+	m.mu.Unlock()
+	var last *ReferencePrice
+	if hasAccepted {
+		last = &accepted
+	}
+
+	checkErr := checkReference(price, last, cfg.MaxChangeFactor)
+
+	m.mu.Lock()
+	if checkErr == nil {
+		if m.acceptedRefs == nil {
+			m.acceptedRefs = make(map[string]ReferencePrice)
+		}
+		m.acceptedRefs[symbol] = price
+		rejections := m.refRejections[symbol]
+		delete(m.refRejections, symbol)
+		m.mu.Unlock()
+		if rejections > 0 {
+			log.Printf("[%s][%s] %s reference price accepted again after %d rejections", m.Name(), m.chain.Name, symbol, rejections)
+			key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(symbol), Metric: "reference_price_rejected"}
+			m.observe(ctx, key, alerts.SeverityOK, 0, "", fmt.Sprintf("Chain: %s\nToken: %s", m.chain.Name, symbol), false, "")
+		}
+		return price, false, nil
+	}
+	if m.refRejections == nil {
+		m.refRejections = make(map[string]int)
+	}
+	m.refRejections[symbol]++
+	rejections := m.refRejections[symbol]
+	m.mu.Unlock()
+
+	escalated := rejections >= max(cfg.EscalateAfter, 1)
+	severity := alerts.SeverityWarning
+	if escalated {
+		severity = alerts.SeverityCritical
+	}
+	action := "Using the last accepted price"
+	switch {
+	case !hasAccepted:
+		action = "No accepted price to fall back on, so the token fails this cycle"
+	case escalated:
+		action = "Rejected too many times in a row, so the token fails until the source returns a plausible price"
+	}
+	log.Printf("[%s][%s] %s reference price rejected (%d in a row): %v", m.Name(), m.chain.Name, symbol, rejections, checkErr)
+
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(symbol), Metric: "reference_price_rejected"}
+	details := fmt.Sprintf("Chain: %s\nToken: %s\nSource: %s\nReason: %v\nRejections in a row: %d (critical from %d)\n%s.",
+		m.chain.Name, symbol, m.chain.PriceRoute(meta), checkErr, rejections, cfg.EscalateAfter, action)
+	if hasAccepted {
+		details += fmt.Sprintf("\nLast accepted: $%g", accepted.Value)
+		if !accepted.UpdatedAt.IsZero() {
+			details += fmt.Sprintf(", updated %s", alerts.FormatTime(accepted.UpdatedAt, m.now()))
+		}
+	}
+	if raw := price.Raw; raw != "" {
+		if len(raw) > maxRawSnippet {
+			raw = raw[:maxRawSnippet] + "..."
+		}
+		details += "\nResponse: " + raw
+	}
+	m.observe(ctx, key, severity, float64(rejections), "", details, false, "")
+
+	if !hasAccepted || escalated {
+		return ReferencePrice{}, false, checkErr
+	}
+	return accepted, true, nil
+}
+
+func registerPriceSanityPolicy(alertManager *alerts.Manager, jobName string) {
+	// The value is the rejection streak, so it changes every cycle; the cooldowns keep
+	// a persistent rejection to an update every few hours
+	alertManager.RegisterPolicy(jobName, "reference_price_rejected", alerts.AlertPolicy{
+		MinValueChange:        1.0,
+		CooldownWarning:       6 * time.Hour,
+		CooldownCritical:      time.Hour,
+		ReminderInterval:      6 * time.Hour,
+		ConsecutiveOKRequired: 1,
+	})
+}
@@ -0,0 +1,92 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestCheckReference(t *testing.T) {
+	accepted := &ReferencePrice{Value: 2}
+	tests := []struct {
+		name     string
+		value    float64
+		accepted *ReferencePrice
+		want     error
+	}{
+		{"zero", 0, nil, errInvalidPrice},
+		{"negative", -1, nil, errInvalidPrice},
+		{"NaN", math.NaN(), nil, errInvalidPrice},
+		{"infinite", math.Inf(1), accepted, errInvalidPrice},
+		{"first price", 1e30, nil, nil},
+		{"within factor", 19, accepted, nil},
+		{"10x up", 25, accepted, errAnomalousPrice},
+		{"10x down", 0.1, accepted, errAnomalousPrice},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkReference(ReferencePrice{Value: tt.value}, tt.accepted, 10)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("checkReference(%v) = %v, want %v", tt.value, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestScreenReferenceEscalates(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	manager.SetClock(func() time.Time { return now })
+	cfg := config.DefaultConfig()
+	cfg.Oracle.PriceSanity = config.PriceSanityConfig{MaxChangeFactor: 10, EscalateAfter: 2}
+	m := &OracleMonitor{
+		chain:        ChainConfig{ID: "sanity_test", Name: "Sanity Test", PriceSource: PriceSourceAlchemy},
+		alertManager: manager,
+		configs:      config.NewHolder(cfg),
+		clock:        func() time.Time { return now },
+	}
+	registerPriceSanityPolicy(manager, m.Name())
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("aero"), Metric: "reference_price_rejected"}
+	meta := TokenMeta{Symbol: "aero"}
+	ctx := context.Background()
+
+	if got, cached, err := m.screenReference(ctx, meta, ReferencePrice{Value: 1.2}); err != nil || cached || got.Value != 1.2 {
+		t.Fatalf("screenReference(1.2) = %v, %v, %v; want accepted", got, cached, err)
+	}
+
+	raw := `{"address":"0xaero","prices":[{"currency":"usd","value":"1.2e5"}]}`
+	got, cached, err := m.screenReference(ctx, meta, ReferencePrice{Value: 1.2e5, Raw: raw})
+	if err != nil || !cached || got.Value != 1.2 {
+		t.Fatalf("screenReference(1.2e5) = %v, %v, %v; want the last accepted price", got, cached, err)
+	}
+	state, ok := manager.GetActiveIncidents()[key]
+	if !ok || state.Severity != alerts.SeverityWarning {
+		t.Fatalf("incident = %+v (active %v), want WARNING after one rejection", state, ok)
+	}
+	if !strings.Contains(state.LastMessage, raw) {
+		t.Errorf("alert does not quote the response:\n%s", state.LastMessage)
+	}
+
+	// Repeated rejections stop pinning to the cache
+	now = now.Add(time.Minute)
+	if _, _, err := m.screenReference(ctx, meta, ReferencePrice{Value: 0}); !errors.Is(err, errInvalidPrice) {
+		t.Fatalf("second rejection = %v, want errInvalidPrice", err)
+	}
+	if state := manager.GetActiveIncidents()[key]; state.Severity != alerts.SeverityCritical {
+		t.Errorf("severity = %s after escalate_after rejections, want CRITICAL", state.Severity)
+	}
+
+	now = now.Add(time.Minute)
+	if _, cached, err := m.screenReference(ctx, meta, ReferencePrice{Value: 1.25}); err != nil || cached {
+		t.Fatalf("screenReference(1.25) = %v, %v; want accepted", cached, err)
+	}
+	if state, ok := manager.GetActiveIncidents()[key]; ok && state.Severity != alerts.SeverityOK {
+		t.Errorf("incident still %s after a plausible price", state.Severity)
+	}
+}
@@ -0,0 +1,297 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0x0Glitch/config"
+	"github.com/0x0Glitch/tracing"
+)
+
+// Reference price sources
+const (
+	PriceSourceAlchemy   = "alchemy"
+	PriceSourceCoinGecko = "coingecko"
+	PriceSourceDefiLlama = "defillama"
+)
+
+// PriceAPIs holds the base URLs of the reference price APIs, so tests can serve them locally
+type PriceAPIs struct {
+	Alchemy   string
+	CoinGecko string
+	DefiLlama string
+	// AlchemyPath is the by-address lookup path under Alchemy, with "{api_key}" standing
+	// for the key; empty means defaultAlchemyPath
+	AlchemyPath string
+}
+
+// defaultAlchemyPath is the by-address lookup path of the Alchemy prices API v1
+const defaultAlchemyPath = "/{api_key}/tokens/by-address"
+
+// DefaultPriceAPIs are the public price API endpoints
+var DefaultPriceAPIs = PriceAPIs{
+	Alchemy:     "https://api.g.alchemy.com/prices/v1",
+	CoinGecko:   "https://api.coingecko.com/api/v3",
+	DefiLlama:   "https://coins.llama.fi",
+	AlchemyPath: defaultAlchemyPath,
+}
+
+// ResolvePriceAPIs returns DefaultPriceAPIs with cfg's overrides applied, then
+// ALCHEMY_PRICE_API_BASE and ALCHEMY_PRICE_API_PATH, for routing price requests
+// through an internal proxy, regional mirror or new API version
+func ResolvePriceAPIs(cfg config.PriceAPIsConfig) PriceAPIs {
+	apis := DefaultPriceAPIs
+	for _, override := range []struct {
+		field *string
+		value string
+	}{
+		{&apis.Alchemy, cfg.AlchemyBaseURL},
+		{&apis.CoinGecko, cfg.CoinGeckoBaseURL},
+		{&apis.DefiLlama, cfg.DefiLlamaBaseURL},
+		{&apis.Alchemy, os.Getenv("ALCHEMY_PRICE_API_BASE")},
+	} {
+		if override.value != "" {
+			*override.field = strings.TrimRight(override.value, "/")
+		}
+	}
+	if cfg.AlchemyPath != "" {
+		apis.AlchemyPath = cfg.AlchemyPath
+	}
+	if path := os.Getenv("ALCHEMY_PRICE_API_PATH"); path != "" {
+		apis.AlchemyPath = path
+	}
+	return apis
+}
+
+// alchemyURL returns the by-address lookup URL for key
+func (a PriceAPIs) alchemyURL(key string) string {
+	path := a.AlchemyPath
+	if path == "" {
+		path = defaultAlchemyPath
+	}
+	return a.Alchemy + strings.ReplaceAll(path, "{api_key}", key)
+}
+
+func isPriceSource(source string) bool {
+	switch source {
+	case PriceSourceAlchemy, PriceSourceCoinGecko, PriceSourceDefiLlama:
+		return true
+	}
+	return false
+}
+
+// ReferencePrice is a reference price with the metadata its source reported
+type ReferencePrice struct {
+	Value     float64
+	Currency  string    // lowercase ISO code, e.g. "usd"
+	UpdatedAt time.Time // when the source last updated the price; zero if not reported
+	Raw       string    // response entry the price was parsed from, when the source keeps it
+}
+
+// PriceRoute is the resolved reference price lookup for a token
+type PriceRoute struct {
+	Source  string
+	Network string // Alchemy network slug, or CoinGecko/DefiLlama platform slug
+	Address string
+}
+
+func (r PriceRoute) String() string {
+	return r.Source + "/" + r.Network
+}
+
+// PriceRoute resolves where meta's reference price comes from, applying any
+// per-token override on top of the chain's source and network
+func (c ChainConfig) PriceRoute(meta TokenMeta) PriceRoute {
+	source := c.PriceSource
+	if meta.PriceSource != "" {
+		source = meta.PriceSource
+	}
+
+	network := meta.PriceNetwork
+	if network == "" {
+		network = c.PricePlatform
+		if source == PriceSourceAlchemy {
+			network = c.PriceNetwork
+		}
+		if network == "" {
+			network = string(c.ID)
+		}
+	}
+
+	return PriceRoute{Source: source, Network: network, Address: meta.PriceAddress}
+}
+
+// DescribePriceRouting summarizes a chain's price routing for startup logs,
+// listing tokens routed differently from the chain default
+func DescribePriceRouting(c ChainConfig) string {
+	chainRoute := c.PriceRoute(TokenMeta{})
+
+	var exceptions []string
+	for _, meta := range c.Tokens {
+		if meta.SkipDEXPrice {
+			continue
+		}
+		if route := c.PriceRoute(meta); route.String() != chainRoute.String() {
+			exceptions = append(exceptions, fmt.Sprintf("%s via %s", meta.Symbol, route))
+		}
+	}
+	sort.Strings(exceptions)
+
+	if len(exceptions) == 0 {
+		return chainRoute.String()
+	}
+	return fmt.Sprintf("%s (%s)", chainRoute, strings.Join(exceptions, ", "))
+}
+
+// errNoPriceData is returned when the price source has no price for a token
+var errNoPriceData = errors.New("no price data")
+
+// PriceRouteVia resolves meta's reference price lookup on source, keeping the token's
+// own network override only when source is the one it is routed to
+func (c ChainConfig) PriceRouteVia(meta TokenMeta, source string) PriceRoute {
+	if route := c.PriceRoute(meta); route.Source == source {
+		return route
+	}
+	return c.PriceRoute(TokenMeta{PriceSource: source, PriceAddress: meta.PriceAddress})
+}
+
+// getReferencePrice fetches a token's USD reference price from its routed source
+func (m *OracleMonitor) getReferencePrice(ctx context.Context, meta TokenMeta) (ReferencePrice, error) {
+	return m.getReferencePriceVia(ctx, meta, m.chain.PriceRoute(meta))
+}
+
+// getReferencePriceVia fetches a token's USD reference price over route
+func (m *OracleMonitor) getReferencePriceVia(ctx context.Context, meta TokenMeta, route PriceRoute) (ReferencePrice, error) {
+	if route.Address == "" {
+		return ReferencePrice{}, fmt.Errorf("no price address")
+	}
+
+	ctx, span := tracing.Start(ctx, "price_api", tracing.Chain(m.chain.Name), tracing.Symbol(meta.Symbol), tracing.Source(route.Source))
+	var prices map[string]ReferencePrice
+	var err error
+	defer func() { tracing.End(span, err) }()
+	switch route.Source {
+	case PriceSourceAlchemy:
+		prices, err = m.getAlchemyPrices(ctx, route.Network, []string{route.Address})
+	case PriceSourceCoinGecko:
+		prices, err = m.getCoinGeckoPrices(ctx, route.Network, []string{route.Address})
+	case PriceSourceDefiLlama:
+		prices, err = m.getDefiLlamaPrices(ctx, route.Network, []string{route.Address})
+	default:
+		err = fmt.Errorf("unsupported price source %q", route.Source)
+	}
+	if err != nil {
+		return ReferencePrice{}, err
+	}
+
+	price, ok := prices[strings.ToLower(route.Address)]
+	if !ok {
+		err = fmt.Errorf("%w from %s", errNoPriceData, route)
+		return ReferencePrice{}, err
+	}
+	return price, nil
+}
+
+// getCoinGeckoPrices fetches USD prices from CoinGecko's token price endpoint for
+// a platform, keyed by lowercase address. COINGECKO_API_KEY is sent when set.
+func (m *OracleMonitor) getCoinGeckoPrices(ctx context.Context, platform string, addresses []string) (map[string]ReferencePrice, error) {
+	endpoint := fmt.Sprintf("%s/simple/token_price/%s?contract_addresses=%s&vs_currencies=usd&include_last_updated_at=true",
+		m.priceAPIs.CoinGecko, url.PathEscape(platform), url.QueryEscape(strings.Join(addresses, ",")))
+
+	header := http.Header{}
+	if key := os.Getenv("COINGECKO_API_KEY"); key != "" {
+		header.Set("x-cg-demo-api-key", key)
+	}
+
+	var result map[string]struct {
+		USD           *float64 `json:"usd"`
+		LastUpdatedAt int64    `json:"last_updated_at"`
+	}
+	if err := m.getPriceJSON(ctx, endpoint, header, &result); err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]ReferencePrice, len(result))
+	for address, entry := range result {
+		if entry.USD != nil {
+			prices[strings.ToLower(address)] = ReferencePrice{Value: *entry.USD, Currency: "usd", UpdatedAt: unixTime(entry.LastUpdatedAt)}
+		}
+	}
+	return prices, nil
+}
+
+// getDefiLlamaPrices fetches current USD prices from DefiLlama for a chain slug,
+// keyed by lowercase address
+func (m *OracleMonitor) getDefiLlamaPrices(ctx context.Context, platform string, addresses []string) (map[string]ReferencePrice, error) {
+	coins := make([]string, len(addresses))
+	for i, address := range addresses {
+		coins[i] = platform + ":" + address
+	}
+	endpoint := m.priceAPIs.DefiLlama + "/prices/current/" + url.PathEscape(strings.Join(coins, ","))
+
+	var result struct {
+		Coins map[string]struct {
+			Price     float64 `json:"price"`
+			Timestamp int64   `json:"timestamp"`
+		} `json:"coins"`
+	}
+	if err := m.getPriceJSON(ctx, endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]ReferencePrice, len(result.Coins))
+	for coin, entry := range result.Coins {
+		_, address, ok := strings.Cut(coin, ":")
+		if ok && entry.Price > 0 {
+			prices[strings.ToLower(address)] = ReferencePrice{Value: entry.Price, Currency: "usd", UpdatedAt: unixTime(entry.Timestamp)}
+		}
+	}
+	return prices, nil
+}
+
+// unixTime converts a Unix timestamp in seconds, treating 0 as not reported
+func unixTime(seconds int64) time.Time {
+	if seconds <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
+
+// getPriceJSON performs a rate-limited GET and decodes the JSON response into out
+func (m *OracleMonitor) getPriceJSON(ctx context.Context, endpoint string, header http.Header, out interface{}) error {
+	if err := m.limiter.Acquire(ctx); err != nil {
+		return err
+	}
+	defer m.limiter.Release()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return newHTTPStatusError(resp.StatusCode, string(body), m.oracleConfig().RetryStatusCodes)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
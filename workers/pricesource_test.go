@@ -0,0 +1,92 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/0x0Glitch/config"
+)
+
+func TestPriceRoute(t *testing.T) {
+	chain := MoonriverChain()
+	err := applyChainOverride(&chain, config.ChainConfig{
+		PriceTokens: map[string]config.PriceRouteConfig{
+			"xcKSM": {Source: PriceSourceAlchemy, Network: "moonbeam-mainnet", Address: "0xffffffff1fcacbd218edc0eba20fc2308c778080"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("applyChainOverride: %v", err)
+	}
+
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{"frax", "defillama/moonriver"},
+		{"xcksm", "alchemy/moonbeam-mainnet"},
+	}
+	for _, tt := range tests {
+		if got := chain.PriceRoute(chain.Tokens[tt.token]).String(); got != tt.want {
+			t.Errorf("%s route = %s, want %s", tt.token, got, tt.want)
+		}
+	}
+
+	if MoonriverTokens()["xcksm"].PriceSource != "" {
+		t.Error("price_tokens override modified the compiled-in token table")
+	}
+	if got := DescribePriceRouting(chain); got != "defillama/moonriver (xcKSM via alchemy/moonbeam-mainnet)" {
+		t.Errorf("DescribePriceRouting = %q", got)
+	}
+}
+
+func TestPriceRoutingEnvOverride(t *testing.T) {
+	t.Setenv("BASE_RPC_URL", "http://localhost:8545")
+	t.Setenv("BASE_PRICE_SOURCE", "CoinGecko")
+	t.Setenv("BASE_PRICE_PLATFORM", "base")
+
+	chains, err := GetChainsByEnv("base", nil, nil)
+	if err != nil {
+		t.Fatalf("GetChainsByEnv: %v", err)
+	}
+	if got := chains[0].PriceRoute(TokenMeta{}).String(); got != "coingecko/base" {
+		t.Errorf("route = %s, want coingecko/base", got)
+	}
+
+	t.Setenv("BASE_PRICE_SOURCE", "dexscreener")
+	if _, err := GetChainsByEnv("base", nil, nil); err == nil {
+		t.Error("GetChainsByEnv with unsupported price source = nil error")
+	}
+}
+
+func TestPriceTokensUnknownToken(t *testing.T) {
+	chain := BaseChain()
+	err := applyChainOverride(&chain, config.ChainConfig{
+		PriceTokens: map[string]config.PriceRouteConfig{"doge": {Network: "eth-mainnet"}},
+	})
+	if err == nil {
+		t.Error("applyChainOverride with unknown token = nil error")
+	}
+}
+
+func TestResolvePriceAPIs(t *testing.T) {
+	t.Setenv("ALCHEMY_PRICE_API_BASE", "")
+	t.Setenv("ALCHEMY_PRICE_API_PATH", "")
+	if got := ResolvePriceAPIs(config.PriceAPIsConfig{}); got != DefaultPriceAPIs {
+		t.Errorf("without override got %+v", got)
+	}
+
+	cfg := config.PriceAPIsConfig{AlchemyBaseURL: "https://cache.internal/alchemy", AlchemyPath: "/v2/{api_key}/prices"}
+	got := ResolvePriceAPIs(cfg)
+	if want := "https://cache.internal/alchemy/v2/secret/prices"; got.alchemyURL("secret") != want {
+		t.Errorf("config override URL = %s, want %s", got.alchemyURL("secret"), want)
+	}
+
+	// The environment wins over the config file
+	t.Setenv("ALCHEMY_PRICE_API_BASE", "https://prices.internal/alchemy/")
+	got = ResolvePriceAPIs(cfg)
+	if got.Alchemy != "https://prices.internal/alchemy" || got.CoinGecko != DefaultPriceAPIs.CoinGecko {
+		t.Errorf("with override got %+v", got)
+	}
+	if want := "https://api.g.alchemy.com/prices/v1/secret/tokens/by-address"; DefaultPriceAPIs.alchemyURL("secret") != want {
+		t.Errorf("default URL = %s, want %s", DefaultPriceAPIs.alchemyURL("secret"), want)
+	}
+}
@@ -0,0 +1,125 @@
+package workers
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0x0Glitch/config"
+)
+
+// Observation is one successful token check as published to the PriceStore
+type Observation struct {
+	OnchainPrice     float64   `json:"onchain_price"`
+	ReferencePrice   float64   `json:"reference_price"`
+	DeviationPercent float64   `json:"deviation_percent"`
+	ObservedAt       time.Time `json:"observed_at"`
+}
+
+// PriceKey identifies a token in the PriceStore by chain ID and symbol
+type PriceKey struct {
+	Chain  string
+	Symbol string
+}
+
+// PriceView is the read-only side of a PriceStore, for jobs and handlers that use
+// the oracle monitors' readings without publishing any
+type PriceView interface {
+	Snapshot() map[PriceKey]Observation
+	Latest(chain, symbol string) (Observation, bool)
+}
+
+// PriceStore holds the latest successful observation per token across all chains.
+// Observations older than oracle.price_store_max_age_minutes are dropped.
+type PriceStore struct {
+	configs *config.Holder
+	clock   func() time.Time
+
+	mu      sync.RWMutex
+	entries map[PriceKey]Observation
+}
+
+// NewPriceStore creates an empty store reading its max age from configs
+func NewPriceStore(configs *config.Holder) *PriceStore {
+	return &PriceStore{
+		configs: configs,
+		entries: make(map[PriceKey]Observation),
+	}
+}
+
+// SetClock replaces time.Now for expiry
+func (s *PriceStore) SetClock(clock func() time.Time) {
+	s.clock = clock
+}
+
+func (s *PriceStore) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock()
+}
+
+func (s *PriceStore) expired(obs Observation, now time.Time) bool {
+	maxAge := s.configs.Get().Oracle.PriceStoreMaxAgeMinutes.Duration()
+	return maxAge > 0 && now.Sub(obs.ObservedAt) > maxAge
+}
+
+// Publish replaces the observation for symbol on chain. A nil store ignores it.
+func (s *PriceStore) Publish(chain, symbol string, obs Observation) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[PriceKey{Chain: chain, Symbol: symbol}] = obs
+}
+
+// Snapshot returns a copy of the unexpired observations, dropping expired ones
+func (s *PriceStore) Snapshot() map[PriceKey]Observation {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := make(map[PriceKey]Observation, len(s.entries))
+	for key, obs := range s.entries {
+		if s.expired(obs, now) {
+			delete(s.entries, key)
+			continue
+		}
+		snapshot[key] = obs
+	}
+	return snapshot
+}
+
+// Latest returns the unexpired observation for symbol on chain
+func (s *PriceStore) Latest(chain, symbol string) (Observation, bool) {
+	s.mu.RLock()
+	obs, ok := s.entries[PriceKey{Chain: chain, Symbol: symbol}]
+	s.mu.RUnlock()
+	if !ok || s.expired(obs, s.now()) {
+		return Observation{}, false
+	}
+	return obs, true
+}
+
+// StoredPrice is a PriceStore entry flattened for JSON
+type StoredPrice struct {
+	Chain  string `json:"chain"`
+	Symbol string `json:"symbol"`
+	Observation
+}
+
+// SortedSnapshot lists view's unexpired observations sorted by chain then symbol
+func SortedSnapshot(view PriceView) []StoredPrice {
+	snapshot := view.Snapshot()
+	prices := make([]StoredPrice, 0, len(snapshot))
+	for key, obs := range snapshot {
+		prices = append(prices, StoredPrice{Chain: key.Chain, Symbol: key.Symbol, Observation: obs})
+	}
+	sort.Slice(prices, func(i, j int) bool {
+		if prices[i].Chain != prices[j].Chain {
+			return prices[i].Chain < prices[j].Chain
+		}
+		return prices[i].Symbol < prices[j].Symbol
+	})
+	return prices
+}
@@ -0,0 +1,76 @@
+package workers
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/config"
+)
+
+func TestPriceStoreExpiresOldObservations(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.DefaultConfig()
+	cfg.Oracle.PriceStoreMaxAgeMinutes = config.Minutes(30 * time.Minute)
+	store := NewPriceStore(config.NewHolder(cfg))
+	store.SetClock(func() time.Time { return now })
+
+	store.Publish("base", "weth", Observation{OnchainPrice: 3000, ReferencePrice: 3010, ObservedAt: now.Add(-40 * time.Minute)})
+	store.Publish("base", "usdc", Observation{OnchainPrice: 1, ReferencePrice: 1, ObservedAt: now.Add(-10 * time.Minute)})
+
+	if _, ok := store.Latest("base", "weth"); ok {
+		t.Error("Latest returned a 40m old observation, want it expired")
+	}
+	if obs, ok := store.Latest("base", "usdc"); !ok || obs.OnchainPrice != 1 {
+		t.Errorf("Latest(usdc) = %+v, %v; want the 10m old observation", obs, ok)
+	}
+	snapshot := store.Snapshot()
+	if len(snapshot) != 1 {
+		t.Fatalf("snapshot = %v, want only usdc", snapshot)
+	}
+	if _, ok := snapshot[PriceKey{Chain: "base", Symbol: "usdc"}]; !ok {
+		t.Errorf("snapshot = %v, want usdc", snapshot)
+	}
+
+	// The snapshot is a copy
+	snapshot[PriceKey{Chain: "base", Symbol: "dai"}] = Observation{}
+	if _, ok := store.Latest("base", "dai"); ok {
+		t.Error("changing the snapshot changed the store")
+	}
+
+	var nilStore *PriceStore
+	nilStore.Publish("base", "weth", Observation{})
+}
+
+func TestPriceStoreConcurrentPublishers(t *testing.T) {
+	store := NewPriceStore(config.NewHolder(config.DefaultConfig()))
+	chains := []string{"base", "optimism", "moonbeam"}
+
+	var wg sync.WaitGroup
+	for _, chain := range chains {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				store.Publish(chain, fmt.Sprintf("token%d", i%10), Observation{OnchainPrice: float64(i), ObservedAt: time.Now()})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				store.Snapshot()
+				store.Latest(chain, "token0")
+			}
+		}()
+	}
+	wg.Wait()
+
+	prices := SortedSnapshot(store)
+	if len(prices) != len(chains)*10 {
+		t.Fatalf("%d prices stored, want %d", len(prices), len(chains)*10)
+	}
+	if prices[0].Chain != "base" || prices[0].Symbol != "token0" {
+		t.Errorf("first price = %s/%s, want base/token0", prices[0].Chain, prices[0].Symbol)
+	}
+}
@@ -0,0 +1,92 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+// errStaleReference is a reference price older than oracle.reference_max_age allows
+var errStaleReference = errors.New("stale reference price")
+
+// maxReferenceAge returns how old meta's reference price may be, 0 for no limit
+func (m *OracleMonitor) maxReferenceAge(meta TokenMeta) time.Duration {
+	cfg := m.oracleConfig().ReferenceMaxAge
+	if meta.IsStablecoin {
+		return cfg.StablecoinMinutes.Duration()
+	}
+	return cfg.VolatileMinutes.Duration()
+}
+
+// checkReferenceAge rejects a reference price its source last updated longer ago than
+// meta's token class allows. Prices without an update time pass.
+func (m *OracleMonitor) checkReferenceAge(meta TokenMeta, price ReferencePrice) error {
+	limit := m.maxReferenceAge(meta)
+	if limit <= 0 || price.UpdatedAt.IsZero() {
+		return nil
+	}
+	if age := m.now().Sub(price.UpdatedAt); age > limit {
+		return fmt.Errorf("%w: updated %s ago, limit %s", errStaleReference, age.Round(time.Minute), limit)
+	}
+	return nil
+}
+
+// trackStaleReference counts another cycle in which symbol's reference price was too
+// old, alerting developers once the streak exceeds oracle.reference_max_age.alert_after_cycles
+func (m *OracleMonitor) trackStaleReference(ctx context.Context, result tokenResult) {
+	symbol := result.symbol
+	m.mu.Lock()
+	if m.staleRef == nil {
+		m.staleRef = make(map[string]int)
+	}
+	m.staleRef[symbol]++
+	cycles := m.staleRef[symbol]
+	m.mu.Unlock()
+
+	limit := m.oracleConfig().ReferenceMaxAge.AlertAfterCycles
+	if limit <= 0 || cycles <= limit {
+		return
+	}
+	if cycles == limit+1 {
+		log.Printf("[%s][%s] %s reference price has been stale for %d cycles", m.Name(), m.chain.Name, symbol, cycles)
+	}
+
+	meta := m.chain.Tokens[symbol]
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(symbol), Metric: "reference_staleness"}
+	details := fmt.Sprintf("Chain: %s\nToken: %s\nSource: %s\nReference updated: %s\nCycles stale: %d (limit %d)\nError: %v\n\n"+
+		"The token's only reference source keeps returning an old price, so its deviation is not checked. "+
+		"The token may be too illiquid for this source; consider another price source for it.",
+		m.chain.Name, symbol, m.chain.PriceRoute(meta), alerts.FormatTime(result.dexUpdatedAt, m.now()), cycles, limit, result.err)
+	m.observe(ctx, key, alerts.SeverityWarning, 1, "", details, false, "")
+}
+
+// clearStaleReference resets symbol's streak once a fresh reference price returns,
+// resolving its alert if one was raised
+func (m *OracleMonitor) clearStaleReference(ctx context.Context, symbol string) {
+	m.mu.Lock()
+	cycles := m.staleRef[symbol]
+	delete(m.staleRef, symbol)
+	m.mu.Unlock()
+
+	if limit := m.oracleConfig().ReferenceMaxAge.AlertAfterCycles; limit <= 0 || cycles <= limit {
+		return
+	}
+	log.Printf("[%s][%s] %s reference price fresh again after %d cycles", m.Name(), m.chain.Name, symbol, cycles)
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(symbol), Metric: "reference_staleness"}
+	m.observe(ctx, key, alerts.SeverityOK, 0, "", fmt.Sprintf("Chain: %s\nToken: %s", m.chain.Name, symbol), false, "")
+}
+
+func registerReferenceStalenessPolicy(alertManager *alerts.Manager, jobName string) {
+	// Observed every cycle while stale with a constant value, so it is sent once
+	alertManager.RegisterPolicy(jobName, "reference_staleness", alerts.AlertPolicy{
+		MinValueChange:        1.0,
+		CooldownWarning:       24 * time.Hour,
+		CooldownCritical:      24 * time.Hour,
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: 1,
+	})
+}
@@ -0,0 +1,89 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestCheckTokenRejectsStaleReference(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	updatedAt := now.Add(-3 * time.Hour)
+	m := newAlchemyTestMonitor(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data": [{"address": "0x4200000000000000000000000000000000000006",
+			"prices": [{"currency": "usd", "value": "3120.55", "lastUpdatedAt": %q}]}]}`, updatedAt.Format(time.RFC3339))
+	})
+	cfg := config.DefaultConfig()
+	cfg.Oracle.ReferenceMaxAge = config.ReferenceMaxAgeConfig{
+		StablecoinMinutes: config.Minutes(4 * time.Hour),
+		VolatileMinutes:   config.Minutes(time.Hour),
+	}
+	m.configs = config.NewHolder(cfg)
+	m.clock = func() time.Time { return now }
+
+	mToken := "0x628ff693426583D9a7FB391E54366292F509D457"
+	meta := TokenMeta{Symbol: "weth", MTokAddr: mToken, Decimals: 18, PriceAddress: "0x4200000000000000000000000000000000000006"}
+	batched := map[common.Address]*big.Int{common.HexToAddress(mToken): new(big.Int).Mul(big.NewInt(3120), big.NewInt(1e18))}
+
+	result := m.checkToken(context.Background(), "weth", meta, batched)
+	if !errors.Is(result.err, errStaleReference) {
+		t.Fatalf("volatile 3h old reference: err = %v, want errStaleReference", result.err)
+	}
+	if !result.dexUpdatedAt.Equal(updatedAt) {
+		t.Errorf("dexUpdatedAt = %v, want %v for the staleness alert", result.dexUpdatedAt, updatedAt)
+	}
+
+	// Stablecoins allow an older reference
+	meta.IsStablecoin, meta.PegValue = true, 3120
+	if result := m.checkToken(context.Background(), "weth", meta, batched); result.err != nil {
+		t.Errorf("stablecoin 3h old reference: err = %v, want accepted", result.err)
+	}
+	if err := m.checkReferenceAge(TokenMeta{}, ReferencePrice{Value: 1}); err != nil {
+		t.Errorf("reference without update time = %v, want accepted", err)
+	}
+}
+
+func TestStaleReferenceAlertsPastLimit(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	manager.SetClock(func() time.Time { return now })
+	cfg := config.DefaultConfig()
+	cfg.Oracle.ReferenceMaxAge.AlertAfterCycles = 2
+	m := &OracleMonitor{
+		chain:        ChainConfig{ID: "stale_ref_test", Name: "Stale Ref Test", PriceSource: PriceSourceAlchemy},
+		alertManager: manager,
+		configs:      config.NewHolder(cfg),
+		clock:        func() time.Time { return now },
+	}
+	registerReferenceStalenessPolicy(manager, m.Name())
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity("mamo"), Metric: "reference_staleness"}
+	result := tokenResult{
+		symbol:       "mamo",
+		dexUpdatedAt: now.Add(-7 * 24 * time.Hour),
+		err:          fmt.Errorf("dex price: %w: updated 168h0m0s ago, limit 1h0m0s", errStaleReference),
+	}
+	ctx := context.Background()
+
+	m.trackStaleReference(ctx, result)
+	m.trackStaleReference(ctx, result)
+	if _, ok := manager.GetActiveIncidents()[key]; ok {
+		t.Fatal("alerted at the limit, want only past it")
+	}
+	m.trackStaleReference(ctx, result)
+	if state, ok := manager.GetActiveIncidents()[key]; !ok || state.Severity != alerts.SeverityWarning {
+		t.Fatalf("incident = %+v (active %v), want WARNING past the limit", state, ok)
+	}
+
+	m.clearStaleReference(ctx, "mamo")
+	if state, ok := manager.GetActiveIncidents()[key]; ok && state.Severity != alerts.SeverityOK {
+		t.Errorf("incident still %s after a fresh price", state.Severity)
+	}
+}
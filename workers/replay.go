@@ -0,0 +1,145 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+// replayFlapWindow is how soon after clearing a reopened incident counts as a flap
+const replayFlapWindow = time.Hour
+
+// ReplayReport summarises what a configuration would have sent for historical readings
+type ReplayReport struct {
+	Observations int
+	Skipped      int // failed reads and unknown chains or tokens
+	// Sent counts messages by severity and channel ("business" or "developer")
+	Sent      map[ReplayChannel]int
+	Incidents []ReplayIncident
+}
+
+// ReplayChannel identifies a message destination and severity in a ReplayReport
+type ReplayChannel struct {
+	Severity alerts.Severity
+	Channel  string
+}
+
+// ReplayIncident is one incident opened during a replay
+type ReplayIncident struct {
+	alerts.AlertKey
+	Opened, Closed time.Time // Closed is the last reading when Open
+	Peak           alerts.Severity
+	Open           bool // still open when the readings ended
+	Flap           bool // reopened within replayFlapWindow of the previous incident clearing
+}
+
+// Duration is how long the incident was open
+func (i ReplayIncident) Duration() time.Duration {
+	return i.Closed.Sub(i.Opened)
+}
+
+// Replay feeds historical readings (as served by /prices) through the deviation
+// classification and manager, whose clock follows the readings. manager should be
+// a dry-run Manager with no channels configured and policies registered (see
+// RegisterOraclePolicies). Broad-move routing is not replayed; clamped deviations
+// go to developers as in a live cycle.
+func Replay(ctx context.Context, manager *alerts.Manager, chains []ChainConfig, cfg *config.Config, observations []TokenPrice) ReplayReport {
+	sorted := make([]TokenPrice, len(observations))
+	copy(sorted, observations)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ObservedAt.Before(sorted[j].ObservedAt) })
+
+	byName := make(map[string]ChainConfig, 2*len(chains))
+	for _, chain := range chains {
+		byName[strings.ToLower(string(chain.ID))] = chain
+		byName[strings.ToLower(chain.Name)] = chain
+	}
+
+	var now time.Time
+	manager.SetClock(func() time.Time { return now })
+	manager.SetDecisionLogSize(len(sorted))
+	oracleCfg, _ := resolveThresholds(cfg.Oracle)
+
+	report := ReplayReport{Observations: len(sorted), Sent: make(map[ReplayChannel]int)}
+	for _, obs := range sorted {
+		chain, ok := byName[strings.ToLower(obs.Chain)]
+		_, meta, found := lookupToken(chain.Tokens, obs.Symbol)
+		if !ok || !found || obs.Error != "" || obs.ObservedAt.IsZero() {
+			report.Skipped++
+			continue
+		}
+		now = obs.ObservedAt
+
+		var pegDeviation float64
+		if meta.IsStablecoin && meta.PegValue > 0 {
+			pegDeviation = (obs.OnchainPrice - meta.PegValue) / meta.PegValue * 100
+		}
+		reported, clamped := clampDeviation(obs.DeviationPercent, oracleCfg.MaxReportedDeviationPercent)
+
+		key := alerts.AlertKey{
+			Job:    fmt.Sprintf("oracle_%s", chain.ID),
+			Entity: alerts.ChainEntity(string(chain.ID), meta.TableName),
+			Metric: deviationMetric(meta),
+		}
+		bands := severityBands(cfg, key.Job, key.Metric, warningCritical(deviationThresholds(meta, &oracleCfg, pegDeviation)))
+		severity := classify(obs.DeviationPercent, bands)
+		details := fmt.Sprintf("Token: %s\nChain: %s\nDeviation: %.2f%%", meta.TableName, chain.Name, reported)
+		manager.Observe(ctx, key, severity, reported, "", details, !clamped, "")
+	}
+
+	report.Incidents = replayIncidents(manager.DecisionLog("", ""), now, &report)
+	return report
+}
+
+// replayIncidents rebuilds incidents from the decision log and counts sent messages
+func replayIncidents(decisions []alerts.Decision, end time.Time, report *ReplayReport) []ReplayIncident {
+	open := make(map[alerts.AlertKey]*ReplayIncident)
+	lastClosed := make(map[alerts.AlertKey]time.Time)
+	var incidents []*ReplayIncident
+
+	for _, d := range decisions {
+		key := alerts.AlertKey{Job: d.Job, Entity: d.Entity, Metric: d.Metric}
+		switch d.Outcome {
+		case alerts.OutcomeNew, alerts.OutcomeEscalation, alerts.OutcomeDeescalation, alerts.OutcomeReminder, alerts.OutcomeUpdate:
+			channel := "developer"
+			if d.Business {
+				channel = "business"
+			}
+			report.Sent[ReplayChannel{Severity: d.Severity, Channel: channel}]++
+		}
+
+		incident := open[key]
+		switch {
+		case d.Outcome == alerts.OutcomeCleared && incident != nil:
+			incident.Closed, incident.Open = d.Time, false
+			lastClosed[key] = d.Time
+			delete(open, key)
+		case d.Severity != alerts.SeverityOK && incident == nil:
+			closed, reopened := lastClosed[key]
+			incident = &ReplayIncident{
+				AlertKey: key,
+				Opened:   d.Time,
+				Peak:     d.Severity,
+				Open:     true,
+				Flap:     reopened && d.Time.Sub(closed) <= replayFlapWindow,
+			}
+			open[key] = incident
+			incidents = append(incidents, incident)
+		case incident != nil && d.Severity == alerts.SeverityCritical:
+			incident.Peak = alerts.SeverityCritical
+		}
+	}
+
+	result := make([]ReplayIncident, len(incidents))
+	for i, incident := range incidents {
+		if incident.Open {
+			incident.Closed = end
+		}
+		result[i] = *incident
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestReplayCountsIncidentsAndFlaps(t *testing.T) {
+	cfg := config.DefaultConfig()
+	chain := ChainConfig{
+		ID:     "replay_test",
+		Name:   "Replay Test",
+		Tokens: map[string]TokenMeta{"WETH": {Symbol: "WETH", TableName: "weth"}},
+	}
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	RegisterOraclePolicies(manager, &cfg.Oracle, string(chain.ID))
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var observations []TokenPrice
+	for i, deviation := range []float64{0, 4, 6, 0, 4, 0} {
+		observations = append(observations, TokenPrice{
+			Chain:            "replay_test",
+			Symbol:           "WETH",
+			DeviationPercent: deviation,
+			ObservedAt:       start.Add(time.Duration(i) * 2 * time.Minute),
+		})
+	}
+	observations = append(observations,
+		TokenPrice{Chain: "replay_test", Symbol: "UNKNOWN", ObservedAt: start},
+		TokenPrice{Chain: "replay_test", Symbol: "WETH", Error: "rpc timeout", ObservedAt: start},
+	)
+
+	report := Replay(context.Background(), manager, []ChainConfig{chain}, cfg, observations)
+
+	if report.Observations != 8 || report.Skipped != 2 {
+		t.Errorf("observations = %d, skipped = %d, want 8 and 2", report.Observations, report.Skipped)
+	}
+	if got := report.Sent[ReplayChannel{Severity: alerts.SeverityWarning, Channel: "business"}]; got != 2 {
+		t.Errorf("business WARNING messages = %d, want 2", got)
+	}
+	if got := report.Sent[ReplayChannel{Severity: alerts.SeverityCritical, Channel: "business"}]; got != 1 {
+		t.Errorf("business CRITICAL messages = %d, want 1", got)
+	}
+	if len(report.Incidents) != 2 {
+		t.Fatalf("incidents = %+v, want 2", report.Incidents)
+	}
+	first, second := report.Incidents[0], report.Incidents[1]
+	if first.Peak != alerts.SeverityCritical || first.Flap || first.Duration() != 4*time.Minute {
+		t.Errorf("first incident = %+v, want a 4m CRITICAL peak and no flap", first)
+	}
+	if second.Peak != alerts.SeverityWarning || !second.Flap || second.Open {
+		t.Errorf("second incident = %+v, want a closed WARNING flap", second)
+	}
+}
@@ -0,0 +1,161 @@
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"expvar"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+// retentionDeleted counts rows removed by the retention job per table
+var retentionDeleted = expvar.NewMap("retention_rows_deleted")
+
+// retentionTable is a history table pruned by the retention job
+type retentionTable struct {
+	name   string
+	column string // timestamp compared against the cutoff
+	days   func(config.RetentionConfig) int
+}
+
+var retentionTables = []retentionTable{
+	{"alert_events", "created_at", func(c config.RetentionConfig) int { return c.AlertEventsDays }},
+	{"oracle_observations", "observed_at", func(c config.RetentionConfig) int { return c.OracleObservationsDays }},
+	{"protocol_snapshots", "created_at", func(c config.RetentionConfig) int { return c.ProtocolSnapshotsDays }},
+}
+
+// RetentionJob deletes history rows older than their configured retention once a day
+// and sends developers a digest of the rows it deleted
+type RetentionJob struct {
+	db           *sql.DB
+	alertManager *alerts.Manager
+	configs      *config.Holder
+}
+
+// NewRetentionJob creates the daily retention job
+func NewRetentionJob(databaseURL string, alertManager *alerts.Manager, configs *config.Holder) (*RetentionJob, error) {
+	if databaseURL == "" {
+		return nil, fmt.Errorf("database URL not configured")
+	}
+
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &RetentionJob{db: db, alertManager: alertManager, configs: configs}, nil
+}
+
+func (j *RetentionJob) Name() string {
+	return "retention"
+}
+
+func (j *RetentionJob) Interval() time.Duration {
+	return 24 * time.Hour
+}
+
+func (j *RetentionJob) Close() error {
+	return j.db.Close()
+}
+
+func (j *RetentionJob) Run(ctx context.Context) error {
+	cfg := j.configs.Get().Retention
+	var firstErr error
+	var lines []string
+	for _, table := range retentionTables {
+		days := table.days(cfg)
+		if days <= 0 {
+			continue
+		}
+		deleted, err := j.prune(ctx, table, days, cfg)
+		if err != nil {
+			log.Printf("[%s] %s: %v", j.Name(), table.name, err)
+			lines = append(lines, fmt.Sprintf("%s: %d rows deleted, then failed: %v", table.name, deleted, err))
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", table.name, err)
+			}
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %d rows older than %d days deleted", table.name, deleted, days))
+	}
+
+	if len(lines) > 0 {
+		msg := "🧹 DAILY RETENTION DIGEST\n\n" + strings.Join(lines, "\n")
+		if err := j.alertManager.SendDeveloperNotice(ctx, j.Name(), msg); err != nil {
+			log.Printf("[%s] failed to send digest: %v", j.Name(), err)
+		}
+	}
+	return firstErr
+}
+
+// prune deletes table's rows older than days in batches of cfg.BatchSize (5000 when
+// unset) and returns how many it deleted
+func (j *RetentionJob) prune(ctx context.Context, table retentionTable, days int, cfg config.RetentionConfig) (int64, error) {
+	exists, err := j.hasColumn(ctx, table.name, table.column)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -days)
+	query := fmt.Sprintf(`DELETE FROM %[1]s WHERE ctid IN (SELECT ctid FROM %[1]s WHERE %[2]s < $1 LIMIT $2)`,
+		table.name, table.column)
+
+	batch := cfg.BatchSize
+	if batch <= 0 {
+		batch = 5000
+	}
+
+	var deleted int64
+	for {
+		res, err := j.db.ExecContext(ctx, query, cutoff, batch)
+		if err != nil {
+			return deleted, fmt.Errorf("delete after %d rows: %w", deleted, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+		retentionDeleted.Add(table.name, n)
+		if n == 0 || n < int64(batch) {
+			break
+		}
+	}
+	log.Printf("[%s] %s: deleted %d rows older than %d days", j.Name(), table.name, deleted, days)
+
+	if cfg.VacuumAnalyze && deleted > 0 {
+		if _, err := j.db.ExecContext(ctx, "VACUUM ANALYZE "+table.name); err != nil {
+			return deleted, fmt.Errorf("vacuum analyze: %w", err)
+		}
+	}
+	return deleted, nil
+}
+
+// hasColumn reports whether table exists in the current schema with column
+func (j *RetentionJob) hasColumn(ctx context.Context, table, column string) (bool, error) {
+	var exists bool
+	err := j.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2
+		)`, table, column).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check table: %w", err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,219 @@
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+// retentionDB is a database/sql driver scripting the statements the retention job
+// sends: which tables have their timestamp column, and the rows each DELETE removes
+type retentionDB struct {
+	mu       sync.Mutex
+	columns  map[string]bool    // table -> column exists
+	batches  map[string][]int64 // table -> rows removed by successive DELETEs
+	executed []string
+	limits   []int64 // LIMIT of each DELETE
+}
+
+func (d *retentionDB) Open(string) (driver.Conn, error) { return retentionConn{d}, nil }
+
+type retentionConn struct{ db *retentionDB }
+
+func (c retentionConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c retentionConn) Close() error { return nil }
+func (c retentionConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+func (c retentionConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !strings.Contains(query, "information_schema.columns") {
+		return nil, errors.New("unexpected query: " + query)
+	}
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	return &boolRows{value: c.db.columns[args[0].Value.(string)]}, nil
+}
+
+func (c retentionConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.executed = append(c.db.executed, query)
+	if len(args) == 2 {
+		c.db.limits = append(c.db.limits, args[1].Value.(int64))
+	}
+	if strings.HasPrefix(query, "VACUUM") {
+		return driver.RowsAffected(0), nil
+	}
+	for table, batches := range c.db.batches {
+		if strings.HasPrefix(query, "DELETE FROM "+table+" ") {
+			if len(batches) == 0 {
+				return driver.RowsAffected(0), nil
+			}
+			c.db.batches[table] = batches[1:]
+			return driver.RowsAffected(batches[0]), nil
+		}
+	}
+	return nil, errors.New("unexpected statement: " + query)
+}
+
+type boolRows struct {
+	value bool
+	done  bool
+}
+
+func (r *boolRows) Columns() []string { return []string{"exists"} }
+func (r *boolRows) Close() error      { return nil }
+func (r *boolRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func (d *retentionDB) statements(prefix string) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var matched []string
+	for _, query := range d.executed {
+		if strings.HasPrefix(query, prefix) {
+			matched = append(matched, query)
+		}
+	}
+	return matched
+}
+
+func newRetentionTestJob(t *testing.T, db *retentionDB, vacuum bool) *RetentionJob {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Retention.BatchSize = 100
+	cfg.Retention.VacuumAnalyze = vacuum
+	conn := sql.OpenDB(retentionConnector{db})
+	t.Cleanup(func() { conn.Close() })
+	manager := alerts.NewManager(alerts.New("", "", "", "", ""))
+	return &RetentionJob{db: conn, alertManager: manager, configs: config.NewHolder(cfg)}
+}
+
+type retentionConnector struct{ db *retentionDB }
+
+func (c retentionConnector) Connect(context.Context) (driver.Conn, error) {
+	return retentionConn{c.db}, nil
+}
+func (c retentionConnector) Driver() driver.Driver { return c.db }
+
+func TestRetentionDeletesInBatches(t *testing.T) {
+	db := &retentionDB{
+		columns: map[string]bool{"alert_events": true, "oracle_observations": true},
+		batches: map[string][]int64{"alert_events": {100, 100, 40, 100}, "oracle_observations": {0}},
+	}
+	j := newRetentionTestJob(t, db, false)
+
+	if err := j.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	// A batch short of batch_size ends the loop; the fourth batch is never sent
+	if got := len(db.statements("DELETE FROM alert_events ")); got != 3 {
+		t.Errorf("%d alert_events DELETEs, want 3", got)
+	}
+	if got := len(db.statements("DELETE FROM oracle_observations ")); got != 1 {
+		t.Errorf("%d oracle_observations DELETEs, want 1", got)
+	}
+	// protocol_snapshots has no created_at column here, so it is skipped
+	if got := db.statements("DELETE FROM protocol_snapshots "); len(got) != 0 {
+		t.Errorf("DELETE sent for a table without its column: %q", got)
+	}
+	if got := db.statements("VACUUM"); len(got) != 0 {
+		t.Errorf("VACUUM sent with vacuum_analyze off: %q", got)
+	}
+}
+
+func TestRetentionVacuumsPrunedTablesWhenEnabled(t *testing.T) {
+	db := &retentionDB{
+		columns: map[string]bool{"alert_events": true, "oracle_observations": true},
+		batches: map[string][]int64{"alert_events": {5}, "oracle_observations": {0}},
+	}
+	j := newRetentionTestJob(t, db, true)
+
+	if err := j.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	// Only tables that had rows removed are vacuumed
+	if got := db.statements("VACUUM"); len(got) != 1 || got[0] != "VACUUM ANALYZE alert_events" {
+		t.Errorf("VACUUM statements = %q, want only alert_events", got)
+	}
+}
+
+func TestRetentionWithoutBatchSizeUsesDefault(t *testing.T) {
+	db := &retentionDB{
+		columns: map[string]bool{"alert_events": true},
+		batches: map[string][]int64{"alert_events": {5000, 3}},
+	}
+	j := newRetentionTestJob(t, db, false)
+	cfg := j.configs.Get()
+	cfg.Retention.BatchSize = 0
+	j.configs.Set(cfg)
+
+	// LIMIT 0 would delete nothing and never end the loop
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := j.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(db.limits) != 2 || db.limits[0] != 5000 {
+		t.Errorf("DELETE limits = %v, want two batches of 5000", db.limits)
+	}
+}
+
+func TestRetentionSendsDigest(t *testing.T) {
+	var developer []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["chat_id"] == "developer-chat" {
+			developer = append(developer, fmt.Sprint(payload["text"]))
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+	service := alerts.New("business-token", "business-chat", "developer-token", "developer-chat", "")
+	service.TelegramAPIURL = server.URL
+
+	db := &retentionDB{
+		columns: map[string]bool{"alert_events": true, "oracle_observations": true},
+		batches: map[string][]int64{"alert_events": {100, 20}, "oracle_observations": {0}},
+	}
+	j := newRetentionTestJob(t, db, false)
+	j.alertManager = alerts.NewManager(service)
+
+	if err := j.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(developer) != 1 {
+		t.Fatalf("developer messages = %q, want one digest", developer)
+	}
+	for _, line := range []string{
+		"alert_events: 120 rows older than 90 days deleted",
+		"oracle_observations: 0 rows older than 30 days deleted",
+	} {
+		if !strings.Contains(developer[0], line) {
+			t.Errorf("digest missing %q:\n%s", line, developer[0])
+		}
+	}
+}
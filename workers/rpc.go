@@ -0,0 +1,115 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/0x0Glitch/internal/httpclient"
+)
+
+// chainIDTimeout bounds the eth_chainId check made when connecting to an endpoint
+const chainIDTimeout = 10 * time.Second
+
+// ChainIDMismatchError reports an RPC endpoint serving a different chain than configured
+type ChainIDMismatchError struct {
+	Chain    string
+	Endpoint string // host only, URLs may embed API keys
+	Expected int64
+	Actual   int64
+}
+
+func (e *ChainIDMismatchError) Error() string {
+	return fmt.Sprintf("%s RPC %s reports chain ID %d, expected %d", e.Chain, e.Endpoint, e.Actual, e.Expected)
+}
+
+// VerifyChainID checks that client is connected to the chain's expected network.
+// Chains without an expected ID are not checked.
+func VerifyChainID(ctx context.Context, client *ethclient.Client, chain ChainConfig, endpoint string) error {
+	if chain.EVMChainID == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, chainIDTimeout)
+	defer cancel()
+
+	id, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("%s RPC %s: eth_chainId failed: %w", chain.Name, endpointHost(endpoint), err)
+	}
+	if !id.IsInt64() || id.Int64() != chain.EVMChainID {
+		return &ChainIDMismatchError{
+			Chain:    chain.Name,
+			Endpoint: endpointHost(endpoint),
+			Expected: chain.EVMChainID,
+			Actual:   id.Int64(),
+		}
+	}
+	return nil
+}
+
+// ClientFactory returns a connected client for a chain, dialing it if needed.
+// OracleMonitor calls it on each run until it succeeds.
+type ClientFactory func(ctx context.Context) (*ManagedClient, error)
+
+// DialChain connects to the chain's RPC endpoints in order and returns the first
+// client that is reachable and serves the expected chain ID. Every candidate,
+// including failover endpoints, is verified before use.
+func DialChain(ctx context.Context, chain ChainConfig) (*ethclient.Client, error) {
+	client, _, err := dialChainFrom(ctx, chain, 0)
+	return client, err
+}
+
+// dialChainFrom is DialChain trying the endpoints from index start, wrapping around.
+// It also returns the index of the endpoint connected to.
+func dialChainFrom(ctx context.Context, chain ChainConfig, start int) (*ethclient.Client, int, error) {
+	var errs []error
+	for i := range chain.RPCURLs {
+		index := (start + i) % len(chain.RPCURLs)
+		rpcURL := chain.RPCURLs[index]
+		client, err := dialRPC(ctx, rpcURL, chain.RPCHeaders)
+		if err == nil {
+			err = VerifyChainID(ctx, client, chain, rpcURL)
+			if err == nil {
+				return client, index, nil
+			}
+			client.Close()
+		}
+		log.Printf("[%s] RPC endpoint %s rejected, trying next: %v", chain.Name, endpointHost(rpcURL), err)
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, 0, fmt.Errorf("no RPC URL configured for %s", chain.Name)
+	}
+	return nil, 0, fmt.Errorf("failed to connect to %s RPC: %w", chain.Name, errors.Join(errs...))
+}
+
+// dialRPC connects to an HTTP or websocket endpoint with the chain's custom headers.
+// HTTP endpoints use the shared client, honouring proxy and CA bundle settings.
+func dialRPC(ctx context.Context, rawURL string, headers http.Header) (*ethclient.Client, error) {
+	options := []rpc.ClientOption{rpc.WithHTTPClient(httpclient.New(0))}
+	if len(headers) > 0 {
+		options = append(options, rpc.WithHeaders(headers))
+	}
+	client, err := rpc.DialOptions(ctx, rawURL, options...)
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(client), nil
+}
+
+// endpointHost returns the host of an RPC URL for logging without exposing API keys
+func endpointHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "(invalid URL)"
+	}
+	return u.Host
+}
@@ -0,0 +1,169 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newChainIDStub serves eth_chainId over JSON-RPC, reporting chainID
+func newChainIDStub(t *testing.T, chainID int64) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method != "eth_chainId" {
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32601,"message":"method not found"}}`, req.ID)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0x%x"}`, req.ID, chainID)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDialChainVerifiesChainID(t *testing.T) {
+	base := newChainIDStub(t, 8453)
+
+	chain := BaseChain()
+	chain.RPCURLs = []string{base.URL}
+
+	client, err := DialChain(context.Background(), chain)
+	if err != nil {
+		t.Fatalf("DialChain: %v", err)
+	}
+	client.Close()
+}
+
+func TestDialChainRejectsWrongChain(t *testing.T) {
+	optimism := newChainIDStub(t, 10)
+
+	chain := BaseChain()
+	chain.RPCURLs = []string{optimism.URL}
+
+	_, err := DialChain(context.Background(), chain)
+	var mismatch *ChainIDMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("DialChain error = %v, want ChainIDMismatchError", err)
+	}
+	if mismatch.Expected != 8453 || mismatch.Actual != 10 {
+		t.Errorf("mismatch = %+v, want expected 8453 actual 10", mismatch)
+	}
+}
+
+func TestDialChainFailsOverToMatchingEndpoint(t *testing.T) {
+	optimism := newChainIDStub(t, 10)
+	base := newChainIDStub(t, 8453)
+
+	chain := BaseChain()
+	chain.RPCURLs = []string{optimism.URL, base.URL}
+
+	client, err := DialChain(context.Background(), chain)
+	if err != nil {
+		t.Fatalf("DialChain: %v", err)
+	}
+	client.Close()
+}
+
+func TestManagedClientFailoverVerifiesChainID(t *testing.T) {
+	tests := []struct {
+		name         string
+		fallbackID   int64
+		wantEndpoint int
+	}{
+		{"wrong chain fallback is not used", 10, 0},
+		{"matching fallback is used", 8453, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := newChainIDStub(t, 8453)
+			fallback := newChainIDStub(t, tt.fallbackID)
+			chain := BaseChain()
+			chain.RPCURLs = []string{primary.URL, fallback.URL}
+			client := NewLazyManagedClient(chain)
+			if err := client.Connect(context.Background()); err != nil {
+				t.Fatalf("Connect: %v", err)
+			}
+			connected := client.HTTP()
+
+			// The primary goes away after connecting
+			primary.Close()
+			client.HeaderByNumber(context.Background(), nil)
+
+			if client.endpoint != tt.wantEndpoint {
+				t.Errorf("endpoint = %d, want %d", client.endpoint, tt.wantEndpoint)
+			}
+			if switched := client.HTTP() != connected; switched != (tt.wantEndpoint != 0) {
+				t.Errorf("HTTP client switched = %v", switched)
+			}
+		})
+	}
+}
+
+func TestDialChainSendsRPCHeaders(t *testing.T) {
+	base := newChainIDStub(t, 8453)
+	var got string
+	authed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		if got != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		base.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(authed.Close)
+
+	chain := BaseChain()
+	chain.RPCURLs = []string{authed.URL}
+
+	if _, err := DialChain(context.Background(), chain); err == nil {
+		t.Fatal("DialChain without headers succeeded, want 401")
+	}
+
+	chain.RPCHeaders = http.Header{"Authorization": {"Bearer secret"}}
+	client, err := DialChain(context.Background(), chain)
+	if err != nil {
+		t.Fatalf("DialChain with headers: %v", err)
+	}
+	client.Close()
+	if got != "Bearer secret" {
+		t.Errorf("Authorization header = %q, want %q", got, "Bearer secret")
+	}
+}
+
+func TestApplyRPCHeaderEnv(t *testing.T) {
+	t.Setenv("BASE_RPC_HEADERS", "Authorization: Bearer abc; x-api-key: k:1")
+
+	chain := BaseChain()
+	chain.RPCHeaders = http.Header{"Authorization": {"Bearer from-config"}}
+	if err := applyRPCHeaderEnv(&chain); err != nil {
+		t.Fatalf("applyRPCHeaderEnv: %v", err)
+	}
+	if got := chain.RPCHeaders.Get("Authorization"); got != "Bearer abc" {
+		t.Errorf("Authorization = %q, want env value", got)
+	}
+	if got := chain.RPCHeaders.Get("X-Api-Key"); got != "k:1" {
+		t.Errorf("X-Api-Key = %q, want %q", got, "k:1")
+	}
+
+	t.Setenv("BASE_RPC_HEADERS", "Bearer abc")
+	err := applyRPCHeaderEnv(&chain)
+	if err == nil {
+		t.Fatal("applyRPCHeaderEnv accepted a pair without a name")
+	}
+	if strings.Contains(err.Error(), "abc") {
+		t.Errorf("error leaks the header value: %v", err)
+	}
+}
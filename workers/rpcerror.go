@@ -0,0 +1,272 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+// RPCErrorClass groups RPC failures by how the caller should react
+type RPCErrorClass int
+
+const (
+	// RPCErrorUnknown is an unrecognised failure; it is retried like a transient one
+	RPCErrorUnknown RPCErrorClass = iota
+	// RPCErrorTransient is a connection failure or timeout worth retrying immediately
+	RPCErrorTransient
+	// RPCErrorRateLimited means the provider is throttling; retrying now fails again
+	RPCErrorRateLimited
+	// RPCErrorPermanent is a contract-level failure (revert, no code) that retrying cannot fix
+	RPCErrorPermanent
+)
+
+func (c RPCErrorClass) String() string {
+	switch c {
+	case RPCErrorTransient:
+		return "transient"
+	case RPCErrorRateLimited:
+		return "rate-limited"
+	case RPCErrorPermanent:
+		return "permanent"
+	default:
+		return "unknown"
+	}
+}
+
+// Retryable reports whether a call failing with this class should be retried in the same cycle
+func (c RPCErrorClass) Retryable() bool {
+	return c == RPCErrorTransient || c == RPCErrorUnknown
+}
+
+// errRPCDeferred is returned for reads skipped while a rate limit is in effect
+var errRPCDeferred = errors.New("deferred to next cycle, RPC provider is rate limiting")
+
+var (
+	rateLimitMessages = []string{
+		"too many requests",
+		"rate limit",
+		"rate-limit",
+		"compute units per second",
+		"request limit",
+		"daily request count exceeded",
+		"limit exceeded",
+		"exceeded the quota",
+		"capacity exceeded",
+	}
+	permanentMessages = []string{
+		"execution reverted",
+		"no contract code at given address",
+		"attempting to unmarshal an empty string",
+		"abi: cannot unmarshal",
+		"invalid opcode",
+		"invalid jump destination",
+	}
+	transientMessages = []string{
+		"connection refused",
+		"connection reset",
+		"broken pipe",
+		"no such host",
+		"i/o timeout",
+		"timeout",
+		"timed out",
+		"tls handshake",
+		"bad gateway",
+		"service unavailable",
+		"gateway timeout",
+		"upstream connect error",
+		"no healthy upstream",
+		"websocket: close",
+		"client is closed",
+		"header not found",
+		"missing trie node",
+		"unexpected eof",
+	}
+
+	// backoffPattern extracts a provider's retry hint, e.g. Infura's "backoff_seconds":30
+	// or "try again in 2s" / "retry after 10 seconds"
+	backoffPattern = regexp.MustCompile(`(?i)(?:"backoff_seconds"\s*:\s*|try again in\s+|retry after\s+)(\d+(?:\.\d+)?)\s*(ms|s|sec|secs|seconds?)?\b`)
+)
+
+// ClassifyRPCError determines how an onchain call failure should be handled.
+// Typed errors (HTTP status, JSON-RPC code, net timeouts) are checked before the
+// message, since providers wrap the same condition in different wording.
+func ClassifyRPCError(err error) RPCErrorClass {
+	if err == nil {
+		return RPCErrorUnknown
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return RPCErrorTransient
+	}
+	if errors.Is(err, errRPCDeferred) {
+		return RPCErrorRateLimited
+	}
+	if errors.Is(err, bind.ErrNoCode) {
+		return RPCErrorPermanent
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return RPCErrorTransient
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		switch {
+		case httpErr.StatusCode == 429:
+			return RPCErrorRateLimited
+		case httpErr.StatusCode == 408 || httpErr.StatusCode >= 500:
+			return RPCErrorTransient
+		}
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.ErrorCode() {
+		case 429, -32005, -32029:
+			return RPCErrorRateLimited
+		case 3:
+			return RPCErrorPermanent
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case containsAny(msg, rateLimitMessages):
+		return RPCErrorRateLimited
+	case containsAny(msg, permanentMessages):
+		return RPCErrorPermanent
+	case containsAny(msg, transientMessages), strings.HasSuffix(msg, ": eof"), msg == "eof":
+		return RPCErrorTransient
+	}
+	return RPCErrorUnknown
+}
+
+// rpcBackoffHint returns the wait a rate-limited provider asked for, or 0 when none was given
+func rpcBackoffHint(err error) time.Duration {
+	if err == nil {
+		return 0
+	}
+	text := err.Error()
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		text += " " + string(httpErr.Body)
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		switch data := dataErr.ErrorData().(type) {
+		case nil:
+		case string:
+			text += " " + data
+		default:
+			if encoded, err := json.Marshal(data); err == nil {
+				text += " " + string(encoded)
+			}
+		}
+	}
+
+	match := backoffPattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0
+	}
+	n, err := strconv.ParseFloat(match[1], 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	if match[2] == "ms" {
+		return time.Duration(n * float64(time.Millisecond))
+	}
+	return time.Duration(n * float64(time.Second))
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// deferRPC records that the provider is rate limiting. Onchain reads are skipped
+// for the rest of the cycle, and until the provider's backoff hint has elapsed.
+func (m *OracleMonitor) deferRPC(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rateLimited = true
+	if until := m.now().Add(rpcBackoffHint(err)); until.After(m.rpcBackoffUntil) {
+		m.rpcBackoffUntil = until
+	}
+}
+
+// rpcDeferred reports whether onchain reads are currently deferred by a rate limit
+func (m *OracleMonitor) rpcDeferred() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rateLimited || m.now().Before(m.rpcBackoffUntil)
+}
+
+// markDegraded records a token whose onchain read fails permanently and alerts
+// developers once. Degraded tokens are still read each cycle without retries.
+func (m *OracleMonitor) markDegraded(ctx context.Context, symbol string, err error) {
+	m.mu.Lock()
+	if m.degraded == nil {
+		m.degraded = make(map[string]string)
+	}
+	_, already := m.degraded[symbol]
+	m.degraded[symbol] = err.Error()
+	m.mu.Unlock()
+
+	if already {
+		return
+	}
+
+	log.Printf("[%s][%s] %s marked degraded: %v", m.Name(), m.chain.Name, symbol, err)
+
+	meta := m.chain.Tokens[symbol]
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(symbol), Metric: "token_degraded"}
+	details := fmt.Sprintf("Chain: %s\nToken: %s\nmToken: %s\nOracle: %s\nError: %v\n\n"+
+		"The oracle call fails in a way retrying cannot fix. This usually means a configuration problem "+
+		"(wrong mToken address, token not listed in the oracle). The token stays degraded until a read succeeds.",
+		m.chain.Name, symbol, meta.MTokAddr, m.chain.OracleAddress, err)
+	m.observe(ctx, key, alerts.SeverityWarning, 1.0, "", details, false, "")
+}
+
+// clearDegraded resolves the degraded alert once a token's onchain read succeeds again
+func (m *OracleMonitor) clearDegraded(ctx context.Context, symbol string) {
+	m.mu.Lock()
+	_, degraded := m.degraded[symbol]
+	delete(m.degraded, symbol)
+	m.mu.Unlock()
+
+	if !degraded {
+		return
+	}
+
+	log.Printf("[%s][%s] %s recovered", m.Name(), m.chain.Name, symbol)
+	key := alerts.AlertKey{Job: m.Name(), Entity: m.entity(symbol), Metric: "token_degraded"}
+	m.observe(ctx, key, alerts.SeverityOK, 0, "", fmt.Sprintf("Chain: %s\nToken: %s", m.chain.Name, symbol), false, "")
+}
+
+func registerRPCErrorPolicy(alertManager *alerts.Manager, jobName string) {
+	alertManager.RegisterPolicy(jobName, "token_degraded", alerts.AlertPolicy{
+		MinValueChange:        1.0,
+		CooldownWarning:       24 * time.Hour,
+		CooldownCritical:      24 * time.Hour,
+		ReminderInterval:      0,
+		ConsecutiveOKRequired: 1,
+	})
+}
@@ -0,0 +1,119 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// jsonRPCError mirrors the error geth's rpc client returns for a JSON-RPC error response
+type jsonRPCError struct {
+	code    int
+	message string
+	data    interface{}
+}
+
+func (e *jsonRPCError) Error() string          { return e.message }
+func (e *jsonRPCError) ErrorCode() int         { return e.code }
+func (e *jsonRPCError) ErrorData() interface{} { return e.data }
+
+func TestClassifyRPCError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want RPCErrorClass
+	}{
+		// geth
+		{"geth revert", &jsonRPCError{code: 3, message: "execution reverted", data: "0x"}, RPCErrorPermanent},
+		{"geth revert reason", errors.New("execution reverted: Oracle: feed not found"), RPCErrorPermanent},
+		{"geth no code", fmt.Errorf("onchain price: %w", bind.ErrNoCode), RPCErrorPermanent},
+		{"geth empty return", errors.New("abi: attempting to unmarshal an empty string while arguments are expected"), RPCErrorPermanent},
+		{"geth invalid opcode", &jsonRPCError{code: -32000, message: "invalid opcode: INVALID"}, RPCErrorPermanent},
+		{"geth header not found", &jsonRPCError{code: -32000, message: "header not found"}, RPCErrorTransient},
+		{"geth missing trie node", &jsonRPCError{code: -32000, message: "missing trie node 5c7a1b0e (path ) state 0x5c7a1b0e is not available"}, RPCErrorTransient},
+		{"geth client closed", rpc.ErrClientQuit, RPCErrorTransient},
+
+		// Alchemy
+		{"alchemy 429", rpc.HTTPError{
+			StatusCode: 429,
+			Status:     "429 Too Many Requests",
+			Body:       []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":429,"message":"Your app has exceeded its compute units per second capacity. If you have retries enabled, you can safely ignore this message. If not, check out https://docs.alchemy.com/reference/throughput"}}`),
+		}, RPCErrorRateLimited},
+		{"alchemy cu limit", &jsonRPCError{code: 429, message: "Your app has exceeded its compute units per second capacity."}, RPCErrorRateLimited},
+		{"alchemy monthly limit", &jsonRPCError{code: -32600, message: "Monthly capacity limit exceeded. Visit https://dashboard.alchemy.com/settings/billing to upgrade your scaling policy for continued service."}, RPCErrorRateLimited},
+		{"alchemy 503", rpc.HTTPError{StatusCode: 503, Status: "503 Service Unavailable", Body: []byte("upstream connect error or disconnect/reset before headers. reset reason: connection termination")}, RPCErrorTransient},
+
+		// OnFinality
+		{"onfinality rate limit", &jsonRPCError{code: -32029, message: "Too Many Requests"}, RPCErrorRateLimited},
+		{"onfinality daily limit", errors.New("daily request count exceeded, Request rate limited"), RPCErrorRateLimited},
+		{"onfinality 502", rpc.HTTPError{StatusCode: 502, Status: "502 Bad Gateway", Body: []byte("<html><head><title>502 Bad Gateway</title></head></html>")}, RPCErrorTransient},
+		{"onfinality no upstream", errors.New("no healthy upstream"), RPCErrorTransient},
+
+		// Infura-style limit with a backoff hint
+		{"limit exceeded", &jsonRPCError{code: -32005, message: "project ID request rate exceeded", data: map[string]interface{}{"backoff_seconds": 30}}, RPCErrorRateLimited},
+
+		// Network
+		{"deadline", fmt.Errorf("onchain price: %w", context.DeadlineExceeded), RPCErrorTransient},
+		{"dial refused", &url.Error{Op: "Post", URL: "https://rpc.example", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: connection refused")}}, RPCErrorTransient},
+		{"no such host", errors.New(`Post "https://base-mainnet.g.alchemy.com/v2/xxx": dial tcp: lookup base-mainnet.g.alchemy.com: no such host`), RPCErrorTransient},
+		{"eof", fmt.Errorf(`Post "https://moonbeam.api.onfinality.io/public": %w`, io.EOF), RPCErrorTransient},
+		{"websocket drop", errors.New("websocket: close 1006 (abnormal closure): unexpected EOF"), RPCErrorTransient},
+
+		// Unknown
+		{"unrecognised", errors.New("getUnderlyingPrice returned no value"), RPCErrorUnknown},
+		{"other http status", rpc.HTTPError{StatusCode: 404, Status: "404 Not Found"}, RPCErrorUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyRPCError(tt.err); got != tt.want {
+				t.Errorf("ClassifyRPCError(%q) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRPCErrorClassRetryable(t *testing.T) {
+	tests := map[RPCErrorClass]bool{
+		RPCErrorUnknown:     true,
+		RPCErrorTransient:   true,
+		RPCErrorRateLimited: false,
+		RPCErrorPermanent:   false,
+	}
+	for class, want := range tests {
+		if got := class.Retryable(); got != want {
+			t.Errorf("%s.Retryable() = %v, want %v", class, got, want)
+		}
+	}
+}
+
+func TestRPCBackoffHint(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want time.Duration
+	}{
+		{"infura data", &jsonRPCError{code: -32005, message: "project ID request rate exceeded", data: `{"see":"https://infura.io/dashboard","current_rps":13.333,"allowed_rps":10.0,"backoff_seconds":30.0}`}, 30 * time.Second},
+		{"decoded data", &jsonRPCError{code: -32005, message: "daily request count exceeded, request rate limited", data: map[string]interface{}{"rate": map[string]interface{}{"backoff_seconds": 12}}}, 12 * time.Second},
+		{"try again", errors.New("rate limit exceeded, try again in 2s"), 2 * time.Second},
+		{"retry after", errors.New("Too Many Requests, retry after 500ms"), 500 * time.Millisecond},
+		{"http body", rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests", Body: []byte(`{"error":{"code":-32005,"data":{"backoff_seconds":5}}}`)}, 5 * time.Second},
+		{"no hint", &jsonRPCError{code: 429, message: "Your app has exceeded its compute units per second capacity."}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rpcBackoffHint(tt.err); got != tt.want {
+				t.Errorf("rpcBackoffHint(%q) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package workers
+
+import (
+	"os"
+	"strings"
+
+	"github.com/0x0Glitch/config"
+)
+
+// builtinRPCProvider serves chains when neither the environment nor config name an
+// endpoint, with ALCHEMY_PRICE_API_KEY as its only key
+var builtinRPCProvider = config.RPCProviderConfig{
+	Provider:    "alchemy",
+	URLTemplate: "https://{network}.g.alchemy.com/v2/{key}",
+	Networks: map[string]string{
+		string(ChainBase):     "base-mainnet",
+		string(ChainOptimism): "opt-mainnet",
+	},
+}
+
+// ResolveRPCURLs returns chain's RPC endpoints in priority order: the <CHAIN>_RPC_URL
+// environment variable, else every configured provider serving the chain (one endpoint
+// per key, in list order), else the built-in Alchemy endpoint. A chain's rpc_urls,
+// applied afterwards by GetChainsByEnv, replace all of these.
+func ResolveRPCURLs(chain ChainID, providers []config.RPCProviderConfig) []string {
+	if url := os.Getenv(strings.ToUpper(string(chain)) + "_RPC_URL"); url != "" {
+		return []string{url}
+	}
+
+	var urls []string
+	for _, provider := range providers {
+		urls = append(urls, providerURLs(provider, chain)...)
+	}
+	if len(urls) > 0 {
+		return urls
+	}
+
+	builtin := builtinRPCProvider
+	builtin.Keys = []string{os.Getenv("ALCHEMY_PRICE_API_KEY")}
+	return providerURLs(builtin, chain)
+}
+
+// providerURLs expands provider's template for chain once per usable key, or once if
+// the template takes no key. It returns nil when the provider does not serve chain.
+func providerURLs(provider config.RPCProviderConfig, chain ChainID) []string {
+	network := provider.Networks[string(chain)]
+	if network == "" {
+		return nil
+	}
+	if !strings.Contains(provider.URLTemplate, "{key}") {
+		return []string{expandRPCTemplate(provider.URLTemplate, network, "")}
+	}
+	var urls []string
+	for _, key := range provider.Keys {
+		if key = strings.TrimSpace(key); key != "" {
+			urls = append(urls, expandRPCTemplate(provider.URLTemplate, network, key))
+		}
+	}
+	return urls
+}
+
+// expandRPCTemplate fills the {network} and {key} placeholders of an RPC URL template
+func expandRPCTemplate(template, network, key string) string {
+	return strings.NewReplacer("{network}", network, "{key}", key).Replace(template)
+}
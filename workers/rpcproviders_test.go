@@ -0,0 +1,113 @@
+package workers
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/0x0Glitch/config"
+)
+
+func TestExpandRPCTemplate(t *testing.T) {
+	tests := []struct {
+		template, network, key string
+		want                   string
+	}{
+		{"https://{network}.g.alchemy.com/v2/{key}", "base-mainnet", "abc", "https://base-mainnet.g.alchemy.com/v2/abc"},
+		{"https://{network}.infura.io/v3/{key}", "optimism-mainnet", "k1", "https://optimism-mainnet.infura.io/v3/k1"},
+		{"https://moonbeam.api.onfinality.io/public", "moonbeam", "", "https://moonbeam.api.onfinality.io/public"},
+	}
+	for _, tt := range tests {
+		if got := expandRPCTemplate(tt.template, tt.network, tt.key); got != tt.want {
+			t.Errorf("expandRPCTemplate(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestResolveRPCURLsPrecedence(t *testing.T) {
+	t.Setenv("BASE_RPC_URL", "")
+	t.Setenv("OPTIMISM_RPC_URL", "")
+	t.Setenv("MOONBEAM_RPC_URL", "")
+	t.Setenv("ALCHEMY_PRICE_API_KEY", "builtin-key")
+	providers := []config.RPCProviderConfig{
+		{
+			Provider:    "infura",
+			URLTemplate: "https://{network}.infura.io/v3/{key}",
+			Networks:    map[string]string{"base": "base-mainnet"},
+			Keys:        []string{"key1", " ", "key2"},
+		},
+		{
+			Provider:    "onfinality",
+			URLTemplate: "https://{network}.api.onfinality.io/public",
+			Networks:    map[string]string{"base": "base", "moonbeam": "moonbeam"},
+		},
+	}
+
+	// Config providers in list order, one endpoint per usable key
+	want := []string{
+		"https://base-mainnet.infura.io/v3/key1",
+		"https://base-mainnet.infura.io/v3/key2",
+		"https://base.api.onfinality.io/public",
+	}
+	if got := ResolveRPCURLs(ChainBase, providers); !reflect.DeepEqual(got, want) {
+		t.Errorf("base = %q, want %q", got, want)
+	}
+	if got := ResolveRPCURLs(ChainMoonbeam, providers); !reflect.DeepEqual(got, []string{"https://moonbeam.api.onfinality.io/public"}) {
+		t.Errorf("moonbeam = %q, want the onfinality endpoint", got)
+	}
+
+	// Chains no provider serves fall back to the built-in Alchemy endpoint
+	if got := ResolveRPCURLs(ChainOptimism, providers); !reflect.DeepEqual(got, []string{"https://opt-mainnet.g.alchemy.com/v2/builtin-key"}) {
+		t.Errorf("optimism = %q, want the built-in endpoint", got)
+	}
+	if got := ResolveRPCURLs(ChainMoonriver, providers); got != nil {
+		t.Errorf("moonriver = %q, want none", got)
+	}
+
+	// An explicit environment URL wins over everything
+	t.Setenv("BASE_RPC_URL", "http://localhost:8545")
+	if got := ResolveRPCURLs(ChainBase, providers); !reflect.DeepEqual(got, []string{"http://localhost:8545"}) {
+		t.Errorf("base with BASE_RPC_URL = %q", got)
+	}
+
+	// Without a key the built-in provider has no endpoint
+	t.Setenv("ALCHEMY_PRICE_API_KEY", "")
+	if got := ResolveRPCURLs(ChainOptimism, nil); got != nil {
+		t.Errorf("optimism without a key = %q, want none", got)
+	}
+}
+
+func TestShippedConfigRPCPrecedence(t *testing.T) {
+	t.Setenv("BASE_RPC_URL", "")
+	t.Setenv("ALCHEMY_PRICE_API_KEY", "shipped-key")
+	baseURLs := func() ([]string, error) {
+		t.Helper()
+		cfg, err := config.Load(filepath.Join("..", "config.json"))
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		chains, err := GetChainsByEnv("base", cfg.Chains, cfg.RPCProviders)
+		if err != nil {
+			return nil, err
+		}
+		return chains[0].RPCURLs, nil
+	}
+
+	// Without BASE_RPC_URL the configured Alchemy provider serves the chain
+	if got, err := baseURLs(); err != nil || !reflect.DeepEqual(got, []string{"https://base-mainnet.g.alchemy.com/v2/shipped-key"}) {
+		t.Errorf("base = %q, %v; want the Alchemy provider endpoint", got, err)
+	}
+
+	// BASE_RPC_URL wins over the providers
+	t.Setenv("BASE_RPC_URL", "http://localhost:8545")
+	if got, err := baseURLs(); err != nil || !reflect.DeepEqual(got, []string{"http://localhost:8545"}) {
+		t.Errorf("base with BASE_RPC_URL = %q, %v", got, err)
+	}
+
+	// With neither set there is no endpoint rather than a keyless Alchemy URL
+	t.Setenv("BASE_RPC_URL", "")
+	t.Setenv("ALCHEMY_PRICE_API_KEY", "")
+	if got, err := baseURLs(); err == nil {
+		t.Errorf("base without URL or key = %q, want an error", got)
+	}
+}
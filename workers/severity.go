@@ -0,0 +1,59 @@
+package workers
+
+import (
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+// severityBand raises a metric to severity once its value reaches threshold
+type severityBand struct {
+	threshold float64
+	severity  alerts.Severity
+}
+
+// warningCritical returns the usual two bands: warning from warning, critical from critical
+func warningCritical(warning, critical float64) []severityBand {
+	return []severityBand{
+		{threshold: warning, severity: alerts.SeverityWarning},
+		{threshold: critical, severity: alerts.SeverityCritical},
+	}
+}
+
+// classify returns the severity of the band with the highest threshold value reaches,
+// OK when it reaches none. Bands may be given in any order.
+func classify(value float64, bands []severityBand) alerts.Severity {
+	severity := alerts.SeverityOK
+	var reached *severityBand
+	for i, band := range bands {
+		if value >= band.threshold && (reached == nil || band.threshold >= reached.threshold) {
+			reached = &bands[i]
+			severity = band.severity
+		}
+	}
+	return severity
+}
+
+// configuredBands returns the severity_bands configured for job:metric, or defaults
+// when there are none
+func configuredBands(configs *config.Holder, job, metric string, defaults []severityBand) []severityBand {
+	if configs == nil {
+		return defaults
+	}
+	return severityBands(configs.Get(), job, metric, defaults)
+}
+
+// severityBands returns cfg's severity_bands for job:metric, or defaults when there are none
+func severityBands(cfg *config.Config, job, metric string, defaults []severityBand) []severityBand {
+	if cfg == nil {
+		return defaults
+	}
+	configured, ok := cfg.SeverityBands[job+":"+metric]
+	if !ok || len(configured) == 0 {
+		return defaults
+	}
+	bands := make([]severityBand, 0, len(configured))
+	for _, band := range configured {
+		bands = append(bands, severityBand{threshold: band.Threshold, severity: alerts.Severity(band.Severity)})
+	}
+	return bands
+}
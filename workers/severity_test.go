@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestClassify(t *testing.T) {
+	bands := []severityBand{
+		{threshold: 20, severity: alerts.SeverityCritical},
+		{threshold: 10, severity: alerts.SeverityWarning},
+	}
+	tests := []struct {
+		value float64
+		want  alerts.Severity
+	}{
+		{5, alerts.SeverityOK},
+		{10, alerts.SeverityWarning},
+		{19.9, alerts.SeverityWarning},
+		{20, alerts.SeverityCritical},
+		{-50, alerts.SeverityOK},
+	}
+	for _, tt := range tests {
+		if got := classify(tt.value, bands); got != tt.want {
+			t.Errorf("classify(%v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+	if got := classify(100, nil); got != alerts.SeverityOK {
+		t.Errorf("classify without bands = %s, want OK", got)
+	}
+}
+
+func TestConfiguredBands(t *testing.T) {
+	defaults := warningCritical(10, 20)
+	cfg := config.DefaultConfig()
+	cfg.SeverityBands = map[string][]config.SeverityBandConfig{
+		"health_aggregate:borrow_spike": {
+			{Threshold: 5, Severity: "WARNING"},
+			{Threshold: 8, Severity: "CRITICAL"},
+		},
+	}
+	configs := config.NewHolder(cfg)
+
+	bands := configuredBands(configs, "health_aggregate", "borrow_spike", defaults)
+	if got := classify(9, bands); got != alerts.SeverityCritical {
+		t.Errorf("configured bands classify 9 as %s, want CRITICAL", got)
+	}
+	bands = configuredBands(configs, "health_aggregate", "withdrawal_spike", defaults)
+	if got := classify(9, bands); got != alerts.SeverityOK {
+		t.Errorf("default bands classify 9 as %s, want OK", got)
+	}
+	if got := classify(15, configuredBands(nil, "health_aggregate", "borrow_spike", defaults)); got != alerts.SeverityWarning {
+		t.Errorf("without config classify 15 as %s, want WARNING", got)
+	}
+}
@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"sort"
+	"time"
+)
+
+// TokenPrice is the latest reading for one token, for exports and reconciliation
+type TokenPrice struct {
+	Chain            string    `json:"chain"`
+	Symbol           string    `json:"symbol"`
+	OnchainPrice     float64   `json:"onchain_price"`
+	DEXPrice         float64   `json:"dex_price"`
+	DeviationPercent float64   `json:"deviation_percent"`
+	DEXCached        bool      `json:"dex_cached"`
+	ObservedAt       time.Time `json:"observed_at"`     // zero until the first successful read
+	Error            string    `json:"error,omitempty"` // set when the latest read failed; prices are from ObservedAt
+}
+
+// recordPrice stores the outcome of a token check. A failed check keeps the last
+// successful prices and records the error alongside them; a successful one is also
+// published to the shared price store.
+func (m *OracleMonitor) recordPrice(result tokenResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.latest == nil {
+		m.latest = make(map[string]TokenPrice)
+	}
+
+	price := m.latest[result.symbol]
+	price.Chain = m.chain.Name
+	price.Symbol = result.symbol
+	if result.err != nil {
+		price.Error = result.err.Error()
+		m.latest[result.symbol] = price
+		return
+	}
+	price.OnchainPrice = result.onchainPrice
+	price.DEXPrice = result.dexPrice
+	price.DeviationPercent = result.deviation
+	price.DEXCached = result.dexCached
+	price.ObservedAt = m.now()
+	price.Error = ""
+	m.latest[result.symbol] = price
+	m.prices.Publish(string(m.chain.ID), result.symbol, Observation{
+		OnchainPrice:     result.onchainPrice,
+		ReferencePrice:   result.dexPrice,
+		DeviationPercent: result.deviation,
+		ObservedAt:       price.ObservedAt,
+	})
+}
+
+// Prices returns the latest reading for each active token, sorted by symbol
+func (m *OracleMonitor) Prices() []TokenPrice {
+	active := m.activeTokens()
+
+	m.mu.Lock()
+	prices := make([]TokenPrice, 0, len(m.latest))
+	for symbol, price := range m.latest {
+		if _, ok := active[symbol]; ok {
+			prices = append(prices, price)
+		}
+	}
+	m.mu.Unlock()
+
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Symbol < prices[j].Symbol })
+	return prices
+}
@@ -0,0 +1,39 @@
+package workers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRecordPrice(t *testing.T) {
+	m := &OracleMonitor{chain: ChainConfig{
+		Name:   "Base",
+		Tokens: map[string]TokenMeta{"WETH": {}, "USDC": {}},
+	}}
+
+	m.recordPrice(tokenResult{symbol: "WETH", onchainPrice: 3000, dexPrice: 2990, deviation: 0.33})
+	m.recordPrice(tokenResult{symbol: "USDC", err: errors.New("rpc down")})
+	m.recordPrice(tokenResult{symbol: "REMOVED", onchainPrice: 1})
+
+	prices := m.Prices()
+	if len(prices) != 2 || prices[0].Symbol != "USDC" || prices[1].Symbol != "WETH" {
+		t.Fatalf("Prices() = %+v, want USDC and WETH only, sorted", prices)
+	}
+	if usdc := prices[0]; usdc.Error != "rpc down" || !usdc.ObservedAt.IsZero() {
+		t.Errorf("USDC = %+v, want the error and no observation time", usdc)
+	}
+	weth := prices[1]
+	if weth.Chain != "Base" || weth.OnchainPrice != 3000 || weth.DEXPrice != 2990 || weth.ObservedAt.IsZero() {
+		t.Errorf("WETH = %+v", weth)
+	}
+
+	// A failed read keeps the last good prices and their timestamp
+	m.recordPrice(tokenResult{symbol: "WETH", err: errors.New("timeout")})
+	if got := m.Prices()[1]; got.OnchainPrice != 3000 || got.ObservedAt != weth.ObservedAt || got.Error != "timeout" {
+		t.Errorf("after error WETH = %+v", got)
+	}
+	m.recordPrice(tokenResult{symbol: "WETH", onchainPrice: 3010, dexPrice: 3010})
+	if got := m.Prices()[1]; got.Error != "" || got.OnchainPrice != 3010 {
+		t.Errorf("after recovery WETH = %+v", got)
+	}
+}
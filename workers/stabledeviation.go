@@ -0,0 +1,71 @@
+package workers
+
+import (
+	"fmt"
+	"math"
+)
+
+// References a stablecoin's deviation can be classified on
+const (
+	referencePeg = "peg"
+	referenceDEX = "dex"
+)
+
+// stableDeviation picks the signed deviation a stablecoin is classified on under
+// strategy ("max" when empty) and returns it with its reference. pegOK and dexOK report
+// which deviations could be computed; when one is missing the other is used whatever
+// the strategy.
+func stableDeviation(strategy string, pegDeviation float64, pegOK bool, dexDeviation float64, dexOK bool) (float64, string) {
+	switch {
+	case !dexOK:
+		return pegDeviation, referencePeg
+	case !pegOK:
+		return dexDeviation, referenceDEX
+	}
+
+	pegWorse := math.Abs(pegDeviation) >= math.Abs(dexDeviation)
+	switch strategy {
+	case "peg":
+		return pegDeviation, referencePeg
+	case "dex":
+		return dexDeviation, referenceDEX
+	case "min":
+		pegWorse = !pegWorse
+	}
+	if pegWorse {
+		return pegDeviation, referencePeg
+	}
+	return dexDeviation, referenceDEX
+}
+
+// signedDeviation is a stablecoin's signed deviation from the reference it was
+// classified on, negative below it
+func (r tokenResult) signedDeviation() float64 {
+	if r.reference == referenceDEX {
+		return r.dexDeviation
+	}
+	return r.pegDeviation
+}
+
+// referenceLabel names the reference a stablecoin result was classified on
+func (r tokenResult) referenceLabel() string {
+	if r.reference == referenceDEX {
+		return "DEX"
+	}
+	return "peg"
+}
+
+// formatStableReferences lists a stablecoin's deviation from each reference and which
+// one its severity came from
+func formatStableReferences(result tokenResult, meta TokenMeta) string {
+	strategy := meta.DeviationStrategy
+	if strategy == "" {
+		strategy = "max"
+	}
+	dex := "DEX: unavailable"
+	if result.dexPrice > 0 {
+		dex = fmt.Sprintf("DEX: $%.6f (%+.2f%%)", result.dexPrice, result.dexDeviation)
+	}
+	return fmt.Sprintf("Peg: %s (%+.2f%%)\n%s\nTriggered by: %s (strategy %s)",
+		formatPeg(result, meta), result.pegDeviation, dex, result.referenceLabel(), strategy)
+}
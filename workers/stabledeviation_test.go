@@ -0,0 +1,59 @@
+package workers
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestStableDeviation(t *testing.T) {
+	tests := []struct {
+		name          string
+		strategy      string
+		peg, dex      float64
+		pegOK, dexOK  bool
+		want          float64
+		wantReference string
+	}{
+		{"max picks peg", "", -2, 0.1, true, true, -2, referencePeg},
+		{"max picks dex", "max", 0.1, 1.5, true, true, 1.5, referenceDEX},
+		{"min", "min", -2, 0.1, true, true, 0.1, referenceDEX},
+		{"peg only", "peg", 0.1, 3, true, true, 0.1, referencePeg},
+		{"dex only", "dex", 3, 0.1, true, true, 0.1, referenceDEX},
+		{"dex only without dex price", "dex", 3, 0, true, false, 3, referencePeg},
+		{"peg only without peg", "peg", 0, 2, false, true, 2, referenceDEX},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reference := stableDeviation(tt.strategy, tt.peg, tt.pegOK, tt.dex, tt.dexOK)
+			if got != tt.want || reference != tt.wantReference {
+				t.Errorf("stableDeviation() = %v, %s; want %v, %s", got, reference, tt.want, tt.wantReference)
+			}
+		})
+	}
+}
+
+func TestStablecoinPhantomDepegClassifiedOnDEX(t *testing.T) {
+	// EUR strengthened: the oracle and DEX agree at $1.20 while the static peg is $1.16
+	eurc := TokenMeta{Symbol: "EURC", TableName: "EURC", IsStablecoin: true, PegValue: 1.16, DeviationStrategy: "dex"}
+	result := tokenResult{symbol: "eurc", onchainPrice: 1.20, dexPrice: 1.2006, peg: 1.16}
+	result.pegDeviation = (result.onchainPrice - result.peg) / result.peg * 100
+	result.dexDeviation = (result.onchainPrice - result.dexPrice) / result.dexPrice * 100
+	var signed float64
+	signed, result.reference = stableDeviation(eurc.DeviationStrategy, result.pegDeviation, true, result.dexDeviation, true)
+	result.deviation = math.Abs(signed)
+
+	cfg := config.DefaultConfig().Oracle
+	if got := ClassifyDeviation(eurc, &cfg, result.deviation, result.signedDeviation()); got != alerts.SeverityOK {
+		t.Errorf("severity = %s, want OK", got)
+	}
+	if got := pegDirection(result); got != "below DEX" {
+		t.Errorf("pegDirection() = %q, want below DEX", got)
+	}
+	if lines := formatStableReferences(result, eurc); !strings.Contains(lines, "Triggered by: DEX (strategy dex)") {
+		t.Errorf("formatStableReferences() = %q, want the DEX reference labelled", lines)
+	}
+}
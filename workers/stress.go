@@ -0,0 +1,148 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+// aggregateBreach is a metric's outcome at its latest evaluation. The outcome stands
+// for the check's window, until the check evaluates the metric again, so a daily
+// check's breach still counts toward a stress event in the cycles that follow it.
+type aggregateBreach struct {
+	severity alerts.Severity
+	value    float64
+	details  string
+	at       time.Time
+	window   time.Duration
+}
+
+// current reports whether the evaluation still stands at now
+func (b aggregateBreach) current(now time.Time) bool {
+	return now.Before(b.at.Add(b.window))
+}
+
+// pendingObservation is a metric observation held until the cycle's stress event is decided
+type pendingObservation struct {
+	key      alerts.AlertKey
+	severity alerts.Severity
+	value    float64
+	details  string
+}
+
+// observeMetric records a stress metric's evaluation, which stands until the check's
+// next one after window, and observes it. During Run the observation is held until
+// finishCycle knows whether it belongs to a stress event; called outside Run it is
+// observed straight away.
+func (j *HealthAggregateJob) observeMetric(ctx context.Context, key alerts.AlertKey, severity alerts.Severity, value float64, details string, window time.Duration) {
+	j.mu.Lock()
+	if j.breaches == nil {
+		j.breaches = make(map[string]aggregateBreach)
+	}
+	j.breaches[key.Metric] = aggregateBreach{severity: severity, value: value, details: details, at: j.now(), window: window}
+	if j.collecting {
+		j.pending = append(j.pending, pendingObservation{key: key, severity: severity, value: value, details: details})
+		j.mu.Unlock()
+		return
+	}
+	j.mu.Unlock()
+
+	if err := j.alertManager.Observe(ctx, key, severity, value, "", details, true, ""); err != nil {
+		log.Printf("[%s] failed to observe %s: %v", j.Name(), key.Metric, err)
+	}
+}
+
+// startCycle holds the metric observations of a Run for finishCycle
+func (j *HealthAggregateJob) startCycle() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.collecting, j.pending = true, nil
+	j.prevCycleStart, j.cycleStart = j.cycleStart, j.now()
+}
+
+// finishCycle observes the protocol stress event, then the cycle's held metric
+// observations, which go to developers only while the stress event is open
+func (j *HealthAggregateJob) finishCycle(ctx context.Context) {
+	stress := j.checkStressEvent(ctx)
+
+	j.mu.Lock()
+	pending := j.pending
+	j.collecting, j.pending = false, nil
+	j.mu.Unlock()
+
+	sort.Slice(pending, func(a, b int) bool { return pending[a].key.Metric < pending[b].key.Metric })
+	for _, p := range pending {
+		details := p.details
+		if stress && p.severity != alerts.SeverityOK {
+			details += "\nPart of a protocol stress event reported to business"
+		}
+		if err := j.alertManager.Observe(ctx, p.key, p.severity, p.value, "", details, !stress, ""); err != nil {
+			log.Printf("[%s] failed to observe %s: %v", j.Name(), p.key.Metric, err)
+		}
+	}
+}
+
+// checkStressEvent raises one CRITICAL protocol_stress alert when every metric in
+// health_factor.stress_event.metrics is breaching at min_severity or above, and
+// reports whether it did. It observes the event's end only in a cycle that evaluated
+// one of the metrics or saw an evaluation lapse; in other cycles nothing has changed.
+func (j *HealthAggregateJob) checkStressEvent(ctx context.Context) bool {
+	cfg := j.configs.Get().HealthFactor.StressEvent
+	key := alerts.AlertKey{
+		Job:    j.Name(),
+		Entity: "protocol",
+		Metric: "protocol_stress",
+	}
+	now := j.now()
+
+	j.mu.Lock()
+	lines := make([]string, 0, len(cfg.Metrics))
+	breaching, changed := cfg.Enabled, false
+	for _, metric := range cfg.Metrics {
+		breach, ok := j.breaches[metric]
+		if !ok {
+			breaching = false
+			continue
+		}
+		lapsed := breach.at.Add(breach.window)
+		if !breach.at.Before(j.cycleStart) || lapsed.After(j.prevCycleStart) && !lapsed.After(now) {
+			changed = true
+		}
+		if !breach.current(now) || !(breach.severity == alerts.SeverityCritical ||
+			breach.severity == alerts.SeverityWarning && cfg.MinSeverity == string(alerts.SeverityWarning)) {
+			breaching = false
+			continue
+		}
+		summary, _, _ := strings.Cut(breach.details, "\n")
+		lines = append(lines, fmt.Sprintf("• %s %s (%s): %s", metric, breach.severity,
+			alerts.FormatAge(breach.at, now), summary))
+	}
+	j.mu.Unlock()
+
+	if !breaching {
+		if !changed {
+			return false
+		}
+		if err := j.alertManager.Observe(ctx, key, alerts.SeverityOK, 0, "", "", false, ""); err != nil {
+			log.Printf("[%s] failed to observe protocol stress: %v", j.Name(), err)
+		}
+		return false
+	}
+
+	log.Printf("[%s] protocol stress event: %s breaching together", j.Name(), strings.Join(cfg.Metrics, ", "))
+	details := fmt.Sprintf(
+		"%d related metrics are breaching together (each at least %s):\n%s\n\nIndividual metric alerts go to developers while this is open.",
+		len(cfg.Metrics),
+		cfg.MinSeverity,
+		strings.Join(lines, "\n"),
+	)
+	if err := j.alertManager.Observe(ctx, key, alerts.SeverityCritical, float64(len(cfg.Metrics)), "", details, true, ""); err != nil {
+		log.Printf("[%s] failed to observe protocol stress: %v", j.Name(), err)
+	}
+	return true
+}
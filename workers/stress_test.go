@@ -0,0 +1,199 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+	"github.com/0x0Glitch/config"
+)
+
+func TestProtocolStressEvent(t *testing.T) {
+	var developer, business []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["chat_id"] == "business-chat" {
+			business = append(business, fmt.Sprint(payload["text"]))
+		} else {
+			developer = append(developer, fmt.Sprint(payload["text"]))
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+	service := alerts.New("business-token", "business-chat", "developer-token", "developer-chat", "")
+	service.TelegramAPIURL = server.URL
+	manager := alerts.NewManager(service)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	j := &HealthAggregateJob{
+		alertManager:     manager,
+		configs:          config.NewHolder(config.DefaultConfig()),
+		last24hCheckTime: start.Add(-24 * time.Hour),
+	}
+	j.SetClock(func() time.Time { return now })
+	ctx := context.Background()
+	cycle := func(metrics *aggregateMetrics) {
+		j.startCycle()
+		j.checkRiskyCountSpike(ctx, metrics)
+		j.checkAvgHealthFactorDrop(ctx, metrics)
+		j.checkWithdrawalSpike(ctx, metrics)
+		j.checkBorrowSpike(ctx, metrics)
+		j.finishCycle(ctx)
+	}
+
+	// Baselines, then a day later risky positions, HF and supply all deteriorate
+	cycle(&aggregateMetrics{RiskyPositions: 10, WeightedAvgHF: 2.0, TotalCollateralUSD: 1000, TotalBorrowUSD: 500})
+	business, developer = nil, nil
+	now = start.Add(24 * time.Hour)
+	cycle(&aggregateMetrics{RiskyPositions: 20, WeightedAvgHF: 1.8, TotalCollateralUSD: 850, TotalBorrowUSD: 500})
+
+	if len(business) != 1 || !strings.Contains(business[0], "PROTOCOL STRESS EVENT") {
+		t.Fatalf("business messages = %q, want one protocol stress event", business)
+	}
+	for _, metric := range []string{"risky_count_spike CRITICAL", "avg_hf_drop WARNING", "withdrawal_spike WARNING"} {
+		if !strings.Contains(business[0], metric) {
+			t.Errorf("stress event missing %q:\n%s", metric, business[0])
+		}
+	}
+	incidents := manager.GetActiveIncidents()
+	if state := incidents[alerts.AlertKey{Job: "health_aggregate", Entity: "protocol", Metric: "protocol_stress"}]; state.Severity != alerts.SeverityCritical {
+		t.Errorf("protocol_stress = %s, want CRITICAL", state.Severity)
+	}
+	for _, metric := range []string{"risky_count_spike", "avg_hf_drop", "withdrawal_spike"} {
+		if state := incidents[alerts.AlertKey{Job: "health_aggregate", Entity: "protocol", Metric: metric}]; state.Severity == alerts.SeverityOK {
+			t.Errorf("%s not recorded", metric)
+		}
+	}
+	if len(developer) != 3 {
+		t.Errorf("%d developer messages, want the three individual metrics", len(developer))
+	}
+
+	// Once the other metrics stop breaching there is no stress event and the
+	// individual alerts go to business as usual
+	j.breaches = nil
+	business = nil
+	now = now.Add(time.Hour)
+	j.startCycle()
+	j.checkAvgHealthFactorDrop(ctx, &aggregateMetrics{WeightedAvgHF: 1.5})
+	j.finishCycle(ctx)
+	if len(business) != 1 || strings.Contains(business[0], "PROTOCOL STRESS EVENT") {
+		t.Errorf("business messages = %q, want only the avg HF drop update", business)
+	}
+}
+
+func TestProtocolStressIgnoresLapsedBreaches(t *testing.T) {
+	var business []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["chat_id"] == "business-chat" {
+			business = append(business, fmt.Sprint(payload["text"]))
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+	service := alerts.New("business-token", "business-chat", "developer-token", "developer-chat", "")
+	service.TelegramAPIURL = server.URL
+	manager := alerts.NewManager(service)
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	j := &HealthAggregateJob{alertManager: manager, configs: config.NewHolder(config.DefaultConfig())}
+	j.SetClock(func() time.Time { return now })
+	ctx := context.Background()
+	hfCycle := func(hf float64) {
+		j.startCycle()
+		j.checkAvgHealthFactorDrop(ctx, &aggregateMetrics{WeightedAvgHF: hf})
+		j.finishCycle(ctx)
+	}
+
+	// The daily checks breached 25 hours ago and have not run since, so their
+	// evaluations no longer stand when the hourly HF check breaches
+	j.breaches = map[string]aggregateBreach{
+		"risky_count_spike": {severity: alerts.SeverityCritical, details: "risky", at: now.Add(-25 * time.Hour), window: 24 * time.Hour},
+		"withdrawal_spike":  {severity: alerts.SeverityCritical, details: "withdrawal", at: now.Add(-25 * time.Hour), window: 24 * time.Hour},
+	}
+	hfCycle(2.0)
+	now = now.Add(time.Hour)
+	hfCycle(1.8)
+
+	for _, message := range business {
+		if strings.Contains(message, "PROTOCOL STRESS EVENT") {
+			t.Fatalf("lapsed daily breaches escalated with the hourly one:\n%s", message)
+		}
+	}
+	if len(business) != 1 {
+		t.Errorf("business messages = %q, want only the avg HF drop alert", business)
+	}
+}
+
+func TestProtocolStressLastsThroughDailyWindow(t *testing.T) {
+	var business []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["chat_id"] == "business-chat" {
+			business = append(business, fmt.Sprint(payload["text"]))
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+	service := alerts.New("business-token", "business-chat", "developer-token", "developer-chat", "")
+	service.TelegramAPIURL = server.URL
+	manager := alerts.NewManager(service)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	j := &HealthAggregateJob{alertManager: manager, configs: config.NewHolder(config.DefaultConfig())}
+	j.SetClock(func() time.Time { return now })
+	ctx := context.Background()
+	key := alerts.AlertKey{Job: "health_aggregate", Entity: "protocol", Metric: "protocol_stress"}
+	open := func() bool {
+		state, ok := manager.GetActiveIncidents()[key]
+		return ok && state.Severity == alerts.SeverityCritical
+	}
+
+	// 5 minute cycles: a quiet day, then risky positions and withdrawals spike while
+	// the average HF slides 0.15 an hour for six hours before holding
+	for now = start; now.Before(start.Add(32 * time.Hour)); now = now.Add(5 * time.Minute) {
+		metrics := &aggregateMetrics{RiskyPositions: 10, WeightedAvgHF: 2.0, TotalCollateralUSD: 1000, TotalBorrowUSD: 500}
+		if elapsed := now.Sub(start); elapsed >= 24*time.Hour {
+			hours := min(int((elapsed-24*time.Hour)/time.Hour)+1, 6)
+			metrics.RiskyPositions, metrics.TotalCollateralUSD = 20, 850
+			metrics.WeightedAvgHF = 2.0 - 0.15*float64(hours)
+		}
+
+		j.startCycle()
+		j.checkRiskyCountSpike(ctx, metrics)
+		j.checkAvgHealthFactorDrop(ctx, metrics)
+		j.checkWithdrawalSpike(ctx, metrics)
+		j.checkBorrowSpike(ctx, metrics)
+		j.finishCycle(ctx)
+
+		// Open for every cycle of the slide, though the daily checks ran only at its
+		// start, and still open half an hour after the slide stops
+		if elapsed := now.Sub(start); elapsed >= 24*time.Hour && elapsed <= 30*time.Hour+30*time.Minute && !open() {
+			t.Fatalf("stress event closed at +%v", elapsed)
+		}
+	}
+	if open() {
+		t.Error("stress event still open two hours after the HF stopped dropping")
+	}
+
+	pages := 0
+	for _, message := range business {
+		if strings.Contains(message, "PROTOCOL STRESS EVENT") {
+			pages++
+		}
+	}
+	if pages != 1 {
+		t.Errorf("%d stress event pages, want 1", pages)
+	}
+}
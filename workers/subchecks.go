@@ -0,0 +1,66 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// subCheck is one independent part of a DB job's run. Sub-checks of a run may execute
+// concurrently, so each must only touch job state no other sub-check of the run touches.
+type subCheck struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// subCheckPanic carries a sub-check's panic to the job's goroutine
+type subCheckPanic struct {
+	check string
+	value any
+	stack []byte
+}
+
+func (p subCheckPanic) String() string {
+	return fmt.Sprintf("%s: %v\n%s", p.check, p.value, p.stack)
+}
+
+// runSubChecks runs checks with at most limit at a time (1 or less runs them in order),
+// sharing the caller's DB pool. Every check runs even when others fail; their errors are
+// joined, each prefixed with the check's name. A panicking check is re-panicked once all
+// checks finish so the worker recovers and reports it like any other job panic.
+func runSubChecks(ctx context.Context, limit int, checks ...subCheck) error {
+	if limit < 1 {
+		limit = 1
+	}
+
+	errs := make([]error, len(checks))
+	panics := make([]*subCheckPanic, len(checks))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					panics[i] = &subCheckPanic{check: check.name, value: r, stack: debug.Stack()}
+				}
+				<-sem
+				wg.Done()
+			}()
+			if err := check.run(ctx); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", check.name, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, p := range panics {
+		if p != nil {
+			panic(p)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunSubChecks(t *testing.T) {
+	var running, peak, ran atomic.Int32
+	check := func(name string, err error) subCheck {
+		return subCheck{name, func(context.Context) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			ran.Add(1)
+			return err
+		}}
+	}
+	failure := errors.New("connection reset")
+
+	err := runSubChecks(context.Background(), 2,
+		check("first", nil), check("second", failure), check("third", nil), check("fourth", nil))
+	if ran.Load() != 4 {
+		t.Errorf("%d checks ran, want all 4 despite a failure", ran.Load())
+	}
+	if peak.Load() != 2 {
+		t.Errorf("peak concurrency = %d, want 2", peak.Load())
+	}
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), "second: connection reset") {
+		t.Errorf("err = %v, want the failure prefixed with its check", err)
+	}
+
+	peak.Store(0)
+	if err := runSubChecks(context.Background(), 1, check("first", nil), check("second", nil)); err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if peak.Load() != 1 {
+		t.Errorf("peak concurrency = %d with a limit of 1, want sequential", peak.Load())
+	}
+}
+
+func TestRunSubChecksRepanicsAfterAllFinish(t *testing.T) {
+	var finished atomic.Bool
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(r.(*subCheckPanic).String(), "broken: boom") {
+			t.Errorf("recovered %v, want the sub-check's panic", r)
+		}
+		if !finished.Load() {
+			t.Error("panic propagated before the other check finished")
+		}
+	}()
+	runSubChecks(context.Background(), 2,
+		subCheck{"broken", func(context.Context) error { panic("boom") }},
+		subCheck{"slow", func(context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			finished.Store(true)
+			return nil
+		}},
+	)
+}
@@ -0,0 +1,97 @@
+package workers
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+// trendTolerance is the smallest change in deviation (percentage points) between two
+// readings that counts as worsening or improving
+const trendTolerance = 0.01
+
+// Direction of a token's deviation since its previous reading
+type deviationTrend string
+
+const (
+	trendNone      deviationTrend = "" // no usable previous reading
+	trendWorsening deviationTrend = "worsening"
+	trendImproving deviationTrend = "improving"
+	trendSteady    deviationTrend = "steady"
+)
+
+// arrow is the log indicator for the trend
+func (t deviationTrend) arrow() string {
+	switch t {
+	case trendWorsening:
+		return "↑"
+	case trendImproving:
+		return "↓"
+	case trendSteady:
+		return "→"
+	}
+	return ""
+}
+
+// previousReading is a token's last successful result, kept to show how its
+// deviation moved since
+type previousReading struct {
+	result   tokenResult
+	severity alerts.Severity
+	at       time.Time
+}
+
+// trendFrom compares deviation with the previous reading. A missing or failed previous
+// reading has no trend.
+func trendFrom(previous *previousReading, deviation float64) deviationTrend {
+	if previous == nil || previous.result.err != nil {
+		return trendNone
+	}
+	switch change := deviation - previous.result.deviation; {
+	case math.Abs(change) < trendTolerance:
+		return trendSteady
+	case change > 0:
+		return trendWorsening
+	}
+	return trendImproving
+}
+
+// previousReading returns symbol's last successful reading, nil when there is none
+func (m *OracleMonitor) previousReading(symbol string) *previousReading {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	previous, ok := m.previous[symbol]
+	if !ok {
+		return nil
+	}
+	return &previous
+}
+
+// recordReading keeps a successful result as symbol's previous reading
+func (m *OracleMonitor) recordReading(result tokenResult, severity alerts.Severity) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.previous == nil {
+		m.previous = make(map[string]previousReading)
+	}
+	m.previous[result.symbol] = previousReading{result: result, severity: severity, at: m.now()}
+}
+
+// forgetReading drops symbol's previous reading after a failed read, so the next
+// success is not compared with a reading from before the gap
+func (m *OracleMonitor) forgetReading(symbol string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.previous, symbol)
+}
+
+// formatPreviousLine describes the previous reading and the trend since, for alert details
+func (m *OracleMonitor) formatPreviousLine(previous *previousReading, trend deviationTrend) string {
+	if trend == trendNone {
+		return ""
+	}
+	return fmt.Sprintf("Previous: %.2f%% %s (%s), trend: %s",
+		previous.result.deviation, previous.severity, alerts.FormatAge(previous.at, m.now()), trend)
+}
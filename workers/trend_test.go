@@ -0,0 +1,53 @@
+package workers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0x0Glitch/alerts"
+)
+
+func TestTrendFrom(t *testing.T) {
+	tests := []struct {
+		name      string
+		previous  *previousReading
+		deviation float64
+		want      deviationTrend
+	}{
+		{"no previous reading", nil, 2, trendNone},
+		{"previous read failed", &previousReading{result: tokenResult{deviation: 1, err: errors.New("timeout")}}, 2, trendNone},
+		{"worsening", &previousReading{result: tokenResult{deviation: 1.8}}, 2.4, trendWorsening},
+		{"improving", &previousReading{result: tokenResult{deviation: 2.4}}, 1.8, trendImproving},
+		{"steady within tolerance", &previousReading{result: tokenResult{deviation: 1.8}}, 1.805, trendSteady},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trendFrom(tt.previous, tt.deviation); got != tt.want {
+				t.Errorf("trendFrom() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreviousReadingForgottenOnError(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := &OracleMonitor{chain: ChainConfig{ID: "trend_test", Name: "Trend Test"}, clock: func() time.Time { return now }}
+
+	m.recordReading(tokenResult{symbol: "weth", deviation: 1.8}, alerts.SeverityWarning)
+	now = now.Add(2 * time.Minute)
+	previous := m.previousReading("weth")
+	line := m.formatPreviousLine(previous, trendFrom(previous, 2.5))
+	if want := "Previous: 1.80% WARNING (2m ago), trend: worsening"; line != want {
+		t.Errorf("formatPreviousLine() = %q, want %q", line, want)
+	}
+
+	m.forgetReading("weth")
+	previous = m.previousReading("weth")
+	if previous != nil {
+		t.Fatalf("previous reading = %+v after an error, want none", previous)
+	}
+	if line := m.formatPreviousLine(previous, trendFrom(previous, 2.5)); line != "" {
+		t.Errorf("formatPreviousLine() = %q without a previous reading, want empty", line)
+	}
+}
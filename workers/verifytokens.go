@@ -0,0 +1,114 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TokenVerification is a configured token checked against live data, see VerifyTokens
+type TokenVerification struct {
+	Key              string
+	Meta             TokenMeta // as configured, before decimals are verified
+	OnchainSymbol    string    // underlying asset's symbol(); empty for native asset markets
+	OnchainDecimals  int
+	Feed             common.Address
+	OnchainPrice     float64
+	ReferencePrice   float64
+	DeviationPercent float64
+	Problems         []string // failed checks; none when the configuration matches the chain
+}
+
+// OK reports whether every check passed
+func (v TokenVerification) OK() bool {
+	return len(v.Problems) == 0
+}
+
+// VerifyTokens connects to the chain and checks every configured token, disabled ones
+// included: the underlying asset's decimals and symbol, that getFeed returns a feed,
+// that getUnderlyingPrice answers and that a reference price is available. Results are
+// sorted by token key. It sends no alerts of its own beyond connecting.
+func (m *OracleMonitor) VerifyTokens(ctx context.Context) ([]TokenVerification, error) {
+	configured := maps.Clone(m.chain.Tokens)
+	if err := m.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(configured))
+	for key := range configured {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	verifications := make([]TokenVerification, 0, len(keys))
+	for _, key := range keys {
+		verifications = append(verifications, m.verifyToken(ctx, key, configured[key]))
+	}
+	return verifications, nil
+}
+
+func (m *OracleMonitor) verifyToken(ctx context.Context, key string, meta TokenMeta) TokenVerification {
+	v := TokenVerification{Key: key, Meta: meta}
+	fail := func(format string, args ...any) {
+		v.Problems = append(v.Problems, fmt.Sprintf(format, args...))
+	}
+
+	decimals, err := m.underlyingDecimals(ctx, meta)
+	switch {
+	case err != nil:
+		fail("decimals: %v", err)
+	case meta.Decimals != 0 && meta.Decimals != decimals:
+		fail("decimals: configured %d, contract %d", meta.Decimals, decimals)
+	}
+	v.OnchainDecimals = decimals
+
+	if underlying, err := m.tokens.Underlying(ctx, common.HexToAddress(meta.MTokAddr)); err == nil {
+		symbol, err := m.tokens.Symbol(ctx, underlying)
+		switch {
+		case err != nil:
+			fail("symbol: %v", err)
+		case !strings.EqualFold(symbol, meta.Symbol):
+			fail("symbol: configured %s, contract %s", meta.Symbol, symbol)
+		}
+		v.OnchainSymbol = symbol
+	}
+
+	v.Feed, err = m.oracle.GetFeed(&bind.CallOpts{Context: ctx}, feedSymbol(meta))
+	switch {
+	case err != nil:
+		fail("getFeed(%q): %v", feedSymbol(meta), err)
+	case v.Feed == (common.Address{}):
+		fail("getFeed(%q) returned no feed", feedSymbol(meta))
+	}
+
+	mantissa, err := m.getOnchainPrice(ctx, meta.MTokAddr)
+	if err != nil {
+		fail("getUnderlyingPrice: %v", err)
+	} else {
+		if decimals == 0 {
+			decimals = meta.Decimals
+		}
+		v.OnchainPrice = scalePrice(mantissa, decimals)
+		if v.OnchainPrice <= 0 {
+			fail("getUnderlyingPrice returned %v", v.OnchainPrice)
+		}
+	}
+
+	if !meta.SkipDEXPrice {
+		reference, err := m.getReferencePrice(ctx, meta)
+		if err != nil {
+			fail("reference price: %v", err)
+		} else {
+			v.ReferencePrice = reference.Value
+			if v.OnchainPrice > 0 && reference.Value > 0 {
+				v.DeviationPercent = (v.OnchainPrice - reference.Value) / reference.Value * 100
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,64 @@
+package workers
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0x0Glitch/contract"
+)
+
+// contractCallers routes calls to a fake per contract address
+type contractCallers map[common.Address]*abiCaller
+
+func (c contractCallers) CodeAt(ctx context.Context, address common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c contractCallers) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return c[*call.To].CallContract(ctx, call, blockNumber)
+}
+
+func TestVerifyToken(t *testing.T) {
+	oracleAddr := common.HexToAddress("0xEC942bE8A8114bFD0396A5052c36027f2cA6a9d0")
+	mToken := common.HexToAddress("0xEdc817A28E8B93B03976FBd4a3dDBc9f7D176c22")
+	underlying := common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+	feed := common.HexToAddress("0x7e860098F58bBFC8648a4311b374B1D669a2bc6B")
+	oracle := &abiCaller{
+		meta: contract.OracleMetaData,
+		outputs: map[string][]interface{}{
+			"getFeed":            {feed},
+			"getUnderlyingPrice": {new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)}, // $1 at 6 decimals
+		},
+		calls: make(map[string]int),
+	}
+	callers := contractCallers{
+		oracleAddr: oracle,
+		mToken:     {meta: ERC20MetaData, outputs: map[string][]interface{}{"underlying": {underlying}}, calls: make(map[string]int)},
+		underlying: {meta: ERC20MetaData, outputs: map[string][]interface{}{"decimals": {uint8(6)}, "symbol": {"USDC"}}, calls: make(map[string]int)},
+	}
+	oracleCaller, err := contract.NewOracleCaller(oracleAddr, callers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &OracleMonitor{chain: ChainConfig{ID: "base", Name: "Base"}, oracle: oracleCaller, tokens: NewERC20Reader(callers)}
+	ctx := context.Background()
+	meta := TokenMeta{Symbol: "USDC", MTokAddr: mToken.Hex(), Decimals: 6, SkipDEXPrice: true}
+
+	v := m.verifyToken(ctx, "usdc", meta)
+	if !v.OK() || v.OnchainPrice != 1 || v.OnchainSymbol != "USDC" || v.Feed != feed {
+		t.Errorf("verifyToken() = %+v, want a passing USDC at $1", v)
+	}
+
+	meta.Decimals, meta.Symbol = 18, "USDbC"
+	oracle.outputs["getFeed"] = []interface{}{common.Address{}}
+	v = m.verifyToken(ctx, "usdbc", meta)
+	want := []string{"decimals: configured 18, contract 6", "symbol: configured USDbC, contract USDC", `getFeed("USDbC") returned no feed`}
+	if strings.Join(v.Problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems = %q, want %q", v.Problems, want)
+	}
+}
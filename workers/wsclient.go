@@ -0,0 +1,360 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	wsKeepaliveInterval = 30 * time.Second
+	wsKeepaliveTimeout  = 10 * time.Second
+	wsMinBackoff        = 1 * time.Second
+	wsMaxBackoff        = 1 * time.Minute
+)
+
+// wsConnected exposes each chain's websocket state (1 connected, 0 down)
+var wsConnected = expvar.NewMap("ws_connected")
+
+// ErrNotConnected is returned by ManagedClient calls made before its HTTP client has
+// been dialed, see Connect
+var ErrNotConnected = errors.New("RPC not connected")
+
+// ManagedClient pairs a chain's HTTP client with an optional websocket client that is
+// kept alive and re-dialed with backoff. Read calls use the websocket while it is up
+// and fall back to HTTP transparently, and HTTP fails over to the chain's next verified
+// RPC URL on connection errors; dependents holding subscriptions are notified through
+// Reconnected to re-subscribe.
+type ManagedClient struct {
+	chain  ChainConfig
+	dialMu sync.Mutex // serializes Connect and HTTP failover
+
+	mu        sync.RWMutex
+	http      *ethclient.Client // nil until Connect succeeds for a lazy client
+	endpoint  int               // index in chain.RPCURLs of http when dialed by Connect
+	ws        *ethclient.Client // nil while disconnected
+	listeners []chan struct{}
+
+	redial     chan struct{}
+	latestHead atomic.Uint64
+
+	keepaliveInterval time.Duration
+	keepaliveTimeout  time.Duration
+	minBackoff        time.Duration
+	maxBackoff        time.Duration
+}
+
+// NewManagedClient wraps http. Call Run to maintain the websocket when chain.WSURL is set.
+func NewManagedClient(chain ChainConfig, http *ethclient.Client) *ManagedClient {
+	return &ManagedClient{
+		chain:             chain,
+		http:              http,
+		redial:            make(chan struct{}, 1),
+		keepaliveInterval: wsKeepaliveInterval,
+		keepaliveTimeout:  wsKeepaliveTimeout,
+		minBackoff:        wsMinBackoff,
+		maxBackoff:        wsMaxBackoff,
+	}
+}
+
+// NewLazyManagedClient returns a client for chain that dials its RPC endpoints with
+// DialChain on Connect instead of up front. Calls fail with ErrNotConnected until then,
+// except over the websocket when Run has connected it.
+func NewLazyManagedClient(chain ChainConfig) *ManagedClient {
+	return NewManagedClient(chain, nil)
+}
+
+// Connect dials the chain's HTTP endpoint if the client is not connected yet. Once a
+// connection succeeds it is kept and later calls return at once.
+func (c *ManagedClient) Connect(ctx context.Context) error {
+	c.dialMu.Lock()
+	defer c.dialMu.Unlock()
+	if c.HTTP() != nil {
+		return nil
+	}
+	http, endpoint, err := dialChainFrom(ctx, c.chain, 0)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.http, c.endpoint = http, endpoint
+	c.mu.Unlock()
+	log.Printf("[%s] RPC connected", c.chain.Name)
+	return nil
+}
+
+// failover switches the HTTP client to the chain's next RPC URL after a call on
+// failed hit a connection error. Candidates are verified like on Connect, so an endpoint
+// serving another chain is never switched to; when none qualifies the current client
+// is kept. It reports whether the client was switched, by this call or a concurrent one.
+func (c *ManagedClient) failover(ctx context.Context, failed *ethclient.Client, cause error) bool {
+	if len(c.chain.RPCURLs) < 2 || ClassifyRPCError(cause) != RPCErrorTransient || ctx.Err() != nil {
+		return false
+	}
+	c.dialMu.Lock()
+	defer c.dialMu.Unlock()
+	c.mu.RLock()
+	current, endpoint := c.http, c.endpoint
+	c.mu.RUnlock()
+	if current != failed {
+		return true // already switched
+	}
+
+	log.Printf("[%s] RPC endpoint %s failed, failing over: %v", c.chain.Name, endpointHost(c.chain.RPCURLs[endpoint]), cause)
+	chain := c.chain
+	// Only the other endpoints; the failed one is retried when they are all down
+	chain.RPCURLs = append(append([]string{}, c.chain.RPCURLs[endpoint+1:]...), c.chain.RPCURLs[:endpoint]...)
+	http, index, err := dialChainFrom(ctx, chain, 0)
+	if err != nil {
+		log.Printf("[%s] RPC failover failed, keeping %s: %v", c.chain.Name, endpointHost(c.chain.RPCURLs[endpoint]), err)
+		return false
+	}
+	c.mu.Lock()
+	c.http, c.endpoint = http, (endpoint+1+index)%len(c.chain.RPCURLs)
+	c.mu.Unlock()
+	failed.Close()
+	log.Printf("[%s] RPC failed over to %s", c.chain.Name, endpointHost(c.chain.RPCURLs[c.endpoint]))
+	return true
+}
+
+// Factory returns a ClientFactory that connects c on first use
+func (c *ManagedClient) Factory() ClientFactory {
+	return func(ctx context.Context) (*ManagedClient, error) {
+		if err := c.Connect(ctx); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+}
+
+// HTTP returns the underlying HTTP client, nil until a lazy client is connected
+func (c *ManagedClient) HTTP() *ethclient.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.http
+}
+
+// WS returns the websocket client, or nil while it is disconnected
+func (c *ManagedClient) WS() *ethclient.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ws
+}
+
+// Reader returns the websocket client when connected, otherwise the HTTP client (nil
+// until a lazy client is connected)
+func (c *ManagedClient) Reader() *ethclient.Client {
+	if ws := c.WS(); ws != nil {
+		return ws
+	}
+	return c.HTTP()
+}
+
+// LatestHead returns the most recent block number seen on the websocket head subscription
+func (c *ManagedClient) LatestHead() uint64 {
+	return c.latestHead.Load()
+}
+
+// Reconnected returns a channel signalled each time the websocket (re)connects.
+// Signals are coalesced if the receiver falls behind.
+func (c *ManagedClient) Reconnected() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	c.listeners = append(c.listeners, ch)
+	return ch
+}
+
+// ReportError lets a dependent report a failed subscription, forcing a re-dial
+func (c *ManagedClient) ReportError(err error) {
+	log.Printf("[%s] websocket dependent reported error: %v", c.chain.Name, err)
+	select {
+	case c.redial <- struct{}{}:
+	default: // re-dial already pending
+	}
+}
+
+// CodeAt implements bind.ContractCaller, falling back to HTTP when the websocket fails
+func (c *ManagedClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if ws := c.WS(); ws != nil {
+		code, err := ws.CodeAt(ctx, account, blockNumber)
+		if err == nil || ctx.Err() != nil {
+			return code, err
+		}
+	}
+	http := c.HTTP()
+	if http == nil {
+		return nil, ErrNotConnected
+	}
+	code, err := http.CodeAt(ctx, account, blockNumber)
+	if err != nil && c.failover(ctx, http, err) {
+		return c.HTTP().CodeAt(ctx, account, blockNumber)
+	}
+	return code, err
+}
+
+// CallContract implements bind.ContractCaller, falling back to HTTP when the websocket fails
+func (c *ManagedClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if ws := c.WS(); ws != nil {
+		out, err := ws.CallContract(ctx, call, blockNumber)
+		if err == nil || ctx.Err() != nil {
+			return out, err
+		}
+	}
+	http := c.HTTP()
+	if http == nil {
+		return nil, ErrNotConnected
+	}
+	out, err := http.CallContract(ctx, call, blockNumber)
+	if err != nil && c.failover(ctx, http, err) {
+		return c.HTTP().CallContract(ctx, call, blockNumber)
+	}
+	return out, err
+}
+
+// HeaderByNumber returns a block header (nil for the latest), falling back to HTTP
+// when the websocket fails
+func (c *ManagedClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if ws := c.WS(); ws != nil {
+		header, err := ws.HeaderByNumber(ctx, number)
+		if err == nil || ctx.Err() != nil {
+			return header, err
+		}
+	}
+	http := c.HTTP()
+	if http == nil {
+		return nil, ErrNotConnected
+	}
+	header, err := http.HeaderByNumber(ctx, number)
+	if err != nil && c.failover(ctx, http, err) {
+		return c.HTTP().HeaderByNumber(ctx, number)
+	}
+	return header, err
+}
+
+// Run maintains the websocket connection until ctx is cancelled. It returns
+// immediately when no websocket URL is configured.
+func (c *ManagedClient) Run(ctx context.Context) {
+	if c.chain.WSURL == "" {
+		return
+	}
+
+	backoff := c.minBackoff
+	for {
+		established, err := c.session(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if established {
+			backoff = c.minBackoff
+		}
+		log.Printf("[%s] websocket %s down, re-dialing in %v: %v", c.chain.Name, endpointHost(c.chain.WSURL), backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(backoff*2, c.maxBackoff)
+	}
+}
+
+// session dials the websocket, subscribes to new heads and monitors the connection
+// until it fails. established reports whether the connection came up at all.
+func (c *ManagedClient) session(ctx context.Context) (established bool, err error) {
+	ws, err := dialRPC(ctx, c.chain.WSURL, c.chain.RPCHeaders)
+	if err != nil {
+		return false, err
+	}
+	if err := VerifyChainID(ctx, ws, c.chain, c.chain.WSURL); err != nil {
+		ws.Close()
+		return false, err
+	}
+
+	heads := make(chan *types.Header, 16)
+	sub, err := ws.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		ws.Close()
+		return false, fmt.Errorf("head subscription: %w", err)
+	}
+
+	// Drop any re-dial requested against the previous connection
+	select {
+	case <-c.redial:
+	default:
+	}
+
+	c.setWS(ws)
+	log.Printf("[%s] websocket connected to %s", c.chain.Name, endpointHost(c.chain.WSURL))
+	defer func() {
+		sub.Unsubscribe()
+		c.setWS(nil)
+		ws.Close()
+	}()
+
+	ticker := time.NewTicker(c.keepaliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			return true, fmt.Errorf("head subscription: %w", err)
+		case head := <-heads:
+			if head != nil && head.Number != nil {
+				c.latestHead.Store(head.Number.Uint64())
+			}
+		case <-ticker.C:
+			pingCtx, cancel := context.WithTimeout(ctx, c.keepaliveTimeout)
+			_, err := ws.BlockNumber(pingCtx)
+			cancel()
+			if err != nil {
+				return true, fmt.Errorf("keepalive: %w", err)
+			}
+		case <-c.redial:
+			return true, errors.New("re-dial requested by dependent")
+		}
+	}
+}
+
+// setWS swaps the active websocket client, notifying listeners on connect
+func (c *ManagedClient) setWS(ws *ethclient.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ws = ws
+	state := new(expvar.Int)
+	if ws != nil {
+		state.Set(1)
+		for _, ch := range c.listeners {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+	wsConnected.Set(string(c.chain.ID), state)
+}
+
+// Close closes the HTTP client; the websocket is closed when Run returns
+func (c *ManagedClient) Close() {
+	if http := c.HTTP(); http != nil {
+		http.Close()
+	}
+}
@@ -0,0 +1,205 @@
+package workers
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ethStub serves the eth_ methods used by ManagedClient
+type ethStub struct {
+	chainID int64
+	block   atomic.Uint64
+}
+
+func (s *ethStub) ChainId() hexutil.Big { return hexutil.Big(*big.NewInt(s.chainID)) }
+
+func (s *ethStub) BlockNumber() hexutil.Uint64 { return hexutil.Uint64(s.block.Load()) }
+
+func (s *ethStub) GetCode(address common.Address, block string) hexutil.Bytes {
+	return hexutil.Bytes{0x60, 0x80}
+}
+
+func (s *ethStub) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				header := &types.Header{Number: new(big.Int).SetUint64(s.block.Add(1)), Difficulty: big.NewInt(0)}
+				if err := notifier.Notify(sub.ID, header); err != nil {
+					return
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// dropListener tracks accepted connections so a test can sever them all
+type dropListener struct {
+	net.Listener
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func (l *dropListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.mu.Lock()
+		l.conns = append(l.conns, conn)
+		l.mu.Unlock()
+	}
+	return conn, err
+}
+
+func (l *dropListener) dropAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, conn := range l.conns {
+		conn.Close()
+	}
+	l.conns = nil
+}
+
+// newDroppingWSServer starts a websocket JSON-RPC server whose connections can be dropped
+func newDroppingWSServer(t *testing.T, chainID int64) (string, *dropListener) {
+	t.Helper()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", &ethStub{chainID: chainID}); err != nil {
+		t.Fatal(err)
+	}
+
+	httpServer := httptest.NewUnstartedServer(server.WebsocketHandler([]string{"*"}))
+	listener := &dropListener{Listener: httpServer.Listener}
+	httpServer.Listener = listener
+	httpServer.Start()
+	t.Cleanup(func() {
+		listener.dropAll()
+		httpServer.Close()
+		server.Stop()
+	})
+
+	return "ws" + strings.TrimPrefix(httpServer.URL, "http"), listener
+}
+
+func TestManagedClientReconnectsAfterDrops(t *testing.T) {
+	if testing.Short() {
+		t.Skip("soak test")
+	}
+
+	wsURL, listener := newDroppingWSServer(t, 8453)
+	httpServer := rpc.NewServer()
+	if err := httpServer.RegisterName("eth", &ethStub{chainID: 8453}); err != nil {
+		t.Fatal(err)
+	}
+	httpStub := httptest.NewServer(httpServer)
+	t.Cleanup(func() {
+		httpStub.Close()
+		httpServer.Stop()
+	})
+	httpClient, err := ethclient.Dial(httpStub.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := BaseChain()
+	chain.WSURL = wsURL
+	client := NewManagedClient(chain, httpClient)
+	client.keepaliveInterval = 50 * time.Millisecond
+	client.minBackoff = 10 * time.Millisecond
+	client.maxBackoff = 50 * time.Millisecond
+	reconnected := client.Reconnected()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		client.Run(ctx)
+		close(done)
+	}()
+
+	const drops = 10
+	for i := 0; i <= drops; i++ {
+		select {
+		case <-reconnected:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no (re)connect after %d drops", i)
+		}
+
+		// While connected, heads keep arriving and contract reads succeed
+		if _, err := client.CodeAt(ctx, Multicall3Address, nil); err != nil {
+			t.Fatalf("read after %d drops: %v", i, err)
+		}
+		head := client.LatestHead()
+		deadline := time.Now().Add(2 * time.Second)
+		for client.LatestHead() == head && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		if client.LatestHead() == head {
+			t.Fatalf("no new heads after %d drops", i)
+		}
+
+		if i < drops {
+			listener.dropAll()
+		}
+	}
+
+	// Reads fall back to HTTP while the websocket is down. Wait for the client to see
+	// the drop: a request racing the dying connection can wait forever for its reply.
+	listener.dropAll()
+	deadline := time.Now().Add(2 * time.Second)
+	for client.WS() != nil && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if client.WS() != nil {
+		t.Fatal("websocket still set after the drop")
+	}
+	if _, err := client.CodeAt(ctx, Multicall3Address, nil); err != nil {
+		t.Errorf("read after drop: %v", err)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	if client.WS() != nil {
+		t.Error("websocket client still set after Run returned")
+	}
+}
+
+func TestManagedClientRejectsWrongChainWebsocket(t *testing.T) {
+	wsURL, _ := newDroppingWSServer(t, 10)
+
+	chain := BaseChain()
+	chain.WSURL = wsURL
+	client := NewManagedClient(chain, nil)
+
+	established, err := client.session(context.Background())
+	if established || err == nil {
+		t.Fatalf("session = %v, %v; want chain ID mismatch", established, err)
+	}
+}